@@ -28,6 +28,7 @@ var Commands = []*Command{
 	cmdFilerSynchronize,
 	cmdFix,
 	cmdFuse,
+	cmdFtp,
 	cmdIam,
 	cmdMaster,
 	cmdMasterFollower,
@@ -36,6 +37,7 @@ var Commands = []*Command{
 	cmdS3,
 	cmdScaffold,
 	cmdServer,
+	cmdSftp,
 	cmdShell,
 	cmdUpdate,
 	cmdUpload,
@@ -0,0 +1,193 @@
+package command
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os/user"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/ftpd"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+
+	ftpserver "github.com/fclairamb/ftpserverlib"
+	"google.golang.org/grpc/credentials/tls/certprovider"
+	"google.golang.org/grpc/credentials/tls/certprovider/pemfile"
+)
+
+var (
+	ftpStandaloneOptions FtpOptions
+)
+
+type FtpOptions struct {
+	filer            *string
+	ip               *string
+	bindIp           *string
+	port             *int
+	filerRootPath    *string
+	passivePortStart *int
+	passivePortStop  *int
+	tlsRequired      *bool
+	collection       *string
+	replication      *string
+	disk             *string
+	maxMB            *int
+	certProvider     certprovider.Provider
+}
+
+func init() {
+	cmdFtp.Run = runFtp // break init cycle
+	ftpStandaloneOptions.filer = cmdFtp.Flag.String("filer", "localhost:8888", "filer server address")
+	ftpStandaloneOptions.ip = cmdFtp.Flag.String("ip", util.DetectedHostAddress(), "ftp server public ip address, returned to the clients for passive transfers")
+	ftpStandaloneOptions.bindIp = cmdFtp.Flag.String("ip.bind", "", "ip address to bind to. If empty, default to same as -ip option.")
+	ftpStandaloneOptions.port = cmdFtp.Flag.Int("port", 8021, "ftp server listen port")
+	ftpStandaloneOptions.filerRootPath = cmdFtp.Flag.String("filer.path", "/ftp", "use this remote path from filer server, with one home directory for each user")
+	ftpStandaloneOptions.passivePortStart = cmdFtp.Flag.Int("port.passive.start", 30000, "passive transfer start port")
+	ftpStandaloneOptions.passivePortStop = cmdFtp.Flag.Int("port.passive.stop", 30100, "passive transfer stop port")
+	ftpStandaloneOptions.tlsRequired = cmdFtp.Flag.Bool("tls.required", false, "refuse clients not switching to TLS with AUTH TLS")
+	ftpStandaloneOptions.collection = cmdFtp.Flag.String("collection", "", "collection to create the files")
+	ftpStandaloneOptions.replication = cmdFtp.Flag.String("replication", "", "replication to create the files")
+	ftpStandaloneOptions.disk = cmdFtp.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
+	ftpStandaloneOptions.maxMB = cmdFtp.Flag.Int("maxMB", 4, "split files larger than the limit")
+}
+
+var cmdFtp = &Command{
+	UsageLine: "ftp -port=8021 -filer=<ip:port>",
+	Short:     "start an ftp server that is backed by a filer",
+	Long: `start an ftp server that is backed by a filer.
+
+	The users and passwords are the identities and their access key and secret key
+	configured for the s3 gateway with "s3.configure". A user can log in with either the
+	identity name or the access key, and is confined to the <filer.path>/<identity name>
+	home directory. Identities with the "Admin" action can access the whole filer.path,
+	identities without the "Write" action can only download files.
+
+	The explicit FTPS, the AUTH TLS command, is enabled with the key and certificate
+	configured in the [https.ftp] section of security.toml.
+
+`,
+}
+
+func runFtp(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+
+	glog.V(0).Infof("Starting Seaweed Ftp Server %s at port %d", util.Version(), *ftpStandaloneOptions.port)
+
+	return ftpStandaloneOptions.startFtpServer()
+
+}
+
+// GetCertificateWithUpdate Auto refreshing TSL certificate
+func (fo *FtpOptions) GetCertificateWithUpdate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certs, err := fo.certProvider.KeyMaterial(context.Background())
+	if certs == nil {
+		return nil, err
+	}
+	return &certs.Certs[0], err
+}
+
+func (fo *FtpOptions) startFtpServer() bool {
+
+	// detect current user
+	uid, gid := uint32(0), uint32(0)
+	if u, err := user.Current(); err == nil {
+		if parsedId, pe := strconv.ParseUint(u.Uid, 10, 32); pe == nil {
+			uid = uint32(parsedId)
+		}
+		if parsedId, pe := strconv.ParseUint(u.Gid, 10, 32); pe == nil {
+			gid = uint32(parsedId)
+		}
+	}
+
+	filerAddress := pb.ServerAddress(*fo.filer)
+
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	var cipher bool
+	// connect to filer
+	for {
+		err := pb.WithGrpcFilerClient(false, 0, filerAddress, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+			if err != nil {
+				return fmt.Errorf("get filer %s configuration: %v", filerAddress, err)
+			}
+			cipher = resp.Cipher
+			return nil
+		})
+		if err != nil {
+			glog.V(0).Infof("wait to connect to filer %s grpc address %s", *fo.filer, filerAddress.ToGrpcAddress())
+			time.Sleep(time.Second)
+		} else {
+			glog.V(0).Infof("connected to filer %s grpc address %s", *fo.filer, filerAddress.ToGrpcAddress())
+			break
+		}
+	}
+
+	var tlsConfig *tls.Config
+	if viper := util.GetViper(); viper.GetString("https.ftp.key") != "" {
+		pemfileOptions := pemfile.Options{
+			CertFile:        viper.GetString("https.ftp.cert"),
+			KeyFile:         viper.GetString("https.ftp.key"),
+			RefreshDuration: security.CredRefreshingInterval,
+		}
+		var err error
+		if fo.certProvider, err = pemfile.NewProvider(pemfileOptions); err != nil {
+			glog.Fatalf("pemfile.NewProvider(%v) failed: %v", pemfileOptions, err)
+		}
+		tlsConfig = &tls.Config{
+			GetCertificate: fo.GetCertificateWithUpdate,
+		}
+	} else if *fo.tlsRequired {
+		glog.Fatalf("-tls.required needs the key and certificate in the [https.ftp] section of security.toml")
+	}
+
+	if *fo.bindIp == "" {
+		*fo.bindIp = *fo.ip
+	}
+
+	listenAddress := util.JoinHostPort(*fo.bindIp, *fo.port)
+	// no read timeout, the control connection is idle during the transfers
+	ftpListener, err := util.NewListener(listenAddress, 0)
+	if err != nil {
+		glog.Fatalf("Ftp Server listener on %s error: %v", listenAddress, err)
+	}
+
+	ftpDriver, err := ftpd.NewFtpServer(ftpListener, &ftpd.FtpServerOption{
+		Filer:            filerAddress,
+		IP:               *fo.ip,
+		IpBind:           *fo.bindIp,
+		Port:             *fo.port,
+		FtpRoot:          path.Clean("/" + *fo.filerRootPath),
+		GrpcDialOption:   grpcDialOption,
+		PassivePortStart: *fo.passivePortStart,
+		PassivePortStop:  *fo.passivePortStop,
+		TLSConfig:        tlsConfig,
+		TLSRequired:      *fo.tlsRequired,
+		Collection:       *fo.collection,
+		Replication:      *fo.replication,
+		DiskType:         *fo.disk,
+		Uid:              uid,
+		Gid:              gid,
+		Cipher:           cipher,
+		MaxMB:            *fo.maxMB,
+	})
+	if err != nil {
+		glog.Fatalf("Ftp Server startup error: %v", err)
+	}
+
+	glog.V(0).Infof("Start Seaweed Ftp Server %s at %s", util.Version(), listenAddress)
+	ftpServer := ftpserver.NewFtpServer(ftpDriver)
+	if err = ftpServer.ListenAndServe(); err != nil {
+		glog.Fatalf("Ftp Server Fail to serve: %v", err)
+	}
+
+	return true
+
+}
@@ -183,6 +183,12 @@ func runFuse(cmd *Command, args []string) bool {
 			} else {
 				panic(fmt.Errorf("readOnly: %s", err))
 			}
+		case "relatime":
+			if parsed, err := strconv.ParseBool(parameter.value); err == nil {
+				mountOptions.relatime = &parsed
+			} else {
+				panic(fmt.Errorf("relatime: %s", err))
+			}
 		case "cpuprofile":
 			mountCpuProfile = &parameter.value
 		case "memprofile":
@@ -249,6 +249,7 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 		Cipher:             cipher,
 		UidGidMapper:       uidGidMapper,
 		DisableXAttr:       *option.disableXAttr,
+		Relatime:           *option.relatime,
 		IsMacOs:            runtime.GOOS == "darwin",
 	})
 
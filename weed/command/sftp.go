@@ -0,0 +1,149 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/sftpd"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+var (
+	sftpOptionsStandalone SftpOptions
+)
+
+type SftpOptions struct {
+	filer         *string
+	bindIp        *string
+	port          *int
+	sshPrivateKey *string
+	userStoreFile *string
+	collection    *string
+	replication   *string
+	disk          *string
+	maxMB         *int
+}
+
+func init() {
+	cmdSftp.Run = runSftp // break init cycle
+	sftpOptionsStandalone.filer = cmdSftp.Flag.String("filer", "localhost:8888", "filer server address")
+	sftpOptionsStandalone.bindIp = cmdSftp.Flag.String("ip.bind", "", "ip address to bind to. Default listen to all.")
+	sftpOptionsStandalone.port = cmdSftp.Flag.Int("port", 2022, "sftp server listen port")
+	sftpOptionsStandalone.sshPrivateKey = cmdSftp.Flag.String("sshPrivateKey", "", "path to the ssh host private key file. If empty, a key is generated and kept in the filer.")
+	sftpOptionsStandalone.userStoreFile = cmdSftp.Flag.String("userStoreFile", "/etc/sftp/users.json", "path to the user store file in the filer")
+	sftpOptionsStandalone.collection = cmdSftp.Flag.String("collection", "", "collection to create the files")
+	sftpOptionsStandalone.replication = cmdSftp.Flag.String("replication", "", "replication to create the files")
+	sftpOptionsStandalone.disk = cmdSftp.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
+	sftpOptionsStandalone.maxMB = cmdSftp.Flag.Int("maxMB", 4, "split files larger than the limit")
+}
+
+var cmdSftp = &Command{
+	UsageLine: "sftp -port=2022 -filer=<ip:port>",
+	Short:     "start an sftp server that is backed by a filer",
+	Long: `start an sftp server that is backed by a filer.
+
+	The users are read from the user store file in the filer at each login, e.g.
+
+	{
+	  "users": [
+	    {
+	      "username": "partner",
+	      "password": "<bcrypt hash or plain password>",
+	      "publicKeys": ["ssh-ed25519 AAAA... partner@example.com"],
+	      "homeDir": "/sftp/partner",
+	      "readOnly": false
+	    }
+	  ]
+	}
+
+	Each user is chrooted to the home directory, which defaults to /home/<username>.
+
+`,
+}
+
+func runSftp(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+
+	glog.V(0).Infof("Starting Seaweed Sftp Server %s at port %d", util.Version(), *sftpOptionsStandalone.port)
+
+	return sftpOptionsStandalone.startSftpServer()
+
+}
+
+func (so *SftpOptions) startSftpServer() bool {
+
+	// detect current user
+	uid, gid := uint32(0), uint32(0)
+	if u, err := user.Current(); err == nil {
+		if parsedId, pe := strconv.ParseUint(u.Uid, 10, 32); pe == nil {
+			uid = uint32(parsedId)
+		}
+		if parsedId, pe := strconv.ParseUint(u.Gid, 10, 32); pe == nil {
+			gid = uint32(parsedId)
+		}
+	}
+
+	filerAddress := pb.ServerAddress(*so.filer)
+
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	var cipher bool
+	// connect to filer
+	for {
+		err := pb.WithGrpcFilerClient(false, 0, filerAddress, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+			if err != nil {
+				return fmt.Errorf("get filer %s configuration: %v", filerAddress, err)
+			}
+			cipher = resp.Cipher
+			return nil
+		})
+		if err != nil {
+			glog.V(0).Infof("wait to connect to filer %s grpc address %s", *so.filer, filerAddress.ToGrpcAddress())
+			time.Sleep(time.Second)
+		} else {
+			glog.V(0).Infof("connected to filer %s grpc address %s", *so.filer, filerAddress.ToGrpcAddress())
+			break
+		}
+	}
+
+	sftpServer, err := sftpd.NewSftpServer(&sftpd.SftpServerOption{
+		Filer:          filerAddress,
+		GrpcDialOption: grpcDialOption,
+		UserStoreFile:  *so.userStoreFile,
+		HostKeyFile:    util.ResolvePath(*so.sshPrivateKey),
+		Collection:     *so.collection,
+		Replication:    *so.replication,
+		DiskType:       *so.disk,
+		Uid:            uid,
+		Gid:            gid,
+		Cipher:         cipher,
+		MaxMB:          *so.maxMB,
+	})
+	if err != nil {
+		glog.Fatalf("Sftp Server startup error: %v", err)
+	}
+
+	listenAddress := util.JoinHostPort(*so.bindIp, *so.port)
+	// no read timeout, the ssh connections are kept open between the transfers
+	sftpListener, err := util.NewListener(listenAddress, 0)
+	if err != nil {
+		glog.Fatalf("Sftp Server listener on %s error: %v", listenAddress, err)
+	}
+
+	glog.V(0).Infof("Start Seaweed Sftp Server %s at %s", util.Version(), listenAddress)
+	if err = sftpServer.Serve(sftpListener); err != nil {
+		glog.Fatalf("Sftp Server Fail to serve: %v", err)
+	}
+
+	return true
+
+}
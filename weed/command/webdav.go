@@ -33,6 +33,7 @@ type WebDavOption struct {
 	cacheDir       *string
 	cacheSizeMB    *int64
 	maxMB          *int
+	auth           *bool
 }
 
 func init() {
@@ -48,6 +49,7 @@ func init() {
 	webDavStandaloneOptions.cacheSizeMB = cmdWebDav.Flag.Int64("cacheCapacityMB", 0, "local cache capacity in MB")
 	webDavStandaloneOptions.maxMB = cmdWebDav.Flag.Int("maxMB", 4, "split files larger than the limit")
 	webDavStandaloneOptions.filerRootPath = cmdWebDav.Flag.String("filer.path", "/", "use this remote path from filer server")
+	webDavStandaloneOptions.auth = cmdWebDav.Flag.Bool("auth", false, "authenticate the users with the s3 identities, confining them to their home directories")
 }
 
 var cmdWebDav = &Command{
@@ -55,6 +57,15 @@ var cmdWebDav = &Command{
 	Short:     "start a webdav server that is backed by a filer",
 	Long: `start a webdav server that is backed by a filer.
 
+	The locks are kept in the filer, so several webdav servers of the same filer cluster honour each other's locks.
+
+	With -auth, the users log in with the s3 identities kept in the filer:
+	  - with HTTP Basic auth, using the identity name or an access key, and the secret key as password
+	  - with the access key and secret key of temporary credentials issued by "weed iam" as HTTP Basic auth,
+	    and their session token in the X-Amz-Security-Token header
+	The admin identities access the whole -filer.path directory, the other identities only <filer.path>/<identity name>,
+	which is read only unless the identity has the Write action.
+
 `,
 }
 
@@ -119,12 +130,13 @@ func (wo *WebDavOption) startWebDav() bool {
 		CacheDir:       util.ResolvePath(*wo.cacheDir),
 		CacheSizeMB:    *wo.cacheSizeMB,
 		MaxMB:          *wo.maxMB,
+		Auth:           *wo.auth,
 	})
 	if webdavServer_err != nil {
 		glog.Fatalf("WebDav Server startup error: %v", webdavServer_err)
 	}
 
-	httpS := &http.Server{Handler: ws.Handler}
+	httpS := &http.Server{Handler: ws}
 
 	listenAddress := fmt.Sprintf(":%d", *wo.port)
 	webDavListener, err := util.NewListener(listenAddress, time.Duration(10)*time.Second)
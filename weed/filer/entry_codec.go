@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"os"
-	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -32,9 +31,11 @@ func (entry *Entry) DecodeAttributesAndChunks(blob []byte) error {
 
 func EntryAttributeToPb(entry *Entry) *filer_pb.FuseAttributes {
 
-	return &filer_pb.FuseAttributes{
+	attr := &filer_pb.FuseAttributes{
 		Crtime:        entry.Attr.Crtime.Unix(),
+		CrtimeNs:      int32(entry.Attr.Crtime.Nanosecond()),
 		Mtime:         entry.Attr.Mtime.Unix(),
+		MtimeNs:       int32(entry.Attr.Mtime.Nanosecond()),
 		FileMode:      uint32(entry.Attr.Mode),
 		Uid:           entry.Uid,
 		Gid:           entry.Gid,
@@ -48,6 +49,13 @@ func EntryAttributeToPb(entry *Entry) *filer_pb.FuseAttributes {
 		Rdev:          entry.Attr.Rdev,
 		Inode:         entry.Attr.Inode,
 	}
+	if !entry.Attr.Ctime.IsZero() {
+		attr.SetChangeTime(entry.Attr.Ctime)
+	}
+	if !entry.Attr.Atime.IsZero() {
+		attr.SetAccessTime(entry.Attr.Atime)
+	}
+	return attr
 }
 
 func PbToEntryAttribute(attr *filer_pb.FuseAttributes) Attr {
@@ -58,8 +66,14 @@ func PbToEntryAttribute(attr *filer_pb.FuseAttributes) Attr {
 		return t
 	}
 
-	t.Crtime = time.Unix(attr.Crtime, 0)
-	t.Mtime = time.Unix(attr.Mtime, 0)
+	t.Crtime = attr.CreateTime()
+	t.Mtime = attr.ModTime()
+	if attr.Ctime != 0 || attr.CtimeNs != 0 {
+		t.Ctime = attr.ChangeTime()
+	}
+	if attr.Atime != 0 || attr.AtimeNs != 0 {
+		t.Atime = attr.AccessTime()
+	}
 	t.Mode = os.FileMode(attr.FileMode)
 	t.Uid = attr.Uid
 	t.Gid = attr.Gid
@@ -0,0 +1,32 @@
+package filer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryAttributeTimes(t *testing.T) {
+	entry := &Entry{FullPath: "/a.txt", Attr: Attr{
+		Mtime:  time.Unix(1700000000, 123456789),
+		Crtime: time.Unix(1600000000, 987654321),
+	}}
+	attr := PbToEntryAttribute(EntryAttributeToPb(entry))
+	assert.True(t, attr.Mtime.Equal(entry.Mtime))
+	assert.True(t, attr.Crtime.Equal(entry.Crtime))
+	assert.True(t, attr.Ctime.IsZero())
+	assert.True(t, attr.Atime.IsZero())
+
+	entry.Ctime = time.Unix(1700000001, 1)
+	entry.Atime = time.Unix(1700000002, 2)
+	attr = PbToEntryAttribute(EntryAttributeToPb(entry))
+	assert.True(t, attr.Ctime.Equal(entry.Ctime))
+	assert.True(t, attr.Atime.Equal(entry.Atime))
+
+	// the entries saved before the nanoseconds were kept
+	attr = PbToEntryAttribute(&filer_pb.FuseAttributes{Mtime: 1700000000, Crtime: 1600000000})
+	assert.True(t, attr.Mtime.Equal(time.Unix(1700000000, 0)))
+	assert.True(t, attr.Ctime.IsZero())
+}
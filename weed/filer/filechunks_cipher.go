@@ -0,0 +1,41 @@
+package filer
+
+import (
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// EncryptChunkCipherKeys encrypts the cipher key of every chunk with the given key,
+// so the chunk data can only be read back by someone presenting the same key.
+// The keys of the chunks inside a manifest are protected by the manifest chunk's own key.
+func EncryptChunkCipherKeys(chunks []*filer_pb.FileChunk, key util.CipherKey) error {
+	for _, chunk := range chunks {
+		if len(chunk.CipherKey) == 0 {
+			return fmt.Errorf("chunk %s is not encrypted", chunk.GetFileIdString())
+		}
+		encryptedKey, err := util.Encrypt(chunk.CipherKey, key)
+		if err != nil {
+			return fmt.Errorf("encrypt cipher key of chunk %s: %v", chunk.GetFileIdString(), err)
+		}
+		chunk.CipherKey = encryptedKey
+	}
+	return nil
+}
+
+// DecryptChunkCipherKeys returns a copy of the chunks with the cipher keys decrypted by the given key.
+func DecryptChunkCipherKeys(chunks []*filer_pb.FileChunk, key util.CipherKey) ([]*filer_pb.FileChunk, error) {
+	decrypted := make([]*filer_pb.FileChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		cipherKey, err := util.Decrypt(chunk.CipherKey, key)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt cipher key of chunk %s: %v", chunk.GetFileIdString(), err)
+		}
+		c := proto.Clone(chunk).(*filer_pb.FileChunk)
+		c.CipherKey = cipherKey
+		decrypted = append(decrypted, c)
+	}
+	return decrypted, nil
+}
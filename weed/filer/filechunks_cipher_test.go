@@ -0,0 +1,33 @@
+package filer
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkCipherKeys(t *testing.T) {
+	chunkKey1, chunkKey2 := util.GenCipherKey(), util.GenCipherKey()
+	chunks := []*filer_pb.FileChunk{
+		{FileId: "1,01", Offset: 0, Size: 10, CipherKey: chunkKey1},
+		{FileId: "1,02", Offset: 10, Size: 10, CipherKey: chunkKey2},
+	}
+
+	key := util.GenCipherKey()
+	assert.Nil(t, EncryptChunkCipherKeys(chunks, key))
+	assert.NotEqual(t, []byte(chunkKey1), chunks[0].CipherKey)
+
+	decrypted, err := DecryptChunkCipherKeys(chunks, key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(chunkKey1), decrypted[0].CipherKey)
+	assert.Equal(t, []byte(chunkKey2), decrypted[1].CipherKey)
+	assert.Equal(t, int64(10), decrypted[1].Offset)
+	assert.NotEqual(t, []byte(chunkKey1), chunks[0].CipherKey, "the original chunks keep the encrypted keys")
+
+	_, err = DecryptChunkCipherKeys(chunks, util.GenCipherKey())
+	assert.NotNil(t, err, "a different key can not decrypt the chunk keys")
+
+	assert.NotNil(t, EncryptChunkCipherKeys([]*filer_pb.FileChunk{{FileId: "1,03"}}, key), "chunks without cipher key")
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/cluster/lock_manager"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3event"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3replication"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/seaweedfs/seaweedfs/weed/util/log_buffer"
 	"github.com/seaweedfs/seaweedfs/weed/wdclient"
@@ -52,6 +55,17 @@ type Filer struct {
 	RemoteStorage       *FilerRemoteStorage
 	Dlm                 *lock_manager.DistributedLockManager
 	MaxFilenameLength   uint32
+	S3EventNotifier     *s3event.Notifier
+	S3Replicator        *s3replication.Replicator
+	snapshotChunkLock   sync.Mutex
+	// quotaUsageLock guards the updates of the quota usages and the reserved changes, quotaCountLock the counting of the new quotas
+	quotaUsageLock        sync.Mutex
+	quotaReserved         map[string]QuotaUsage
+	quotaCountLock        sync.Mutex
+	quotaLocationPrefixes map[string]bool
+	// dirUsageLock guards the recursive folder usages and their changes not flushed yet
+	dirUsageLock    sync.Mutex
+	dirUsagePending map[util.FullPath]*pendingDirUsage
 }
 
 func NewFiler(masters pb.ServerDiscovery, grpcDialOption grpc.DialOption, filerHost pb.ServerAddress, filerGroup string, collection string, replication string, dataCenter string, maxFilenameLength uint32, notifyFn func()) *Filer {
@@ -64,6 +78,7 @@ func NewFiler(masters pb.ServerDiscovery, grpcDialOption grpc.DialOption, filerH
 		UniqueFilerId:       util.RandomInt32(),
 		Dlm:                 lock_manager.NewDistributedLockManager(filerHost),
 		MaxFilenameLength:   maxFilenameLength,
+		dirUsagePending:     make(map[util.FullPath]*pendingDirUsage),
 	}
 	if f.UniqueFilerId < 0 {
 		f.UniqueFilerId = -f.UniqueFilerId
@@ -74,6 +89,8 @@ func NewFiler(masters pb.ServerDiscovery, grpcDialOption grpc.DialOption, filerH
 	f.metaLogReplication = replication
 
 	go f.loopProcessingDeletion()
+	go f.loopPurgingTrash()
+	go f.loopFlushingDirectoryUsage()
 
 	return f
 }
@@ -195,6 +212,12 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool, isFr
 		return fmt.Errorf("entry name too long")
 	}
 
+	if !isFromOtherCluster && !isSnapshotOp(ctx) {
+		if err := CheckSnapshotWritable(entry.FullPath); err != nil {
+			return err
+		}
+	}
+
 	oldEntry, _ := f.FindEntry(ctx, entry.FullPath)
 
 	/*
@@ -205,6 +228,16 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool, isFr
 		}
 	*/
 
+	if !isFromOtherCluster && !isSnapshotOp(ctx) && ctx.Value("OP") != "MV" {
+		release, err := f.ReserveQuota(ctx, oldEntry, entry)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	f.MarkS3ReplicationPending(oldEntry, entry, isFromOtherCluster)
+
 	if oldEntry == nil {
 
 		if !skipCreateParentDir {
@@ -233,7 +266,10 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool, isFr
 
 	f.NotifyUpdateEvent(ctx, oldEntry, entry, true, isFromOtherCluster, signatures)
 
-	f.deleteChunksIfNotNew(oldEntry, entry)
+	// the chunks of the snapshot entries are released by their references
+	if !isSnapshotOp(ctx) {
+		f.deleteChunksIfNotNew(oldEntry, entry)
+	}
 
 	glog.V(4).Infof("CreateEntry %s: created", entry.FullPath)
 
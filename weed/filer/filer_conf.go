@@ -24,7 +24,6 @@ const (
 	FilerConfName         = "filer.conf"
 	IamConfigDirectory    = "/etc/iam"
 	IamIdentityFile       = "identity.json"
-	IamPoliciesFile       = "policies.json"
 )
 
 type FilerConf struct {
@@ -196,6 +195,16 @@ func mergePathConf(a, b *filer_pb.FilerConf_PathConf) {
 	if b.WormGracePeriodSeconds > 0 {
 		a.WormGracePeriodSeconds = b.WormGracePeriodSeconds
 	}
+	a.Trash = b.Trash || a.Trash
+	if b.TrashRetentionSeconds > 0 {
+		a.TrashRetentionSeconds = b.TrashRetentionSeconds
+	}
+	if b.QuotaBytes > 0 {
+		a.QuotaBytes = b.QuotaBytes
+	}
+	if b.QuotaInodes > 0 {
+		a.QuotaInodes = b.QuotaInodes
+	}
 }
 
 func (fc *FilerConf) ToProto() *filer_pb.FilerConf {
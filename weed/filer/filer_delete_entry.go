@@ -29,7 +29,19 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p util.FullPath, isR
 	if ifNotModifiedAfter > 0 && entry.Attr.Mtime.Unix() > ifNotModifiedAfter {
 		return nil
 	}
+	if !isFromOtherCluster {
+		if err = f.checkSnapshotDeletion(ctx, entry); err != nil {
+			return err
+		}
+	}
 	isDeleteCollection := f.isBucket(entry)
+	if shouldDeleteChunks && !isFromOtherCluster && !isDeleteCollection {
+		if trashDir := f.trashDirectory(p); trashDir != "" {
+			if moved, err := f.moveToTrash(ctx, entry, trashDir, isRecursive, signatures); moved || err != nil {
+				return err
+			}
+		}
+	}
 	if entry.IsDirectory() {
 		// delete the folder children, not including the folder itself
 		err = f.doBatchDeleteFolderMetaAndData(ctx, entry, isRecursive, ignoreRecursiveError, shouldDeleteChunks && !isDeleteCollection, isDeleteCollection, isFromOtherCluster, signatures, func(hardLinkIds []HardLinkId) error {
@@ -86,6 +98,12 @@ func (f *Filer) doBatchDeleteFolderMetaAndData(ctx context.Context, entry *Entry
 			for _, sub := range entries {
 				lastFileName = sub.Name()
 				if sub.IsDirectory() {
+					if sub.Name() == SnapshotsFolder && !isFromOtherCluster {
+						// the chunks are kept, but the snapshots would be lost
+						if err = f.checkSnapshotDeletion(ctx, sub); err != nil {
+							return err
+						}
+					}
 					subIsDeletingBucket := f.isBucket(sub)
 					err = f.doBatchDeleteFolderMetaAndData(ctx, sub, isRecursive, ignoreRecursiveError, shouldDeleteChunks, subIsDeletingBucket, false, nil, onHardLinkIdsFn)
 				} else {
@@ -85,9 +85,10 @@ func (f *Filer) DeleteChunks(fullpath util.FullPath, chunks []*filer_pb.FileChun
 }
 
 func (f *Filer) doDeleteChunks(chunks []*filer_pb.FileChunk) {
+	var fileIds []string
 	for _, chunk := range chunks {
 		if !chunk.IsChunkManifest {
-			f.fileIdDeletionQueue.EnQueue(chunk.GetFileIdString())
+			fileIds = append(fileIds, chunk.GetFileIdString())
 			continue
 		}
 		dataChunks, manifestResolveErr := ResolveOneChunkManifest(f.MasterClient.LookupFileId, chunk)
@@ -95,15 +96,28 @@ func (f *Filer) doDeleteChunks(chunks []*filer_pb.FileChunk) {
 			glog.V(0).Infof("failed to resolve manifest %s: %v", chunk.FileId, manifestResolveErr)
 		}
 		for _, dChunk := range dataChunks {
-			f.fileIdDeletionQueue.EnQueue(dChunk.GetFileIdString())
+			fileIds = append(fileIds, dChunk.GetFileIdString())
 		}
-		f.fileIdDeletionQueue.EnQueue(chunk.GetFileIdString())
+		fileIds = append(fileIds, chunk.GetFileIdString())
 	}
+	f.deleteFileIds(fileIds)
 }
 
 func (f *Filer) DeleteChunksNotRecursive(chunks []*filer_pb.FileChunk) {
+	var fileIds []string
 	for _, chunk := range chunks {
-		f.fileIdDeletionQueue.EnQueue(chunk.GetFileIdString())
+		fileIds = append(fileIds, chunk.GetFileIdString())
+	}
+	f.deleteFileIds(fileIds)
+}
+
+// deleteFileIds queues the chunks to delete, except the ones still referenced by the snapshots
+func (f *Filer) deleteFileIds(fileIds []string) {
+	if len(fileIds) == 0 {
+		return
+	}
+	for _, fileId := range f.keepSnapshotChunks(fileIds) {
+		f.fileIdDeletionQueue.EnQueue(fileId)
 	}
 }
 
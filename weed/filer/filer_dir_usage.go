@@ -0,0 +1,276 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// The recursive usage of each folder, i.e. the files, the folders and the sizes under it, is kept up to date with
+// the changes of the entries. The changes are accumulated in memory, and added to the usages in the filer store
+// every second. A store shared by several filers is only updated by the filer counting the usages of all of them,
+// see countsSharedStoreUsages, so the filers do not overwrite each other's usages.
+const (
+	dirUsageKeyPrefix     = "dir.usage."
+	dirUsageFlushInterval = time.Second
+)
+
+type dirUsage struct {
+	files, directories, logicalSize, physicalSize, chunks int64
+}
+
+// pendingDirUsage is the change of the usage not flushed yet, reset means the stored usage is replaced
+type pendingDirUsage struct {
+	delta dirUsage
+	reset bool
+}
+
+func (u *dirUsage) add(o dirUsage, sign int64) {
+	u.files += sign * o.files
+	u.directories += sign * o.directories
+	u.logicalSize += sign * o.logicalSize
+	u.physicalSize += sign * o.physicalSize
+	u.chunks += sign * o.chunks
+}
+
+func (u dirUsage) isZero() bool {
+	return u == dirUsage{}
+}
+
+func (u dirUsage) toPb() *filer_pb.DirectoryUsage {
+	toUint64 := func(v int64) uint64 {
+		return uint64(max(v, 0))
+	}
+	return &filer_pb.DirectoryUsage{
+		FileCount:      toUint64(u.files),
+		DirectoryCount: toUint64(u.directories),
+		LogicalSize:    toUint64(u.logicalSize),
+		PhysicalSize:   toUint64(u.physicalSize),
+		ChunkCount:     toUint64(u.chunks),
+	}
+}
+
+func dirUsageFromPb(usage *filer_pb.DirectoryUsage) dirUsage {
+	return dirUsage{
+		files:        int64(usage.FileCount),
+		directories:  int64(usage.DirectoryCount),
+		logicalSize:  int64(usage.LogicalSize),
+		physicalSize: int64(usage.PhysicalSize),
+		chunks:       int64(usage.ChunkCount),
+	}
+}
+
+// entryDirUsage is the usage of one entry, not including its children
+func entryDirUsage(entry *Entry) dirUsage {
+	if entry.IsDirectory() {
+		return dirUsage{directories: 1}
+	}
+	return dirUsage{
+		files:        1,
+		logicalSize:  int64(entry.Size()),
+		physicalSize: int64(TotalSize(entry.GetChunks())),
+		chunks:       int64(len(entry.GetChunks())),
+	}
+}
+
+func dirUsageKey(dir util.FullPath) []byte {
+	return []byte(dirUsageKeyPrefix + string(dir))
+}
+
+// parentDirectories lists the folders containing the path, from the parent to the root
+func parentDirectories(p util.FullPath) (dirs []util.FullPath) {
+	for p != "/" && p != "" {
+		dir, _ := p.DirAndName()
+		p = util.FullPath(dir)
+		dirs = append(dirs, p)
+	}
+	return
+}
+
+func isInSnapshot(p util.FullPath) bool {
+	_, _, found := snapshotOf(p)
+	return found
+}
+
+// updateDirectoryUsage counts the change of the entry in the usage of its parent folders
+func (f *Filer) updateDirectoryUsage(ctx context.Context, oldEntry, newEntry *Entry) {
+	// the snapshots share the content of the live entries
+	if oldEntry != nil && isInSnapshot(oldEntry.FullPath) {
+		oldEntry = nil
+	}
+	if newEntry != nil && isInSnapshot(newEntry.FullPath) {
+		newEntry = nil
+	}
+	if oldEntry == nil && newEntry == nil {
+		return
+	}
+	if oldEntry != nil && newEntry != nil && oldEntry.FullPath == newEntry.FullPath && oldEntry.IsDirectory() && newEntry.IsDirectory() {
+		return
+	}
+
+	f.dirUsageLock.Lock()
+	defer f.dirUsageLock.Unlock()
+
+	if oldEntry != nil {
+		removed := entryDirUsage(oldEntry)
+		if oldEntry.IsDirectory() {
+			// the children of a dropped bucket are deleted without their changes
+			remaining, err := f.readDirectoryUsage(ctx, oldEntry.FullPath)
+			if err != nil {
+				glog.Errorf("read usage of %s: %v", oldEntry.FullPath, err)
+			}
+			removed.add(remaining, 1)
+			f.dirUsagePending[oldEntry.FullPath] = &pendingDirUsage{reset: true}
+		}
+		f.addDirectoryUsage(oldEntry.FullPath, removed, -1)
+	}
+	if newEntry != nil {
+		if newEntry.IsDirectory() && oldEntry == nil {
+			// a new folder has no children, forget the usage of a deleted folder at the same path
+			f.dirUsagePending[newEntry.FullPath] = &pendingDirUsage{reset: true}
+		}
+		f.addDirectoryUsage(newEntry.FullPath, entryDirUsage(newEntry), 1)
+	}
+}
+
+func (f *Filer) addDirectoryUsage(p util.FullPath, usage dirUsage, sign int64) {
+	if usage.isZero() {
+		return
+	}
+	for _, dir := range parentDirectories(p) {
+		pending, found := f.dirUsagePending[dir]
+		if !found {
+			pending = &pendingDirUsage{}
+			f.dirUsagePending[dir] = pending
+		}
+		pending.delta.add(usage, sign)
+	}
+}
+
+// readDirectoryUsage returns the usage of the folder, including the changes not flushed yet
+func (f *Filer) readDirectoryUsage(ctx context.Context, dir util.FullPath) (usage dirUsage, err error) {
+	pending, found := f.dirUsagePending[dir]
+	if found && pending.reset {
+		return pending.delta, nil
+	}
+	value, err := f.Store.KvGet(ctx, dirUsageKey(dir))
+	if err != nil && err != ErrKvNotFound {
+		return usage, err
+	}
+	if err == nil {
+		stored := &filer_pb.DirectoryUsage{}
+		if err = proto.Unmarshal(value, stored); err != nil {
+			return usage, fmt.Errorf("decode usage of %s: %v", dir, err)
+		}
+		usage = dirUsageFromPb(stored)
+	}
+	if found {
+		usage.add(pending.delta, 1)
+	}
+	return usage, nil
+}
+
+// DirectoryUsage returns the recursive usage of the folder
+func (f *Filer) DirectoryUsage(ctx context.Context, dir util.FullPath) (*filer_pb.DirectoryUsage, error) {
+	f.dirUsageLock.Lock()
+	defer f.dirUsageLock.Unlock()
+	usage, err := f.readDirectoryUsage(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read usage of %s: %v", dir, err)
+	}
+	return usage.toPb(), nil
+}
+
+// RecountDirectoryUsage counts the usage of the folder and its sub folders by walking them,
+// and corrects the usage of the parent folders. The changes during the walk may be counted twice or missed.
+func (f *Filer) RecountDirectoryUsage(ctx context.Context, dir util.FullPath) (*filer_pb.DirectoryUsage, error) {
+	counted := make(map[util.FullPath]dirUsage)
+	usage, err := f.countDirectoryUsage(ctx, dir, counted)
+	if err != nil {
+		return nil, err
+	}
+
+	f.dirUsageLock.Lock()
+	defer f.dirUsageLock.Unlock()
+	previous, err := f.readDirectoryUsage(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read usage of %s: %v", dir, err)
+	}
+	for p, u := range counted {
+		f.dirUsagePending[p] = &pendingDirUsage{delta: u, reset: true}
+	}
+	usage.add(previous, -1)
+	f.addDirectoryUsage(dir, usage, 1)
+	return counted[dir].toPb(), nil
+}
+
+func (f *Filer) countDirectoryUsage(ctx context.Context, dir util.FullPath, counted map[util.FullPath]dirUsage) (usage dirUsage, err error) {
+	lastFileName := ""
+	for {
+		entries, hasMore, err := f.ListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize, "", "", "")
+		if err != nil {
+			return usage, fmt.Errorf("list folder %s: %v", dir, err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			usage.add(entryDirUsage(entry), 1)
+			if entry.IsDirectory() && entry.Name() != SnapshotsFolder {
+				subUsage, err := f.countDirectoryUsage(ctx, entry.FullPath, counted)
+				if err != nil {
+					return usage, err
+				}
+				usage.add(subUsage, 1)
+			}
+		}
+		if !hasMore {
+			break
+		}
+	}
+	counted[dir] = usage
+	return usage, nil
+}
+
+func (f *Filer) loopFlushingDirectoryUsage() {
+	for {
+		time.Sleep(dirUsageFlushInterval)
+		f.flushDirectoryUsage()
+	}
+}
+
+// flushDirectoryUsage adds the accumulated changes to the usages in the filer store
+func (f *Filer) flushDirectoryUsage() {
+	ctx := context.Background()
+	f.dirUsageLock.Lock()
+	defer f.dirUsageLock.Unlock()
+	for dir, pending := range f.dirUsagePending {
+		if pending.reset && pending.delta.isZero() {
+			if err := f.Store.KvDelete(ctx, dirUsageKey(dir)); err != nil {
+				glog.Errorf("delete usage of %s: %v", dir, err)
+				continue
+			}
+			delete(f.dirUsagePending, dir)
+			continue
+		}
+		usage, err := f.readDirectoryUsage(ctx, dir)
+		if err != nil {
+			glog.Errorf("read usage of %s: %v", dir, err)
+			continue
+		}
+		value, err := proto.Marshal(usage.toPb())
+		if err != nil {
+			glog.Errorf("encode usage of %s: %v", dir, err)
+			continue
+		}
+		if err = f.Store.KvPut(ctx, dirUsageKey(dir), value); err != nil {
+			glog.Errorf("save usage of %s: %v", dir, err)
+			continue
+		}
+		delete(f.dirUsagePending, dir)
+	}
+}
@@ -0,0 +1,105 @@
+package filer
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type kvOnlyStore struct {
+	VirtualFilerStore
+	kv map[string][]byte
+}
+
+func (store *kvOnlyStore) KvGet(ctx context.Context, key []byte) ([]byte, error) {
+	value, found := store.kv[string(key)]
+	if !found {
+		return nil, ErrKvNotFound
+	}
+	return value, nil
+}
+
+func (store *kvOnlyStore) KvPut(ctx context.Context, key []byte, value []byte) error {
+	store.kv[string(key)] = value
+	return nil
+}
+
+func (store *kvOnlyStore) KvDelete(ctx context.Context, key []byte) error {
+	delete(store.kv, string(key))
+	return nil
+}
+
+func TestParentDirectories(t *testing.T) {
+	assert.Equal(t, []util.FullPath{"/a/b", "/a", "/"}, parentDirectories("/a/b/c.txt"))
+	assert.Equal(t, []util.FullPath{"/"}, parentDirectories("/a"))
+	assert.Empty(t, parentDirectories("/"))
+}
+
+func TestUpdateDirectoryUsage(t *testing.T) {
+	f := &Filer{
+		Store:           &kvOnlyStore{kv: make(map[string][]byte)},
+		dirUsagePending: make(map[util.FullPath]*pendingDirUsage),
+	}
+	ctx := context.Background()
+	dir := func(p util.FullPath) *Entry {
+		return &Entry{FullPath: p, Attr: Attr{Mode: os.ModeDir | 0755}}
+	}
+	file := func(p util.FullPath, size uint64) *Entry {
+		return &Entry{FullPath: p, Attr: Attr{FileSize: size}, Chunks: []*filer_pb.FileChunk{{FileId: "1,01", Size: size}}}
+	}
+	usageOf := func(p util.FullPath) *filer_pb.DirectoryUsage {
+		usage, err := f.DirectoryUsage(ctx, p)
+		assert.NoError(t, err)
+		return usage
+	}
+
+	f.updateDirectoryUsage(ctx, nil, dir("/a"))
+	f.updateDirectoryUsage(ctx, nil, dir("/a/b"))
+	f.updateDirectoryUsage(ctx, nil, file("/a/b/1.txt", 100))
+	f.flushDirectoryUsage()
+	f.updateDirectoryUsage(ctx, nil, file("/a/2.txt", 20))
+	f.updateDirectoryUsage(ctx, file("/a/b/1.txt", 100), file("/a/b/1.txt", 150))
+
+	assert.Equal(t, &filer_pb.DirectoryUsage{FileCount: 2, DirectoryCount: 1, LogicalSize: 170, PhysicalSize: 170, ChunkCount: 2}, usageOf("/a"))
+	assert.Equal(t, &filer_pb.DirectoryUsage{FileCount: 2, DirectoryCount: 2, LogicalSize: 170, PhysicalSize: 170, ChunkCount: 2}, usageOf("/"))
+	assert.Equal(t, uint64(150), usageOf("/a/b").LogicalSize)
+
+	// the snapshots are not counted
+	f.updateDirectoryUsage(ctx, nil, file("/a/.snapshots/s1/2.txt", 20))
+	assert.Equal(t, uint64(2), usageOf("/a").FileCount)
+
+	// the children of a dropped folder are removed with it
+	f.flushDirectoryUsage()
+	f.updateDirectoryUsage(ctx, dir("/a/b"), nil)
+	assert.Equal(t, &filer_pb.DirectoryUsage{FileCount: 1, LogicalSize: 20, PhysicalSize: 20, ChunkCount: 1}, usageOf("/a"))
+	assert.Equal(t, &filer_pb.DirectoryUsage{}, usageOf("/a/b"))
+
+	f.updateDirectoryUsage(ctx, nil, dir("/a/b"))
+	f.flushDirectoryUsage()
+	assert.Equal(t, &filer_pb.DirectoryUsage{}, usageOf("/a/b"))
+	assert.Equal(t, &filer_pb.DirectoryUsage{FileCount: 1, DirectoryCount: 2, LogicalSize: 20, PhysicalSize: 20, ChunkCount: 1}, usageOf("/"))
+}
+
+func TestCountUsagesOfSharedStore(t *testing.T) {
+	f := &Filer{
+		FilerConf:       NewFilerConf(),
+		Store:           &kvOnlyStore{kv: make(map[string][]byte)},
+		dirUsagePending: make(map[util.FullPath]*pendingDirUsage),
+	}
+	f.MetaAggregator = NewMetaAggregator(f, "filer2:8888", nil)
+	ctx := context.Background()
+	file := &Entry{FullPath: "/a/1.txt", Attr: Attr{FileSize: 10}}
+
+	// the filer with the smallest address counts the usages of the shared store
+	f.MetaAggregator.setSharedStorePeer("filer1:8888", true)
+	f.countUsages(ctx, nil, file)
+	assert.Empty(t, f.dirUsagePending)
+
+	f.MetaAggregator.setSharedStorePeer("filer1:8888", false)
+	f.countUsages(ctx, nil, file)
+	assert.Equal(t, int64(1), f.dirUsagePending["/a"].delta.files)
+}
@@ -63,10 +63,23 @@ func (f *Filer) NotifyUpdateEvent(ctx context.Context, oldEntry, newEntry *Entry
 		}
 	}
 
+	f.notifyS3Events(oldEntry, newEntry, isFromOtherCluster, ctx.Value("OP") == "MV")
+	f.replicateS3Objects(oldEntry, newEntry, isFromOtherCluster, ctx.Value("OP") == "MV", signatures)
+	f.countUsages(ctx, oldEntry, newEntry)
+
 	f.logMetaEvent(ctx, fullpath, eventNotification)
 
 }
 
+// countUsages counts the change of the entry in the quota and folder usages, if this filer counts them
+func (f *Filer) countUsages(ctx context.Context, oldEntry, newEntry *Entry) {
+	if f.MetaAggregator != nil && !f.MetaAggregator.countsSharedStoreUsages() {
+		return
+	}
+	f.updateQuotaUsage(ctx, oldEntry, newEntry)
+	f.updateDirectoryUsage(ctx, oldEntry, newEntry)
+}
+
 func (f *Filer) logMetaEvent(ctx context.Context, fullpath string, eventNotification *filer_pb.EventNotification) {
 
 	dir, _ := util.FullPath(fullpath).DirAndName()
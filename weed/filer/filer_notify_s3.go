@@ -0,0 +1,159 @@
+package filer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3event"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// LoadS3EventNotification starts the targets of the bucket notification configurations,
+// configured in the [s3_notification] section of notification.toml
+func (f *Filer) LoadS3EventNotification(config *util.ViperProxy) {
+	notifier, err := s3event.LoadNotifier(config, "s3_notification", f.bucketConfigurationReader(s3_constants.ExtNotificationKey))
+	if err != nil {
+		glog.Fatalf("Failed to initialize s3 event notification: %v", err)
+	}
+	f.S3EventNotifier = notifier
+}
+
+// bucketConfigurationReader reads a configuration kept in the extended attributes of the buckets
+func (f *Filer) bucketConfigurationReader(extendedKey string) func(bucket string) ([]byte, error) {
+	return func(bucket string) ([]byte, error) {
+		entry, err := f.FindEntry(context.Background(), util.NewFullPath(f.DirBucketsPath, bucket))
+		if err == filer_pb.ErrNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return entry.Extended[extendedKey], nil
+	}
+}
+
+// notifyS3Events sends the s3 events of a change made by this filer, the filers of other clusters notify their own changes.
+// The entries moved by renames, e.g. the versions archived or promoted by the versioned writes and deletes, are not notified.
+func (f *Filer) notifyS3Events(oldEntry, newEntry *Entry, isFromOtherCluster, isMove bool) {
+	if f.S3EventNotifier == nil || f.DirBucketsPath == "" {
+		return
+	}
+	f.invalidateS3NotificationConfiguration(oldEntry)
+	f.invalidateS3NotificationConfiguration(newEntry)
+	if isFromOtherCluster || isMove {
+		return
+	}
+	for _, event := range f.s3ObjectEvents(oldEntry, newEntry) {
+		f.S3EventNotifier.Notify(event)
+	}
+}
+
+// invalidateS3NotificationConfiguration forgets the cached configuration of a changed bucket
+func (f *Filer) invalidateS3NotificationConfiguration(entry *Entry) {
+	if entry == nil || !entry.IsDirectory() {
+		return
+	}
+	if dir, name := entry.FullPath.DirAndName(); dir == f.DirBucketsPath {
+		f.S3EventNotifier.Invalidate(name)
+	}
+}
+
+// s3Object is the object, or the object version, kept by an entry
+type s3Object struct {
+	bucket    string
+	key       string
+	versionId string
+	// the noncurrent versions and the delete markers are kept in the versions folder
+	isVersion bool
+}
+
+func (f *Filer) toS3Object(entry *Entry) *s3Object {
+	if entry == nil || entry.IsDirectory() {
+		return nil
+	}
+	if _, _, inSnapshot := snapshotOf(entry.FullPath); inSnapshot {
+		return nil
+	}
+	bucketAndKey, found := strings.CutPrefix(string(entry.FullPath), f.DirBucketsPath+"/")
+	if !found {
+		return nil
+	}
+	bucket, key, found := strings.Cut(bucketAndKey, "/")
+	if !found || strings.HasPrefix(key, s3_constants.MultipartUploadsFolder+"/") || strings.HasPrefix(key, TrashFolder+"/") {
+		return nil
+	}
+	// .versions/<key>/<versionId>
+	if versionKey, found := strings.CutPrefix(key, s3_constants.VersionsFolder+"/"); found {
+		dir, versionId := util.FullPath("/" + versionKey).DirAndName()
+		if dir == "/" {
+			return nil
+		}
+		return &s3Object{bucket: bucket, key: dir[1:], versionId: versionId, isVersion: true}
+	}
+	return &s3Object{bucket: bucket, key: key, versionId: string(entry.Extended[s3_constants.ExtVersionIdKey])}
+}
+
+// s3ObjectEvents tells the s3 events of a change of the entries, the changes of the metadata only are not notified
+func (f *Filer) s3ObjectEvents(oldEntry, newEntry *Entry) (events []*s3event.ObjectEvent) {
+	oldObject, newObject := f.toS3Object(oldEntry), f.toS3Object(newEntry)
+	if oldObject != nil && newObject != nil && oldEntry.FullPath == newEntry.FullPath && !isContentChanged(oldEntry, newEntry) {
+		return nil
+	}
+	tsNs := time.Now().UnixNano()
+	if oldObject != nil && (newObject == nil || oldEntry.FullPath != newEntry.FullPath) {
+		events = append(events, &s3event.ObjectEvent{
+			EventName: s3event.ObjectRemovedDelete,
+			Bucket:    oldObject.bucket,
+			Key:       oldObject.key,
+			VersionId: oldObject.versionId,
+			Owner:     string(oldEntry.Extended[s3_constants.ExtAmzOwnerKey]),
+			TsNs:      tsNs,
+		})
+	}
+	if newObject == nil {
+		return events
+	}
+	event := &s3event.ObjectEvent{
+		Bucket:    newObject.bucket,
+		Key:       newObject.key,
+		VersionId: newObject.versionId,
+		Owner:     string(newEntry.Extended[s3_constants.ExtAmzOwnerKey]),
+		TsNs:      tsNs,
+	}
+	if newObject.isVersion {
+		if string(newEntry.Extended[s3_constants.ExtDeleteMarkerKey]) != "true" {
+			return events
+		}
+		event.EventName = s3event.ObjectRemovedDeleteMarkerCreated
+		return append(events, event)
+	}
+	event.EventName = s3event.ObjectCreatedPut
+	if len(newEntry.Extended[s3_constants.ExtMultipartPartsKey]) > 0 {
+		event.EventName = s3event.ObjectCreatedCompleteMultipartUpload
+	}
+	event.Size = newEntry.Size()
+	event.ETag = ETagEntry(newEntry)
+	return append(events, event)
+}
+
+// isContentChanged tells an overwritten object from an object whose tags, acl or retention are changed
+func isContentChanged(oldEntry, newEntry *Entry) bool {
+	if !bytes.Equal(oldEntry.Md5, newEntry.Md5) || !bytes.Equal(oldEntry.Content, newEntry.Content) {
+		return true
+	}
+	oldChunks, newChunks := oldEntry.GetChunks(), newEntry.GetChunks()
+	if len(oldChunks) != len(newChunks) {
+		return true
+	}
+	for i, chunk := range oldChunks {
+		if chunk.GetFileIdString() != newChunks[i].GetFileIdString() {
+			return true
+		}
+	}
+	return false
+}
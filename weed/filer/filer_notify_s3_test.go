@@ -0,0 +1,58 @@
+package filer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3event"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3ObjectEvents(t *testing.T) {
+	f := &Filer{DirBucketsPath: "/buckets"}
+	file := func(path, fileId string, extended map[string][]byte) *Entry {
+		return &Entry{
+			FullPath: util.FullPath(path),
+			Attr:     Attr{FileSize: 3},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 3}},
+			Extended: extended,
+		}
+	}
+	eventNames := func(events []*s3event.ObjectEvent) (names []string) {
+		for _, event := range events {
+			names = append(names, event.EventName+" "+event.Bucket+" "+event.Key+" "+event.VersionId)
+		}
+		return names
+	}
+	version := map[string][]byte{s3_constants.ExtVersionIdKey: []byte("v1")}
+	tagged := map[string][]byte{s3_constants.ExtVersionIdKey: []byte("v1"), "X-Amz-Tagging-a": []byte("b")}
+	deleteMarker := map[string][]byte{s3_constants.ExtVersionIdKey: []byte("v2"), s3_constants.ExtDeleteMarkerKey: []byte("true")}
+	multipart := map[string][]byte{s3_constants.ExtMultipartPartsKey: []byte("[]")}
+
+	tests := []struct {
+		name     string
+		oldEntry *Entry
+		newEntry *Entry
+		expected []string
+	}{
+		{"put", nil, file("/buckets/photos/a/b.jpg", "3,01", version), []string{"s3:ObjectCreated:Put photos a/b.jpg v1"}},
+		{"overwrite", file("/buckets/photos/a.jpg", "3,01", nil), file("/buckets/photos/a.jpg", "3,02", nil), []string{"s3:ObjectCreated:Put photos a.jpg "}},
+		{"tagging", file("/buckets/photos/a.jpg", "3,01", version), file("/buckets/photos/a.jpg", "3,01", tagged), nil},
+		{"multipart", nil, file("/buckets/photos/a.jpg", "3,01", multipart), []string{"s3:ObjectCreated:CompleteMultipartUpload photos a.jpg "}},
+		{"delete", file("/buckets/photos/a.jpg", "3,01", nil), nil, []string{"s3:ObjectRemoved:Delete photos a.jpg "}},
+		{"delete marker", nil, file("/buckets/photos/.versions/a/b.jpg/v2", "", deleteMarker), []string{"s3:ObjectRemoved:DeleteMarkerCreated photos a/b.jpg v2"}},
+		{"delete version", file("/buckets/photos/.versions/a.jpg/v1", "3,01", version), nil, []string{"s3:ObjectRemoved:Delete photos a.jpg v1"}},
+		{"part", nil, file("/buckets/photos/.uploads/123/0001_abc.part", "3,01", nil), nil},
+		{"outside buckets", nil, file("/data/a.jpg", "3,01", nil), nil},
+		{"directory", nil, &Entry{FullPath: "/buckets/photos/a", Attr: Attr{Mode: os.ModeDir}}, nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, eventNames(f.s3ObjectEvents(tt.oldEntry, tt.newEntry)), tt.name)
+	}
+
+	events := f.s3ObjectEvents(nil, file("/buckets/photos/a.jpg", "3,01", nil))
+	assert.Equal(t, uint64(3), events[0].Size)
+}
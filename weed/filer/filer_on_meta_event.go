@@ -18,6 +18,24 @@ func (f *Filer) onBucketEvents(event *filer_pb.SubscribeMetadataResponse) {
 	message := event.EventNotification
 
 	if f.DirBucketsPath == event.Directory {
+		if f.S3EventNotifier != nil {
+			// the notification configuration may be changed by other filers
+			if message.OldEntry != nil {
+				f.S3EventNotifier.Invalidate(message.OldEntry.Name)
+			}
+			if message.NewEntry != nil {
+				f.S3EventNotifier.Invalidate(message.NewEntry.Name)
+			}
+		}
+		if f.S3Replicator != nil {
+			// the replication configuration may be changed by other filers
+			if message.OldEntry != nil {
+				f.S3Replicator.Invalidate(message.OldEntry.Name)
+			}
+			if message.NewEntry != nil {
+				f.S3Replicator.Invalidate(message.NewEntry.Name)
+			}
+		}
 		if filer_pb.IsCreate(event) {
 			if message.NewEntry.IsDirectory {
 				f.Store.OnBucketCreation(message.NewEntry.Name)
@@ -66,6 +84,7 @@ func (f *Filer) reloadFilerConfiguration(entry *filer_pb.Entry) {
 		return
 	}
 	f.FilerConf = fc
+	go f.countQuotaUsages()
 }
 
 func (f *Filer) LoadFilerConf() {
@@ -78,6 +97,7 @@ func (f *Filer) LoadFilerConf() {
 		return
 	}
 	f.FilerConf = fc
+	go f.countQuotaUsages()
 }
 
 // //////////////////////////////////
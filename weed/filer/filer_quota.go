@@ -0,0 +1,285 @@
+package filer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// The quotas limit the bytes and the inodes, i.e. the files and the folders, under a location prefix:
+//
+//	fs.configure -locationPrefix=/home/alice/ -quotaMB=1024 -quotaInodes=100000 -apply
+//
+// The usage of a location is counted once when its quota is configured, then kept up to date with the changes
+// of the entries, and stored in the filer store by one of the filers sharing it. The changes growing the usage over
+// a quota are refused.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+const quotaUsageKeyPrefix = "quota.usage."
+
+type QuotaUsage struct {
+	Bytes  int64
+	Inodes int64
+}
+
+func QuotaUsageKey(locationPrefix string) []byte {
+	return []byte(quotaUsageKeyPrefix + locationPrefix)
+}
+
+func (usage QuotaUsage) Encode() []byte {
+	value := make([]byte, 16)
+	util.Uint64toBytes(value[:8], uint64(usage.Bytes))
+	util.Uint64toBytes(value[8:], uint64(usage.Inodes))
+	return value
+}
+
+func DecodeQuotaUsage(value []byte) (usage QuotaUsage, err error) {
+	if len(value) != 16 {
+		return usage, fmt.Errorf("unexpected quota usage length %d", len(value))
+	}
+	usage.Bytes = int64(util.BytesToUint64(value[:8]))
+	usage.Inodes = int64(util.BytesToUint64(value[8:]))
+	return usage, nil
+}
+
+// quotaLocations lists the locations with a quota containing the path
+func (f *Filer) quotaLocations(p util.FullPath) (locations []*filer_pb.FilerConf_PathConf) {
+	// the snapshots share the content of the live entries
+	if _, _, inSnapshot := snapshotOf(p); inSnapshot {
+		return nil
+	}
+	f.FilerConf.rules.MatchPrefix([]byte(p), func(key []byte, value *filer_pb.FilerConf_PathConf) bool {
+		if value.QuotaBytes > 0 || value.QuotaInodes > 0 {
+			locations = append(locations, value)
+		}
+		return true
+	})
+	return
+}
+
+// quotaDelta tells the change of the usage of the location by the change of the entry
+func quotaDelta(locationPrefix string, oldEntry, newEntry *Entry) (bytes, inodes int64) {
+	usageOf := func(entry *Entry) (int64, int64) {
+		if entry == nil || !strings.HasPrefix(string(entry.FullPath), locationPrefix) {
+			return 0, 0
+		}
+		if _, _, inSnapshot := snapshotOf(entry.FullPath); inSnapshot {
+			return 0, 0
+		}
+		if entry.IsDirectory() {
+			return 0, 1
+		}
+		return int64(entry.Size()), 1
+	}
+	oldBytes, oldInodes := usageOf(oldEntry)
+	newBytes, newInodes := usageOf(newEntry)
+	return newBytes - oldBytes, newInodes - oldInodes
+}
+
+// ReserveQuota refuses the change of the entry growing the usage of a location over its quota, otherwise reserves
+// the growth until the returned release is called, after the change is notified and counted.
+// The folders moved into a location are counted after the move, and the deletes are never refused.
+func (f *Filer) ReserveQuota(ctx context.Context, oldEntry, newEntry *Entry) (release func(), err error) {
+	release = func() {}
+	if newEntry == nil || f.isInTrash(newEntry.FullPath) {
+		return release, nil
+	}
+
+	f.quotaUsageLock.Lock()
+	defer f.quotaUsageLock.Unlock()
+	reserved := make(map[string]QuotaUsage)
+	for _, location := range f.quotaLocations(newEntry.FullPath) {
+		deltaBytes, deltaInodes := quotaDelta(location.LocationPrefix, oldEntry, newEntry)
+		if deltaBytes <= 0 && deltaInodes <= 0 {
+			continue
+		}
+		usage, found, err := f.readQuotaUsage(ctx, location.LocationPrefix)
+		if err != nil {
+			return release, err
+		}
+		if !found {
+			// still counting
+			continue
+		}
+		// the changes being written by the other requests
+		usage.Bytes += f.quotaReserved[location.LocationPrefix].Bytes
+		usage.Inodes += f.quotaReserved[location.LocationPrefix].Inodes
+		if location.QuotaBytes > 0 && deltaBytes > 0 && usage.Bytes+deltaBytes > int64(location.QuotaBytes) {
+			return release, fmt.Errorf("%w: %s uses %d of %d bytes", ErrQuotaExceeded, location.LocationPrefix, usage.Bytes, location.QuotaBytes)
+		}
+		if location.QuotaInodes > 0 && deltaInodes > 0 && usage.Inodes+deltaInodes > int64(location.QuotaInodes) {
+			return release, fmt.Errorf("%w: %s uses %d of %d files and folders", ErrQuotaExceeded, location.LocationPrefix, usage.Inodes, location.QuotaInodes)
+		}
+		reserved[location.LocationPrefix] = QuotaUsage{Bytes: max(deltaBytes, 0), Inodes: max(deltaInodes, 0)}
+	}
+	if len(reserved) == 0 {
+		return release, nil
+	}
+	f.addQuotaReserved(reserved, 1)
+	return func() {
+		f.quotaUsageLock.Lock()
+		defer f.quotaUsageLock.Unlock()
+		f.addQuotaReserved(reserved, -1)
+	}, nil
+}
+
+// CheckQuota refuses the change of the entry growing the usage of a location over its quota, without reserving it,
+// e.g. before a move counted after
+func (f *Filer) CheckQuota(ctx context.Context, oldEntry, newEntry *Entry) error {
+	release, err := f.ReserveQuota(ctx, oldEntry, newEntry)
+	release()
+	return err
+}
+
+func (f *Filer) addQuotaReserved(reserved map[string]QuotaUsage, sign int64) {
+	if f.quotaReserved == nil {
+		f.quotaReserved = make(map[string]QuotaUsage)
+	}
+	for locationPrefix, usage := range reserved {
+		total := f.quotaReserved[locationPrefix]
+		total.Bytes += sign * usage.Bytes
+		total.Inodes += sign * usage.Inodes
+		if total == (QuotaUsage{}) {
+			delete(f.quotaReserved, locationPrefix)
+			continue
+		}
+		f.quotaReserved[locationPrefix] = total
+	}
+}
+
+func (f *Filer) readQuotaUsage(ctx context.Context, locationPrefix string) (usage QuotaUsage, found bool, err error) {
+	value, err := f.Store.KvGet(ctx, QuotaUsageKey(locationPrefix))
+	if err == ErrKvNotFound {
+		return usage, false, nil
+	}
+	if err != nil {
+		return usage, false, fmt.Errorf("read quota usage of %s: %v", locationPrefix, err)
+	}
+	usage, err = DecodeQuotaUsage(value)
+	return usage, err == nil, err
+}
+
+// updateQuotaUsage counts the change of the entry in the usage of the locations with a quota
+func (f *Filer) updateQuotaUsage(ctx context.Context, oldEntry, newEntry *Entry) {
+	var locations []*filer_pb.FilerConf_PathConf
+	if oldEntry != nil {
+		locations = f.quotaLocations(oldEntry.FullPath)
+	}
+	if newEntry != nil && (oldEntry == nil || newEntry.FullPath != oldEntry.FullPath) {
+		locations = append(locations, f.quotaLocations(newEntry.FullPath)...)
+	}
+	if len(locations) == 0 {
+		return
+	}
+
+	f.quotaUsageLock.Lock()
+	defer f.quotaUsageLock.Unlock()
+	counted := make(map[string]bool)
+	for _, location := range locations {
+		if counted[location.LocationPrefix] {
+			continue
+		}
+		counted[location.LocationPrefix] = true
+		usage, found, err := f.readQuotaUsage(ctx, location.LocationPrefix)
+		if err != nil {
+			glog.Errorf("update quota usage: %v", err)
+			continue
+		}
+		if !found {
+			continue
+		}
+		if newEntry == nil && oldEntry.IsDirectory() && string(oldEntry.FullPath)+"/" == location.LocationPrefix {
+			// the children of a deleted bucket may be dropped without their changes
+			usage = QuotaUsage{}
+		} else {
+			deltaBytes, deltaInodes := quotaDelta(location.LocationPrefix, oldEntry, newEntry)
+			if deltaBytes == 0 && deltaInodes == 0 {
+				continue
+			}
+			usage.Bytes = max(usage.Bytes+deltaBytes, 0)
+			usage.Inodes = max(usage.Inodes+deltaInodes, 0)
+		}
+		if err = f.Store.KvPut(ctx, QuotaUsageKey(location.LocationPrefix), usage.Encode()); err != nil {
+			glog.Errorf("update quota usage of %s: %v", location.LocationPrefix, err)
+		}
+	}
+}
+
+// countQuotaUsages counts the usage of the locations with a new quota, and forgets the usage of the removed ones.
+// The changes during the counting are not counted.
+func (f *Filer) countQuotaUsages() {
+	f.quotaCountLock.Lock()
+	defer f.quotaCountLock.Unlock()
+
+	ctx := context.Background()
+	locationPrefixes := make(map[string]bool)
+	f.FilerConf.rules.Walk(func(key []byte, value *filer_pb.FilerConf_PathConf) bool {
+		if value.QuotaBytes > 0 || value.QuotaInodes > 0 {
+			locationPrefixes[string(key)] = true
+		}
+		return true
+	})
+	for locationPrefix := range f.quotaLocationPrefixes {
+		if !locationPrefixes[locationPrefix] {
+			if err := f.Store.KvDelete(ctx, QuotaUsageKey(locationPrefix)); err != nil {
+				glog.Warningf("delete quota usage of %s: %v", locationPrefix, err)
+			}
+		}
+	}
+	f.quotaLocationPrefixes = locationPrefixes
+
+	for locationPrefix := range locationPrefixes {
+		if _, found, err := f.readQuotaUsage(ctx, locationPrefix); err != nil || found {
+			continue
+		}
+		usage := &QuotaUsage{}
+		dir, namePrefix := locationPrefix, ""
+		if i := strings.LastIndex(locationPrefix, "/"); i >= 0 {
+			dir, namePrefix = locationPrefix[:i], locationPrefix[i+1:]
+		}
+		if dir == "" {
+			dir = "/"
+		}
+		if err := f.countQuotaUsage(ctx, util.FullPath(dir), namePrefix, usage); err != nil {
+			glog.Warningf("count quota usage of %s: %v", locationPrefix, err)
+			continue
+		}
+		if err := f.Store.KvPut(ctx, QuotaUsageKey(locationPrefix), usage.Encode()); err != nil {
+			glog.Warningf("save quota usage of %s: %v", locationPrefix, err)
+			continue
+		}
+		glog.V(0).Infof("counted quota usage of %s: %d bytes, %d files and folders", locationPrefix, usage.Bytes, usage.Inodes)
+	}
+}
+
+func (f *Filer) countQuotaUsage(ctx context.Context, dir util.FullPath, namePrefix string, usage *QuotaUsage) error {
+	lastFileName := ""
+	for {
+		entries, hasMore, err := f.ListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize, namePrefix, "", "")
+		if err != nil && err != filer_pb.ErrNotFound {
+			return fmt.Errorf("list folder %s: %v", dir, err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			usage.Inodes++
+			if !entry.IsDirectory() {
+				usage.Bytes += int64(entry.Size())
+				continue
+			}
+			if entry.Name() == SnapshotsFolder {
+				continue
+			}
+			if err = f.countQuotaUsage(ctx, entry.FullPath, "", usage); err != nil {
+				return err
+			}
+		}
+		if !hasMore {
+			return nil
+		}
+	}
+}
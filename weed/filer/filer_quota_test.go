@@ -0,0 +1,89 @@
+package filer
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaUsageCodec(t *testing.T) {
+	usage := QuotaUsage{Bytes: 1 << 40, Inodes: 12345}
+	decoded, err := DecodeQuotaUsage(usage.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, usage, decoded)
+
+	_, err = DecodeQuotaUsage([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestQuotaLocations(t *testing.T) {
+	f := &Filer{FilerConf: NewFilerConf()}
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/", QuotaInodes: 1000})
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/alice/", QuotaBytes: 1 << 30})
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/alice/tmp/", Collection: "tmp"})
+
+	var prefixes []string
+	for _, location := range f.quotaLocations("/home/alice/tmp/a.txt") {
+		prefixes = append(prefixes, location.LocationPrefix)
+	}
+	assert.Equal(t, []string{"/home/", "/home/alice/"}, prefixes)
+	assert.Len(t, f.quotaLocations("/home/bob/a.txt"), 1)
+	assert.Len(t, f.quotaLocations("/data/a.txt"), 0)
+	// the snapshots share the content of the live entries
+	assert.Len(t, f.quotaLocations("/home/alice/.snapshots/daily/a.txt"), 0)
+}
+
+func TestQuotaDelta(t *testing.T) {
+	file := func(p util.FullPath, size uint64) *Entry {
+		return &Entry{FullPath: p, Attr: Attr{FileSize: size}}
+	}
+	dir := &Entry{FullPath: "/home/alice/docs", Attr: Attr{Mode: os.ModeDir}}
+
+	tests := []struct {
+		name           string
+		oldEntry       *Entry
+		newEntry       *Entry
+		bytes, inodes  int64
+		locationPrefix string
+	}{
+		{"create", nil, file("/home/alice/a.txt", 100), 100, 1, "/home/alice/"},
+		{"overwrite", file("/home/alice/a.txt", 100), file("/home/alice/a.txt", 30), -70, 0, "/home/alice/"},
+		{"delete", file("/home/alice/a.txt", 100), nil, -100, -1, "/home/alice/"},
+		{"mkdir", nil, dir, 0, 1, "/home/alice/"},
+		{"move in", file("/home/bob/a.txt", 100), file("/home/alice/a.txt", 100), 100, 1, "/home/alice/"},
+		{"move out", file("/home/alice/a.txt", 100), file("/home/bob/a.txt", 100), -100, -1, "/home/alice/"},
+		{"move within", file("/home/alice/a.txt", 100), file("/home/alice/b.txt", 100), 0, 0, "/home/alice/"},
+		{"other location", nil, file("/home/bob/a.txt", 100), 0, 0, "/home/alice/"},
+		{"snapshot", nil, file("/home/alice/.snapshots/daily/a.txt", 100), 0, 0, "/home/alice/"},
+	}
+	for _, tt := range tests {
+		bytes, inodes := quotaDelta(tt.locationPrefix, tt.oldEntry, tt.newEntry)
+		assert.Equal(t, tt.bytes, bytes, tt.name)
+		assert.Equal(t, tt.inodes, inodes, tt.name)
+	}
+}
+
+func TestReserveQuota(t *testing.T) {
+	f := &Filer{FilerConf: NewFilerConf(), Store: &kvOnlyStore{kv: make(map[string][]byte)}}
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/alice/", QuotaBytes: 100})
+	ctx := context.Background()
+	assert.NoError(t, f.Store.KvPut(ctx, QuotaUsageKey("/home/alice/"), QuotaUsage{Bytes: 40, Inodes: 1}.Encode()))
+	file := func(p util.FullPath, size uint64) *Entry {
+		return &Entry{FullPath: p, Attr: Attr{Mode: 0644, FileSize: size}}
+	}
+
+	release, err := f.ReserveQuota(ctx, nil, file("/home/alice/a.txt", 50))
+	assert.NoError(t, err)
+	// the reserved bytes are counted until the change is counted
+	_, err = f.ReserveQuota(ctx, nil, file("/home/alice/b.txt", 50))
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	release()
+	assert.Empty(t, f.quotaReserved)
+	release, err = f.ReserveQuota(ctx, nil, file("/home/alice/b.txt", 50))
+	assert.NoError(t, err)
+	release()
+}
@@ -1,6 +1,7 @@
 package filer
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -13,6 +14,27 @@ func (f *Filer) CanRename(source, target util.FullPath, oldName string) error {
 		return fmt.Errorf("mv: can not move directory to a subdirectory of itself")
 	}
 
+	if err := CheckSnapshotWritable(sourcePath); err != nil {
+		return err
+	}
+	if err := CheckSnapshotWritable(target.Child(oldName)); err != nil {
+		return err
+	}
+	if oldName == SnapshotsFolder || target.Name() == SnapshotsFolder {
+		return fmt.Errorf("mv: can not move the snapshots, see fs.snapshot.create")
+	}
+	if sourceEntry, err := f.FindEntry(context.Background(), sourcePath); err == nil {
+		if err = f.checkSnapshotDeletion(context.Background(), sourceEntry); err != nil {
+			return err
+		}
+		// the entries moved within a location with a quota are not counted again
+		movedEntry := sourceEntry.ShallowClone()
+		movedEntry.FullPath = target.Child(oldName)
+		if err = f.CheckQuota(context.Background(), sourceEntry, movedEntry); err != nil {
+			return err
+		}
+	}
+
 	sourceBucket := f.DetectBucket(source)
 	targetBucket := f.DetectBucket(target)
 	if sourceBucket != targetBucket {
@@ -0,0 +1,253 @@
+package filer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3replication"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// the interval of the rescans of the buckets, queuing the replications left pending or failed
+const s3ReplicationRescanInterval = 10 * time.Minute
+
+// LoadS3Replication starts the targets of the bucket replication configurations,
+// configured in the [s3_replication] section of replication.toml
+func (f *Filer) LoadS3Replication(config *util.ViperProxy, filerAddress pb.ServerAddress) {
+	replicator, err := s3replication.LoadReplicator(config, "s3_replication", filerAddress,
+		f.bucketConfigurationReader(s3_constants.ExtReplicationKey), f.setS3ReplicationStatus)
+	if err != nil {
+		glog.Fatalf("Failed to initialize s3 bucket replication: %v", err)
+	}
+	f.S3Replicator = replicator
+	if replicator != nil {
+		go f.loopRescanningS3Replication()
+	}
+}
+
+// MarkS3ReplicationPending marks the objects copied by the replication rules of their buckets, before the entries are saved.
+// The changes from other clusters and the changes of the replicas are not replicated.
+func (f *Filer) MarkS3ReplicationPending(oldEntry, newEntry *Entry, isFromOtherCluster bool) {
+	if f.S3Replicator == nil || f.DirBucketsPath == "" || isFromOtherCluster {
+		return
+	}
+	object := f.toS3Object(newEntry)
+	if object == nil || object.isVersion || string(newEntry.Extended[s3_constants.ExtReplicationStatusKey]) == s3replication.StatusReplica {
+		return
+	}
+	if oldEntry != nil && !isContentChanged(oldEntry, newEntry) && !isS3MetadataChanged(oldEntry, newEntry) {
+		return
+	}
+	if f.S3Replicator.FindRule(object.bucket, object.key, s3ObjectTags(newEntry)) == nil {
+		delete(newEntry.Extended, s3_constants.ExtReplicationStatusKey)
+		return
+	}
+	if newEntry.Extended == nil {
+		newEntry.Extended = make(map[string][]byte)
+	}
+	newEntry.Extended[s3_constants.ExtReplicationStatusKey] = []byte(s3replication.StatusPending)
+}
+
+// replicateS3Objects queues the pending objects changed by this filer, and the deletes replicated by the rules,
+// i.e. the delete markers, or the deleted objects of the buckets without versioning.
+func (f *Filer) replicateS3Objects(oldEntry, newEntry *Entry, isFromOtherCluster, isMove bool, signatures []int32) {
+	if f.S3Replicator == nil || f.DirBucketsPath == "" {
+		return
+	}
+	f.invalidateS3ReplicationConfiguration(oldEntry)
+	f.invalidateS3ReplicationConfiguration(newEntry)
+	if isFromOtherCluster || isMove {
+		return
+	}
+
+	oldObject, newObject := f.toS3Object(oldEntry), f.toS3Object(newEntry)
+	if newObject != nil && !newObject.isVersion {
+		if string(newEntry.Extended[s3_constants.ExtReplicationStatusKey]) != s3replication.StatusPending {
+			return
+		}
+		rule := f.S3Replicator.FindRule(newObject.bucket, newObject.key, s3ObjectTags(newEntry))
+		if rule == nil {
+			return
+		}
+		task := &s3replication.Task{
+			FullPath:   newEntry.FullPath,
+			Key:        newObject.key,
+			Rule:       rule,
+			NewEntry:   newEntry.ToProtoEntry(),
+			Signatures: signatures,
+		}
+		if oldObject != nil && oldEntry.FullPath == newEntry.FullPath {
+			task.OldEntry = oldEntry.ToProtoEntry()
+		}
+		f.S3Replicator.Replicate(task)
+		return
+	}
+
+	var deleted *s3Object
+	switch {
+	case newObject != nil && string(newEntry.Extended[s3_constants.ExtDeleteMarkerKey]) == "true":
+		deleted = newObject
+	case newObject == nil && oldObject != nil && !oldObject.isVersion && oldObject.versionId == "":
+		deleted = oldObject
+	default:
+		return
+	}
+	rule := f.S3Replicator.FindRule(deleted.bucket, deleted.key, nil)
+	if rule == nil || !rule.ReplicateDeleteMarkers {
+		return
+	}
+	f.S3Replicator.Replicate(&s3replication.Task{
+		FullPath:   util.NewFullPath(f.DirBucketsPath, deleted.bucket+"/"+deleted.key),
+		Key:        deleted.key,
+		Rule:       rule,
+		Signatures: signatures,
+	})
+}
+
+// invalidateS3ReplicationConfiguration forgets the cached configuration of a changed bucket
+func (f *Filer) invalidateS3ReplicationConfiguration(entry *Entry) {
+	if entry == nil || !entry.IsDirectory() {
+		return
+	}
+	if dir, name := entry.FullPath.DirAndName(); dir == f.DirBucketsPath {
+		f.S3Replicator.Invalidate(name)
+	}
+}
+
+// setS3ReplicationStatus records the replication status of the object, unless the object is replicated again since
+func (f *Filer) setS3ReplicationStatus(fullpath util.FullPath, replicated *filer_pb.Entry, status string) {
+	ctx := context.Background()
+	entry, err := f.FindEntry(ctx, fullpath)
+	if err != nil {
+		glog.V(1).Infof("s3 replication status of %s: %v", fullpath, err)
+		return
+	}
+	dir, _ := fullpath.DirAndName()
+	if previous := string(entry.Extended[s3_constants.ExtReplicationStatusKey]); previous == status ||
+		previous != s3replication.StatusPending && previous != s3replication.StatusFailed || isContentChanged(entry, FromPbEntry(dir, replicated)) {
+		return
+	}
+	newEntry := entry.ShallowClone()
+	newEntry.Extended = make(map[string][]byte, len(entry.Extended))
+	for k, v := range entry.Extended {
+		newEntry.Extended[k] = v
+	}
+	newEntry.Extended[s3_constants.ExtReplicationStatusKey] = []byte(status)
+	if err = f.UpdateEntry(ctx, entry, newEntry); err != nil {
+		glog.Warningf("s3 replication status of %s: %v", fullpath, err)
+		return
+	}
+	f.NotifyUpdateEvent(ctx, entry, newEntry, false, false, nil)
+}
+
+func (f *Filer) loopRescanningS3Replication() {
+	// the replications queued before a restart are only kept in memory
+	time.Sleep(time.Minute)
+	for {
+		f.rescanS3Replication()
+		time.Sleep(s3ReplicationRescanInterval)
+	}
+}
+
+// rescanS3Replication queues again the pending objects left out of the queues, e.g. by a restart, and the failed objects
+func (f *Filer) rescanS3Replication() {
+	ctx := context.Background()
+	if err := f.eachDirectoryEntry(ctx, util.FullPath(f.DirBucketsPath), func(bucket *Entry) {
+		// one of the filers rescans each bucket
+		if !bucket.IsDirectory() || !f.Dlm.IsLocal(string(bucket.FullPath)) || !f.S3Replicator.IsEnabled(bucket.Name()) {
+			return
+		}
+		if err := f.rescanS3ReplicationFolder(ctx, bucket.FullPath, time.Now().Add(-s3ReplicationRescanInterval)); err != nil {
+			glog.Warningf("s3 replication rescan of %s: %v", bucket.FullPath, err)
+		}
+	}); err != nil {
+		glog.Warningf("s3 replication rescan of %s: %v", f.DirBucketsPath, err)
+	}
+}
+
+// rescanS3ReplicationFolder queues the failed objects, and the pending objects changed before the time,
+// the later ones being still queued
+func (f *Filer) rescanS3ReplicationFolder(ctx context.Context, dir util.FullPath, pendingBefore time.Time) error {
+	var subDirs []util.FullPath
+	err := f.eachDirectoryEntry(ctx, dir, func(entry *Entry) {
+		if entry.IsDirectory() {
+			if name := entry.Name(); name != s3_constants.MultipartUploadsFolder && name != s3_constants.VersionsFolder && name != TrashFolder && name != SnapshotsFolder {
+				subDirs = append(subDirs, entry.FullPath)
+			}
+			return
+		}
+		object := f.toS3Object(entry)
+		if object == nil || object.isVersion {
+			return
+		}
+		switch string(entry.Extended[s3_constants.ExtReplicationStatusKey]) {
+		case s3replication.StatusPending:
+			if !entry.Mtime.Before(pendingBefore) {
+				return
+			}
+		case s3replication.StatusFailed:
+		default:
+			return
+		}
+		rule := f.S3Replicator.FindRule(object.bucket, object.key, s3ObjectTags(entry))
+		if rule == nil {
+			return
+		}
+		if f.S3Replicator.Retry(&s3replication.Task{
+			FullPath: entry.FullPath,
+			Key:      object.key,
+			Rule:     rule,
+			NewEntry: entry.ToProtoEntry(),
+		}) {
+			glog.V(2).Infof("s3 replication rescan queued %s", entry.FullPath)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for _, subDir := range subDirs {
+		if err = f.rescanS3ReplicationFolder(ctx, subDir, pendingBefore); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// s3ObjectTags returns the tags of an object, matched by the tag filters of the replication rules
+func s3ObjectTags(entry *Entry) map[string]string {
+	tags := make(map[string]string)
+	for k, v := range entry.Extended {
+		if tag, found := strings.CutPrefix(k, s3_constants.AmzObjectTaggingPrefix); found {
+			tags[tag] = string(v)
+		}
+	}
+	return tags
+}
+
+// isS3MetadataChanged tells whether the tags, the acl or the other s3 metadata of an object are changed, besides its replication status
+func isS3MetadataChanged(oldEntry, newEntry *Entry) bool {
+	count := func(extended map[string][]byte) int {
+		if _, found := extended[s3_constants.ExtReplicationStatusKey]; found {
+			return len(extended) - 1
+		}
+		return len(extended)
+	}
+	if count(oldEntry.Extended) != count(newEntry.Extended) {
+		return true
+	}
+	for k, v := range newEntry.Extended {
+		if k == s3_constants.ExtReplicationStatusKey {
+			continue
+		}
+		if oldValue, found := oldEntry.Extended[k]; !found || !bytes.Equal(oldValue, v) {
+			return true
+		}
+	}
+	return false
+}
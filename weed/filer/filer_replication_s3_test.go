@@ -0,0 +1,26 @@
+package filer
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsS3MetadataChanged(t *testing.T) {
+	entry := func(extended map[string][]byte) *Entry {
+		return &Entry{FullPath: "/buckets/photos/a.jpg", Extended: extended}
+	}
+	pending := map[string][]byte{s3_constants.ExtReplicationStatusKey: []byte("PENDING"), "X-Amz-Tagging-a": []byte("b")}
+	completed := map[string][]byte{s3_constants.ExtReplicationStatusKey: []byte("COMPLETED"), "X-Amz-Tagging-a": []byte("b")}
+	tagged := map[string][]byte{"X-Amz-Tagging-a": []byte("b")}
+	retagged := map[string][]byte{s3_constants.ExtReplicationStatusKey: []byte("COMPLETED"), "X-Amz-Tagging-a": []byte("c")}
+
+	assert.False(t, isS3MetadataChanged(entry(pending), entry(completed)), "the replication status is not replicated")
+	assert.False(t, isS3MetadataChanged(entry(tagged), entry(completed)))
+	assert.True(t, isS3MetadataChanged(entry(completed), entry(retagged)))
+	assert.True(t, isS3MetadataChanged(entry(nil), entry(tagged)))
+	assert.False(t, isS3MetadataChanged(entry(nil), entry(nil)))
+
+	assert.Equal(t, map[string]string{"a": "c"}, s3ObjectTags(entry(retagged)))
+}
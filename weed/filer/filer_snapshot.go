@@ -0,0 +1,461 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/log_buffer"
+)
+
+// A snapshot is a read-only copy of the entries of a folder at one point in time, sharing their chunks:
+//
+//	/buckets/data/a.txt  =>  /buckets/data/.snapshots/<name>/a.txt
+//
+// The chunks referenced by the snapshots are counted in the filer store. Deleting or overwriting a live entry
+// only releases its referenced chunks, which are deleted when the last snapshot referencing them is deleted.
+const (
+	SnapshotsFolder        = ".snapshots"
+	SnapshotCreatedAtKey   = "Seaweed-Snapshot-Created-At"
+	snapshotOp             = "SNAPSHOT"
+	snapshotChunkKeyPrefix = "snapshot.chunk."
+	// set once a snapshot is created, to skip looking up the chunk references otherwise
+	snapshotsCreatedKey = "snapshot.created"
+)
+
+// snapshotOf returns the folder and the name of the snapshot containing the path
+func snapshotOf(p util.FullPath) (dir util.FullPath, name string, found bool) {
+	before, after, found := strings.Cut(string(p), "/"+SnapshotsFolder+"/")
+	if !found {
+		return "", "", false
+	}
+	if before == "" {
+		before = "/"
+	}
+	name, _, _ = strings.Cut(after, "/")
+	return util.FullPath(before), name, name != ""
+}
+
+// snapshotTarget maps a path under the folder to its copy in the snapshot,
+// the snapshots and the trash of the folder are not copied.
+func snapshotTarget(dir, snapshotRoot, p util.FullPath) (target util.FullPath, ok bool) {
+	prefix := string(dir) + "/"
+	if dir == "/" {
+		prefix = "/"
+	}
+	rel, found := strings.CutPrefix(string(p), prefix)
+	if !found || rel == "" {
+		return "", false
+	}
+	for _, name := range strings.Split(rel, "/") {
+		if name == SnapshotsFolder || name == TrashFolder {
+			return "", false
+		}
+	}
+	return snapshotRoot.Child(rel), true
+}
+
+func isSnapshotOp(ctx context.Context) bool {
+	return ctx.Value("OP") == snapshotOp
+}
+
+// CheckSnapshotWritable refuses to change the entries in the snapshots
+func CheckSnapshotWritable(p util.FullPath) error {
+	if _, name, found := snapshotOf(p); found {
+		return fmt.Errorf("%s is in the read-only snapshot %s", p, name)
+	}
+	return nil
+}
+
+// checkSnapshotDeletion refuses to delete the entries in the snapshots, or the folders having snapshots
+func (f *Filer) checkSnapshotDeletion(ctx context.Context, entry *Entry) error {
+	if isSnapshotOp(ctx) {
+		return nil
+	}
+	if err := CheckSnapshotWritable(entry.FullPath); err != nil {
+		return err
+	}
+	if !entry.IsDirectory() {
+		return nil
+	}
+	snapshotsDir := entry.FullPath.Child(SnapshotsFolder)
+	if entry.Name() == SnapshotsFolder {
+		snapshotsDir = entry.FullPath
+	}
+	hasSnapshots, err := f.hasSnapshots(ctx, snapshotsDir)
+	if err != nil {
+		return err
+	}
+	if hasSnapshots {
+		return fmt.Errorf("%s has snapshots, delete them first with fs.snapshot.delete", entry.FullPath)
+	}
+	return nil
+}
+
+func (f *Filer) hasSnapshots(ctx context.Context, snapshotsDir util.FullPath) (bool, error) {
+	entries, _, err := f.ListDirectoryEntries(ctx, snapshotsDir, "", false, 1, "", "", "")
+	if err != nil && err != filer_pb.ErrNotFound {
+		return false, fmt.Errorf("list snapshots %s: %v", snapshotsDir, err)
+	}
+	return len(entries) > 0, nil
+}
+
+// CreateSnapshot copies the entries of the folder to a new snapshot. The changes during the copy are replayed
+// from the metadata log, so the snapshot has the entries as of the end of the copy, except the changes made
+// through the other filers and not yet in the persisted metadata log.
+func (f *Filer) CreateSnapshot(ctx context.Context, dir util.FullPath, name string) (root *Entry, fileCount uint64, err error) {
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return nil, 0, fmt.Errorf("invalid snapshot name %q", name)
+	}
+	if err = CheckSnapshotWritable(dir); err != nil {
+		return nil, 0, err
+	}
+	if dir.Name() == SnapshotsFolder || dir.Name() == TrashFolder {
+		return nil, 0, fmt.Errorf("can not snapshot %s", dir)
+	}
+	dirEntry, err := f.FindEntry(ctx, dir)
+	if err != nil {
+		return nil, 0, fmt.Errorf("find %s: %v", dir, err)
+	}
+	if !dirEntry.IsDirectory() {
+		return nil, 0, fmt.Errorf("%s is not a folder", dir)
+	}
+
+	ctx = context.WithValue(ctx, "OP", snapshotOp)
+	if err = f.Store.KvPut(ctx, []byte(snapshotsCreatedKey), []byte{1}); err != nil {
+		return nil, 0, fmt.Errorf("mark snapshots created: %v", err)
+	}
+
+	startTsNs := time.Now().UnixNano()
+	root = dirEntry.ShallowClone()
+	root.FullPath = dir.Child(SnapshotsFolder).Child(name)
+	root.Extended = map[string][]byte{
+		SnapshotCreatedAtKey: []byte(time.Unix(0, startTsNs).UTC().Format(time.RFC3339)),
+	}
+	if err = f.CreateEntry(ctx, root, true, false, nil, false, f.MaxFilenameLength); err != nil {
+		return nil, 0, fmt.Errorf("create snapshot %s: %v", root.FullPath, err)
+	}
+
+	if err = f.copyToSnapshot(ctx, dirEntry, root.FullPath, &fileCount); err == nil {
+		err = f.replaySnapshotEvents(ctx, dir, root.FullPath, startTsNs, time.Now().UnixNano())
+	}
+	if err != nil {
+		if deleteErr := f.deleteFromSnapshot(ctx, root.FullPath); deleteErr != nil {
+			glog.Errorf("clean up snapshot %s: %v", root.FullPath, deleteErr)
+		}
+		return nil, 0, fmt.Errorf("create snapshot %s: %v", root.FullPath, err)
+	}
+	glog.V(0).Infof("created snapshot %s with %d files", root.FullPath, fileCount)
+	return root, fileCount, nil
+}
+
+// DeleteSnapshot deletes the snapshot, and the chunks referenced only by it
+func (f *Filer) DeleteSnapshot(ctx context.Context, dir util.FullPath, name string) error {
+	if name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid snapshot name %q", name)
+	}
+	root := dir.Child(SnapshotsFolder).Child(name)
+	if _, err := f.FindEntry(ctx, root); err != nil {
+		return fmt.Errorf("find snapshot %s: %v", root, err)
+	}
+	return f.deleteFromSnapshot(context.WithValue(ctx, "OP", snapshotOp), root)
+}
+
+// copyToSnapshot copies the children of the folder to the snapshot
+func (f *Filer) copyToSnapshot(ctx context.Context, dirEntry *Entry, target util.FullPath, fileCount *uint64) error {
+	lastFileName := ""
+	for {
+		entries, hasMore, err := f.ListDirectoryEntries(ctx, dirEntry.FullPath, lastFileName, false, PaginationSize, "", "", "")
+		if err != nil {
+			return fmt.Errorf("list folder %s: %v", dirEntry.FullPath, err)
+		}
+		for _, sub := range entries {
+			lastFileName = sub.Name()
+			if sub.IsDirectory() && (sub.Name() == SnapshotsFolder || sub.Name() == TrashFolder) {
+				continue
+			}
+			if err = f.upsertSnapshotEntry(ctx, sub, target.Child(sub.Name())); err != nil {
+				return err
+			}
+			if sub.IsDirectory() {
+				if err = f.copyToSnapshot(ctx, sub, target.Child(sub.Name()), fileCount); err != nil {
+					return err
+				}
+			} else {
+				*fileCount++
+			}
+		}
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+// upsertSnapshotEntry copies the entry to the snapshot, referencing its chunks and releasing the replaced ones
+func (f *Filer) upsertSnapshotEntry(ctx context.Context, entry *Entry, target util.FullPath) error {
+	copied := entry.ShallowClone()
+	copied.FullPath = target
+	copied.HardLinkId = nil
+	copied.HardLinkCounter = 0
+	existing, err := f.FindEntry(ctx, target)
+	if err != nil && err != filer_pb.ErrNotFound {
+		return fmt.Errorf("find %s: %v", target, err)
+	}
+	if existing != nil && existing.IsDirectory() != copied.IsDirectory() {
+		if err = f.deleteFromSnapshot(ctx, target); err != nil {
+			return err
+		}
+		existing = nil
+	}
+	if err = f.referenceSnapshotChunks(ctx, copied.GetChunks()); err != nil {
+		return err
+	}
+	if err = f.CreateEntry(ctx, copied, false, false, nil, false, f.MaxFilenameLength); err != nil {
+		return err
+	}
+	if existing != nil {
+		return f.releaseSnapshotChunks(ctx, existing.GetChunks())
+	}
+	return nil
+}
+
+// deleteFromSnapshot deletes the entry and its children from the snapshot, releasing their chunks
+func (f *Filer) deleteFromSnapshot(ctx context.Context, target util.FullPath) error {
+	entry, err := f.FindEntry(ctx, target)
+	if err == filer_pb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find %s: %v", target, err)
+	}
+	if err = f.releaseSnapshotTree(ctx, entry); err != nil {
+		return err
+	}
+	return f.DeleteEntryMetaAndData(ctx, target, true, false, false, false, nil, 0)
+}
+
+func (f *Filer) releaseSnapshotTree(ctx context.Context, entry *Entry) error {
+	if !entry.IsDirectory() {
+		return f.releaseSnapshotChunks(ctx, entry.GetChunks())
+	}
+	lastFileName := ""
+	for {
+		entries, hasMore, err := f.ListDirectoryEntries(ctx, entry.FullPath, lastFileName, false, PaginationSize, "", "", "")
+		if err != nil {
+			return fmt.Errorf("list folder %s: %v", entry.FullPath, err)
+		}
+		for _, sub := range entries {
+			lastFileName = sub.Name()
+			if err = f.releaseSnapshotTree(ctx, sub); err != nil {
+				return err
+			}
+		}
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+// replaySnapshotEvents applies the changes of the folder logged during the copy to the snapshot
+func (f *Filer) replaySnapshotEvents(ctx context.Context, dir, snapshotRoot util.FullPath, startTsNs, stopTsNs int64) error {
+	eachLogEntryFn := func(logEntry *filer_pb.LogEntry) (bool, error) {
+		event := &filer_pb.SubscribeMetadataResponse{}
+		if err := proto.Unmarshal(logEntry.Data, event); err != nil {
+			return false, fmt.Errorf("unmarshal metadata event: %v", err)
+		}
+		return false, f.applySnapshotEvent(ctx, dir, snapshotRoot, event)
+	}
+
+	lastReadTime := log_buffer.NewMessagePosition(startTsNs, -2)
+	for retry := 0; ; retry++ {
+		processedTsNs, isDone, err := f.ReadPersistedLogBuffer(lastReadTime, stopTsNs, eachLogEntryFn)
+		if err != nil {
+			return err
+		}
+		if isDone {
+			return nil
+		}
+		if processedTsNs != 0 {
+			lastReadTime = log_buffer.NewMessagePosition(processedTsNs, -2)
+		}
+		lastReadTime, isDone, err = f.LocalMetaLogBuffer.LoopProcessLogData("snapshot:"+string(snapshotRoot), lastReadTime, stopTsNs, func() bool {
+			return false
+		}, eachLogEntryFn)
+		if err == log_buffer.ResumeFromDiskError && retry < 3 {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read metadata log: %v", err)
+		}
+		if isDone {
+			return nil
+		}
+	}
+}
+
+func (f *Filer) applySnapshotEvent(ctx context.Context, dir, snapshotRoot util.FullPath, event *filer_pb.SubscribeMetadataResponse) error {
+	message := event.EventNotification
+	var oldPath, newPath util.FullPath
+	if message.OldEntry != nil {
+		oldPath = util.NewFullPath(event.Directory, message.OldEntry.Name)
+	}
+	newParentPath := message.NewParentPath
+	if newParentPath == "" {
+		newParentPath = event.Directory
+	}
+	if message.NewEntry != nil {
+		newPath = util.NewFullPath(newParentPath, message.NewEntry.Name)
+	}
+	if message.OldEntry != nil && oldPath != newPath {
+		if target, ok := snapshotTarget(dir, snapshotRoot, oldPath); ok {
+			if err := f.deleteFromSnapshot(ctx, target); err != nil {
+				return err
+			}
+		}
+	}
+	if message.NewEntry != nil {
+		if target, ok := snapshotTarget(dir, snapshotRoot, newPath); ok {
+			return f.upsertSnapshotEntry(ctx, FromPbEntry(newParentPath, message.NewEntry), target)
+		}
+	}
+	return nil
+}
+
+func snapshotChunkKey(fileId string) []byte {
+	return []byte(snapshotChunkKeyPrefix + fileId)
+}
+
+// the chunk references are the count of the snapshot entries referencing the chunk,
+// and whether the live entries released the chunk
+func encodeSnapshotChunkRef(count uint32, released bool) []byte {
+	value := make([]byte, 5)
+	util.Uint32toBytes(value, count)
+	if released {
+		value[4] = 1
+	}
+	return value
+}
+
+func decodeSnapshotChunkRef(value []byte) (count uint32, released bool) {
+	if len(value) < 5 {
+		return 0, false
+	}
+	return util.BytesToUint32(value[:4]), value[4] == 1
+}
+
+// snapshotFileIds lists the file ids of the chunks, including the data chunks of the chunk manifests
+func (f *Filer) snapshotFileIds(chunks []*filer_pb.FileChunk) (fileIds []string, err error) {
+	if len(chunks) == 0 {
+		return nil, nil
+	}
+	dataChunks, manifestChunks, err := ResolveChunkManifest(f.MasterClient.GetLookupFileIdFunction(), chunks, 0, math.MaxInt64)
+	if err != nil {
+		return nil, fmt.Errorf("resolve chunk manifests: %v", err)
+	}
+	for _, chunk := range append(dataChunks, manifestChunks...) {
+		fileIds = append(fileIds, chunk.GetFileIdString())
+	}
+	return fileIds, nil
+}
+
+func (f *Filer) referenceSnapshotChunks(ctx context.Context, chunks []*filer_pb.FileChunk) error {
+	fileIds, err := f.snapshotFileIds(chunks)
+	if err != nil {
+		return err
+	}
+	f.snapshotChunkLock.Lock()
+	defer f.snapshotChunkLock.Unlock()
+	for _, fileId := range fileIds {
+		value, err := f.Store.KvGet(ctx, snapshotChunkKey(fileId))
+		if err != nil && err != ErrKvNotFound {
+			return fmt.Errorf("get chunk %s references: %v", fileId, err)
+		}
+		count, released := decodeSnapshotChunkRef(value)
+		if err = f.Store.KvPut(ctx, snapshotChunkKey(fileId), encodeSnapshotChunkRef(count+1, released)); err != nil {
+			return fmt.Errorf("reference chunk %s: %v", fileId, err)
+		}
+	}
+	return nil
+}
+
+// releaseSnapshotChunks drops the references of a snapshot entry, and deletes the chunks released by the live entries
+func (f *Filer) releaseSnapshotChunks(ctx context.Context, chunks []*filer_pb.FileChunk) error {
+	fileIds, err := f.snapshotFileIds(chunks)
+	if err != nil {
+		return err
+	}
+	var toDelete []string
+	defer func() {
+		for _, fileId := range toDelete {
+			f.fileIdDeletionQueue.EnQueue(fileId)
+		}
+	}()
+	f.snapshotChunkLock.Lock()
+	defer f.snapshotChunkLock.Unlock()
+	for _, fileId := range fileIds {
+		value, err := f.Store.KvGet(ctx, snapshotChunkKey(fileId))
+		if err == ErrKvNotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("get chunk %s references: %v", fileId, err)
+		}
+		count, released := decodeSnapshotChunkRef(value)
+		if count > 1 {
+			err = f.Store.KvPut(ctx, snapshotChunkKey(fileId), encodeSnapshotChunkRef(count-1, released))
+		} else {
+			err = f.Store.KvDelete(ctx, snapshotChunkKey(fileId))
+			if released {
+				toDelete = append(toDelete, fileId)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("release chunk %s: %v", fileId, err)
+		}
+	}
+	return nil
+}
+
+// keepSnapshotChunks filters out the file ids referenced by the snapshots, marking them released by the live entries
+func (f *Filer) keepSnapshotChunks(fileIds []string) (toDelete []string) {
+	ctx := context.Background()
+	if _, err := f.Store.KvGet(ctx, []byte(snapshotsCreatedKey)); err != nil {
+		if err != ErrKvNotFound {
+			glog.V(1).Infof("get %s: %v", snapshotsCreatedKey, err)
+		}
+		return fileIds
+	}
+	f.snapshotChunkLock.Lock()
+	defer f.snapshotChunkLock.Unlock()
+	for _, fileId := range fileIds {
+		value, err := f.Store.KvGet(ctx, snapshotChunkKey(fileId))
+		if err == ErrKvNotFound {
+			toDelete = append(toDelete, fileId)
+			continue
+		}
+		if err != nil {
+			// keep the chunk if not sure
+			glog.Errorf("get chunk %s references: %v", fileId, err)
+			continue
+		}
+		count, _ := decodeSnapshotChunkRef(value)
+		if err = f.Store.KvPut(ctx, snapshotChunkKey(fileId), encodeSnapshotChunkRef(count, true)); err != nil {
+			glog.Errorf("release chunk %s: %v", fileId, err)
+		}
+		glog.V(3).Infof("keep chunk %s referenced by %d snapshot entries", fileId, count)
+	}
+	return toDelete
+}
+
+// SnapshotCreatedAt returns the creation time recorded on the folder of a snapshot
+func SnapshotCreatedAt(extended map[string][]byte) (createdAt time.Time, found bool) {
+	createdAt, err := time.Parse(time.RFC3339, string(extended[SnapshotCreatedAtKey]))
+	return createdAt, err == nil
+}
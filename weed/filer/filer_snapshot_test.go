@@ -0,0 +1,80 @@
+package filer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotOf(t *testing.T) {
+	tests := []struct {
+		path  util.FullPath
+		dir   util.FullPath
+		name  string
+		found bool
+	}{
+		{"/buckets/data/.snapshots/daily", "/buckets/data", "daily", true},
+		{"/buckets/data/.snapshots/daily/a/b.txt", "/buckets/data", "daily", true},
+		{"/.snapshots/daily/a.txt", "/", "daily", true},
+		{"/buckets/data/.snapshots", "", "", false},
+		{"/buckets/data/.snapshots/", "", "", false},
+		{"/buckets/data/a.snapshots/daily", "", "", false},
+		{"/buckets/data/a.txt", "", "", false},
+	}
+	for _, tt := range tests {
+		dir, name, found := snapshotOf(tt.path)
+		assert.Equal(t, tt.found, found, string(tt.path))
+		if tt.found {
+			assert.Equal(t, tt.dir, dir, string(tt.path))
+			assert.Equal(t, tt.name, name, string(tt.path))
+		}
+	}
+	assert.Error(t, CheckSnapshotWritable("/buckets/data/.snapshots/daily/a.txt"))
+	assert.NoError(t, CheckSnapshotWritable("/buckets/data/a.txt"))
+}
+
+func TestSnapshotTarget(t *testing.T) {
+	tests := []struct {
+		dir    util.FullPath
+		path   util.FullPath
+		target util.FullPath
+		ok     bool
+	}{
+		{"/data", "/data/a.txt", "/data/.snapshots/s1/a.txt", true},
+		{"/data", "/data/dir/a.txt", "/data/.snapshots/s1/dir/a.txt", true},
+		{"/data", "/data", "", false},
+		{"/data", "/data2/a.txt", "", false},
+		{"/data", "/data/.snapshots/s1/a.txt", "", false},
+		{"/data", "/data/dir/.snapshots/s2/a.txt", "", false},
+		{"/data", "/data/.trash/1760680000123456789/a.txt", "", false},
+		{"/", "/a.txt", "/.snapshots/s1/a.txt", true},
+	}
+	for _, tt := range tests {
+		target, ok := snapshotTarget(tt.dir, tt.dir.Child(SnapshotsFolder).Child("s1"), tt.path)
+		assert.Equal(t, tt.ok, ok, string(tt.path))
+		assert.Equal(t, tt.target, target, string(tt.path))
+	}
+}
+
+func TestSnapshotChunkRef(t *testing.T) {
+	count, released := decodeSnapshotChunkRef(encodeSnapshotChunkRef(3, true))
+	assert.Equal(t, uint32(3), count)
+	assert.True(t, released)
+	count, released = decodeSnapshotChunkRef(encodeSnapshotChunkRef(1, false))
+	assert.Equal(t, uint32(1), count)
+	assert.False(t, released)
+	count, released = decodeSnapshotChunkRef(nil)
+	assert.Equal(t, uint32(0), count)
+	assert.False(t, released)
+}
+
+func TestSnapshotCreatedAt(t *testing.T) {
+	createdAt := time.Date(2026, 10, 17, 5, 46, 40, 0, time.UTC)
+	parsed, found := SnapshotCreatedAt(map[string][]byte{SnapshotCreatedAtKey: []byte(createdAt.Format(time.RFC3339))})
+	assert.True(t, found)
+	assert.True(t, createdAt.Equal(parsed))
+	_, found = SnapshotCreatedAt(nil)
+	assert.False(t, found)
+}
@@ -0,0 +1,255 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// The deleted entries under a location with the trash enabled are moved, with their chunks, to the trash folder
+// of their bucket, or of the folder of the location prefix:
+//
+//	/data/logs/a.txt  =>  /data/.trash/<deletion time in nanoseconds>/a.txt
+//
+// The folder of each deletion records the original path and the deletion time, and is purged after the retention time.
+const (
+	TrashFolder          = ".trash"
+	TrashOriginalPathKey = "Seaweed-Trash-Original-Path"
+	TrashDeletedAtKey    = "Seaweed-Trash-Deleted-At"
+	trashPurgeInterval   = 10 * time.Minute
+)
+
+// trashDirectory returns the trash folder keeping the deleted entry, or empty if the entry is deleted right away
+func (f *Filer) trashDirectory(p util.FullPath) (trashDir util.FullPath) {
+	locationPrefix := ""
+	f.FilerConf.rules.MatchPrefix([]byte(p), func(key []byte, value *filer_pb.FilerConf_PathConf) bool {
+		if value.Trash {
+			locationPrefix = string(key)
+		}
+		return true
+	})
+	if locationPrefix == "" {
+		return ""
+	}
+	trashDir = f.locationTrash(locationPrefix, p)
+	// the entries in the trash are deleted for good
+	if trashDir == "" || p == trashDir || p.IsUnder(trashDir) {
+		return ""
+	}
+	return trashDir
+}
+
+// locationTrash returns the trash folder of the path under the location with the trash enabled
+func (f *Filer) locationTrash(locationPrefix string, p util.FullPath) util.FullPath {
+	if bucketAndKey, found := strings.CutPrefix(string(p), f.DirBucketsPath+"/"); found && f.DirBucketsPath != "" {
+		// the s3 objects are kept in their bucket, the buckets and the multipart uploads are not
+		bucket, key, found := strings.Cut(bucketAndKey, "/")
+		if !found || key == s3_constants.MultipartUploadsFolder || strings.HasPrefix(key, s3_constants.MultipartUploadsFolder+"/") {
+			return ""
+		}
+		return util.NewFullPath(f.DirBucketsPath, bucket).Child(TrashFolder)
+	}
+	return locationTrashDirectory(locationPrefix)
+}
+
+// isInTrash tells whether the path is in the trash folder of a location with the trash enabled
+func (f *Filer) isInTrash(p util.FullPath) (inTrash bool) {
+	f.FilerConf.rules.Walk(func(key []byte, value *filer_pb.FilerConf_PathConf) bool {
+		if !value.Trash {
+			return true
+		}
+		locationPrefix := string(key)
+		trashDir := f.locationTrash(locationPrefix, p)
+		if trashDir == "" || (p != trashDir && !p.IsUnder(trashDir)) {
+			return true
+		}
+		if bucketDir, found := strings.CutSuffix(string(trashDir), "/"+TrashFolder); found && f.DirBucketsPath != "" && util.FullPath(bucketDir).IsUnder(util.FullPath(f.DirBucketsPath)) {
+			// the trash of a bucket only keeps the entries of the locations overlapping the bucket
+			if !strings.HasPrefix(bucketDir+"/", locationPrefix) && !strings.HasPrefix(locationPrefix, bucketDir+"/") {
+				return true
+			}
+		}
+		inTrash = true
+		return false
+	})
+	return
+}
+
+// locationTrashDirectory returns the trash folder in the folder of the location prefix
+func locationTrashDirectory(locationPrefix string) util.FullPath {
+	return util.FullPath(locationPrefix[:strings.LastIndex(locationPrefix, "/")+1]).Child(TrashFolder)
+}
+
+// moveToTrash keeps the deleted entry and its chunks in a new folder of the trash, the empty folders are deleted right away
+func (f *Filer) moveToTrash(ctx context.Context, entry *Entry, trashDir util.FullPath, isRecursive bool, signatures []int32) (moved bool, err error) {
+	if entry.IsDirectory() {
+		entries, _, err := f.ListDirectoryEntries(ctx, entry.FullPath, "", false, 1, "", "", "")
+		if err != nil {
+			return false, fmt.Errorf("list folder %s: %v", entry.FullPath, err)
+		}
+		if len(entries) == 0 {
+			return false, nil
+		}
+		if !isRecursive {
+			return false, fmt.Errorf("%s: %s", MsgFailDelNonEmptyFolder, entry.FullPath)
+		}
+	}
+
+	now := time.Now()
+	deletion := &Entry{
+		FullPath: trashDir.Child(strconv.FormatInt(now.UnixNano(), 10)),
+		Attr: Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   os.ModeDir | 0700,
+			Uid:    OS_UID,
+			Gid:    OS_GID,
+		},
+		Extended: map[string][]byte{
+			TrashOriginalPathKey: []byte(entry.FullPath),
+			TrashDeletedAtKey:    []byte(now.UTC().Format(time.RFC3339)),
+		},
+	}
+	if err = f.CreateEntry(ctx, deletion, true, false, signatures, false, f.MaxFilenameLength); err != nil {
+		return false, fmt.Errorf("create trash folder %s: %v", deletion.FullPath, err)
+	}
+	glog.V(2).Infof("move %s to the trash %s", entry.FullPath, deletion.FullPath)
+	return true, f.moveEntry(ctx, entry, deletion.FullPath.Child(entry.Name()), signatures)
+}
+
+// moveEntry recreates the entry and its children at the new path, then deletes them without their chunks
+func (f *Filer) moveEntry(ctx context.Context, entry *Entry, newPath util.FullPath, signatures []int32) error {
+	newEntry := entry.ShallowClone()
+	newEntry.FullPath = newPath
+	if err := f.CreateEntry(context.WithValue(ctx, "OP", "MV"), newEntry, false, false, signatures, false, f.MaxFilenameLength); err != nil {
+		return err
+	}
+	if entry.IsDirectory() {
+		lastFileName := ""
+		for {
+			entries, hasMore, err := f.ListDirectoryEntries(ctx, entry.FullPath, lastFileName, false, PaginationSize, "", "", "")
+			if err != nil {
+				return fmt.Errorf("list folder %s: %v", entry.FullPath, err)
+			}
+			for _, sub := range entries {
+				lastFileName = sub.Name()
+				if err = f.moveEntry(ctx, sub, newPath.Child(sub.Name()), signatures); err != nil {
+					return err
+				}
+			}
+			if !hasMore {
+				break
+			}
+		}
+	}
+	// the deletion is still notified, e.g. for the s3 events
+	return f.DeleteEntryMetaAndData(ctx, entry.FullPath, false, false, false, false, signatures, 0)
+}
+
+func (f *Filer) loopPurgingTrash() {
+	for {
+		time.Sleep(trashPurgeInterval)
+		for _, trashDir := range f.trashDirectories() {
+			// one of the filers purges each trash
+			if f.Dlm.IsLocal(string(trashDir)) {
+				f.purgeTrash(trashDir, time.Now())
+			}
+		}
+	}
+}
+
+// trashDirectories lists the trash folders of the locations with the trash enabled
+func (f *Filer) trashDirectories() (trashDirs []util.FullPath) {
+	ctx := context.Background()
+	hasBucketTrash := false
+	found := make(map[util.FullPath]bool)
+	f.FilerConf.rules.Walk(func(key []byte, value *filer_pb.FilerConf_PathConf) bool {
+		if !value.Trash {
+			return true
+		}
+		locationPrefix := string(key)
+		if f.DirBucketsPath != "" && (strings.HasPrefix(locationPrefix, f.DirBucketsPath+"/") || strings.HasPrefix(f.DirBucketsPath+"/", locationPrefix)) {
+			hasBucketTrash = true
+		}
+		trashDir := locationTrashDirectory(locationPrefix)
+		if (f.DirBucketsPath == "" || !strings.HasPrefix(string(trashDir), f.DirBucketsPath+"/")) && !found[trashDir] {
+			found[trashDir] = true
+			trashDirs = append(trashDirs, trashDir)
+		}
+		return true
+	})
+	if hasBucketTrash {
+		if err := f.eachDirectoryEntry(ctx, util.FullPath(f.DirBucketsPath), func(bucket *Entry) {
+			if bucket.IsDirectory() {
+				trashDirs = append(trashDirs, bucket.FullPath.Child(TrashFolder))
+			}
+		}); err != nil {
+			glog.Warningf("list buckets %s: %v", f.DirBucketsPath, err)
+		}
+	}
+	return
+}
+
+// purgeTrash deletes the entries kept in the trash longer than the retention time of their original locations
+func (f *Filer) purgeTrash(trashDir util.FullPath, now time.Time) {
+	ctx := context.Background()
+	var expired []util.FullPath
+	if err := f.eachDirectoryEntry(ctx, trashDir, func(entry *Entry) {
+		originalPath, deletedAt, found := TrashDeletion(entry.Extended)
+		if !found {
+			return
+		}
+		retention := f.FilerConf.MatchStorageRule(originalPath).TrashRetentionSeconds
+		if retention > 0 && deletedAt.Add(time.Duration(retention)*time.Second).Before(now) {
+			expired = append(expired, entry.FullPath)
+		}
+	}); err != nil {
+		glog.Warningf("list trash %s: %v", trashDir, err)
+		return
+	}
+	for _, p := range expired {
+		if err := f.DeleteEntryMetaAndData(ctx, p, true, true, true, false, nil, 0); err != nil {
+			glog.Warningf("purge trash %s: %v", p, err)
+			continue
+		}
+		glog.V(1).Infof("purged trash %s", p)
+	}
+}
+
+func (f *Filer) eachDirectoryEntry(ctx context.Context, p util.FullPath, fn func(entry *Entry)) error {
+	lastFileName := ""
+	for {
+		entries, hasMore, err := f.ListDirectoryEntries(ctx, p, lastFileName, false, PaginationSize, "", "", "")
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			fn(entry)
+		}
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+// TrashDeletion returns the original path and the deletion time recorded on a folder of the trash
+func TrashDeletion(extended map[string][]byte) (originalPath string, deletedAt time.Time, found bool) {
+	originalPath = string(extended[TrashOriginalPathKey])
+	if originalPath == "" {
+		return "", time.Time{}, false
+	}
+	deletedAt, err := time.Parse(time.RFC3339, string(extended[TrashDeletedAtKey]))
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return originalPath, deletedAt, true
+}
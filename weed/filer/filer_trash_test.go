@@ -0,0 +1,82 @@
+package filer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashDirectory(t *testing.T) {
+	f := &Filer{FilerConf: NewFilerConf(), DirBucketsPath: "/buckets"}
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/data/", Trash: true})
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/app/logs", Trash: true, TrashRetentionSeconds: 3600})
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/buckets/", Trash: true})
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/tmp/", Collection: "tmp"})
+
+	tests := []struct {
+		path     util.FullPath
+		trashDir util.FullPath
+	}{
+		{"/data/a.txt", "/data/.trash"},
+		{"/data/dir/a.txt", "/data/.trash"},
+		{"/data", ""},
+		{"/home/app/logs/a.log", "/home/app/.trash"},
+		{"/home/app/logs2/a.log", "/home/app/.trash"},
+		{"/home/app/a.txt", ""},
+		{"/tmp/a.txt", ""},
+		{"/buckets/photos/a/b.jpg", "/buckets/photos/.trash"},
+		{"/buckets/photos", ""},
+		{"/buckets/photos/.uploads/id/0001.part", ""},
+		// the entries in the trash are deleted for good
+		{"/data/.trash/1760680000123456789/a.txt", ""},
+		{"/data/.trash", ""},
+		{"/buckets/photos/.trash/1760680000123456789", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.trashDir, f.trashDirectory(tt.path), string(tt.path))
+	}
+}
+
+func TestTrashDeletion(t *testing.T) {
+	deletedAt := time.Date(2026, 10, 17, 5, 46, 40, 0, time.UTC)
+	originalPath, parsed, found := TrashDeletion(map[string][]byte{
+		TrashOriginalPathKey: []byte("/data/logs/a.txt"),
+		TrashDeletedAtKey:    []byte(deletedAt.Format(time.RFC3339)),
+	})
+	assert.True(t, found)
+	assert.Equal(t, "/data/logs/a.txt", originalPath)
+	assert.True(t, deletedAt.Equal(parsed))
+
+	_, _, found = TrashDeletion(map[string][]byte{TrashOriginalPathKey: []byte("/data/logs/a.txt")})
+	assert.False(t, found)
+	_, _, found = TrashDeletion(nil)
+	assert.False(t, found)
+}
+
+func TestIsInTrash(t *testing.T) {
+	f := &Filer{FilerConf: NewFilerConf(), DirBucketsPath: "/buckets"}
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/home/app/logs", Trash: true})
+	f.FilerConf.SetLocationConf(&filer_pb.FilerConf_PathConf{LocationPrefix: "/buckets/photos/", Trash: true})
+
+	tests := []struct {
+		path    util.FullPath
+		inTrash bool
+	}{
+		{"/home/app/.trash", true},
+		{"/home/app/.trash/1760680000123456789/a.log", true},
+		{"/home/app/logs/a.log", false},
+		{"/buckets/photos/.trash/1760680000123456789/a.jpg", true},
+		// the folders named like the trash are not the trash
+		{"/home/app/logs/.trash/a.log", false},
+		{"/home/.trash/a.txt", false},
+		{"/data/.trash/a.txt", false},
+		{"/buckets/videos/.trash/a.mp4", false},
+		{"/buckets/photos/a/.trash/b.jpg", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.inTrash, f.isInTrash(tt.path), string(tt.path))
+	}
+}
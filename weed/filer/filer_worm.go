@@ -0,0 +1,46 @@
+package filer
+
+import (
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+)
+
+// WormEnforced checks whether an entry under a worm rule can no longer be changed or deleted.
+// An S3 object lock, i.e. a legal hold or a retain-until date kept in the extended attributes,
+// takes precedence over the retention time of the rule.
+func WormEnforced(rule *filer_pb.FilerConf_PathConf, wormEnforcedAtTsNs int64, extended map[string][]byte, now time.Time) bool {
+	if !rule.Worm {
+		return false
+	}
+
+	// legal hold never expires until it is removed
+	if string(extended[s3_constants.ExtObjectLockLegalHoldKey]) == s3_constants.ObjectLockLegalHoldOn {
+		return true
+	}
+
+	if retainUntilDate, found := extended[s3_constants.ExtObjectLockRetainUntilDateKey]; found {
+		retainUntil, err := time.Parse(time.RFC3339, string(retainUntilDate))
+		if err != nil {
+			// keep the entry if the retention can not be understood
+			return true
+		}
+		return now.Before(retainUntil)
+	}
+
+	// worm is not enforced
+	if wormEnforcedAtTsNs == 0 {
+		return false
+	}
+
+	// worm will never expire
+	if rule.WormRetentionTimeSeconds == 0 {
+		return true
+	}
+
+	enforcedAt := time.Unix(0, wormEnforcedAtTsNs)
+
+	// worm is expired
+	return now.Sub(enforcedAt).Seconds() < float64(rule.WormRetentionTimeSeconds)
+}
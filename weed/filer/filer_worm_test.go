@@ -0,0 +1,41 @@
+package filer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWormEnforced(t *testing.T) {
+	now := time.Now()
+	enforcedAt := now.Add(-time.Hour).UnixNano()
+
+	assert.False(t, WormEnforced(&filer_pb.FilerConf_PathConf{}, enforcedAt, nil, now))
+
+	rule := &filer_pb.FilerConf_PathConf{Worm: true}
+	assert.False(t, WormEnforced(rule, 0, nil, now), "worm not enforced yet")
+	assert.True(t, WormEnforced(rule, enforcedAt, nil, now), "worm never expires")
+
+	rule.WormRetentionTimeSeconds = 60
+	assert.False(t, WormEnforced(rule, enforcedAt, nil, now), "worm expired")
+	rule.WormRetentionTimeSeconds = 7200
+	assert.True(t, WormEnforced(rule, enforcedAt, nil, now))
+
+	retainUntil := func(t time.Time) map[string][]byte {
+		return map[string][]byte{
+			s3_constants.ExtObjectLockRetainUntilDateKey: []byte(t.UTC().Format(time.RFC3339)),
+		}
+	}
+	assert.False(t, WormEnforced(rule, enforcedAt, retainUntil(now.Add(-time.Minute)), now), "retention expired")
+	assert.True(t, WormEnforced(rule, 0, retainUntil(now.Add(time.Minute)), now))
+	assert.True(t, WormEnforced(rule, 0, map[string][]byte{
+		s3_constants.ExtObjectLockRetainUntilDateKey: []byte("invalid"),
+	}, now))
+
+	legalHold := retainUntil(now.Add(-time.Minute))
+	legalHold[s3_constants.ExtObjectLockLegalHoldKey] = []byte(s3_constants.ObjectLockLegalHoldOn)
+	assert.True(t, WormEnforced(rule, 0, legalHold, now), "legal hold outlives the retention")
+}
@@ -28,6 +28,9 @@ type MetaAggregator struct {
 	MetaLogBuffer  *log_buffer.LogBuffer
 	peerChans      map[pb.ServerAddress]chan struct{}
 	peerChansLock  sync.Mutex
+	// the peers sharing the filer store, whose usages are counted by one of the filers
+	sharedStorePeers     map[pb.ServerAddress]bool
+	sharedStorePeersLock sync.Mutex
 	// notifying clients
 	ListenersLock sync.Mutex
 	ListenersCond *sync.Cond
@@ -37,10 +40,11 @@ type MetaAggregator struct {
 // The old data comes from what each LocalMetadata persisted on disk.
 func NewMetaAggregator(filer *Filer, self pb.ServerAddress, grpcDialOption grpc.DialOption) *MetaAggregator {
 	t := &MetaAggregator{
-		filer:          filer,
-		self:           self,
-		grpcDialOption: grpcDialOption,
-		peerChans:      make(map[pb.ServerAddress]chan struct{}),
+		filer:            filer,
+		self:             self,
+		grpcDialOption:   grpcDialOption,
+		peerChans:        make(map[pb.ServerAddress]chan struct{}),
+		sharedStorePeers: make(map[pb.ServerAddress]bool),
 	}
 	t.ListenersCond = sync.NewCond(&t.ListenersLock)
 	t.MetaLogBuffer = log_buffer.NewLogBuffer("aggr", LogFlushInterval, nil, nil, func() {
@@ -67,9 +71,34 @@ func (ma *MetaAggregator) OnPeerUpdate(update *master_pb.ClusterNodeUpdate, star
 			close(prevChan)
 			delete(ma.peerChans, address)
 		}
+		ma.setSharedStorePeer(address, false)
 	}
 }
 
+func (ma *MetaAggregator) setSharedStorePeer(peer pb.ServerAddress, shared bool) {
+	ma.sharedStorePeersLock.Lock()
+	defer ma.sharedStorePeersLock.Unlock()
+	if shared {
+		ma.sharedStorePeers[peer] = true
+	} else {
+		delete(ma.sharedStorePeers, peer)
+	}
+}
+
+// countsSharedStoreUsages tells whether this filer counts the quota and folder usages in the filer store.
+// The usages in a store shared by several filers are only counted by the filer with the smallest address,
+// with the changes of all of them, so that the filers do not overwrite each other's counts.
+func (ma *MetaAggregator) countsSharedStoreUsages() bool {
+	ma.sharedStorePeersLock.Lock()
+	defer ma.sharedStorePeersLock.Unlock()
+	for peer := range ma.sharedStorePeers {
+		if peer < ma.self {
+			return false
+		}
+	}
+	return true
+}
+
 func (ma *MetaAggregator) loopSubscribeToOneFiler(f *Filer, self pb.ServerAddress, peer pb.ServerAddress, startFrom time.Time, stopChan chan struct{}) {
 	lastTsNs := startFrom.UnixNano()
 	for {
@@ -142,6 +171,8 @@ func (ma *MetaAggregator) doSubscribeToOneFiler(f *Filer, self pb.ServerAddress,
 				glog.Errorf("failed to reply metadata change from %v: %v", peer, err)
 				return
 			}
+			oldEntry, newEntry := oldAndNewEntries(event)
+			f.countUsages(context.Background(), oldEntry, newEntry)
 			counter++
 			if lastPersistTime.Add(time.Minute).Before(time.Now()) {
 				if err := ma.updateOffset(f, peer, peerSignature, event.TsNs); err == nil {
@@ -158,6 +189,13 @@ func (ma *MetaAggregator) doSubscribeToOneFiler(f *Filer, self pb.ServerAddress,
 				}
 			}
 		}
+	} else {
+		// the changes of the peer are already in the shared store, only counted in the usages
+		ma.setSharedStorePeer(peer, true)
+		maybeReplicateMetadataChange = func(event *filer_pb.SubscribeMetadataResponse) {
+			oldEntry, newEntry := oldAndNewEntries(event)
+			f.countUsages(context.Background(), oldEntry, newEntry)
+		}
 	}
 
 	processEventFn := func(event *filer_pb.SubscribeMetadataResponse) error {
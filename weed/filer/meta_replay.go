@@ -37,6 +37,22 @@ func Replay(filerStore FilerStore, resp *filer_pb.SubscribeMetadataResponse) err
 	return nil
 }
 
+// oldAndNewEntries returns the entries before and after the metadata change
+func oldAndNewEntries(resp *filer_pb.SubscribeMetadataResponse) (oldEntry, newEntry *Entry) {
+	message := resp.EventNotification
+	if message.OldEntry != nil {
+		oldEntry = FromPbEntry(resp.Directory, message.OldEntry)
+	}
+	if message.NewEntry != nil {
+		dir := resp.Directory
+		if message.NewParentPath != "" {
+			dir = message.NewParentPath
+		}
+		newEntry = FromPbEntry(dir, message.NewEntry)
+	}
+	return
+}
+
 // ParallelProcessDirectoryStructure processes each entry in parallel, and also ensure parent directories are processed first.
 // This also assumes the parent directories are in the entryChan already.
 func ParallelProcessDirectoryStructure(entryChan chan *Entry, concurrency int, eachEntryFn func(entry *Entry) error) (firstErr error) {
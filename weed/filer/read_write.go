@@ -66,7 +66,7 @@ func SaveInsideFiler(client filer_pb.SeaweedFilerClient, dir, name string, conte
 	} else if err == nil {
 		entry := resp.Entry
 		entry.Content = content
-		entry.Attributes.Mtime = time.Now().Unix()
+		entry.Attributes.SetModTime(time.Now())
 		entry.Attributes.FileSize = uint64(len(content))
 		err = filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
 			Directory: dir,
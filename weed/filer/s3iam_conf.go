@@ -1,10 +1,14 @@
 package filer
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"io"
+	"path"
 
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	jsonpb "google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -54,3 +58,58 @@ func CheckDuplicateAccessKey(s3cfg *iam_pb.S3ApiConfiguration) error {
 	}
 	return nil
 }
+
+// ReadS3Identities reads the identities of the s3 configuration, which the ftp and webdav servers share with the s3 gateway
+func ReadS3Identities(filerClient filer_pb.FilerClient) ([]*iam_pb.Identity, error) {
+	var content []byte
+	err := filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) (err error) {
+		content, err = ReadInsideFiler(client, IamConfigDirectory, IamIdentityFile)
+		return err
+	})
+	if err == filer_pb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s/%s: %v", IamConfigDirectory, IamIdentityFile, err)
+	}
+	config := &iam_pb.S3ApiConfiguration{}
+	if err = ParseS3ConfigurationFromBytes(content, config); err != nil {
+		return nil, fmt.Errorf("parse %s/%s: %v", IamConfigDirectory, IamIdentityFile, err)
+	}
+	return config.Identities, nil
+}
+
+// FindS3IdentityByPassword returns the identity logging in with its name or access key, and the secret key as password
+func FindS3IdentityByPassword(identities []*iam_pb.Identity, username, password string) *iam_pb.Identity {
+	if username == "" || password == "" {
+		return nil
+	}
+	for _, identity := range identities {
+		for _, credential := range identity.Credentials {
+			if username != identity.Name && username != credential.AccessKey {
+				continue
+			}
+			if subtle.ConstantTimeCompare([]byte(password), []byte(credential.SecretKey)) == 1 {
+				return identity
+			}
+		}
+	}
+	return nil
+}
+
+// S3IdentityHomeDirectory returns the directory under the root the identity is confined to.
+// Admins get the whole root, the other identities a directory named after them,
+// which is read only unless they are allowed to write.
+func S3IdentityHomeDirectory(root string, identity *iam_pb.Identity) (home string, readOnly bool) {
+	root = path.Clean("/" + root)
+	readOnly = true
+	for _, action := range identity.Actions {
+		switch action {
+		case s3_constants.ACTION_ADMIN:
+			return root, false
+		case s3_constants.ACTION_WRITE:
+			readOnly = false
+		}
+	}
+	return path.Join(root, path.Clean("/"+identity.Name)), readOnly
+}
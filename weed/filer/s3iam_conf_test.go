@@ -181,3 +181,36 @@ func TestCheckDuplicateAccessKey(t *testing.T) {
 		}
 	}
 }
+
+func TestFindS3IdentityByPassword(t *testing.T) {
+	identities := []*iam_pb.Identity{
+		{Name: "admin", Credentials: []*iam_pb.Credential{{AccessKey: "admin_ak", SecretKey: "admin_sk"}}, Actions: []string{"Admin"}},
+		{Name: "partner", Credentials: []*iam_pb.Credential{{AccessKey: "old_ak", SecretKey: "old_sk"}, {AccessKey: "new_ak", SecretKey: "new_sk"}}},
+		{Name: "anonymous", Actions: []string{"Read"}},
+	}
+
+	assert.Equal(t, "admin", FindS3IdentityByPassword(identities, "admin", "admin_sk").GetName())
+	assert.Equal(t, "admin", FindS3IdentityByPassword(identities, "admin_ak", "admin_sk").GetName())
+	assert.Equal(t, "partner", FindS3IdentityByPassword(identities, "partner", "new_sk").GetName())
+	assert.Equal(t, "partner", FindS3IdentityByPassword(identities, "old_ak", "old_sk").GetName())
+	assert.Nil(t, FindS3IdentityByPassword(identities, "old_ak", "new_sk"), "the secret key of another access key")
+	assert.Nil(t, FindS3IdentityByPassword(identities, "admin", "partner_sk"))
+	assert.Nil(t, FindS3IdentityByPassword(identities, "anonymous", ""))
+}
+
+func TestS3IdentityHomeDirectory(t *testing.T) {
+	home, readOnly := S3IdentityHomeDirectory("/dav", &iam_pb.Identity{Name: "admin", Actions: []string{"Read", "Admin"}})
+	assert.Equal(t, "/dav", home)
+	assert.False(t, readOnly)
+
+	home, readOnly = S3IdentityHomeDirectory("/dav", &iam_pb.Identity{Name: "partner", Actions: []string{"Read", "Write"}})
+	assert.Equal(t, "/dav/partner", home)
+	assert.False(t, readOnly)
+
+	home, readOnly = S3IdentityHomeDirectory("/dav", &iam_pb.Identity{Name: "../viewer", Actions: []string{"Read", "Write:bucket"}})
+	assert.Equal(t, "/dav/viewer", home)
+	assert.True(t, readOnly)
+
+	home, _ = S3IdentityHomeDirectory("", &iam_pb.Identity{Name: "admin", Actions: []string{"Admin"}})
+	assert.Equal(t, "/", home)
+}
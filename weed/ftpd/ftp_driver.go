@@ -0,0 +1,334 @@
+package ftpd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+
+	ftpserver "github.com/fclairamb/ftpserverlib"
+	"github.com/spf13/afero"
+)
+
+// FtpDriver serves the files of one logged in user, confined to the user's home directory on the filer
+type FtpDriver struct {
+	server   *FtpServer
+	home     string
+	readOnly bool
+}
+
+var (
+	_ = ftpserver.ClientDriver(&FtpDriver{})
+	_ = ftpserver.ClientDriverExtensionFileList(&FtpDriver{})
+	_ = ftpserver.ClientDriverExtentionFileTransfer(&FtpDriver{})
+	_ = ftpserver.ClientDriverExtensionRemoveDir(&FtpDriver{})
+)
+
+// fullPath maps the path seen by the ftp client to the path on the filer,
+// the client can not go above its home directory
+func (d *FtpDriver) fullPath(name string) util.FullPath {
+	return util.FullPath(path.Join(d.home, path.Clean("/"+name)))
+}
+
+func (d *FtpDriver) Name() string {
+	return "SeaweedFS"
+}
+
+func (d *FtpDriver) getEntry(fullPath util.FullPath) (*filer_pb.Entry, error) {
+	if fullPath == "/" {
+		return &filer_pb.Entry{
+			Name:        "/",
+			IsDirectory: true,
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode: uint32(0755 | os.ModeDir),
+				Mtime:    time.Now().Unix(),
+			},
+		}, nil
+	}
+	entry, err := filer_pb.GetEntry(d.server, fullPath)
+	if err == filer_pb.ErrNotFound || (err == nil && entry == nil) {
+		return nil, os.ErrNotExist
+	}
+	return entry, err
+}
+
+func (d *FtpDriver) Stat(name string) (os.FileInfo, error) {
+	glog.V(2).Infof("FtpDriver.Stat %v", name)
+
+	entry, err := d.getEntry(d.fullPath(name))
+	if err != nil {
+		return nil, err
+	}
+	return newFileInfo(entry), nil
+}
+
+func (d *FtpDriver) ReadDir(name string) (fileInfos []os.FileInfo, err error) {
+	glog.V(2).Infof("FtpDriver.ReadDir %v", name)
+
+	err = filer_pb.ReadDirAllEntries(d.server, d.fullPath(name), "", func(entry *filer_pb.Entry, isLast bool) error {
+		fileInfos = append(fileInfos, newFileInfo(entry))
+		return nil
+	})
+	return fileInfos, err
+}
+
+func (d *FtpDriver) Mkdir(name string, perm os.FileMode) error {
+	glog.V(2).Infof("FtpDriver.Mkdir %v", name)
+
+	if d.readOnly {
+		return os.ErrPermission
+	}
+	fullPath := d.fullPath(name)
+	if _, err := d.getEntry(fullPath); err == nil {
+		return os.ErrExist
+	} else if err != os.ErrNotExist {
+		return err
+	}
+	dir, dirName := fullPath.DirAndName()
+	return filer_pb.Mkdir(d.server, dir, dirName, func(entry *filer_pb.Entry) {
+		entry.Attributes.FileMode = uint32(perm.Perm() | os.ModeDir)
+		entry.Attributes.Uid = d.server.option.Uid
+		entry.Attributes.Gid = d.server.option.Gid
+	})
+}
+
+func (d *FtpDriver) MkdirAll(name string, perm os.FileMode) error {
+	glog.V(2).Infof("FtpDriver.MkdirAll %v", name)
+
+	if d.readOnly {
+		return os.ErrPermission
+	}
+	// the filer creates the missing parent directories
+	return d.server.ensureDirectory(string(d.fullPath(name)))
+}
+
+// Remove deletes a file, the DELE command
+func (d *FtpDriver) Remove(name string) error {
+	glog.V(2).Infof("FtpDriver.Remove %v", name)
+
+	return d.remove(name, false, false)
+}
+
+// RemoveDir deletes an empty directory, the RMD command
+func (d *FtpDriver) RemoveDir(name string) error {
+	glog.V(2).Infof("FtpDriver.RemoveDir %v", name)
+
+	return d.remove(name, true, false)
+}
+
+func (d *FtpDriver) RemoveAll(name string) error {
+	glog.V(2).Infof("FtpDriver.RemoveAll %v", name)
+
+	err := d.remove(name, true, true)
+	if err == os.ErrNotExist {
+		return nil
+	}
+	return err
+}
+
+func (d *FtpDriver) remove(name string, isDirectory, isRecursive bool) error {
+	if d.readOnly {
+		return os.ErrPermission
+	}
+	fullPath := d.fullPath(name)
+	if string(fullPath) == d.home {
+		return os.ErrPermission
+	}
+	entry, err := d.getEntry(fullPath)
+	if err != nil {
+		return err
+	}
+	if entry.IsDirectory != isDirectory {
+		if isDirectory {
+			return fmt.Errorf("%s is not a directory", name)
+		}
+		return fmt.Errorf("%s is a directory", name)
+	}
+	dir, entryName := fullPath.DirAndName()
+	return filer_pb.Remove(d.server, dir, entryName, true, isRecursive, false, false, []int32{d.server.signature})
+}
+
+func (d *FtpDriver) Rename(oldName, newName string) error {
+	glog.V(2).Infof("FtpDriver.Rename %v to %v", oldName, newName)
+
+	if d.readOnly {
+		return os.ErrPermission
+	}
+	oldPath, newPath := d.fullPath(oldName), d.fullPath(newName)
+	if string(oldPath) == d.home || string(newPath) == d.home {
+		return os.ErrPermission
+	}
+	oldDir, oldBaseName := oldPath.DirAndName()
+	newDir, newBaseName := newPath.DirAndName()
+
+	return d.server.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDir,
+			OldName:      oldBaseName,
+			NewDirectory: newDir,
+			NewName:      newBaseName,
+		}
+		if _, err := client.AtomicRenameEntry(context.Background(), request); err != nil {
+			return fmt.Errorf("renaming %s => %s: %v", oldPath, newPath, err)
+		}
+		return nil
+	})
+}
+
+func (d *FtpDriver) updateEntry(name string, fn func(entry *filer_pb.Entry)) error {
+	if d.readOnly {
+		return os.ErrPermission
+	}
+	fullPath := d.fullPath(name)
+	entry, err := d.getEntry(fullPath)
+	if err != nil {
+		return err
+	}
+	fn(entry)
+	dir, _ := fullPath.DirAndName()
+	return d.server.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{d.server.signature},
+		})
+	})
+}
+
+func (d *FtpDriver) Chmod(name string, mode os.FileMode) error {
+	glog.V(2).Infof("FtpDriver.Chmod %v %v", name, mode)
+
+	return d.updateEntry(name, func(entry *filer_pb.Entry) {
+		entry.Attributes.FileMode = uint32(os.FileMode(entry.Attributes.FileMode)&^os.ModePerm | mode.Perm())
+	})
+}
+
+func (d *FtpDriver) Chown(name string, uid, gid int) error {
+	glog.V(2).Infof("FtpDriver.Chown %v %d:%d", name, uid, gid)
+
+	return d.updateEntry(name, func(entry *filer_pb.Entry) {
+		entry.Attributes.Uid = uint32(uid)
+		entry.Attributes.Gid = uint32(gid)
+	})
+}
+
+func (d *FtpDriver) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	glog.V(2).Infof("FtpDriver.Chtimes %v %v", name, mtime)
+
+	return d.updateEntry(name, func(entry *filer_pb.Entry) {
+		entry.Attributes.SetModTime(mtime)
+	})
+}
+
+func (d *FtpDriver) Create(name string) (afero.File, error) {
+	return d.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+}
+
+func (d *FtpDriver) Open(name string) (afero.File, error) {
+	return d.OpenFile(name, os.O_RDONLY, 0)
+}
+
+func (d *FtpDriver) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	glog.V(2).Infof("FtpDriver.OpenFile %v %x", name, flag)
+
+	return d.openFile(name, flag, perm)
+}
+
+// GetHandle opens the file to download, or to upload with the STOR, APPE and REST commands
+func (d *FtpDriver) GetHandle(name string, flags int, offset int64) (ftpserver.FileTransfer, error) {
+	glog.V(2).Infof("FtpDriver.GetHandle %v %x %d", name, flags, offset)
+
+	return d.openFile(name, flags, 0644)
+}
+
+func (d *FtpDriver) openFile(name string, flag int, perm os.FileMode) (*FtpFile, error) {
+	fullPath := d.fullPath(name)
+	entry, err := d.getEntry(fullPath)
+	if err != nil && err != os.ErrNotExist {
+		return nil, err
+	}
+
+	f := &FtpFile{
+		driver:   d,
+		name:     name,
+		fullPath: fullPath,
+		entry:    entry,
+	}
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		if entry == nil {
+			return nil, os.ErrNotExist
+		}
+		return f, nil
+	}
+
+	if d.readOnly {
+		return nil, os.ErrPermission
+	}
+	if entry != nil && entry.IsDirectory {
+		return nil, fmt.Errorf("%s is a directory", name)
+	}
+	if entry == nil && flag&os.O_CREATE == 0 {
+		return nil, os.ErrNotExist
+	}
+	f.writable = true
+	if entry == nil || flag&os.O_TRUNC != 0 {
+		now := time.Now().Unix()
+		f.entry = &filer_pb.Entry{
+			Name: fullPath.Name(),
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode: uint32(perm.Perm()),
+				Uid:      d.server.option.Uid,
+				Gid:      d.server.option.Gid,
+				Crtime:   now,
+				Mtime:    now,
+			},
+		}
+		return f, nil
+	}
+
+	// keep the existing content to append to it, or to resume an upload at the offset of the REST command
+	if len(entry.Content) > 0 {
+		chunk, err := f.saveDataAsChunk(util.NewBytesReader(entry.Content), string(fullPath), 0, time.Now().UnixNano())
+		if err != nil {
+			return nil, err
+		}
+		entry.Content = nil
+		entry.Chunks = append(entry.GetChunks(), chunk)
+	}
+	if flag&os.O_APPEND != 0 {
+		f.offset = int64(filer.FileSize(entry))
+	}
+	return f, nil
+}
+
+type fileInfo struct {
+	name  string
+	entry *filer_pb.Entry
+}
+
+func newFileInfo(entry *filer_pb.Entry) *fileInfo {
+	return &fileInfo{
+		name:  entry.Name,
+		entry: entry,
+	}
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return int64(filer.FileSize(fi.entry)) }
+func (fi *fileInfo) ModTime() time.Time { return fi.entry.Attributes.ModTime() }
+func (fi *fileInfo) IsDir() bool        { return fi.entry.IsDirectory }
+func (fi *fileInfo) Sys() interface{}   { return nil }
+
+func (fi *fileInfo) Mode() os.FileMode {
+	mode := os.FileMode(fi.entry.Attributes.FileMode)
+	if fi.entry.IsDirectory {
+		mode |= os.ModeDir
+	}
+	return mode
+}
@@ -0,0 +1,261 @@
+package ftpd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+
+	ftpserver "github.com/fclairamb/ftpserverlib"
+	"github.com/spf13/afero"
+)
+
+// FtpFile reads a file from the volume servers, or uploads it in chunks of MaxMB,
+// and saves the entry to the filer when closed
+type FtpFile struct {
+	driver   *FtpDriver
+	name     string
+	fullPath util.FullPath
+	entry    *filer_pb.Entry
+	writable bool
+	offset   int64
+
+	reader io.ReaderAt
+
+	buffer       bytes.Buffer
+	bufferOffset int64
+}
+
+var (
+	_ = ftpserver.FileTransfer(&FtpFile{})
+	_ = afero.File(&FtpFile{})
+)
+
+func (f *FtpFile) Name() string {
+	return f.name
+}
+
+func (f *FtpFile) Stat() (os.FileInfo, error) {
+	return newFileInfo(f.entry), nil
+}
+
+func (f *FtpFile) Readdir(count int) ([]os.FileInfo, error) {
+	fileInfos, err := f.driver.ReadDir(f.name)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 && len(fileInfos) > count {
+		fileInfos = fileInfos[:count]
+	}
+	return fileInfos, nil
+}
+
+func (f *FtpFile) Readdirnames(n int) (names []string, err error) {
+	fileInfos, err := f.Readdir(n)
+	for _, fileInfo := range fileInfos {
+		names = append(names, fileInfo.Name())
+	}
+	return names, err
+}
+
+func (f *FtpFile) Read(p []byte) (n int, err error) {
+	n, err = f.ReadAt(p, f.offset)
+	f.offset += int64(n)
+	return
+}
+
+func (f *FtpFile) ReadAt(p []byte, offset int64) (n int, err error) {
+	if f.entry.IsDirectory {
+		return 0, fmt.Errorf("%s is a directory", f.name)
+	}
+	fileSize := int64(filer.FileSize(f.entry))
+	if offset >= fileSize {
+		return 0, io.EOF
+	}
+	if f.reader == nil {
+		if len(f.entry.Content) > 0 {
+			f.reader = bytes.NewReader(f.entry.Content)
+		} else {
+			visibleIntervals, err := filer.NonOverlappingVisibleIntervals(filer.LookupFn(f.driver.server), f.entry.GetChunks(), 0, fileSize)
+			if err != nil {
+				return 0, err
+			}
+			chunkViews := filer.ViewFromVisibleIntervals(visibleIntervals, 0, fileSize)
+			f.reader = filer.NewChunkReaderAtFromClient(f.driver.server.readerCache, chunkViews, fileSize)
+		}
+	}
+
+	n, err = f.reader.ReadAt(p, offset)
+	glog.V(3).Infof("FtpFile.Read %v: [%d,%d)", f.name, offset, offset+int64(n))
+	if err != nil && err != io.EOF {
+		glog.Errorf("file read %s: %v", f.name, err)
+	}
+	return
+}
+
+func (f *FtpFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(filer.FileSize(f.entry))
+	default:
+		return 0, os.ErrInvalid
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	if f.writable && offset != f.offset {
+		if err := f.flush(); err != nil {
+			return 0, err
+		}
+	}
+	f.offset = offset
+	return f.offset, nil
+}
+
+func (f *FtpFile) Write(p []byte) (n int, err error) {
+	if !f.writable {
+		return 0, os.ErrPermission
+	}
+	if f.buffer.Len() == 0 {
+		f.bufferOffset = f.offset
+	}
+	n, _ = f.buffer.Write(p)
+	f.offset += int64(n)
+	f.entry.Attributes.FileSize = uint64(max(f.offset, int64(f.entry.Attributes.FileSize)))
+	glog.V(3).Infof("FtpFile.Write %v: [%d,%d)", f.name, f.offset-int64(n), f.offset)
+
+	if f.buffer.Len() >= f.driver.server.option.MaxMB*1024*1024 {
+		err = f.flush()
+	}
+	return
+}
+
+func (f *FtpFile) WriteAt(p []byte, offset int64) (n int, err error) {
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return f.Write(p)
+}
+
+func (f *FtpFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+func (f *FtpFile) Truncate(size int64) error {
+	if !f.writable {
+		return os.ErrPermission
+	}
+	if size != 0 {
+		return fmt.Errorf("truncate %s to %d bytes is not supported", f.name, size)
+	}
+	f.buffer.Reset()
+	f.entry.Content = nil
+	f.entry.Chunks = nil
+	f.entry.Attributes.FileSize = 0
+	f.offset = 0
+	return nil
+}
+
+func (f *FtpFile) Sync() error {
+	if !f.writable {
+		return nil
+	}
+	return f.flush()
+}
+
+// flush uploads the buffered data as one chunk
+func (f *FtpFile) flush() error {
+	if f.buffer.Len() == 0 {
+		return nil
+	}
+	chunk, err := f.saveDataAsChunk(util.NewBytesReader(f.buffer.Bytes()), string(f.fullPath), f.bufferOffset, time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	f.entry.Chunks = append(f.entry.GetChunks(), chunk)
+	f.buffer.Reset()
+	return nil
+}
+
+// Close saves the uploaded file. An interrupted upload is kept,
+// so the client can resume it with the REST or APPE commands.
+func (f *FtpFile) Close() error {
+	glog.V(2).Infof("FtpFile.Close %v", f.name)
+
+	if !f.writable {
+		return nil
+	}
+
+	if err := f.flush(); err != nil {
+		return err
+	}
+	manifestedChunks, manifestErr := filer.MaybeManifestize(f.saveDataAsChunk, f.entry.GetChunks())
+	if manifestErr != nil {
+		// not good, but should be ok
+		glog.V(0).Infof("file %s close MaybeManifestize: %v", f.name, manifestErr)
+	} else {
+		f.entry.Chunks = manifestedChunks
+	}
+	f.entry.Attributes.SetModTime(time.Now())
+
+	dir, _ := f.fullPath.DirAndName()
+	return f.driver.server.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.CreateEntryRequest{
+			Directory:  dir,
+			Entry:      f.entry,
+			Signatures: []int32{f.driver.server.signature},
+		}
+		if err := filer_pb.CreateEntry(client, request); err != nil {
+			return fmt.Errorf("save %s: %v", f.fullPath, err)
+		}
+		return nil
+	})
+}
+
+func (f *FtpFile) saveDataAsChunk(reader io.Reader, name string, offset int64, tsNs int64) (chunk *filer_pb.FileChunk, err error) {
+	option := f.driver.server.option
+	uploader, uploaderErr := operation.NewUploader()
+	if uploaderErr != nil {
+		glog.V(0).Infof("upload data %v: %v", f.fullPath, uploaderErr)
+		return nil, fmt.Errorf("upload data: %v", uploaderErr)
+	}
+
+	fileId, uploadResult, flushErr, _ := uploader.UploadWithRetry(
+		f.driver.server,
+		&filer_pb.AssignVolumeRequest{
+			Count:       1,
+			Replication: option.Replication,
+			Collection:  option.Collection,
+			DiskType:    option.DiskType,
+			Path:        name,
+		},
+		&operation.UploadOption{
+			Filename: f.entry.Name,
+			Cipher:   option.Cipher,
+		},
+		func(host, fileId string) string {
+			return fmt.Sprintf("http://%s/%s", host, fileId)
+		},
+		reader,
+	)
+
+	if flushErr != nil {
+		glog.V(0).Infof("upload data %v: %v", f.fullPath, flushErr)
+		return nil, fmt.Errorf("upload data: %v", flushErr)
+	}
+	if uploadResult.Error != "" {
+		glog.V(0).Infof("upload failure %v: %v", f.fullPath, uploadResult.Error)
+		return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
+	}
+	return uploadResult.ToPbFileChunk(fileId, offset, tsNs), nil
+}
@@ -3,44 +3,68 @@ package ftpd
 import (
 	"crypto/tls"
 	"errors"
-	"github.com/seaweedfs/seaweedfs/weed/util"
 	"net"
+	"os"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/chunk_cache"
 
 	ftpserver "github.com/fclairamb/ftpserverlib"
 	"google.golang.org/grpc"
 )
 
 type FtpServerOption struct {
-	Filer            string
+	Filer            pb.ServerAddress
 	IP               string
 	IpBind           string
 	Port             int
-	FilerGrpcAddress string
 	FtpRoot          string
 	GrpcDialOption   grpc.DialOption
 	PassivePortStart int
 	PassivePortStop  int
+	TLSConfig        *tls.Config
+	TLSRequired      bool
+	Collection       string
+	Replication      string
+	DiskType         string
+	Uid              uint32
+	Gid              uint32
+	Cipher           bool
+	MaxMB            int
 }
 
-type SftpServer struct {
+type FtpServer struct {
 	option      *FtpServerOption
 	ftpListener net.Listener
+	readerCache *filer.ReaderCache
+	signature   int32
 }
 
-var _ = ftpserver.MainDriver(&SftpServer{})
+var (
+	_ = ftpserver.MainDriver(&FtpServer{})
+	_ = filer_pb.FilerClient(&FtpServer{})
+
+	errAuthenticationFailed = errors.New("authentication failed")
+)
 
 // NewFtpServer returns a new FTP server driver
-func NewFtpServer(ftpListener net.Listener, option *FtpServerOption) (*SftpServer, error) {
+func NewFtpServer(ftpListener net.Listener, option *FtpServerOption) (*FtpServer, error) {
 	var err error
-	server := &SftpServer{
+	server := &FtpServer{
 		option:      option,
 		ftpListener: ftpListener,
+		signature:   util.RandomInt32(),
 	}
+	server.readerCache = filer.NewReaderCache(32, chunk_cache.NewChunkCacheInMemory(256), filer.LookupFn(server))
 	return server, err
 }
 
 // GetSettings returns some general settings around the server setup
-func (s *SftpServer) GetSettings() (*ftpserver.Settings, error) {
+func (s *FtpServer) GetSettings() (*ftpserver.Settings, error) {
 	var portRange *ftpserver.PortRange
 	if s.option.PassivePortStart > 0 && s.option.PassivePortStop > s.option.PassivePortStart {
 		portRange = &ftpserver.PortRange{
@@ -49,11 +73,17 @@ func (s *SftpServer) GetSettings() (*ftpserver.Settings, error) {
 		}
 	}
 
+	tlsRequired := ftpserver.ClearOrEncrypted
+	if s.option.TLSRequired {
+		tlsRequired = ftpserver.MandatoryEncryption
+	}
+
 	return &ftpserver.Settings{
 		Listener:                 s.ftpListener,
 		ListenAddr:               util.JoinHostPort(s.option.IpBind, s.option.Port),
 		PublicHost:               s.option.IP,
 		PassiveTransferPortRange: portRange,
+		TLSRequired:              tlsRequired,
 		ActiveTransferPortNon20:  true,
 		IdleTimeout:              -1,
 		ConnectionTimeout:        20,
@@ -61,21 +91,78 @@ func (s *SftpServer) GetSettings() (*ftpserver.Settings, error) {
 }
 
 // ClientConnected is called to send the very first welcome message
-func (s *SftpServer) ClientConnected(cc ftpserver.ClientContext) (string, error) {
+func (s *FtpServer) ClientConnected(cc ftpserver.ClientContext) (string, error) {
 	return "Welcome to SeaweedFS FTP Server", nil
 }
 
 // ClientDisconnected is called when the user disconnects, even if he never authenticated
-func (s *SftpServer) ClientDisconnected(cc ftpserver.ClientContext) {
+func (s *FtpServer) ClientDisconnected(cc ftpserver.ClientContext) {
 }
 
 // AuthUser authenticates the user and selects an handling driver
-func (s *SftpServer) AuthUser(cc ftpserver.ClientContext, username, password string) (ftpserver.ClientDriver, error) {
-	return nil, nil
+func (s *FtpServer) AuthUser(cc ftpserver.ClientContext, username, password string) (ftpserver.ClientDriver, error) {
+	identities, err := filer.ReadS3Identities(s)
+	if err != nil {
+		glog.Errorf("ftp user %s: %v", username, err)
+		return nil, errAuthenticationFailed
+	}
+	identity := filer.FindS3IdentityByPassword(identities, username, password)
+	if identity == nil {
+		glog.V(1).Infof("ftp user %s from %v: %v", username, cc.RemoteAddr(), errAuthenticationFailed)
+		return nil, errAuthenticationFailed
+	}
+
+	home, readOnly := filer.S3IdentityHomeDirectory(s.option.FtpRoot, identity)
+	if err = s.ensureDirectory(home); err != nil {
+		glog.Errorf("ftp user %s home %s: %v", username, home, err)
+		return nil, err
+	}
+	glog.V(1).Infof("ftp user %s from %v logged in to %s", identity.Name, cc.RemoteAddr(), home)
+
+	return &FtpDriver{
+		server:   s,
+		home:     home,
+		readOnly: readOnly,
+	}, nil
 }
 
 // GetTLSConfig returns a TLS Certificate to use
 // The certificate could frequently change if we use something like "let's encrypt"
-func (s *SftpServer) GetTLSConfig() (*tls.Config, error) {
-	return nil, errors.New("no TLS certificate configured")
+func (s *FtpServer) GetTLSConfig() (*tls.Config, error) {
+	if s.option.TLSConfig == nil {
+		return nil, errors.New("no TLS certificate configured")
+	}
+	return s.option.TLSConfig, nil
+}
+
+func (s *FtpServer) WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithGrpcClient(streamingMode, s.signature, func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		return fn(client)
+	}, s.option.Filer.ToGrpcAddress(), false, s.option.GrpcDialOption)
+}
+
+func (s *FtpServer) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+func (s *FtpServer) GetDataCenter() string {
+	return ""
+}
+
+// ensureDirectory creates the directory with its parent directories if it does not exist
+func (s *FtpServer) ensureDirectory(dir string) error {
+	if dir == "/" {
+		return nil
+	}
+	parent, name := util.FullPath(dir).DirAndName()
+	exists, err := filer_pb.Exists(s, parent, name, true)
+	if err != nil || exists {
+		return err
+	}
+	return filer_pb.Mkdir(s, parent, name, func(entry *filer_pb.Entry) {
+		entry.Attributes.FileMode = uint32(0755 | os.ModeDir)
+		entry.Attributes.Uid = s.option.Uid
+		entry.Attributes.Gid = s.option.Gid
+	})
 }
@@ -0,0 +1,25 @@
+package ftpd
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriverFullPath(t *testing.T) {
+	d := &FtpDriver{home: "/ftp/partner"}
+	for name, expected := range map[string]util.FullPath{
+		"/":              "/ftp/partner",
+		"":               "/ftp/partner",
+		"/in/a.txt":      "/ftp/partner/in/a.txt",
+		"in/a.txt":       "/ftp/partner/in/a.txt",
+		"/../admin/a":    "/ftp/partner/admin/a",
+		"in/../../../..": "/ftp/partner",
+	} {
+		assert.Equal(t, expected, d.fullPath(name), name)
+	}
+
+	d = &FtpDriver{home: "/"}
+	assert.Equal(t, util.FullPath("/in/a.txt"), d.fullPath("in/a.txt"))
+}
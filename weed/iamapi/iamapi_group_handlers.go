@@ -0,0 +1,193 @@
+package iamapi
+
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/id_groups.html
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+const (
+	groupArnPrefix = "arn:aws:iam:::group/"
+
+	GROUP_DOES_NOT_EXIST = "the group with name %s cannot be found."
+)
+
+func findGroup(s3cfg *iam_pb.S3ApiConfiguration, groupName string) (*iam_pb.Group, *IamError) {
+	for _, group := range s3cfg.Groups {
+		if group.Name == groupName {
+			return group, nil
+		}
+	}
+	return nil, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(GROUP_DOES_NOT_EXIST, groupName)}
+}
+
+func toIamGroup(group *iam_pb.Group) *iam.Group {
+	arn := groupArnPrefix + group.Name
+	groupId := Hash(&arn)
+	return &iam.Group{
+		GroupName: &group.Name,
+		GroupId:   &groupId,
+		Arn:       &arn,
+	}
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreateGroup.html
+func (iama *IamApiServer) CreateGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreateGroupResponse, iamError *IamError) {
+	groupName := values.Get("GroupName")
+	if groupName == "" {
+		return resp, &IamError{Code: iam.ErrCodeInvalidInputException, Error: fmt.Errorf("the group name is required")}
+	}
+	if _, iamError = findGroup(s3cfg, groupName); iamError == nil {
+		return resp, &IamError{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("the group with name %s already exists.", groupName)}
+	}
+	group := &iam_pb.Group{Name: groupName}
+	s3cfg.Groups = append(s3cfg.Groups, group)
+	resp.CreateGroupResult.Group = *toIamGroup(group)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_GetGroup.html
+func (iama *IamApiServer) GetGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp GetGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.GetGroupResult.Group = *toIamGroup(group)
+	for _, member := range group.Members {
+		userName := member
+		resp.GetGroupResult.Users = append(resp.GetGroupResult.Users, &iam.User{UserName: &userName})
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListGroups.html
+func (iama *IamApiServer) ListGroups(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListGroupsResponse) {
+	for _, group := range s3cfg.Groups {
+		resp.ListGroupsResult.Groups = append(resp.ListGroupsResult.Groups, toIamGroup(group))
+	}
+	return resp
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListGroupsForUser.html
+func (iama *IamApiServer) ListGroupsForUser(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListGroupsForUserResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	for _, group := range s3cfg.Groups {
+		if slices.Contains(group.Members, ident.Name) {
+			resp.ListGroupsForUserResult.Groups = append(resp.ListGroupsForUserResult.Groups, toIamGroup(group))
+		}
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DeleteGroup.html
+func (iama *IamApiServer) DeleteGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DeleteGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if len(group.Members) > 0 || len(group.PolicyNames) > 0 {
+		return resp, &IamError{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("the group %s must have no users and no attached policies.", group.Name)}
+	}
+	s3cfg.Groups = slices.DeleteFunc(s3cfg.Groups, func(g *iam_pb.Group) bool {
+		return g == group
+	})
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_AddUserToGroup.html
+func (iama *IamApiServer) AddUserToGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AddUserToGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if !slices.Contains(group.Members, ident.Name) {
+		group.Members = append(group.Members, ident.Name)
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_RemoveUserFromGroup.html
+func (iama *IamApiServer) RemoveUserFromGroup(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp RemoveUserFromGroupResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	userName := values.Get("UserName")
+	if !slices.Contains(group.Members, userName) {
+		return resp, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf("the user %s is not a member of the group %s.", userName, group.Name)}
+	}
+	group.Members = slices.DeleteFunc(group.Members, func(member string) bool {
+		return member == userName
+	})
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_AttachGroupPolicy.html
+func (iama *IamApiServer) AttachGroupPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AttachGroupPolicyResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	policy, iamError := findPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if !slices.Contains(group.PolicyNames, policy.Name) {
+		group.PolicyNames = append(group.PolicyNames, policy.Name)
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DetachGroupPolicy.html
+func (iama *IamApiServer) DetachGroupPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DetachGroupPolicyResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	arn := values.Get("PolicyArn")
+	policyName := strings.TrimPrefix(arn, policyArnPrefix)
+	if !slices.Contains(group.PolicyNames, policyName) {
+		return resp, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf("the policy %s is not attached to the group %s.", arn, group.Name)}
+	}
+	group.PolicyNames = slices.DeleteFunc(group.PolicyNames, func(name string) bool {
+		return name == policyName
+	})
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListAttachedGroupPolicies.html
+func (iama *IamApiServer) ListAttachedGroupPolicies(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListAttachedGroupPoliciesResponse, iamError *IamError) {
+	group, iamError := findGroup(s3cfg, values.Get("GroupName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.ListAttachedGroupPoliciesResult.AttachedPolicies = toAttachedPolicies(group.PolicyNames)
+	return resp, nil
+}
+
+// renameGroupMember keeps the group memberships of a renamed user, or drops them if newUserName is empty
+func renameGroupMember(s3cfg *iam_pb.S3ApiConfiguration, userName, newUserName string) {
+	for _, group := range s3cfg.Groups {
+		for i, member := range group.Members {
+			if member == userName {
+				group.Members[i] = newUserName
+			}
+		}
+		group.Members = slices.DeleteFunc(group.Members, func(member string) bool {
+			return member == ""
+		})
+	}
+}
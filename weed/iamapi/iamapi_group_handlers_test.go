@@ -0,0 +1,103 @@
+package iamapi
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const denyDeletePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:ListBucket"], "Resource": ["arn:aws:s3:::reports", "arn:aws:s3:::reports/*"]},
+    {"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "*"}
+  ]
+}`
+
+func TestGroupsAndManagedPolicies(t *testing.T) {
+	s3cfg := &iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{{Name: "alice"}, {Name: "bob"}},
+	}
+	policyArn := "arn:aws:iam:::policy/ReadReports"
+
+	created, iamError := ias.CreatePolicy(s3cfg, url.Values{"PolicyName": {"ReadReports"}, "PolicyDocument": {denyDeletePolicy}})
+	assert.Nil(t, iamError)
+	assert.Equal(t, policyArn, *created.CreatePolicyResult.Policy.Arn)
+	_, iamError = ias.CreatePolicy(s3cfg, url.Values{"PolicyName": {"ReadReports"}, "PolicyDocument": {denyDeletePolicy}})
+	assert.Equal(t, iam.ErrCodeEntityAlreadyExistsException, iamError.Code)
+	_, iamError = ias.CreatePolicy(s3cfg, url.Values{"PolicyName": {"Invalid"}, "PolicyDocument": {`{"Version": "2012-10-17", "Statement": [{"Effect": "Maybe"}]}`}})
+	assert.Equal(t, iam.ErrCodeMalformedPolicyDocumentException, iamError.Code)
+
+	_, iamError = ias.CreateGroup(s3cfg, url.Values{"GroupName": {"analysts"}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.AddUserToGroup(s3cfg, url.Values{"GroupName": {"analysts"}, "UserName": {"alice"}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.AddUserToGroup(s3cfg, url.Values{"GroupName": {"analysts"}, "UserName": {"bob"}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.AddUserToGroup(s3cfg, url.Values{"GroupName": {"analysts"}, "UserName": {"nobody"}})
+	assert.Equal(t, iam.ErrCodeNoSuchEntityException, iamError.Code)
+	_, iamError = ias.AttachGroupPolicy(s3cfg, url.Values{"GroupName": {"analysts"}, "PolicyArn": {policyArn}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.AttachUserPolicy(s3cfg, url.Values{"UserName": {"bob"}, "PolicyArn": {policyArn}})
+	assert.Nil(t, iamError)
+	assert.Equal(t, []string{"alice", "bob"}, s3cfg.Groups[0].Members)
+	assert.Equal(t, []string{"ReadReports"}, s3cfg.Groups[0].PolicyNames)
+
+	policy, iamError := ias.GetPolicy(s3cfg, url.Values{"PolicyArn": {policyArn}})
+	assert.Nil(t, iamError)
+	assert.Equal(t, int64(2), *policy.GetPolicyResult.Policy.AttachmentCount)
+	version, iamError := ias.GetPolicyVersion(s3cfg, url.Values{"PolicyArn": {policyArn}, "VersionId": {"v1"}})
+	assert.Nil(t, iamError)
+	document, _ := url.PathUnescape(*version.GetPolicyVersionResult.PolicyVersion.Document)
+	assert.Equal(t, denyDeletePolicy, document)
+	assert.Equal(t, 1, len(ias.ListPolicies(s3cfg, url.Values{"OnlyAttached": {"true"}}).ListPoliciesResult.Policies))
+
+	groups, iamError := ias.ListGroupsForUser(s3cfg, url.Values{"UserName": {"bob"}})
+	assert.Nil(t, iamError)
+	assert.Equal(t, "analysts", *groups.ListGroupsForUserResult.Groups[0].GroupName)
+
+	_, iamError = ias.DeletePolicy(s3cfg, url.Values{"PolicyArn": {policyArn}})
+	assert.Equal(t, iam.ErrCodeDeleteConflictException, iamError.Code, "the policy is attached")
+	_, iamError = ias.DeleteGroup(s3cfg, url.Values{"GroupName": {"analysts"}})
+	assert.Equal(t, iam.ErrCodeDeleteConflictException, iamError.Code, "the group has members")
+
+	// renamed and deleted users keep the memberships consistent
+	_, iamError = ias.UpdateUser(s3cfg, url.Values{"UserName": {"alice"}, "NewUserName": {"alice2"}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.DeleteUser(s3cfg, "bob")
+	assert.Nil(t, iamError)
+	assert.Equal(t, []string{"alice2"}, s3cfg.Groups[0].Members)
+
+	_, iamError = ias.RemoveUserFromGroup(s3cfg, url.Values{"GroupName": {"analysts"}, "UserName": {"alice2"}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.DetachGroupPolicy(s3cfg, url.Values{"GroupName": {"analysts"}, "PolicyArn": {policyArn}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.DeleteGroup(s3cfg, url.Values{"GroupName": {"analysts"}})
+	assert.Nil(t, iamError)
+	_, iamError = ias.DeletePolicy(s3cfg, url.Values{"PolicyArn": {policyArn}})
+	assert.Nil(t, iamError)
+	assert.Empty(t, s3cfg.Groups)
+	assert.Empty(t, s3cfg.Policies)
+}
+
+func TestCreateGroup(t *testing.T) {
+	params := &iam.CreateGroupInput{GroupName: aws.String("Test-Group")}
+	req, _ := iam.New(session.New()).CreateGroupRequest(params)
+	_ = req.Build()
+	out := CreateGroupResponse{}
+	response, err := executeRequest(req.HTTPRequest, &out)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "arn:aws:iam:::group/Test-Group", *out.CreateGroupResult.Group.Arn)
+
+	req, _ = iam.New(session.New()).CreateGroupRequest(params)
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
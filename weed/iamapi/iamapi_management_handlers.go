@@ -10,7 +10,6 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -40,7 +39,6 @@ var (
 	seededRand *rand.Rand = rand.New(
 		rand.NewSource(time.Now().UnixNano()))
 	policyDocuments = map[string]*PolicyDocument{}
-	policyLock      = sync.RWMutex{}
 )
 
 func MapToStatementAction(action string) string {
@@ -99,10 +97,6 @@ type Statement struct {
 	Resource []string `json:"Resource"`
 }
 
-type Policies struct {
-	Policies map[string]PolicyDocument `json:"policies"`
-}
-
 type PolicyDocument struct {
 	Version   string       `json:"Version"`
 	Statement []*Statement `json:"Statement"`
@@ -161,6 +155,7 @@ func (iama *IamApiServer) DeleteUser(s3cfg *iam_pb.S3ApiConfiguration, userName
 	for i, ident := range s3cfg.Identities {
 		if userName == ident.Name {
 			s3cfg.Identities = append(s3cfg.Identities[:i], s3cfg.Identities[i+1:]...)
+			renameGroupMember(s3cfg, userName, "")
 			return resp, nil
 		}
 	}
@@ -184,6 +179,7 @@ func (iama *IamApiServer) UpdateUser(s3cfg *iam_pb.S3ApiConfiguration, values ur
 		for _, ident := range s3cfg.Identities {
 			if userName == ident.Name {
 				ident.Name = newUserName
+				renameGroupMember(s3cfg, userName, newUserName)
 				return resp, nil
 			}
 		}
@@ -200,31 +196,6 @@ func GetPolicyDocument(policy *string) (policyDocument PolicyDocument, err error
 	return policyDocument, err
 }
 
-func (iama *IamApiServer) CreatePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreatePolicyResponse, iamError *IamError) {
-	policyName := values.Get("PolicyName")
-	policyDocumentString := values.Get("PolicyDocument")
-	policyDocument, err := GetPolicyDocument(&policyDocumentString)
-	if err != nil {
-		return CreatePolicyResponse{}, &IamError{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
-	}
-	policyId := Hash(&policyDocumentString)
-	arn := fmt.Sprintf("arn:aws:iam:::policy/%s", policyName)
-	resp.CreatePolicyResult.Policy.PolicyName = &policyName
-	resp.CreatePolicyResult.Policy.Arn = &arn
-	resp.CreatePolicyResult.Policy.PolicyId = &policyId
-	policies := Policies{}
-	policyLock.Lock()
-	defer policyLock.Unlock()
-	if err = iama.s3ApiConfig.GetPolicies(&policies); err != nil {
-		return resp, &IamError{Code: iam.ErrCodeServiceFailureException, Error: err}
-	}
-	policies.Policies[policyName] = policyDocument
-	if err = iama.s3ApiConfig.PutPolicies(&policies); err != nil {
-		return resp, &IamError{Code: iam.ErrCodeServiceFailureException, Error: err}
-	}
-	return resp, nil
-}
-
 type IamError struct {
 	Code  string
 	Error error
@@ -497,10 +468,98 @@ func (iama *IamApiServer) DoActions(w http.ResponseWriter, r *http.Request) {
 	case "CreatePolicy":
 		response, iamError = iama.CreatePolicy(s3cfg, values)
 		if iamError != nil {
-			glog.Errorf("CreatePolicy:  %+v", iamError.Error)
-			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "GetPolicy":
+		response, iamError = iama.GetPolicy(s3cfg, values)
+		if iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "GetPolicyVersion":
+		response, iamError = iama.GetPolicyVersion(s3cfg, values)
+		if iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "ListPolicies":
+		response = iama.ListPolicies(s3cfg, values)
+		changed = false
+	case "DeletePolicy":
+		if response, iamError = iama.DeletePolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "AttachUserPolicy":
+		if response, iamError = iama.AttachUserPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "DetachUserPolicy":
+		if response, iamError = iama.DetachUserPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "ListAttachedUserPolicies":
+		if response, iamError = iama.ListAttachedUserPolicies(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "CreateGroup":
+		if response, iamError = iama.CreateGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "GetGroup":
+		if response, iamError = iama.GetGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "ListGroups":
+		response = iama.ListGroups(s3cfg, values)
+		changed = false
+	case "ListGroupsForUser":
+		if response, iamError = iama.ListGroupsForUser(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
+	case "DeleteGroup":
+		if response, iamError = iama.DeleteGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
 			return
 		}
+	case "AddUserToGroup":
+		if response, iamError = iama.AddUserToGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "RemoveUserFromGroup":
+		if response, iamError = iama.RemoveUserFromGroup(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "AttachGroupPolicy":
+		if response, iamError = iama.AttachGroupPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "DetachGroupPolicy":
+		if response, iamError = iama.DetachGroupPolicy(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+	case "ListAttachedGroupPolicies":
+		if response, iamError = iama.ListAttachedGroupPolicies(s3cfg, values); iamError != nil {
+			writeIamErrorResponse(w, r, iamError)
+			return
+		}
+		changed = false
 	case "PutUserPolicy":
 		var iamError *IamError
 		response, iamError = iama.PutUserPolicy(s3cfg, values)
@@ -0,0 +1,221 @@
+package iamapi
+
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_managed-vs-inline.html
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
+)
+
+const (
+	policyArnPrefix = "arn:aws:iam:::policy/"
+	// the managed policies have a single version, replaced by deleting and creating the policy again
+	policyVersionId = "v1"
+
+	POLICY_DOES_NOT_EXIST = "the policy with arn %s cannot be found."
+)
+
+func policyArn(policyName string) string {
+	return policyArnPrefix + policyName
+}
+
+func findPolicy(s3cfg *iam_pb.S3ApiConfiguration, arn string) (*iam_pb.Policy, *IamError) {
+	if policyName, found := strings.CutPrefix(arn, policyArnPrefix); found {
+		for _, policy := range s3cfg.Policies {
+			if policy.Name == policyName {
+				return policy, nil
+			}
+		}
+	}
+	return nil, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(POLICY_DOES_NOT_EXIST, arn)}
+}
+
+func findIdentity(s3cfg *iam_pb.S3ApiConfiguration, userName string) (*iam_pb.Identity, *IamError) {
+	for _, ident := range s3cfg.Identities {
+		if ident.Name == userName {
+			return ident, nil
+		}
+	}
+	return nil, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(USER_DOES_NOT_EXIST, userName)}
+}
+
+// policyAttachmentCount counts the identities and groups the policy is attached to
+func policyAttachmentCount(s3cfg *iam_pb.S3ApiConfiguration, policyName string) (count int64) {
+	for _, ident := range s3cfg.Identities {
+		if slices.Contains(ident.PolicyNames, policyName) {
+			count++
+		}
+	}
+	for _, group := range s3cfg.Groups {
+		if slices.Contains(group.PolicyNames, policyName) {
+			count++
+		}
+	}
+	return count
+}
+
+func toIamPolicy(s3cfg *iam_pb.S3ApiConfiguration, policy *iam_pb.Policy) *iam.Policy {
+	arn := policyArn(policy.Name)
+	policyId := Hash(&policy.Content)
+	attachmentCount := policyAttachmentCount(s3cfg, policy.Name)
+	versionId := policyVersionId
+	isAttachable := true
+	createDate := time.Unix(policy.CreateDate, 0).UTC()
+	updateDate := time.Unix(policy.UpdateDate, 0).UTC()
+	return &iam.Policy{
+		PolicyName:       &policy.Name,
+		Arn:              &arn,
+		PolicyId:         &policyId,
+		AttachmentCount:  &attachmentCount,
+		DefaultVersionId: &versionId,
+		IsAttachable:     &isAttachable,
+		CreateDate:       &createDate,
+		UpdateDate:       &updateDate,
+	}
+}
+
+func toAttachedPolicies(policyNames []string) (attachedPolicies []*iam.AttachedPolicy) {
+	for _, policyName := range policyNames {
+		policyName, arn := policyName, policyArn(policyName)
+		attachedPolicies = append(attachedPolicies, &iam.AttachedPolicy{PolicyName: &policyName, PolicyArn: &arn})
+	}
+	return attachedPolicies
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreatePolicy.html
+func (iama *IamApiServer) CreatePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreatePolicyResponse, iamError *IamError) {
+	policyName := values.Get("PolicyName")
+	policyDocumentString := values.Get("PolicyDocument")
+	if policyName == "" {
+		return resp, &IamError{Code: iam.ErrCodeInvalidInputException, Error: fmt.Errorf("the policy name is required")}
+	}
+	if _, err := s3policy.ParseIdentityPolicy([]byte(policyDocumentString)); err != nil {
+		return resp, &IamError{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
+	}
+	if _, iamError = findPolicy(s3cfg, policyArn(policyName)); iamError == nil {
+		return resp, &IamError{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("the policy with name %s already exists.", policyName)}
+	}
+	now := time.Now().Unix()
+	policy := &iam_pb.Policy{
+		Name:       policyName,
+		Content:    policyDocumentString,
+		CreateDate: now,
+		UpdateDate: now,
+	}
+	s3cfg.Policies = append(s3cfg.Policies, policy)
+	resp.CreatePolicyResult.Policy = *toIamPolicy(s3cfg, policy)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_GetPolicy.html
+func (iama *IamApiServer) GetPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp GetPolicyResponse, iamError *IamError) {
+	policy, iamError := findPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.GetPolicyResult.Policy = *toIamPolicy(s3cfg, policy)
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_GetPolicyVersion.html
+func (iama *IamApiServer) GetPolicyVersion(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp GetPolicyVersionResponse, iamError *IamError) {
+	arn := values.Get("PolicyArn")
+	policy, iamError := findPolicy(s3cfg, arn)
+	if iamError != nil {
+		return resp, iamError
+	}
+	versionId := values.Get("VersionId")
+	if versionId != policyVersionId {
+		return resp, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf("the policy %s has no version %s.", arn, versionId)}
+	}
+	// the document is url encoded, as the clients expect
+	document := url.PathEscape(policy.Content)
+	isDefaultVersion := true
+	createDate := time.Unix(policy.CreateDate, 0).UTC()
+	resp.GetPolicyVersionResult.PolicyVersion = iam.PolicyVersion{
+		Document:         &document,
+		VersionId:        &versionId,
+		IsDefaultVersion: &isDefaultVersion,
+		CreateDate:       &createDate,
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListPolicies.html
+func (iama *IamApiServer) ListPolicies(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListPoliciesResponse) {
+	onlyAttached := values.Get("OnlyAttached") == "true"
+	for _, policy := range s3cfg.Policies {
+		iamPolicy := toIamPolicy(s3cfg, policy)
+		if onlyAttached && *iamPolicy.AttachmentCount == 0 {
+			continue
+		}
+		resp.ListPoliciesResult.Policies = append(resp.ListPoliciesResult.Policies, iamPolicy)
+	}
+	return resp
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DeletePolicy.html
+func (iama *IamApiServer) DeletePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DeletePolicyResponse, iamError *IamError) {
+	arn := values.Get("PolicyArn")
+	policy, iamError := findPolicy(s3cfg, arn)
+	if iamError != nil {
+		return resp, iamError
+	}
+	if policyAttachmentCount(s3cfg, policy.Name) > 0 {
+		return resp, &IamError{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("the policy %s must be detached from all users and groups first.", arn)}
+	}
+	s3cfg.Policies = slices.DeleteFunc(s3cfg.Policies, func(p *iam_pb.Policy) bool {
+		return p == policy
+	})
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_AttachUserPolicy.html
+func (iama *IamApiServer) AttachUserPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AttachUserPolicyResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	policy, iamError := findPolicy(s3cfg, values.Get("PolicyArn"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	if !slices.Contains(ident.PolicyNames, policy.Name) {
+		ident.PolicyNames = append(ident.PolicyNames, policy.Name)
+	}
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_DetachUserPolicy.html
+func (iama *IamApiServer) DetachUserPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp DetachUserPolicyResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	arn := values.Get("PolicyArn")
+	policyName := strings.TrimPrefix(arn, policyArnPrefix)
+	if !slices.Contains(ident.PolicyNames, policyName) {
+		return resp, &IamError{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf("the policy %s is not attached to the user %s.", arn, ident.Name)}
+	}
+	ident.PolicyNames = slices.DeleteFunc(ident.PolicyNames, func(name string) bool {
+		return name == policyName
+	})
+	return resp, nil
+}
+
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_ListAttachedUserPolicies.html
+func (iama *IamApiServer) ListAttachedUserPolicies(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListAttachedUserPoliciesResponse, iamError *IamError) {
+	ident, iamError := findIdentity(s3cfg, values.Get("UserName"))
+	if iamError != nil {
+		return resp, iamError
+	}
+	resp.ListAttachedUserPoliciesResult.AttachedPolicies = toAttachedPolicies(ident.PolicyNames)
+	return resp, nil
+}
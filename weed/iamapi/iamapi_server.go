@@ -7,15 +7,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api"
 	. "github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/seaweedfs/seaweedfs/weed/wdclient"
 	"google.golang.org/grpc"
@@ -24,8 +29,6 @@ import (
 type IamS3ApiConfig interface {
 	GetS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error)
 	PutS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error)
-	GetPolicies(policies *Policies) (err error)
-	PutPolicies(policies *Policies) (err error)
 }
 
 type IamS3ApiConfigure struct {
@@ -43,6 +46,13 @@ type IamServerOption struct {
 type IamApiServer struct {
 	s3ApiConfig IamS3ApiConfig
 	iam         *s3api.IdentityAccessManagement
+
+	// sessionSigningKey signs the session tokens of temporary credentials, empty if they are disabled
+	sessionSigningKey security.SigningKey
+	// maxSessionDuration limits the DurationSeconds of the temporary credentials
+	maxSessionDuration time.Duration
+	// webIdentity validates the tokens of AssumeRoleWithWebIdentity, nil if not configured
+	webIdentity *webIdentityProvider
 }
 
 var s3ApiConfigure IamS3ApiConfig
@@ -56,9 +66,18 @@ func NewIamApiServer(router *mux.Router, option *IamServerOption) (iamApiServer
 		Filer:          option.Filer,
 		GrpcDialOption: option.GrpcDialOption,
 	}
+	v := util.GetViper()
+	v.SetDefault("jwt.sts.max_duration_seconds", 43200)
+	webIdentity, err := newWebIdentityProvider(v)
+	if err != nil {
+		return nil, err
+	}
 	iamApiServer = &IamApiServer{
-		s3ApiConfig: s3ApiConfigure,
-		iam:         s3api.NewIdentityAccessManagement(&s3Option),
+		s3ApiConfig:        s3ApiConfigure,
+		iam:                s3api.NewIdentityAccessManagement(&s3Option),
+		sessionSigningKey:  security.SigningKey(v.GetString("jwt.sts.key")),
+		maxSessionDuration: time.Duration(v.GetInt("jwt.sts.max_duration_seconds")) * time.Second,
+		webIdentity:        webIdentity,
 	}
 
 	iamApiServer.registerRouter(router)
@@ -72,21 +91,28 @@ func (iama *IamApiServer) registerRouter(router *mux.Router) {
 	// ListBuckets
 
 	// apiRouter.Methods("GET").Path("/").HandlerFunc(track(s3a.iam.Auth(s3a.ListBucketsHandler, ACTION_ADMIN), "LIST"))
+	apiRouter.Methods(http.MethodPost).Path("/").MatcherFunc(isStsRequest).HandlerFunc(iama.DoStsActions)
 	apiRouter.Methods(http.MethodPost).Path("/").HandlerFunc(iama.iam.Auth(iama.DoActions, ACTION_ADMIN))
 	//
 	// NotFound
 	apiRouter.NotFoundHandler = http.HandlerFunc(s3err.NotFoundHandler)
 }
 
+// the managed policies were kept apart from the identities before, and are imported into the identities file
+const legacyPoliciesFile = "policies.json"
+
 func (iam IamS3ApiConfigure) GetS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error) {
-	var buf bytes.Buffer
+	var buf, legacyPolicies bytes.Buffer
 	err = pb.WithGrpcFilerClient(false, 0, iam.option.Filer, iam.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		if err = filer.ReadEntry(iam.masterClient, client, filer.IamConfigDirectory, legacyPoliciesFile, &legacyPolicies); err != nil && err != filer_pb.ErrNotFound {
+			return err
+		}
 		if err = filer.ReadEntry(iam.masterClient, client, filer.IamConfigDirectory, filer.IamIdentityFile, &buf); err != nil {
 			return err
 		}
 		return nil
 	})
-	if err != nil {
+	if err != nil && (err != filer_pb.ErrNotFound || legacyPolicies.Len() == 0) {
 		return err
 	}
 	if buf.Len() > 0 {
@@ -94,6 +120,43 @@ func (iam IamS3ApiConfigure) GetS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfigurat
 			return err
 		}
 	}
+	if legacyPolicies.Len() > 0 {
+		if err = importLegacyPolicies(s3cfg, legacyPolicies.Bytes()); err != nil {
+			return fmt.Errorf("import %s/%s: %v", filer.IamConfigDirectory, legacyPoliciesFile, err)
+		}
+	}
+	return nil
+}
+
+// importLegacyPolicies adds the policies of the former policies file missing in the managed policies
+func importLegacyPolicies(s3cfg *iam_pb.S3ApiConfiguration, data []byte) error {
+	var legacy struct {
+		Policies map[string]PolicyDocument `json:"policies"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	var policyNames []string
+	for policyName := range legacy.Policies {
+		policyNames = append(policyNames, policyName)
+	}
+	sort.Strings(policyNames)
+	now := time.Now().Unix()
+	for _, policyName := range policyNames {
+		if slices.ContainsFunc(s3cfg.Policies, func(policy *iam_pb.Policy) bool { return policy.Name == policyName }) {
+			continue
+		}
+		content, err := json.Marshal(legacy.Policies[policyName])
+		if err != nil {
+			return err
+		}
+		s3cfg.Policies = append(s3cfg.Policies, &iam_pb.Policy{
+			Name:       policyName,
+			Content:    string(content),
+			CreateDate: now,
+			UpdateDate: now,
+		})
+	}
 	return nil
 }
 
@@ -109,39 +172,9 @@ func (iam IamS3ApiConfigure) PutS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfigurat
 		if err != nil {
 			return err
 		}
-		return nil
-	})
-}
-
-func (iam IamS3ApiConfigure) GetPolicies(policies *Policies) (err error) {
-	var buf bytes.Buffer
-	err = pb.WithGrpcFilerClient(false, 0, iam.option.Filer, iam.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
-		if err = filer.ReadEntry(iam.masterClient, client, filer.IamConfigDirectory, filer.IamPoliciesFile, &buf); err != nil {
-			return err
-		}
-		return nil
-	})
-	if err != nil && err != filer_pb.ErrNotFound {
-		return err
-	}
-	if err == filer_pb.ErrNotFound || buf.Len() == 0 {
-		policies.Policies = make(map[string]PolicyDocument)
-		return nil
-	}
-	if err := json.Unmarshal(buf.Bytes(), policies); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (iam IamS3ApiConfigure) PutPolicies(policies *Policies) (err error) {
-	var b []byte
-	if b, err = json.Marshal(policies); err != nil {
-		return err
-	}
-	return pb.WithGrpcFilerClient(false, 0, iam.option.Filer, iam.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
-		if err := filer.SaveInsideFiler(client, filer.IamConfigDirectory, filer.IamPoliciesFile, b); err != nil {
-			return err
+		// the imported policies are saved with the identities
+		if err = filer_pb.DoRemove(client, filer.IamConfigDirectory, legacyPoliciesFile, true, false, false, false, nil); err != nil {
+			glog.Warningf("remove %s/%s: %v", filer.IamConfigDirectory, legacyPoliciesFile, err)
 		}
 		return nil
 	})
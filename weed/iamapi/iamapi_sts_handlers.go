@@ -0,0 +1,267 @@
+package iamapi
+
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithWebIdentity.html
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+const (
+	stsActionAssumeRole                = "AssumeRole"
+	stsActionAssumeRoleWithWebIdentity = "AssumeRoleWithWebIdentity"
+
+	minSessionDuration     = 15 * time.Minute
+	defaultSessionDuration = time.Hour
+	maxStsRequestPeekSize  = 64 * 1024
+
+	stsErrCodeAccessDenied    = "AccessDenied"
+	stsErrCodeValidationError = "ValidationError"
+	stsErrCodeInternalFailure = "InternalFailure"
+)
+
+var roleSessionNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+
+// isStsRequest tells the STS actions from the IAM actions, both posted to the same path
+func isStsRequest(r *http.Request, rm *mux.RouteMatch) bool {
+	switch peekAction(r) {
+	case stsActionAssumeRole, stsActionAssumeRoleWithWebIdentity:
+		return true
+	}
+	return false
+}
+
+// peekAction reads the Action parameter, leaving the request body intact for the signature verification
+func peekAction(r *http.Request) string {
+	if action := r.URL.Query().Get("Action"); action != "" {
+		return action
+	}
+	if r.Body == nil || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return ""
+	}
+	buf, err := io.ReadAll(io.LimitReader(r.Body, maxStsRequestPeekSize))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(buf), r.Body))
+	if err != nil {
+		return ""
+	}
+	values, _ := url.ParseQuery(string(buf))
+	return values.Get("Action")
+}
+
+func (iama *IamApiServer) DoStsActions(w http.ResponseWriter, r *http.Request) {
+	var caller *s3api.Identity
+	action := peekAction(r)
+	if action == stsActionAssumeRole {
+		// AssumeRoleWithWebIdentity is authenticated by the web identity token instead
+		var errCode s3err.ErrorCode
+		if caller, errCode = iama.iam.Authenticate(r); errCode != s3err.ErrNone {
+			apiErr := s3err.GetAPIError(errCode)
+			writeStsErrorResponse(w, r, apiErr.HTTPStatusCode, apiErr.Code, apiErr.Description)
+			return
+		}
+	}
+	if err := r.ParseForm(); err != nil {
+		writeStsErrorResponse(w, r, http.StatusBadRequest, stsErrCodeValidationError, err.Error())
+		return
+	}
+	if len(iama.sessionSigningKey) == 0 {
+		writeStsErrorResponse(w, r, http.StatusForbidden, stsErrCodeAccessDenied, "temporary credentials are not enabled, missing jwt.sts.key in security.toml")
+		return
+	}
+	s3cfg := &iam_pb.S3ApiConfiguration{}
+	if err := iama.s3ApiConfig.GetS3ApiConfiguration(s3cfg); err != nil && !errors.Is(err, filer_pb.ErrNotFound) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	glog.V(4).Infof("DoStsActions: %s", action)
+	var response interface{}
+	var stsError *StsError
+	switch action {
+	case stsActionAssumeRole:
+		response, stsError = iama.AssumeRole(s3cfg, caller, r.Header.Get(s3_constants.AmzSecurityToken) != "", r.Form)
+	case stsActionAssumeRoleWithWebIdentity:
+		response, stsError = iama.AssumeRoleWithWebIdentity(s3cfg, r.Form)
+	}
+	if stsError != nil {
+		writeStsErrorResponse(w, r, stsError.HTTPStatusCode, stsError.Code, stsError.Error.Error())
+		return
+	}
+	s3err.WriteXMLResponse(w, r, http.StatusOK, response)
+}
+
+type StsError struct {
+	HTTPStatusCode int
+	Code           string
+	Error          error
+}
+
+func newStsError(httpStatusCode int, code string, format string, args ...interface{}) *StsError {
+	return &StsError{HTTPStatusCode: httpStatusCode, Code: code, Error: fmt.Errorf(format, args...)}
+}
+
+func writeStsErrorResponse(w http.ResponseWriter, r *http.Request, httpStatusCode int, code, message string) {
+	glog.V(1).Infof("sts response %s: %s", code, message)
+	errorResp := StsErrorResponse{}
+	errorResp.Error.Type = "Sender"
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+	errorResp.SetRequestId()
+	s3err.WriteXMLResponse(w, r, httpStatusCode, errorResp)
+}
+
+// AssumeRole issues temporary credentials of an identity. An identity can get temporary
+// credentials of itself, and admin identities can get those of any identity.
+func (iama *IamApiServer) AssumeRole(s3cfg *iam_pb.S3ApiConfiguration, caller *s3api.Identity, withSessionToken bool, values url.Values) (resp AssumeRoleResponse, stsError *StsError) {
+	if withSessionToken {
+		return resp, newStsError(http.StatusForbidden, stsErrCodeAccessDenied, "temporary credentials can not assume a role")
+	}
+	ident, sessionName, stsError := findRole(s3cfg, values)
+	if stsError != nil {
+		return resp, stsError
+	}
+	if ident.Name != caller.Name && !isAdminIdentity(caller) {
+		return resp, newStsError(http.StatusForbidden, stsErrCodeAccessDenied, "%s is not allowed to assume %s", caller.Name, ident.Name)
+	}
+	duration, stsError := iama.sessionDuration(values)
+	if stsError != nil {
+		return resp, stsError
+	}
+	credentials, err := s3api.NewSessionCredentials(iama.sessionSigningKey, ident.Name, sessionName, duration)
+	if err != nil {
+		return resp, newStsError(http.StatusInternalServerError, stsErrCodeInternalFailure, "%v", err)
+	}
+	glog.V(1).Infof("%s assumes %s with session %s until %v", caller.Name, ident.Name, sessionName, credentials.Expiration)
+	resp.AssumeRoleResult.AssumedRoleUser = assumedRoleUser(ident, sessionName, credentials)
+	resp.AssumeRoleResult.Credentials = stsCredentials(credentials)
+	resp.SetRequestId()
+	return resp, nil
+}
+
+// AssumeRoleWithWebIdentity issues temporary credentials of an identity listed in jwt.sts.web_identity.roles,
+// for the bearer of an OIDC token signed by the configured provider, whose subject is allowed to assume it.
+func (iama *IamApiServer) AssumeRoleWithWebIdentity(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp AssumeRoleWithWebIdentityResponse, stsError *StsError) {
+	if iama.webIdentity == nil {
+		return resp, newStsError(http.StatusForbidden, stsErrCodeAccessDenied, "web identity is not enabled, missing jwt.sts.web_identity.jwks_file in security.toml")
+	}
+	token := values.Get("WebIdentityToken")
+	if token == "" {
+		return resp, newStsError(http.StatusBadRequest, stsErrCodeValidationError, "missing WebIdentityToken")
+	}
+	ident, sessionName, stsError := findRole(s3cfg, values)
+	if stsError != nil {
+		return resp, stsError
+	}
+	duration, stsError := iama.sessionDuration(values)
+	if stsError != nil {
+		return resp, stsError
+	}
+	claims, err := iama.webIdentity.verify(token)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return resp, newStsError(http.StatusBadRequest, sts.ErrCodeExpiredTokenException, "web identity token has expired")
+		}
+		return resp, newStsError(http.StatusBadRequest, sts.ErrCodeInvalidIdentityTokenException, "invalid web identity token: %v", err)
+	}
+	if !iama.webIdentity.allows(ident.Name, claims.Subject) {
+		glog.V(1).Infof("web identity %s of %s can not assume %s", claims.Subject, claims.Issuer, ident.Name)
+		return resp, newStsError(http.StatusForbidden, stsErrCodeAccessDenied, "%s can not be assumed by the web identity %s", ident.Name, claims.Subject)
+	}
+	credentials, err := s3api.NewSessionCredentials(iama.sessionSigningKey, ident.Name, sessionName, duration)
+	if err != nil {
+		return resp, newStsError(http.StatusInternalServerError, stsErrCodeInternalFailure, "%v", err)
+	}
+	glog.V(1).Infof("web identity %s of %s assumes %s with session %s until %v", claims.Subject, claims.Issuer, ident.Name, sessionName, credentials.Expiration)
+	result := &resp.AssumeRoleWithWebIdentityResult
+	result.AssumedRoleUser = assumedRoleUser(ident, sessionName, credentials)
+	result.Credentials = stsCredentials(credentials)
+	result.SubjectFromWebIdentityToken = claims.Subject
+	result.Provider = claims.Issuer
+	if len(claims.Audience) > 0 {
+		result.Audience = claims.Audience[0]
+	}
+	resp.SetRequestId()
+	return resp, nil
+}
+
+// findRole looks up the identity named by the RoleArn, either an ARN ending with /<name> or the plain name
+func findRole(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (*iam_pb.Identity, string, *StsError) {
+	roleArn := values.Get("RoleArn")
+	if roleArn == "" {
+		return nil, "", newStsError(http.StatusBadRequest, stsErrCodeValidationError, "missing RoleArn")
+	}
+	sessionName := values.Get("RoleSessionName")
+	if !roleSessionNameRegexp.MatchString(sessionName) {
+		return nil, "", newStsError(http.StatusBadRequest, stsErrCodeValidationError, "invalid RoleSessionName %q", sessionName)
+	}
+	roleName := roleArn[strings.LastIndex(roleArn, "/")+1:]
+	for _, ident := range s3cfg.Identities {
+		if ident.Name == roleName {
+			return ident, sessionName, nil
+		}
+	}
+	return nil, "", newStsError(http.StatusForbidden, stsErrCodeAccessDenied, "role %s is not found", roleArn)
+}
+
+func (iama *IamApiServer) sessionDuration(values url.Values) (time.Duration, *StsError) {
+	value := values.Get("DurationSeconds")
+	if value == "" {
+		return min(defaultSessionDuration, iama.maxSessionDuration), nil
+	}
+	seconds, err := strconv.Atoi(value)
+	duration := time.Duration(seconds) * time.Second
+	if err != nil || duration < minSessionDuration || duration > iama.maxSessionDuration {
+		return 0, newStsError(http.StatusBadRequest, stsErrCodeValidationError, "DurationSeconds must be between %d and %d",
+			int(minSessionDuration.Seconds()), int(iama.maxSessionDuration.Seconds()))
+	}
+	return duration, nil
+}
+
+func isAdminIdentity(identity *s3api.Identity) bool {
+	for _, action := range identity.Actions {
+		if action == s3_constants.ACTION_ADMIN {
+			return true
+		}
+	}
+	return false
+}
+
+func assumedRoleUser(ident *iam_pb.Identity, sessionName string, credentials *s3api.SessionCredentials) sts.AssumedRoleUser {
+	accountId := s3_constants.AccountAdminId
+	if ident.Account != nil && ident.Account.Id != "" {
+		accountId = ident.Account.Id
+	}
+	return sts.AssumedRoleUser{
+		Arn:           aws.String(fmt.Sprintf("arn:aws:sts::%s:assumed-role/%s/%s", accountId, ident.Name, sessionName)),
+		AssumedRoleId: aws.String(credentials.AccessKeyId + ":" + sessionName),
+	}
+}
+
+func stsCredentials(credentials *s3api.SessionCredentials) sts.Credentials {
+	return sts.Credentials{
+		AccessKeyId:     aws.String(credentials.AccessKeyId),
+		SecretAccessKey: aws.String(credentials.SecretAccessKey),
+		SessionToken:    aws.String(credentials.SessionToken),
+		Expiration:      aws.Time(credentials.Expiration.UTC()),
+	}
+}
@@ -0,0 +1,256 @@
+package iamapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStsTestServer(t *testing.T) (*IamApiServer, *rsa.PrivateKey) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "s3.json")
+	assert.NoError(t, os.WriteFile(configFile, []byte(`{"identities": [
+		{"name": "ci", "credentials": [{"accessKey": "ci_access_key", "secretKey": "ci_secret_key"}], "actions": ["Read"]},
+		{"name": "deployer", "actions": ["Write"]}
+	]}`), 0644))
+	s3config = iam_pb.S3ApiConfiguration{Identities: []*iam_pb.Identity{{Name: "ci"}, {Name: "deployer"}}}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	jwksFile := filepath.Join(dir, "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, []byte(fmt.Sprintf(`{"keys": [{"kty": "RSA", "kid": "k1", "use": "sig", "n": %q, "e": %q}]}`,
+		base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()))), 0644))
+
+	return &IamApiServer{
+		s3ApiConfig:        iamS3ApiConfigureMock{},
+		iam:                s3api.NewIdentityAccessManagement(&s3api.S3ApiServerOption{Config: configFile}),
+		sessionSigningKey:  []byte("sts-key"),
+		maxSessionDuration: 12 * time.Hour,
+		webIdentity: &webIdentityProvider{
+			jwksFile:  jwksFile,
+			issuer:    "https://ci.example.com",
+			audiences: []string{"seaweedfs"},
+			roles:     map[string][]string{"deployer": {"repo:myorg/deploy:*", "repo:myorg/main"}},
+		},
+	}, privateKey
+}
+
+func executeStsRequest(server *IamApiServer, req *http.Request, v interface{}) (*httptest.ResponseRecorder, error) {
+	// as received by the http server
+	req.Host = req.URL.Host
+	rr := httptest.NewRecorder()
+	apiRouter := mux.NewRouter().SkipClean(true)
+	apiRouter.Path("/").Methods(http.MethodPost).MatcherFunc(isStsRequest).HandlerFunc(server.DoStsActions)
+	apiRouter.Path("/").Methods(http.MethodPost).HandlerFunc(server.DoActions)
+	apiRouter.ServeHTTP(rr, req)
+	return rr, xml.Unmarshal(rr.Body.Bytes(), v)
+}
+
+func newStsClient(accessKey, secretKey string) *sts.STS {
+	config := &aws.Config{
+		Region:   aws.String("us-east-1"),
+		Endpoint: aws.String("http://localhost:8111"),
+	}
+	if accessKey != "" {
+		config.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, "")
+	} else {
+		config.Credentials = credentials.AnonymousCredentials
+	}
+	return sts.New(session.Must(session.NewSession(config)))
+}
+
+func signWebIdentityToken(t *testing.T, key interface{}, method jwt.SigningMethod, kid string, claims jwt.RegisteredClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestAssumeRole(t *testing.T) {
+	server, _ := newStsTestServer(t)
+
+	req, _ := newStsClient("ci_access_key", "ci_secret_key").AssumeRoleRequest(&sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::admin:role/ci"),
+		RoleSessionName: aws.String("build-1"),
+		DurationSeconds: aws.Int64(900),
+	})
+	assert.NoError(t, req.Sign())
+	out := AssumeRoleResponse{}
+	response, err := executeStsRequest(server, req.HTTPRequest, &out)
+	assert.NoError(t, err)
+	if !assert.Equal(t, http.StatusOK, response.Code, response.Body.String()) {
+		return
+	}
+	result := out.AssumeRoleResult
+	assert.Equal(t, "arn:aws:sts::admin:assumed-role/ci/build-1", aws.StringValue(result.AssumedRoleUser.Arn))
+	assert.NotEmpty(t, aws.StringValue(result.Credentials.SessionToken))
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), aws.TimeValue(result.Credentials.Expiration), time.Minute)
+
+	// a non admin identity can not assume another identity
+	req, _ = newStsClient("ci_access_key", "ci_secret_key").AssumeRoleRequest(&sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::admin:role/deployer"),
+		RoleSessionName: aws.String("build-1"),
+	})
+	assert.NoError(t, req.Sign())
+	response, _ = executeStsRequest(server, req.HTTPRequest, &StsErrorResponse{})
+	assert.Equal(t, http.StatusForbidden, response.Code)
+
+	// AssumeRole needs a signed request
+	req, _ = newStsClient("", "").AssumeRoleRequest(&sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::admin:role/ci"),
+		RoleSessionName: aws.String("build-1"),
+	})
+	assert.NoError(t, req.Sign())
+	response, _ = executeStsRequest(server, req.HTTPRequest, &StsErrorResponse{})
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestAssumeRoleWithWebIdentity(t *testing.T) {
+	server, privateKey := newStsTestServer(t)
+	validClaims := jwt.RegisteredClaims{
+		Issuer:    "https://ci.example.com",
+		Subject:   "repo:myorg/deploy:ref:refs/heads/main",
+		Audience:  jwt.ClaimStrings{"seaweedfs"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+
+	assumeRole := func(roleName, token string) (*httptest.ResponseRecorder, AssumeRoleWithWebIdentityResponse) {
+		req, _ := newStsClient("", "").AssumeRoleWithWebIdentityRequest(&sts.AssumeRoleWithWebIdentityInput{
+			RoleArn:          aws.String("arn:aws:iam::admin:role/" + roleName),
+			RoleSessionName:  aws.String("job-42"),
+			WebIdentityToken: aws.String(token),
+		})
+		assert.NoError(t, req.Build())
+		out := AssumeRoleWithWebIdentityResponse{}
+		response, _ := executeStsRequest(server, req.HTTPRequest, &out)
+		return response, out
+	}
+
+	response, out := assumeRole("deployer", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", validClaims))
+	if assert.Equal(t, http.StatusOK, response.Code, response.Body.String()) {
+		result := out.AssumeRoleWithWebIdentityResult
+		assert.Equal(t, "repo:myorg/deploy:ref:refs/heads/main", result.SubjectFromWebIdentityToken)
+		assert.Equal(t, "seaweedfs", result.Audience)
+		assert.Equal(t, "https://ci.example.com", result.Provider)
+		assert.Equal(t, "arn:aws:sts::admin:assumed-role/deployer/job-42", aws.StringValue(result.AssumedRoleUser.Arn))
+		assert.NotEmpty(t, aws.StringValue(result.Credentials.SecretAccessKey))
+	}
+
+	// only the configured roles can be assumed
+	response, _ = assumeRole("ci", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", validClaims))
+	assert.Equal(t, http.StatusForbidden, response.Code)
+
+	// the other users of the provider can not assume the role
+	otherSubject := validClaims
+	otherSubject.Subject = "repo:evil/deploy:ref:refs/heads/main"
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", otherSubject))
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	otherSubject.Subject = "repo:myorg/main2"
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", otherSubject))
+	assert.Equal(t, http.StatusForbidden, response.Code)
+
+	expiredClaims := validClaims
+	expiredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", expiredClaims))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), sts.ErrCodeExpiredTokenException)
+
+	otherAudience := validClaims
+	otherAudience.Audience = jwt.ClaimStrings{"other"}
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", otherAudience))
+	assert.Contains(t, response.Body.String(), sts.ErrCodeInvalidIdentityTokenException)
+	noAudience := validClaims
+	noAudience.Audience = nil
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", noAudience))
+	assert.Contains(t, response.Body.String(), sts.ErrCodeInvalidIdentityTokenException)
+
+	otherIssuer := validClaims
+	otherIssuer.Issuer = "https://evil.example.com"
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, privateKey, jwt.SigningMethodRS256, "k1", otherIssuer))
+	assert.Contains(t, response.Body.String(), sts.ErrCodeInvalidIdentityTokenException)
+
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, otherKey, jwt.SigningMethodRS256, "k1", validClaims))
+	assert.Contains(t, response.Body.String(), sts.ErrCodeInvalidIdentityTokenException)
+
+	response, _ = assumeRole("deployer", signWebIdentityToken(t, []byte("sts-key"), jwt.SigningMethodHS256, "k1", validClaims))
+	assert.Contains(t, response.Body.String(), sts.ErrCodeInvalidIdentityTokenException)
+}
+
+func TestNewWebIdentityProvider(t *testing.T) {
+	server, _ := newStsTestServer(t)
+	newProvider := func(settings map[string]interface{}) (*webIdentityProvider, error) {
+		v := &util.ViperProxy{Viper: viper.New()}
+		v.Set("jwt.sts.web_identity.jwks_file", server.webIdentity.jwksFile)
+		for key, value := range settings {
+			v.Set("jwt.sts.web_identity."+key, value)
+		}
+		return newWebIdentityProvider(v)
+	}
+
+	p, err := newProvider(map[string]interface{}{
+		"issuer":   "https://ci.example.com",
+		"audience": "seaweedfs, sts",
+		"roles":    []string{"Deployer=repo:myorg/deploy:*", "Deployer = repo:myorg/main"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"seaweedfs", "sts"}, p.audiences)
+		assert.True(t, p.allows("Deployer", "repo:myorg/deploy:ref:refs/heads/main"))
+		assert.True(t, p.allows("Deployer", "repo:myorg/main"))
+		assert.False(t, p.allows("Deployer", "repo:other/deploy:ref:refs/heads/main"))
+		assert.False(t, p.allows("deployer", "repo:myorg/main"))
+	}
+
+	_, err = newProvider(map[string]interface{}{"audience": "seaweedfs"})
+	assert.Error(t, err, "no issuer")
+	_, err = newProvider(map[string]interface{}{"issuer": "https://ci.example.com"})
+	assert.Error(t, err, "no audience")
+	_, err = newProvider(map[string]interface{}{"issuer": "https://ci.example.com", "audience": "seaweedfs", "roles": []string{"deployer"}})
+	assert.Error(t, err, "no subject")
+}
+
+func TestParseJwks(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	keys, err := parseJwks([]byte(fmt.Sprintf(`{"keys": [
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"}
+	]}`, base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()), base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()))))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, keys, 1)
+	assert.True(t, ecKey.PublicKey.Equal(keys["ec"]))
+
+	_, err = parseJwks([]byte(`{"keys": [{"kty": "EC", "kid": "ec", "crv": "P-256", "x": "AQAB", "y": "AQAB"}]}`))
+	assert.Error(t, err)
+	_, err = parseJwks([]byte(`{"keys": []}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,243 @@
+package iamapi
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/tidwall/match"
+)
+
+// webIdentityProvider validates the OIDC tokens of AssumeRoleWithWebIdentity,
+// with the public keys of the identity provider kept in a JWKS file.
+// The providers of CI jobs sign the tokens of all their tenants with the same keys,
+// so each role lists the subjects of the tokens allowed to assume it.
+type webIdentityProvider struct {
+	jwksFile  string
+	issuer    string
+	audiences []string
+	// the patterns of the subjects allowed to assume each role, * matching any characters
+	roles map[string][]string
+
+	keysLock    sync.Mutex
+	keys        map[string]crypto.PublicKey
+	keysModTime time.Time
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func newWebIdentityProvider(v *util.ViperProxy) (*webIdentityProvider, error) {
+	jwksFile := v.GetString("jwt.sts.web_identity.jwks_file")
+	if jwksFile == "" {
+		return nil, nil
+	}
+	p := &webIdentityProvider{
+		jwksFile:  jwksFile,
+		issuer:    v.GetString("jwt.sts.web_identity.issuer"),
+		audiences: splitCommaSeparated(v.GetString("jwt.sts.web_identity.audience")),
+		roles:     make(map[string][]string),
+	}
+	if p.issuer == "" || len(p.audiences) == 0 {
+		return nil, fmt.Errorf("jwt.sts.web_identity.issuer and jwt.sts.web_identity.audience are required with jwt.sts.web_identity.jwks_file")
+	}
+	for _, role := range v.GetStringSlice("jwt.sts.web_identity.roles") {
+		name, subject, found := strings.Cut(role, "=")
+		name, subject = strings.TrimSpace(name), strings.TrimSpace(subject)
+		if !found || name == "" || subject == "" {
+			return nil, fmt.Errorf("jwt.sts.web_identity.roles: %q is not role=subject", role)
+		}
+		p.roles[name] = append(p.roles[name], subject)
+	}
+	if _, err := p.publicKeys(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func splitCommaSeparated(value string) (items []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return
+}
+
+// publicKeys returns the keys of the JWKS file, read again after the file is modified
+func (p *webIdentityProvider) publicKeys() (map[string]crypto.PublicKey, error) {
+	p.keysLock.Lock()
+	defer p.keysLock.Unlock()
+	info, err := os.Stat(p.jwksFile)
+	if err != nil {
+		return nil, fmt.Errorf("jwks file: %v", err)
+	}
+	if p.keys != nil && info.ModTime().Equal(p.keysModTime) {
+		return p.keys, nil
+	}
+	data, err := os.ReadFile(p.jwksFile)
+	if err != nil {
+		return nil, fmt.Errorf("read jwks file: %v", err)
+	}
+	keys, err := parseJwks(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse jwks file %s: %v", p.jwksFile, err)
+	}
+	p.keys, p.keysModTime = keys, info.ModTime()
+	return keys, nil
+}
+
+// parseJwks reads the RSA and EC signing keys of a JSON Web Key Set, by key id
+func parseJwks(data []byte) (map[string]crypto.PublicKey, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, key := range jwks.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing key")
+	}
+	return keys, nil
+}
+
+func (key jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := decodeBase64UrlInt(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %v", err)
+		}
+		e, err := decodeBase64UrlInt(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %v", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch key.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+		}
+		x, err := decodeBase64UrlInt(key.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %v", err)
+		}
+		y, err := decodeBase64UrlInt(key.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %v", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point not on curve %s", key.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", key.Kty)
+}
+
+func decodeBase64UrlInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// verify checks the signature, issuer, audience and validity time of the token
+func (p *webIdentityProvider) verify(token string) (*jwt.RegisteredClaims, error) {
+	keys, err := p.publicKeys()
+	if err != nil {
+		return nil, err
+	}
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(p.issuer),
+	}
+	claims := &jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if key, found := keys[kid]; found {
+			return key, nil
+		}
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	if !p.hasAudience(claims.Audience) {
+		return nil, fmt.Errorf("%w: audience %v", jwt.ErrTokenInvalidAudience, claims.Audience)
+	}
+	return claims, nil
+}
+
+func (p *webIdentityProvider) hasAudience(audiences jwt.ClaimStrings) bool {
+	for _, audience := range audiences {
+		for _, allowed := range p.audiences {
+			if audience == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allows tells whether the subject of a token may assume the role
+func (p *webIdentityProvider) allows(role, subject string) bool {
+	for _, pattern := range p.roles[role] {
+		if match.Match(subject, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -19,32 +19,23 @@ import (
 
 var GetS3ApiConfiguration func(s3cfg *iam_pb.S3ApiConfiguration) (err error)
 var PutS3ApiConfiguration func(s3cfg *iam_pb.S3ApiConfiguration) (err error)
-var GetPolicies func(policies *Policies) (err error)
-var PutPolicies func(policies *Policies) (err error)
 
 var s3config = iam_pb.S3ApiConfiguration{}
-var policiesFile = Policies{Policies: make(map[string]PolicyDocument)}
 var ias = IamApiServer{s3ApiConfig: iamS3ApiConfigureMock{}}
 
 type iamS3ApiConfigureMock struct{}
 
 func (iam iamS3ApiConfigureMock) GetS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error) {
 	_ = copier.Copy(&s3cfg.Identities, &s3config.Identities)
+	_ = copier.Copy(&s3cfg.Groups, &s3config.Groups)
+	_ = copier.Copy(&s3cfg.Policies, &s3config.Policies)
 	return nil
 }
 
 func (iam iamS3ApiConfigureMock) PutS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error) {
 	_ = copier.Copy(&s3config.Identities, &s3cfg.Identities)
-	return nil
-}
-
-func (iam iamS3ApiConfigureMock) GetPolicies(policies *Policies) (err error) {
-	_ = copier.Copy(&policies, &policiesFile)
-	return nil
-}
-
-func (iam iamS3ApiConfigureMock) PutPolicies(policies *Policies) (err error) {
-	_ = copier.Copy(&policiesFile, &policies)
+	_ = copier.Copy(&s3config.Groups, &s3cfg.Groups)
+	_ = copier.Copy(&s3config.Policies, &s3cfg.Policies)
 	return nil
 }
 
@@ -262,3 +253,15 @@ func TestHandleImplicitUsername(t *testing.T) {
 		}
 	}
 }
+
+func TestImportLegacyPolicies(t *testing.T) {
+	s3cfg := &iam_pb.S3ApiConfiguration{Policies: []*iam_pb.Policy{{Name: "kept", Content: "{}"}}}
+	legacy := `{"policies":{"readonly":{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*"],"Resource":["arn:aws:s3:::photos/*"]}]},"kept":{"Version":"2012-10-17","Statement":[]}}}`
+	assert.NoError(t, importLegacyPolicies(s3cfg, []byte(legacy)))
+	assert.Len(t, s3cfg.Policies, 2)
+	assert.Equal(t, "{}", s3cfg.Policies[0].Content, "the managed policies are kept")
+	assert.Equal(t, "readonly", s3cfg.Policies[1].Name)
+	assert.JSONEq(t, `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*"],"Resource":["arn:aws:s3:::photos/*"]}]}`, s3cfg.Policies[1].Content)
+
+	assert.Error(t, importLegacyPolicies(s3cfg, []byte("not json")))
+}
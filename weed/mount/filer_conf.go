@@ -85,22 +85,5 @@ func (wfs *WFS) wormEnforcedForEntry(path util.FullPath, entry *filer_pb.Entry)
 		return false, false
 	}
 
-	// worm is not enforced
-	if entry.WormEnforcedAtTsNs == 0 {
-		return false, true
-	}
-
-	// worm will never expire
-	if rule.WormRetentionTimeSeconds == 0 {
-		return true, true
-	}
-
-	enforcedAt := time.Unix(0, entry.WormEnforcedAtTsNs)
-
-	// worm is expired
-	if time.Now().Sub(enforcedAt).Seconds() >= float64(rule.WormRetentionTimeSeconds) {
-		return false, true
-	}
-
-	return true, true
+	return filer.WormEnforced(rule, entry.WormEnforcedAtTsNs, entry.Extended, time.Now()), true
 }
@@ -47,6 +47,7 @@ type Option struct {
 	Umask              os.FileMode
 	Quota              int64
 	DisableXAttr       bool
+	Relatime           bool // record the access time of the files read
 	IsMacOs            bool
 
 	MountUid         uint32
@@ -193,10 +194,12 @@ func (wfs *WFS) maybeLoadEntry(fullpath util.FullPath) (*filer_pb.Entry, fuse.St
 			IsDirectory: true,
 			Attributes: &filer_pb.FuseAttributes{
 				Mtime:    wfs.option.MountMtime.Unix(),
+				MtimeNs:  int32(wfs.option.MountMtime.Nanosecond()),
 				FileMode: uint32(wfs.option.MountMode),
 				Uid:      wfs.option.MountUid,
 				Gid:      wfs.option.MountGid,
 				Crtime:   wfs.option.MountCtime.Unix(),
+				CrtimeNs: int32(wfs.option.MountCtime.Nanosecond()),
 			},
 		}, fuse.OK
 	}
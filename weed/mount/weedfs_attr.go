@@ -9,6 +9,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"google.golang.org/protobuf/proto"
 )
 
 func (wfs *WFS) GetAttr(cancel <-chan struct{}, input *fuse.GetAttrIn, out *fuse.AttrOut) (code fuse.Status) {
@@ -85,7 +86,7 @@ func (wfs *WFS) SetAttr(cancel <-chan struct{}, input *fuse.SetAttrIn, out *fuse
 				fh.entryChunkGroup.SetChunks(chunks)
 			}
 		}
-		entry.Attributes.Mtime = time.Now().Unix()
+		entry.Attributes.SetModTime(time.Now())
 		entry.Attributes.FileSize = size
 
 	}
@@ -118,13 +119,16 @@ func (wfs *WFS) SetAttr(cancel <-chan struct{}, input *fuse.SetAttrIn, out *fuse
 	}
 
 	if atime, ok := input.GetATime(); ok {
-		entry.Attributes.Mtime = atime.Unix()
+		entry.Attributes.SetAccessTime(atime)
 	}
 
 	if mtime, ok := input.GetMTime(); ok {
-		entry.Attributes.Mtime = mtime.Unix()
+		entry.Attributes.SetModTime(mtime)
 	}
 
+	// any change of the attributes is a change
+	entry.Attributes.SetChangeTime(time.Now())
+
 	out.AttrValid = 1
 	size, includeSize := input.GetSize()
 	if includeSize {
@@ -141,6 +145,37 @@ func (wfs *WFS) SetAttr(cancel <-chan struct{}, input *fuse.SetAttrIn, out *fuse
 
 }
 
+// updateAccessTime records the access time of the file read, the same way as relatime:
+// only when it is not after the last modification or change, or is older than a day.
+func (wfs *WFS) updateAccessTime(fh *FileHandle) {
+	now := time.Now()
+	var updated *filer_pb.Entry
+	fh.entryLock.Lock()
+	fh.UpdateEntry(func(entry *filer_pb.Entry) {
+		if entry.Attributes == nil || !isAccessTimeStale(entry.Attributes, now) {
+			return
+		}
+		entry.Attributes.SetAccessTime(now)
+		if !fh.dirtyMetadata {
+			// the dirty metadata is saved when flushed
+			updated = proto.Clone(entry).(*filer_pb.Entry)
+		}
+	})
+	fh.entryLock.Unlock()
+
+	if updated != nil {
+		wfs.saveEntry(fh.FullPath(), updated)
+	}
+}
+
+func isAccessTimeStale(attr *filer_pb.FuseAttributes, now time.Time) bool {
+	if attr.Atime == 0 && attr.AtimeNs == 0 {
+		return true
+	}
+	atime := attr.AccessTime()
+	return !atime.After(attr.ModTime()) || !atime.After(attr.ChangeTime()) || now.Sub(atime) >= 24*time.Hour
+}
+
 func (wfs *WFS) setRootAttr(out *fuse.AttrOut) {
 	now := uint64(time.Now().Unix())
 	out.AttrValid = 119
@@ -171,9 +206,10 @@ func (wfs *WFS) setAttrByPbEntry(out *fuse.Attr, inode uint64, entry *filer_pb.E
 		out.Size = uint64(len(entry.Attributes.SymlinkTarget))
 	}
 	out.Blocks = (out.Size + blockSize - 1) / blockSize
-	out.Mtime = uint64(entry.Attributes.Mtime)
-	out.Ctime = uint64(entry.Attributes.Mtime)
-	out.Atime = uint64(entry.Attributes.Mtime)
+	if entry.Attributes != nil {
+		atime, mtime, ctime := entry.Attributes.AccessTime(), entry.Attributes.ModTime(), entry.Attributes.ChangeTime()
+		out.SetTimes(&atime, &mtime, &ctime)
+	}
 	out.Mode = toSyscallMode(os.FileMode(entry.Attributes.FileMode))
 	if entry.HardLinkCounter > 0 {
 		out.Nlink = uint32(entry.HardLinkCounter)
@@ -193,9 +229,14 @@ func (wfs *WFS) setAttrByFilerEntry(out *fuse.Attr, inode uint64, entry *filer.E
 	}
 	out.Blocks = (out.Size + blockSize - 1) / blockSize
 	setBlksize(out, blockSize)
-	out.Atime = uint64(entry.Attr.Mtime.Unix())
-	out.Mtime = uint64(entry.Attr.Mtime.Unix())
-	out.Ctime = uint64(entry.Attr.Mtime.Unix())
+	atime, ctime := entry.Attr.Atime, entry.Attr.Ctime
+	if atime.IsZero() {
+		atime = entry.Attr.Mtime
+	}
+	if ctime.IsZero() {
+		ctime = entry.Attr.Mtime
+	}
+	out.SetTimes(&atime, &entry.Attr.Mtime, &ctime)
 	out.Mode = toSyscallMode(entry.Attr.Mode)
 	if entry.HardLinkCounter > 0 {
 		out.Nlink = uint32(entry.HardLinkCounter)
@@ -0,0 +1,33 @@
+package mount
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestIsAccessTimeStale(t *testing.T) {
+	now := time.Now()
+	attr := &filer_pb.FuseAttributes{}
+	attr.SetModTime(now.Add(-time.Hour))
+	if !isAccessTimeStale(attr, now) {
+		t.Errorf("the access time not recorded should be stale")
+	}
+
+	attr.SetAccessTime(now.Add(-time.Minute))
+	if isAccessTimeStale(attr, now) {
+		t.Errorf("the access time after the modification should not be stale")
+	}
+
+	attr.SetModTime(now.Add(-time.Second))
+	if !isAccessTimeStale(attr, now) {
+		t.Errorf("the access time before the modification should be stale")
+	}
+
+	attr.SetModTime(now.Add(-48 * time.Hour))
+	attr.SetAccessTime(now.Add(-25 * time.Hour))
+	if !isAccessTimeStale(attr, now) {
+		t.Errorf("the access time older than a day should be stale")
+	}
+}
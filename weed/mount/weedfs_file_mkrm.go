@@ -52,15 +52,13 @@ func (wfs *WFS) Mknod(cancel <-chan struct{}, in *fuse.MknodIn, name string, out
 
 	entryFullPath := dirFullPath.Child(name)
 	fileMode := toOsFileMode(in.Mode)
-	now := time.Now().Unix()
-	inode := wfs.inodeToPath.AllocateInode(entryFullPath, now)
+	now := time.Now()
+	inode := wfs.inodeToPath.AllocateInode(entryFullPath, now.Unix())
 
 	newEntry := &filer_pb.Entry{
 		Name:        name,
 		IsDirectory: false,
 		Attributes: &filer_pb.FuseAttributes{
-			Mtime:    now,
-			Crtime:   now,
 			FileMode: uint32(fileMode),
 			Uid:      in.Uid,
 			Gid:      in.Gid,
@@ -69,6 +67,8 @@ func (wfs *WFS) Mknod(cancel <-chan struct{}, in *fuse.MknodIn, name string, out
 			Inode:    inode,
 		},
 	}
+	newEntry.Attributes.SetModTime(now)
+	newEntry.Attributes.SetCreateTime(now)
 
 	err := wfs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
 
@@ -98,7 +98,7 @@ func (wfs *WFS) Mknod(cancel <-chan struct{}, in *fuse.MknodIn, name string, out
 	glog.V(3).Infof("mknod %s: %v", entryFullPath, err)
 
 	if err != nil {
-		return fuse.EIO
+		return writeErrorStatus(err)
 	}
 
 	// this is to increase nlookup counter
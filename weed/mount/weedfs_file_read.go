@@ -52,6 +52,10 @@ func (wfs *WFS) Read(cancel <-chan struct{}, in *fuse.ReadIn, buff []byte) (fuse
 		return nil, fuse.EIO
 	}
 
+	if wfs.option.Relatime {
+		wfs.updateAccessTime(fh)
+	}
+
 	if IsDebugFileReadWrite {
 		// print(".")
 		mirrorData := make([]byte, totalRead)
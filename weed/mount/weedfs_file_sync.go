@@ -129,7 +129,9 @@ func (wfs *WFS) doFlush(fh *FileHandle, uid, gid uint32) fuse.Status {
 			if entry.Attributes.Gid == 0 {
 				entry.Attributes.Gid = gid
 			}
-			entry.Attributes.Mtime = time.Now().Unix()
+			now := time.Now()
+			entry.Attributes.SetModTime(now)
+			entry.Attributes.SetChangeTime(now)
 		}
 
 		request := &filer_pb.CreateEntryRequest{
@@ -173,7 +175,7 @@ func (wfs *WFS) doFlush(fh *FileHandle, uid, gid uint32) fuse.Status {
 
 	if err != nil {
 		glog.Errorf("%v fh %d flush: %v", fileFullPath, fh.fh, err)
-		return fuse.EIO
+		return writeErrorStatus(err)
 	}
 
 	if IsDebugFileReadWrite {
@@ -1,6 +1,9 @@
 package notification
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"google.golang.org/protobuf/proto"
@@ -12,6 +15,8 @@ type MessageQueue interface {
 	// Initialize initializes the file store
 	Initialize(configuration util.Configuration, prefix string) error
 	SendMessage(key string, message proto.Message) error
+	// SendRawMessage sends the already encoded message, e.g. the json of the s3 events
+	SendRawMessage(key string, message []byte) error
 }
 
 var (
@@ -42,6 +47,22 @@ func LoadConfiguration(config *util.ViperProxy, prefix string) {
 
 }
 
+// NewMessageQueue creates and initializes a message queue of the type, separately from the global Queue,
+// so the same type of queue can be configured more than once
+func NewMessageQueue(queueType string, configuration util.Configuration, prefix string) (MessageQueue, error) {
+	for _, queue := range MessageQueues {
+		if queue.GetName() != queueType {
+			continue
+		}
+		newQueue := reflect.New(reflect.TypeOf(queue).Elem()).Interface().(MessageQueue)
+		if err := newQueue.Initialize(configuration, prefix); err != nil {
+			return nil, fmt.Errorf("initialize %s message queue: %v", queueType, err)
+		}
+		return newQueue, nil
+	}
+	return nil, fmt.Errorf("unknown message queue type %s", queueType)
+}
+
 func validateOneEnabledQueue(config *util.ViperProxy) {
 	enabledQueue := ""
 	for _, queue := range MessageQueues {
@@ -107,9 +107,13 @@ func (k *GoCDKPubSub) SendMessage(key string, message proto.Message) error {
 	if err != nil {
 		return err
 	}
+	return k.SendRawMessage(key, bytes)
+}
+
+func (k *GoCDKPubSub) SendRawMessage(key string, bytes []byte) error {
 	k.topicLock.RLock()
 	defer k.topicLock.RUnlock()
-	err = k.topic.Send(context.Background(), &pubsub.Message{
+	err := k.topic.Send(context.Background(), &pubsub.Message{
 		Body:     bytes,
 		Metadata: map[string]string{"key": key},
 	})
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/notification"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	jsonpb "google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	notification.MessageQueues = append(notification.MessageQueues, &WebhookQueue{})
+}
+
+// WebhookQueue posts the messages as json to an http endpoint
+type WebhookQueue struct {
+	endpoint    string
+	bearerToken string
+	maxRetries  int
+	client      *http.Client
+}
+
+func (k *WebhookQueue) GetName() string {
+	return "webhook"
+}
+
+func (k *WebhookQueue) Initialize(configuration util.Configuration, prefix string) (err error) {
+	glog.V(0).Infof("notification.webhook.endpoint: %v", configuration.GetString(prefix+"endpoint"))
+	configuration.SetDefault(prefix+"timeout_seconds", 10)
+	configuration.SetDefault(prefix+"max_retries", 3)
+	return k.initialize(
+		configuration.GetString(prefix+"endpoint"),
+		configuration.GetString(prefix+"bearer_token"),
+		configuration.GetInt(prefix+"timeout_seconds"),
+		configuration.GetInt(prefix+"max_retries"),
+	)
+}
+
+func (k *WebhookQueue) initialize(endpoint, bearerToken string, timeoutSeconds, maxRetries int) (err error) {
+	if endpoint == "" {
+		return fmt.Errorf("webhook endpoint is required")
+	}
+	k.endpoint = endpoint
+	k.bearerToken = bearerToken
+	k.maxRetries = maxRetries
+	k.client = &http.Client{Timeout: time.Duration(timeoutSeconds) * time.Second}
+	return nil
+}
+
+func (k *WebhookQueue) SendMessage(key string, message proto.Message) (err error) {
+	data, err := jsonpb.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("send message marshal %+v: %v", message, err)
+	}
+	return k.SendRawMessage(key, data)
+}
+
+// SendRawMessage posts the message, retrying the failed connections and the server errors
+func (k *WebhookQueue) SendRawMessage(key string, message []byte) (err error) {
+	waitTime := time.Second
+	for i := 0; ; i++ {
+		var retryable bool
+		if retryable, err = k.post(key, message); err == nil || !retryable || i >= k.maxRetries {
+			break
+		}
+		glog.V(1).Infof("retry webhook %s: %v", k.endpoint, err)
+		time.Sleep(waitTime)
+		waitTime *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("send message to webhook %s: %v", k.endpoint, err)
+	}
+	return nil
+}
+
+func (k *WebhookQueue) post(key string, message []byte) (retryable bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, k.endpoint, bytes.NewReader(message))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Seaweedfs-Key", key)
+	if k.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+k.bearerToken)
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, fmt.Errorf("status %s", resp.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookQueue(t *testing.T) {
+	var requests int
+	var body, authorization, key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		body, authorization, key = string(data), r.Header.Get("Authorization"), r.Header.Get("X-Seaweedfs-Key")
+	}))
+	defer server.Close()
+
+	queue := &WebhookQueue{}
+	assert.Nil(t, queue.initialize(server.URL, "secret", 1, 1))
+	assert.Nil(t, queue.SendRawMessage("bucket/a.txt", []byte(`{"Records":[]}`)))
+	assert.Equal(t, 2, requests, "the server errors are retried")
+	assert.Equal(t, `{"Records":[]}`, body)
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, "bucket/a.txt", key)
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer rejecting.Close()
+	requests = 0
+	assert.Nil(t, queue.initialize(rejecting.URL, "", 1, 3))
+	assert.NotNil(t, queue.SendRawMessage("bucket/a.txt", []byte(`{}`)))
+	assert.Equal(t, 1, requests, "the client errors are not retried")
+}
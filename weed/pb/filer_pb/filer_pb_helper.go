@@ -35,6 +35,48 @@ func (entry *Entry) IsOlderDir() bool {
 	return entry.IsDirectory && entry.Attributes != nil && entry.Attributes.Mime == "" && entry.Attributes.GetCrtime() <= time.Now().Unix()-cutoffTimeNewEmptyDir
 }
 
+// ModTime returns the time of the last modification, the entries saved before the nanoseconds were kept have none
+func (attr *FuseAttributes) ModTime() time.Time {
+	return time.Unix(attr.GetMtime(), int64(attr.GetMtimeNs()))
+}
+
+// CreateTime returns the time of the creation
+func (attr *FuseAttributes) CreateTime() time.Time {
+	return time.Unix(attr.GetCrtime(), int64(attr.GetCrtimeNs()))
+}
+
+// ChangeTime returns the time of the last change, or the time of the last modification if not recorded
+func (attr *FuseAttributes) ChangeTime() time.Time {
+	if attr.GetCtime() == 0 && attr.GetCtimeNs() == 0 {
+		return attr.ModTime()
+	}
+	return time.Unix(attr.GetCtime(), int64(attr.GetCtimeNs()))
+}
+
+// AccessTime returns the time of the last access, or the time of the last modification if not recorded
+func (attr *FuseAttributes) AccessTime() time.Time {
+	if attr.GetAtime() == 0 && attr.GetAtimeNs() == 0 {
+		return attr.ModTime()
+	}
+	return time.Unix(attr.GetAtime(), int64(attr.GetAtimeNs()))
+}
+
+func (attr *FuseAttributes) SetModTime(t time.Time) {
+	attr.Mtime, attr.MtimeNs = t.Unix(), int32(t.Nanosecond())
+}
+
+func (attr *FuseAttributes) SetCreateTime(t time.Time) {
+	attr.Crtime, attr.CrtimeNs = t.Unix(), int32(t.Nanosecond())
+}
+
+func (attr *FuseAttributes) SetChangeTime(t time.Time) {
+	attr.Ctime, attr.CtimeNs = t.Unix(), int32(t.Nanosecond())
+}
+
+func (attr *FuseAttributes) SetAccessTime(t time.Time) {
+	attr.Atime, attr.AtimeNs = t.Unix(), int32(t.Nanosecond())
+}
+
 func ToFileIdObject(fileIdStr string) (*FileId, error) {
 	t, err := needle.ParseFileIdFromString(fileIdStr)
 	if err != nil {
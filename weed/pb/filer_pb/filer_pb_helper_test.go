@@ -2,6 +2,7 @@ package filer_pb
 
 import (
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -15,3 +16,20 @@ func TestFileIdSize(t *testing.T) {
 	println(len(fileIdStr))
 	println(len(bytes))
 }
+
+func TestFuseAttributesTimes(t *testing.T) {
+	// the entries saved before the nanoseconds, the change and the access times were kept
+	old := &FuseAttributes{Mtime: 1700000000, Crtime: 1600000000}
+	if !old.ModTime().Equal(time.Unix(1700000000, 0)) || !old.ChangeTime().Equal(old.ModTime()) || !old.AccessTime().Equal(old.ModTime()) {
+		t.Errorf("unexpected times of old attributes: %v %v %v", old.ModTime(), old.ChangeTime(), old.AccessTime())
+	}
+
+	mtime, ctime, atime := time.Unix(1700000000, 123456789), time.Unix(1700000001, 1), time.Unix(1700000002, 999999999)
+	attr := &FuseAttributes{}
+	attr.SetModTime(mtime)
+	attr.SetChangeTime(ctime)
+	attr.SetAccessTime(atime)
+	if !attr.ModTime().Equal(mtime) || !attr.ChangeTime().Equal(ctime) || !attr.AccessTime().Equal(atime) {
+		t.Errorf("unexpected times: %v %v %v", attr.ModTime(), attr.ChangeTime(), attr.AccessTime())
+	}
+}
@@ -0,0 +1,56 @@
+package s3select
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// SelectError is reported with the error codes of S3 Select,
+// either as the error response or as an error event once the response has started
+type SelectError struct {
+	Code           string
+	Message        string
+	HTTPStatusCode int
+}
+
+func (e *SelectError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func newError(code string, format string, args ...interface{}) *SelectError {
+	return &SelectError{
+		Code:           code,
+		Message:        fmt.Sprintf(format, args...),
+		HTTPStatusCode: http.StatusBadRequest,
+	}
+}
+
+func errParse(format string, args ...interface{}) *SelectError {
+	return newError("ParseSelectFailure", format, args...)
+}
+
+func errUnsupported(format string, args ...interface{}) *SelectError {
+	return newError("ParseUnsupportedSyntax", format, args...)
+}
+
+func errEvaluation(format string, args ...interface{}) *SelectError {
+	return newError("EvaluatorInvalidArguments", format, args...)
+}
+
+func errCast(format string, args ...interface{}) *SelectError {
+	return newError("CastFailed", format, args...)
+}
+
+var errDivideByZero = newError("EvaluatorDivisionByZero", "division by zero")
+
+func errRequest(code string, format string, args ...interface{}) *SelectError {
+	return newError(code, format, args...)
+}
+
+func errInternal(err error) *SelectError {
+	return &SelectError{
+		Code:           "InternalError",
+		Message:        err.Error(),
+		HTTPStatusCode: http.StatusInternalServerError,
+	}
+}
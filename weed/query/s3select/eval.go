@@ -0,0 +1,514 @@
+package s3select
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Record is a row of the queried object
+type Record interface {
+	// Value returns the value at the column path, or Null if there is none
+	Value(path []pathElement) Value
+	// Columns lists the top level columns, as returned by SELECT *
+	Columns() (names []string, values []Value)
+}
+
+type expr interface {
+	eval(r Record) (Value, error)
+}
+
+type literalExpr struct {
+	v Value
+}
+
+func (e *literalExpr) eval(r Record) (Value, error) {
+	return e.v, nil
+}
+
+type columnExpr struct {
+	path []pathElement
+}
+
+func (e *columnExpr) eval(r Record) (Value, error) {
+	if r == nil {
+		return Null, nil
+	}
+	return r.Value(e.path), nil
+}
+
+// name is used as the output field name of the projected column
+func (e *columnExpr) name() string {
+	last := e.path[len(e.path)-1]
+	if last.isIndex || last.isWildcard {
+		return ""
+	}
+	return last.name
+}
+
+// truth evaluates a condition, where null and non boolean values are false
+func truth(e expr, r Record) (bool, error) {
+	v, err := e.eval(r)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.toBool()
+	return ok && b, nil
+}
+
+type logicalExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *logicalExpr) eval(r Record) (Value, error) {
+	left, err := evalBool(e.left, r)
+	if err != nil {
+		return Null, err
+	}
+	// short circuit
+	if !left.IsNull() && left.b == (e.op == "OR") {
+		return left, nil
+	}
+	right, err := evalBool(e.right, r)
+	if err != nil {
+		return Null, err
+	}
+	switch {
+	case !right.IsNull() && right.b == (e.op == "OR"):
+		return right, nil
+	case left.IsNull() || right.IsNull():
+		return Null, nil
+	}
+	return right, nil
+}
+
+type notExpr struct {
+	e expr
+}
+
+func (e *notExpr) eval(r Record) (Value, error) {
+	v, err := evalBool(e.e, r)
+	if err != nil || v.IsNull() {
+		return v, err
+	}
+	return BoolValue(!v.b), nil
+}
+
+// evalBool evaluates an operand of a logical operator, which is either a boolean or null
+func evalBool(e expr, r Record) (Value, error) {
+	v, err := e.eval(r)
+	if err != nil || v.IsNull() {
+		return v, err
+	}
+	b, ok := v.toBool()
+	if !ok {
+		return Null, errEvaluation("%q is not a boolean", v.String())
+	}
+	return BoolValue(b), nil
+}
+
+type comparisonExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *comparisonExpr) eval(r Record) (Value, error) {
+	left, err := e.left.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	right, err := e.right.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	if left.IsNull() || right.IsNull() {
+		return Null, nil
+	}
+	c, ok := compareValues(left, right)
+	if !ok {
+		// values of different types are never equal
+		return BoolValue(e.op == "!="), nil
+	}
+	switch e.op {
+	case "=":
+		return BoolValue(c == 0), nil
+	case "!=":
+		return BoolValue(c != 0), nil
+	case "<":
+		return BoolValue(c < 0), nil
+	case "<=":
+		return BoolValue(c <= 0), nil
+	case ">":
+		return BoolValue(c > 0), nil
+	}
+	return BoolValue(c >= 0), nil
+}
+
+type isNullExpr struct {
+	e   expr
+	not bool
+}
+
+func (e *isNullExpr) eval(r Record) (Value, error) {
+	v, err := e.e.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	return BoolValue(v.IsNull() != e.not), nil
+}
+
+type likeExpr struct {
+	e, pattern, escape expr
+	compiled           *regexp.Regexp
+	not                bool
+}
+
+func (e *likeExpr) eval(r Record) (Value, error) {
+	v, err := e.e.eval(r)
+	if err != nil || v.IsNull() {
+		return Null, err
+	}
+	re := e.compiled
+	if re == nil {
+		pattern, err := e.pattern.eval(r)
+		if err != nil || pattern.IsNull() {
+			return Null, err
+		}
+		escape := ""
+		if e.escape != nil {
+			escapeValue, err := e.escape.eval(r)
+			if err != nil {
+				return Null, err
+			}
+			escape = escapeValue.String()
+		}
+		if re, err = compileLike(pattern.String(), escape); err != nil {
+			return Null, err
+		}
+	}
+	return BoolValue(re.MatchString(v.String()) != e.not), nil
+}
+
+type betweenExpr struct {
+	e, low, high expr
+	not          bool
+}
+
+func (e *betweenExpr) eval(r Record) (Value, error) {
+	v, err := e.e.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	low, err := e.low.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	high, err := e.high.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	c1, ok1 := compareValues(v, low)
+	c2, ok2 := compareValues(v, high)
+	if !ok1 || !ok2 {
+		return Null, nil
+	}
+	return BoolValue((c1 >= 0 && c2 <= 0) != e.not), nil
+}
+
+type inExpr struct {
+	e    expr
+	list []expr
+	not  bool
+}
+
+func (e *inExpr) eval(r Record) (Value, error) {
+	v, err := e.e.eval(r)
+	if err != nil || v.IsNull() {
+		return Null, err
+	}
+	for _, item := range e.list {
+		x, err := item.eval(r)
+		if err != nil {
+			return Null, err
+		}
+		if c, ok := compareValues(v, x); ok && c == 0 {
+			return BoolValue(!e.not), nil
+		}
+	}
+	return BoolValue(e.not), nil
+}
+
+type arithmeticExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *arithmeticExpr) eval(r Record) (Value, error) {
+	left, err := e.left.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	right, err := e.right.eval(r)
+	if err != nil {
+		return Null, err
+	}
+	if left.IsNull() || right.IsNull() {
+		return Null, nil
+	}
+	if e.op == "||" {
+		return StringValue(left.String() + right.String()), nil
+	}
+	x, ok := left.toNumber()
+	if !ok {
+		return Null, errEvaluation("%q is not a number", left.String())
+	}
+	y, ok := right.toNumber()
+	if !ok {
+		return Null, errEvaluation("%q is not a number", right.String())
+	}
+	if x.kind == KindInt && y.kind == KindInt {
+		switch e.op {
+		case "+":
+			return IntValue(x.i + y.i), nil
+		case "-":
+			return IntValue(x.i - y.i), nil
+		case "*":
+			return IntValue(x.i * y.i), nil
+		case "/", "%":
+			if y.i == 0 {
+				return Null, errDivideByZero
+			}
+			if e.op == "/" {
+				return IntValue(x.i / y.i), nil
+			}
+			return IntValue(x.i % y.i), nil
+		}
+	}
+	a, b := x.float(), y.float()
+	switch e.op {
+	case "+":
+		return FloatValue(a + b), nil
+	case "-":
+		return FloatValue(a - b), nil
+	case "*":
+		return FloatValue(a * b), nil
+	}
+	if b == 0 {
+		return Null, errDivideByZero
+	}
+	if e.op == "/" {
+		return FloatValue(a / b), nil
+	}
+	return FloatValue(math.Mod(a, b)), nil
+}
+
+func negate(v Value) Value {
+	if v.kind == KindInt {
+		return IntValue(-v.i)
+	}
+	return FloatValue(-v.f)
+}
+
+type castExpr struct {
+	e    expr
+	kind Kind
+}
+
+func (e *castExpr) eval(r Record) (Value, error) {
+	v, err := e.e.eval(r)
+	if err != nil || v.IsNull() {
+		return Null, err
+	}
+	switch e.kind {
+	case KindInt:
+		if v.kind == KindBool {
+			if v.b {
+				return IntValue(1), nil
+			}
+			return IntValue(0), nil
+		}
+		if n, ok := v.toNumber(); ok {
+			if n.kind == KindInt {
+				return n, nil
+			}
+			return IntValue(int64(n.f)), nil
+		}
+	case KindFloat:
+		if n, ok := v.toNumber(); ok {
+			return FloatValue(n.float()), nil
+		}
+	case KindString:
+		return StringValue(v.String()), nil
+	case KindBool:
+		if v.isNumber() {
+			return BoolValue(v.float() != 0), nil
+		}
+		if b, ok := v.toBool(); ok {
+			return BoolValue(b), nil
+		}
+	case KindTimestamp:
+		if t, ok := v.toTimestamp(); ok {
+			return TimestampValue(t), nil
+		}
+	}
+	return Null, errCast("can not cast %q", v.String())
+}
+
+type functionExpr struct {
+	name string
+	args []expr
+}
+
+func (e *functionExpr) eval(r Record) (Value, error) {
+	args := make([]Value, len(e.args))
+	for i, arg := range e.args {
+		v, err := arg.eval(r)
+		if err != nil {
+			return Null, err
+		}
+		args[i] = v
+	}
+	switch e.name {
+	case "COALESCE":
+		for _, v := range args {
+			if !v.IsNull() {
+				return v, nil
+			}
+		}
+		return Null, nil
+	case "NULLIF":
+		if c, ok := compareValues(args[0], args[1]); ok && c == 0 {
+			return Null, nil
+		}
+		return args[0], nil
+	}
+	if args[0].IsNull() {
+		return Null, nil
+	}
+	s := args[0].String()
+	switch e.name {
+	case "LOWER":
+		return StringValue(strings.ToLower(s)), nil
+	case "UPPER":
+		return StringValue(strings.ToUpper(s)), nil
+	case "TRIM":
+		return StringValue(strings.TrimSpace(s)), nil
+	case "CHAR_LENGTH", "CHARACTER_LENGTH":
+		return IntValue(int64(utf8.RuneCountInString(s))), nil
+	case "SUBSTRING":
+		return substring(s, args[1:])
+	}
+	return Null, errUnsupported("function %s is not supported", e.name)
+}
+
+// substring follows SQL, where the start position is 1 based and may be before the string
+func substring(s string, args []Value) (Value, error) {
+	runes := []rune(s)
+	var bounds []int64
+	for _, arg := range args {
+		if arg.IsNull() {
+			return Null, nil
+		}
+		n, ok := arg.toNumber()
+		if !ok || n.kind != KindInt {
+			return Null, errEvaluation("SUBSTRING expects integer positions, found %q", arg.String())
+		}
+		bounds = append(bounds, n.i)
+	}
+	start, end := bounds[0], int64(len(runes))+1
+	if len(bounds) > 1 {
+		if bounds[1] < 0 {
+			return Null, errEvaluation("SUBSTRING expects a non negative length")
+		}
+		end = start + bounds[1]
+	}
+	start = max(start, 1)
+	end = min(end, int64(len(runes))+1)
+	if start >= end {
+		return StringValue(""), nil
+	}
+	return StringValue(string(runes[start-1 : end-1])), nil
+}
+
+// aggregate accumulates the matched records, and evaluates to the result
+type aggregate struct {
+	name string
+	arg  expr
+
+	count   int64
+	sum     Value
+	extreme Value
+}
+
+func (a *aggregate) accumulate(r Record) error {
+	if a.arg == nil {
+		a.count++
+		return nil
+	}
+	v, err := a.arg.eval(r)
+	if err != nil || v.IsNull() {
+		return err
+	}
+	switch a.name {
+	case "SUM", "AVG":
+		n, ok := v.toNumber()
+		if !ok {
+			return errEvaluation("%s expects numbers, found %q", a.name, v.String())
+		}
+		switch {
+		case a.sum.IsNull():
+			a.sum = n
+		case a.sum.kind == KindInt && n.kind == KindInt && !addOverflows(a.sum.i, n.i):
+			a.sum = IntValue(a.sum.i + n.i)
+		default:
+			a.sum = FloatValue(a.sum.float() + n.float())
+		}
+	case "MIN", "MAX":
+		if n, ok := v.toNumber(); ok {
+			v = n
+		}
+		if a.extreme.IsNull() {
+			a.extreme = v
+		} else if c, ok := compareValues(v, a.extreme); ok && (c < 0) == (a.name == "MIN") && c != 0 {
+			a.extreme = v
+		}
+	}
+	a.count++
+	return nil
+}
+
+func addOverflows(a, b int64) bool {
+	c := a + b
+	return (c > a) != (b > 0)
+}
+
+func (a *aggregate) eval(r Record) (Value, error) {
+	switch a.name {
+	case "COUNT":
+		return IntValue(a.count), nil
+	case "SUM":
+		return a.sum, nil
+	case "AVG":
+		if a.count == 0 {
+			return Null, nil
+		}
+		return FloatValue(a.sum.float() / float64(a.count)), nil
+	}
+	return a.extreme, nil
+}
+
+// outputName is the field name of the projection in JSON output
+func (proj projection) outputName(position int) string {
+	if proj.alias != "" {
+		return proj.alias
+	}
+	if column, ok := proj.expr.(*columnExpr); ok {
+		if name := column.name(); name != "" {
+			return name
+		}
+	}
+	return "_" + strconv.Itoa(position+1)
+}
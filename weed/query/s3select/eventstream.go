@@ -0,0 +1,77 @@
+package s3select
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// The response of SelectObjectContent is a stream of messages, each framed as
+//
+//	total length (4 bytes) | headers length (4 bytes) | prelude crc (4 bytes) | headers | payload | message crc (4 bytes)
+//
+// where each header is: name length (1 byte) | name | value type 7 (1 byte) | value length (2 bytes) | value
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTSelectObjectAppendix.html
+
+const headerValueTypeString = 7
+
+type header struct {
+	name, value string
+}
+
+func encodeMessage(headers []header, payload []byte) []byte {
+	headersLength := 0
+	for _, h := range headers {
+		headersLength += 1 + len(h.name) + 1 + 2 + len(h.value)
+	}
+	totalLength := 4 + 4 + 4 + headersLength + len(payload) + 4
+
+	msg := make([]byte, 0, totalLength)
+	msg = binary.BigEndian.AppendUint32(msg, uint32(totalLength))
+	msg = binary.BigEndian.AppendUint32(msg, uint32(headersLength))
+	msg = binary.BigEndian.AppendUint32(msg, crc32.ChecksumIEEE(msg[:8]))
+	for _, h := range headers {
+		msg = append(msg, byte(len(h.name)))
+		msg = append(msg, h.name...)
+		msg = append(msg, headerValueTypeString)
+		msg = binary.BigEndian.AppendUint16(msg, uint16(len(h.value)))
+		msg = append(msg, h.value...)
+	}
+	msg = append(msg, payload...)
+	return binary.BigEndian.AppendUint32(msg, crc32.ChecksumIEEE(msg))
+}
+
+func eventMessage(eventType, contentType string, payload []byte) []byte {
+	headers := []header{{":event-type", eventType}}
+	if contentType != "" {
+		headers = append(headers, header{":content-type", contentType})
+	}
+	headers = append(headers, header{":message-type", "event"})
+	return encodeMessage(headers, payload)
+}
+
+func recordsMessage(payload []byte) []byte {
+	return eventMessage("Records", "application/octet-stream", payload)
+}
+
+func continuationMessage() []byte {
+	return eventMessage("Cont", "", nil)
+}
+
+func endMessage() []byte {
+	return eventMessage("End", "", nil)
+}
+
+func statsMessage(eventType string, scanned, processed, returned int64) []byte {
+	payload := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?><%s><BytesScanned>%d</BytesScanned><BytesProcessed>%d</BytesProcessed><BytesReturned>%d</BytesReturned></%s>`,
+		eventType, scanned, processed, returned, eventType)
+	return eventMessage(eventType, "text/xml", []byte(payload))
+}
+
+func errorMessage(code, message string) []byte {
+	return encodeMessage([]header{
+		{":error-code", code},
+		{":error-message", message},
+		{":message-type", "error"},
+	}, nil)
+}
@@ -0,0 +1,212 @@
+package s3select
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const maxRecordSize = 1 << 20
+
+type csvReader struct {
+	scanner        *bufio.Scanner
+	recordDelim    string
+	fieldDelim     byte
+	quote          byte
+	escape         byte
+	comment        byte
+	allowQuotedEOL bool
+	headers        map[string]int
+	headerNames    []string
+}
+
+func newCsvReader(r io.Reader, in *CSVInput) (*csvReader, error) {
+	c := &csvReader{
+		recordDelim:    firstOr(in.RecordDelimiter, "\n"),
+		fieldDelim:     firstOr(in.FieldDelimiter, ",")[0],
+		quote:          firstOr(in.QuoteCharacter, `"`)[0],
+		escape:         firstOr(in.QuoteEscapeCharacter, `"`)[0],
+		comment:        firstOr(in.Comments, "#")[0],
+		allowQuotedEOL: in.AllowQuotedRecordDelimiter,
+	}
+	c.scanner = bufio.NewScanner(r)
+	c.scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	c.scanner.Split(c.splitLines)
+
+	switch strings.ToUpper(in.FileHeaderInfo) {
+	case "USE", "IGNORE":
+		header, err := c.readFields()
+		if err == io.EOF {
+			return c, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(in.FileHeaderInfo, "USE") {
+			c.headerNames = header
+			c.headers = make(map[string]int)
+			for i, name := range header {
+				if _, found := c.headers[name]; !found {
+					c.headers[name] = i
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+func firstOr(s, defaultValue string) string {
+	if s == "" {
+		return defaultValue
+	}
+	return s
+}
+
+func (c *csvReader) splitLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.Index(data, []byte(c.recordDelim)); i >= 0 {
+		return i + len(c.recordDelim), data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func (c *csvReader) nextLine() (string, error) {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			if err == bufio.ErrTooLong {
+				return "", newError("OverMaxRecordSize", "a record is larger than %d bytes", maxRecordSize)
+			}
+			return "", err
+		}
+		return "", io.EOF
+	}
+	line := c.scanner.Text()
+	if c.recordDelim == "\n" {
+		line = strings.TrimSuffix(line, "\r")
+	}
+	return line, nil
+}
+
+// readFields reads the fields of the next record, skipping comments and empty lines
+func (c *csvReader) readFields() ([]string, error) {
+	for {
+		line, err := c.nextLine()
+		if err != nil {
+			return nil, err
+		}
+		if line == "" || line[0] == c.comment {
+			continue
+		}
+		return c.parseFields(line)
+	}
+}
+
+func (c *csvReader) parseFields(line string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	quoted, inQuotes := false, false
+	for i := 0; ; i++ {
+		if i >= len(line) {
+			if !inQuotes {
+				break
+			}
+			if !c.allowQuotedEOL {
+				return nil, newError("CSVParsingError", "unterminated quoted field in %q", line)
+			}
+			next, err := c.nextLine()
+			if err != nil {
+				return nil, newError("CSVParsingError", "unterminated quoted field in %q", line)
+			}
+			field.WriteString(c.recordDelim)
+			line, i = next, -1
+			continue
+		}
+		ch := line[i]
+		switch {
+		case inQuotes:
+			switch {
+			case ch == c.escape && i+1 < len(line) && line[i+1] == c.quote:
+				field.WriteByte(c.quote)
+				i++
+			case ch == c.quote:
+				inQuotes = false
+			default:
+				field.WriteByte(ch)
+			}
+		case ch == c.quote && field.Len() == 0 && !quoted:
+			quoted, inQuotes = true, true
+		case ch == c.fieldDelim:
+			fields = append(fields, field.String())
+			field.Reset()
+			quoted = false
+		default:
+			field.WriteByte(ch)
+		}
+	}
+	return append(fields, field.String()), nil
+}
+
+func (c *csvReader) Read() (Record, error) {
+	fields, err := c.readFields()
+	if err != nil {
+		return nil, err
+	}
+	return &csvRecord{reader: c, fields: fields}, nil
+}
+
+type csvRecord struct {
+	reader *csvReader
+	fields []string
+}
+
+func (r *csvRecord) Value(path []pathElement) Value {
+	if len(path) != 1 || path[0].isIndex || path[0].isWildcard {
+		return Null
+	}
+	name := path[0]
+	if r.reader.headers != nil {
+		if i, found := r.reader.headers[name.name]; found {
+			return r.field(i)
+		}
+		if !name.caseSensitive {
+			for i, header := range r.reader.headerNames {
+				if strings.EqualFold(header, name.name) {
+					return r.field(i)
+				}
+			}
+		}
+	}
+	// positional columns _1, _2, ...
+	if strings.HasPrefix(name.name, "_") {
+		if n, err := strconv.Atoi(name.name[1:]); err == nil && n > 0 {
+			return r.field(n - 1)
+		}
+	}
+	return Null
+}
+
+func (r *csvRecord) field(i int) Value {
+	if i < len(r.fields) {
+		return StringValue(r.fields[i])
+	}
+	return Null
+}
+
+func (r *csvRecord) Columns() (names []string, values []Value) {
+	for i, field := range r.fields {
+		if i < len(r.reader.headerNames) {
+			names = append(names, r.reader.headerNames[i])
+		} else {
+			names = append(names, "_"+strconv.Itoa(i+1))
+		}
+		values = append(values, StringValue(field))
+	}
+	return
+}
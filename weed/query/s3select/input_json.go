@@ -0,0 +1,133 @@
+package s3select
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// jsonReader reads both JSON documents and JSON lines, as a stream of JSON values
+type jsonReader struct {
+	decoder  *json.Decoder
+	fromPath []pathElement
+	pending  []gjson.Result
+}
+
+func newJsonReader(r io.Reader, fromPath []pathElement) *jsonReader {
+	return &jsonReader{
+		decoder:  json.NewDecoder(r),
+		fromPath: fromPath,
+	}
+}
+
+func (j *jsonReader) Read() (Record, error) {
+	for len(j.pending) == 0 {
+		var raw json.RawMessage
+		if err := j.decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return nil, err
+			}
+			return nil, newError("JSONParsingError", "%v", err)
+		}
+		j.pending = unroll(gjson.ParseBytes(raw), j.fromPath)
+	}
+	record := &jsonRecord{value: j.pending[0]}
+	j.pending = j.pending[1:]
+	return record, nil
+}
+
+// unroll finds the records selected by FROM S3Object[*].path, where [*] selects each element of an array
+func unroll(value gjson.Result, path []pathElement) []gjson.Result {
+	for i, element := range path {
+		if !element.isWildcard {
+			value = lookupJson(value, element)
+			continue
+		}
+		var records []gjson.Result
+		value.ForEach(func(_, item gjson.Result) bool {
+			records = append(records, unroll(item, path[i+1:])...)
+			return true
+		})
+		return records
+	}
+	if !value.Exists() {
+		return nil
+	}
+	return []gjson.Result{value}
+}
+
+func lookupJson(value gjson.Result, element pathElement) gjson.Result {
+	if element.isIndex {
+		if !value.IsArray() {
+			return gjson.Result{}
+		}
+		return value.Get(strconv.Itoa(element.index))
+	}
+	if !value.IsObject() {
+		return gjson.Result{}
+	}
+	var exact, folded gjson.Result
+	value.ForEach(func(key, item gjson.Result) bool {
+		if key.Str == element.name {
+			exact = item
+			return false
+		}
+		if !element.caseSensitive && !folded.Exists() && strings.EqualFold(key.Str, element.name) {
+			folded = item
+		}
+		return true
+	})
+	if exact.Exists() {
+		return exact
+	}
+	return folded
+}
+
+type jsonRecord struct {
+	value gjson.Result
+}
+
+func (r *jsonRecord) Value(path []pathElement) Value {
+	value := r.value
+	for _, element := range path {
+		if element.isWildcard {
+			return Null
+		}
+		value = lookupJson(value, element)
+	}
+	return jsonValue(value)
+}
+
+func (r *jsonRecord) Columns() (names []string, values []Value) {
+	if !r.value.IsObject() {
+		return []string{"_1"}, []Value{jsonValue(r.value)}
+	}
+	r.value.ForEach(func(key, item gjson.Result) bool {
+		names = append(names, key.Str)
+		values = append(values, jsonValue(item))
+		return true
+	})
+	return
+}
+
+func jsonValue(value gjson.Result) Value {
+	switch value.Type {
+	case gjson.True:
+		return BoolValue(true)
+	case gjson.False:
+		return BoolValue(false)
+	case gjson.Number:
+		if i, err := strconv.ParseInt(value.Raw, 10, 64); err == nil {
+			return IntValue(i)
+		}
+		return FloatValue(value.Num)
+	case gjson.String:
+		return StringValue(value.Str)
+	case gjson.JSON:
+		return ObjectValue(value.Raw)
+	}
+	return Null
+}
@@ -0,0 +1,88 @@
+package s3select
+
+import (
+	"io"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type parquetReader struct {
+	reader  *parquet.Reader
+	columns []string
+}
+
+func newParquetReader(r io.ReaderAt, size int64) (*parquetReader, error) {
+	f, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return nil, newError("InvalidParquetFile", "%v", err)
+	}
+	p := &parquetReader{reader: parquet.NewReader(f)}
+	for _, field := range f.Schema().Fields() {
+		p.columns = append(p.columns, field.Name())
+	}
+	return p, nil
+}
+
+func (p *parquetReader) Read() (Record, error) {
+	row := make(map[string]interface{})
+	if err := p.reader.Read(&row); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, newError("ParquetParsingError", "%v", err)
+	}
+	return &parquetRecord{columns: p.columns, row: row}, nil
+}
+
+func (p *parquetReader) Close() error {
+	return p.reader.Close()
+}
+
+type parquetRecord struct {
+	columns []string
+	row     map[string]interface{}
+}
+
+func (r *parquetRecord) Value(path []pathElement) Value {
+	var value interface{} = r.row
+	for _, element := range path {
+		switch x := value.(type) {
+		case map[string]interface{}:
+			if element.isIndex || element.isWildcard {
+				return Null
+			}
+			value = lookupMap(x, element)
+		case []interface{}:
+			if !element.isIndex || element.index >= len(x) {
+				return Null
+			}
+			value = x[element.index]
+		default:
+			return Null
+		}
+	}
+	return valueOf(value)
+}
+
+func lookupMap(m map[string]interface{}, element pathElement) interface{} {
+	if v, found := m[element.name]; found {
+		return v
+	}
+	if !element.caseSensitive {
+		for k, v := range m {
+			if strings.EqualFold(k, element.name) {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func (r *parquetRecord) Columns() (names []string, values []Value) {
+	for _, column := range r.columns {
+		names = append(names, column)
+		values = append(values, valueOf(r.row[column]))
+	}
+	return
+}
@@ -0,0 +1,120 @@
+package s3select
+
+import (
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// is tells whether the token is the keyword or operator, keywords being case insensitive
+func (t token) is(s string) bool {
+	switch t.kind {
+	case tokenIdent:
+		return strings.EqualFold(t.text, s)
+	case tokenOperator:
+		return t.text == s
+	}
+	return false
+}
+
+func tokenize(sql string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"':
+			text, next, ok := scanQuoted(sql, i)
+			if !ok {
+				return nil, errParse("unterminated quote at position %d", i)
+			}
+			kind := tokenString
+			if c == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: i})
+			i = next
+		case isDigit(c) || c == '.' && i+1 < len(sql) && isDigit(sql[i+1]):
+			start := i
+			for i < len(sql) && (isDigit(sql[i]) || sql[i] == '.') {
+				i++
+			}
+			if i < len(sql) && (sql[i] == 'e' || sql[i] == 'E') {
+				i++
+				if i < len(sql) && (sql[i] == '+' || sql[i] == '-') {
+					i++
+				}
+				for i < len(sql) && isDigit(sql[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: sql[start:i], pos: start})
+		case isIdentStart(c):
+			start := i
+			for i < len(sql) && (isIdentStart(sql[i]) || isDigit(sql[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: sql[start:i], pos: start})
+		default:
+			op := ""
+			for _, candidate := range []string{"<=", ">=", "<>", "!=", "||"} {
+				if strings.HasPrefix(sql[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				if !strings.ContainsRune("=<>+-*/%(),.[]", rune(c)) {
+					return nil, errParse("unexpected character %q at position %d", c, i)
+				}
+				op = string(c)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(sql)}), nil
+}
+
+// scanQuoted reads a quoted string where the quote is escaped by doubling it
+func scanQuoted(sql string, start int) (text string, next int, ok bool) {
+	quote := sql[start]
+	var sb strings.Builder
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != quote {
+			sb.WriteByte(sql[i])
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == quote {
+			sb.WriteByte(quote)
+			i++
+			continue
+		}
+		return sb.String(), i + 1, true
+	}
+	return "", 0, false
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
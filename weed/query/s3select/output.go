@@ -0,0 +1,70 @@
+package s3select
+
+import (
+	"strings"
+)
+
+type recordWriter interface {
+	appendRecord(buf []byte, names []string, values []Value) []byte
+}
+
+func newRecordWriter(out OutputSerialization) recordWriter {
+	if out.JSON != nil {
+		return &jsonWriter{recordDelim: firstOr(out.JSON.RecordDelimiter, "\n")}
+	}
+	return &csvWriter{
+		fieldDelim:  firstOr(out.CSV.FieldDelimiter, ","),
+		recordDelim: firstOr(out.CSV.RecordDelimiter, "\n"),
+		quote:       firstOr(out.CSV.QuoteCharacter, `"`),
+		escape:      firstOr(out.CSV.QuoteEscapeCharacter, `"`),
+		always:      strings.EqualFold(out.CSV.QuoteFields, "ALWAYS"),
+	}
+}
+
+type csvWriter struct {
+	fieldDelim  string
+	recordDelim string
+	quote       string
+	escape      string
+	always      bool
+}
+
+func (c *csvWriter) appendRecord(buf []byte, names []string, values []Value) []byte {
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, c.fieldDelim...)
+		}
+		s := v.String()
+		if !c.always && !c.needsQuotes(s) {
+			buf = append(buf, s...)
+			continue
+		}
+		buf = append(buf, c.quote...)
+		buf = append(buf, strings.ReplaceAll(s, c.quote, c.escape+c.quote)...)
+		buf = append(buf, c.quote...)
+	}
+	return append(buf, c.recordDelim...)
+}
+
+func (c *csvWriter) needsQuotes(s string) bool {
+	return strings.Contains(s, c.fieldDelim) || strings.Contains(s, c.quote) ||
+		strings.Contains(s, c.recordDelim) || strings.ContainsAny(s, "\r\n")
+}
+
+type jsonWriter struct {
+	recordDelim string
+}
+
+func (j *jsonWriter) appendRecord(buf []byte, names []string, values []Value) []byte {
+	buf = append(buf, '{')
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = StringValue(names[i]).appendJson(buf)
+		buf = append(buf, ':')
+		buf = v.appendJson(buf)
+	}
+	buf = append(buf, '}')
+	return append(buf, j.recordDelim...)
+}
@@ -0,0 +1,698 @@
+package s3select
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Query is a parsed S3 Select statement:
+//
+//	SELECT * | expr [[AS] alias], ... FROM S3Object[[*][.path]] [[AS] alias] [WHERE cond] [LIMIT n]
+type Query struct {
+	projections []projection
+	selectAll   bool
+	fromAlias   string
+	fromPath    []pathElement
+	where       expr
+	limit       int64
+	aggregates  []*aggregate
+}
+
+type projection struct {
+	expr  expr
+	alias string
+}
+
+type pathElement struct {
+	name          string
+	caseSensitive bool
+	index         int
+	isIndex       bool
+	isWildcard    bool
+}
+
+func (e pathElement) matches(name string) bool {
+	if e.caseSensitive {
+		return e.name == name
+	}
+	return strings.EqualFold(e.name, name)
+}
+
+// ParseQuery parses the SQL expression of a SelectObjectContent request
+func ParseQuery(sql string) (*Query, error) {
+	tokens, err := tokenize(sql)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// IsAggregate tells whether the query returns a single row of aggregates
+func (q *Query) IsAggregate() bool {
+	return len(q.aggregates) > 0
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+
+	columns          []*columnExpr
+	aggregates       []*aggregate
+	inAggregate      bool
+	columnsOutsideAg bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// unread steps back before the token returned by next
+func (p *parser) unread(t token) {
+	if t.kind != tokenEOF {
+		p.pos--
+	}
+}
+
+func (p *parser) accept(s string) bool {
+	if p.peek().is(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) error {
+	if !p.accept(s) {
+		return p.unexpected("expected " + s)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(context string) error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return errParse("%s, reached the end of the expression", context)
+	}
+	return errParse("%s, found %q at position %d", context, t.text, t.pos)
+}
+
+var reservedWords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "LIMIT": true, "AS": true,
+	"AND": true, "OR": true, "NOT": true, "IS": true, "NULL": true, "LIKE": true, "ESCAPE": true,
+	"BETWEEN": true, "IN": true, "TRUE": true, "FALSE": true, "CAST": true,
+	"GROUP": true, "ORDER": true, "HAVING": true, "JOIN": true, "UNION": true,
+}
+
+func isReserved(t token) bool {
+	return t.kind == tokenIdent && reservedWords[strings.ToUpper(t.text)]
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	q := &Query{limit: -1}
+	if err := p.expect("SELECT"); err != nil {
+		return nil, err
+	}
+	if p.accept("*") {
+		q.selectAll = true
+	} else {
+		for {
+			proj, err := p.parseProjection()
+			if err != nil {
+				return nil, err
+			}
+			q.projections = append(q.projections, proj)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	q.aggregates = p.aggregates
+	if len(q.aggregates) > 0 && p.columnsOutsideAg {
+		return nil, errUnsupported("aggregate functions can not be mixed with columns without GROUP BY")
+	}
+
+	if err := p.parseFrom(q); err != nil {
+		return nil, err
+	}
+	p.stripAlias(q.fromAlias)
+
+	if p.accept("WHERE") {
+		where, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if len(p.aggregates) > len(q.aggregates) {
+			return nil, errUnsupported("aggregate functions are not allowed in WHERE")
+		}
+		q.where = where
+		p.stripAlias(q.fromAlias)
+	}
+
+	if p.accept("LIMIT") {
+		t := p.next()
+		limit, err := strconv.ParseInt(t.text, 10, 64)
+		if t.kind != tokenNumber || err != nil || limit < 0 {
+			return nil, errParse("LIMIT expects a non negative integer, found %q", t.text)
+		}
+		q.limit = limit
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		if t.is("GROUP") || t.is("ORDER") || t.is("HAVING") || t.is("JOIN") || t.is("UNION") {
+			return nil, errUnsupported("%s is not supported", strings.ToUpper(t.text))
+		}
+		return nil, p.unexpected("unexpected token")
+	}
+	return q, nil
+}
+
+func (p *parser) parseProjection() (projection, error) {
+	e, err := p.parseExpr()
+	if err != nil {
+		return projection{}, err
+	}
+	proj := projection{expr: e}
+	if p.accept("AS") {
+		t := p.next()
+		if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+			return projection{}, errParse("expected an alias after AS")
+		}
+		proj.alias = t.text
+	} else if t := p.peek(); (t.kind == tokenIdent && !isReserved(t)) || t.kind == tokenQuotedIdent {
+		p.next()
+		proj.alias = t.text
+	}
+	return proj, nil
+}
+
+func (p *parser) parseFrom(q *Query) error {
+	if err := p.expect("FROM"); err != nil {
+		return err
+	}
+	if !p.accept("S3Object") {
+		return p.unexpected("expected S3Object after FROM")
+	}
+	if p.peek().is("[") {
+		p.next()
+		if err := p.expect("*"); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+		path, err := p.parsePathTail(nil)
+		if err != nil {
+			return err
+		}
+		q.fromPath = path
+	}
+	p.accept("AS")
+	if t := p.peek(); (t.kind == tokenIdent && !isReserved(t)) || t.kind == tokenQuotedIdent {
+		p.next()
+		q.fromAlias = t.text
+	}
+	return nil
+}
+
+// parsePathTail parses the .name and [index] elements following a column name
+func (p *parser) parsePathTail(path []pathElement) ([]pathElement, error) {
+	for {
+		switch {
+		case p.peek().is("."):
+			p.next()
+			t := p.next()
+			switch t.kind {
+			case tokenIdent:
+				path = append(path, pathElement{name: t.text})
+			case tokenQuotedIdent:
+				path = append(path, pathElement{name: t.text, caseSensitive: true})
+			default:
+				return nil, errParse("expected a name after '.' at position %d", t.pos)
+			}
+		case p.peek().is("["):
+			p.next()
+			t := p.next()
+			switch {
+			case t.is("*"):
+				path = append(path, pathElement{isWildcard: true})
+			case t.kind == tokenNumber:
+				index, err := strconv.Atoi(t.text)
+				if err != nil || index < 0 {
+					return nil, errParse("invalid array index %q", t.text)
+				}
+				path = append(path, pathElement{index: index, isIndex: true})
+			case t.kind == tokenString:
+				path = append(path, pathElement{name: t.text, caseSensitive: true})
+			default:
+				return nil, errParse("invalid array index at position %d", t.pos)
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+		default:
+			return path, nil
+		}
+	}
+}
+
+func (p *parser) parseExpr() (expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.accept("NOT") {
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{e: e}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.is("=") || t.is("!=") || t.is("<>") || t.is("<") || t.is("<=") || t.is(">") || t.is(">="):
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		op := t.text
+		if op == "<>" {
+			op = "!="
+		}
+		return &comparisonExpr{op: op, left: left, right: right}, nil
+	case t.is("IS"):
+		p.next()
+		not := p.accept("NOT")
+		if err := p.expect("NULL"); err != nil {
+			return nil, err
+		}
+		return &isNullExpr{e: left, not: not}, nil
+	}
+
+	not := p.accept("NOT")
+	switch {
+	case p.accept("LIKE"):
+		return p.parseLike(left, not)
+	case p.accept("BETWEEN"):
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("AND"); err != nil {
+			return nil, err
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &betweenExpr{e: left, low: low, high: high, not: not}, nil
+	case p.accept("IN"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &inExpr{e: left, list: list, not: not}, nil
+	}
+	if not {
+		return nil, p.unexpected("expected LIKE, BETWEEN or IN after NOT")
+	}
+	return left, nil
+}
+
+func (p *parser) parseLike(left expr, not bool) (expr, error) {
+	pattern, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	e := &likeExpr{e: left, pattern: pattern, not: not}
+	if p.accept("ESCAPE") {
+		if e.escape, err = p.parseAdditive(); err != nil {
+			return nil, err
+		}
+	}
+	// compile constant patterns once
+	if lit, ok := pattern.(*literalExpr); ok && e.escape == nil {
+		if e.compiled, err = compileLike(lit.v.String(), ""); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// parseList parses comma separated expressions up to the closing parenthesis
+func (p *parser) parseList() ([]expr, error) {
+	var list []expr
+	if p.accept(")") {
+		return list, nil
+	}
+	for {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		if p.accept(")") {
+			return list, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !t.is("+") && !t.is("-") && !t.is("||") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &arithmeticExpr{op: t.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseMultiplicative() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !t.is("*") && !t.is("/") && !t.is("%") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &arithmeticExpr{op: t.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.accept("-") {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if lit, ok := e.(*literalExpr); ok && lit.v.isNumber() {
+			return &literalExpr{v: negate(lit.v)}, nil
+		}
+		return &arithmeticExpr{op: "-", left: &literalExpr{v: IntValue(0)}, right: e}, nil
+	}
+	p.accept("+")
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &literalExpr{v: IntValue(i)}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errParse("invalid number %q", t.text)
+		}
+		return &literalExpr{v: FloatValue(f)}, nil
+	case tokenString:
+		return &literalExpr{v: StringValue(t.text)}, nil
+	case tokenQuotedIdent:
+		return p.parseColumn(pathElement{name: t.text, caseSensitive: true})
+	case tokenOperator:
+		if t.text == "(" {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return e, nil
+		}
+		p.unread(t)
+		return nil, p.unexpected("expected an expression")
+	case tokenIdent:
+		switch strings.ToUpper(t.text) {
+		case "NULL":
+			return &literalExpr{v: Null}, nil
+		case "TRUE":
+			return &literalExpr{v: BoolValue(true)}, nil
+		case "FALSE":
+			return &literalExpr{v: BoolValue(false)}, nil
+		case "CAST":
+			return p.parseCast()
+		}
+		if p.peek().is("(") {
+			p.next()
+			return p.parseFunction(strings.ToUpper(t.text))
+		}
+		if isReserved(t) {
+			p.unread(t)
+			return nil, p.unexpected("expected an expression")
+		}
+		return p.parseColumn(pathElement{name: t.text})
+	}
+	p.unread(t)
+	return nil, p.unexpected("expected an expression")
+}
+
+func (p *parser) parseColumn(first pathElement) (expr, error) {
+	path, err := p.parsePathTail([]pathElement{first})
+	if err != nil {
+		return nil, err
+	}
+	if !p.inAggregate {
+		p.columnsOutsideAg = true
+	}
+	column := &columnExpr{path: path}
+	p.columns = append(p.columns, column)
+	return column, nil
+}
+
+// stripAlias removes the table alias from column paths, as in "SELECT s.name FROM S3Object s"
+func (p *parser) stripAlias(alias string) {
+	for _, column := range p.columns {
+		if len(column.path) < 2 || column.path[0].isIndex || column.path[0].isWildcard {
+			continue
+		}
+		if alias != "" && column.path[0].matches(alias) || strings.EqualFold(column.path[0].name, "S3Object") {
+			column.path = column.path[1:]
+		}
+	}
+	p.columns = nil
+}
+
+var castTypes = map[string]Kind{
+	"INT": KindInt, "INTEGER": KindInt, "BIGINT": KindInt, "SMALLINT": KindInt,
+	"FLOAT": KindFloat, "DOUBLE": KindFloat, "REAL": KindFloat, "DECIMAL": KindFloat, "NUMERIC": KindFloat,
+	"STRING": KindString, "VARCHAR": KindString, "CHAR": KindString,
+	"BOOL": KindBool, "BOOLEAN": KindBool,
+	"TIMESTAMP": KindTimestamp,
+}
+
+func (p *parser) parseCast() (expr, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	e, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("AS"); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	kind, found := castTypes[strings.ToUpper(t.text)]
+	if t.kind != tokenIdent || !found {
+		return nil, errUnsupported("can not cast to %q", t.text)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &castExpr{e: e, kind: kind}, nil
+}
+
+var aggregateFunctions = map[string]bool{"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true}
+
+func (p *parser) parseFunction(name string) (expr, error) {
+	if aggregateFunctions[name] {
+		return p.parseAggregate(name)
+	}
+	switch name {
+	case "SUBSTRING":
+		return p.parseSubstring()
+	case "TRIM", "LOWER", "UPPER", "CHAR_LENGTH", "CHARACTER_LENGTH", "COALESCE", "NULLIF":
+	default:
+		return nil, errUnsupported("function %s is not supported", name)
+	}
+	args, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "COALESCE":
+		if len(args) == 0 {
+			return nil, errParse("COALESCE expects at least one argument")
+		}
+	case "NULLIF":
+		if len(args) != 2 {
+			return nil, errParse("NULLIF expects two arguments")
+		}
+	default:
+		if len(args) != 1 {
+			return nil, errParse("%s expects one argument", name)
+		}
+	}
+	return &functionExpr{name: name, args: args}, nil
+}
+
+// parseSubstring parses both SUBSTRING(s, start[, length]) and SUBSTRING(s FROM start [FOR length])
+func (p *parser) parseSubstring() (expr, error) {
+	s, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	args := []expr{s}
+	if !p.accept("FROM") {
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+	start, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, start)
+	if p.accept("FOR") || p.accept(",") {
+		length, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, length)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &functionExpr{name: "SUBSTRING", args: args}, nil
+}
+
+func (p *parser) parseAggregate(name string) (expr, error) {
+	if p.inAggregate {
+		return nil, errParse("aggregate functions can not be nested")
+	}
+	ag := &aggregate{name: name}
+	if name == "COUNT" && p.accept("*") {
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	} else {
+		p.inAggregate = true
+		arg, err := p.parseExpr()
+		p.inAggregate = false
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		ag.arg = arg
+	}
+	p.aggregates = append(p.aggregates, ag)
+	return ag, nil
+}
+
+// compileLike translates a LIKE pattern, where % matches any characters and _ any single character
+func compileLike(pattern, escape string) (*regexp.Regexp, error) {
+	if len([]rune(escape)) > 1 {
+		return nil, errEvaluation("ESCAPE expects a single character, found %q", escape)
+	}
+	var sb strings.Builder
+	sb.WriteString("(?s)^")
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case escape != "" && string(c) == escape:
+			escaped = true
+		case c == '%':
+			sb.WriteString(".*")
+		case c == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if escaped {
+		return nil, errEvaluation("LIKE pattern %q ends with the escape character", pattern)
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
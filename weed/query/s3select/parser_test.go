@@ -0,0 +1,111 @@
+package s3select
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapRecord map[string]Value
+
+func (m mapRecord) Value(path []pathElement) Value {
+	if v, found := m[path[0].name]; found && len(path) == 1 {
+		return v
+	}
+	return Null
+}
+
+func (m mapRecord) Columns() ([]string, []Value) {
+	return nil, nil
+}
+
+func TestEvalExpressions(t *testing.T) {
+	record := mapRecord{
+		"a": IntValue(7),
+		"b": StringValue("2.5"),
+		"s": StringValue("Hello World"),
+		"t": BoolValue(true),
+		"n": Null,
+	}
+	tests := []struct {
+		sql      string
+		expected Value
+	}{
+		{"a + 1", IntValue(8)},
+		{"a / 2", IntValue(3)},
+		{"a % 4", IntValue(3)},
+		{"a * b", FloatValue(17.5)},
+		{"-a + 10", IntValue(3)},
+		{"2 + 3 * 4", IntValue(14)},
+		{"(2 + 3) * 4", IntValue(20)},
+		{"a > b", BoolValue(true)},
+		{"a = '7'", BoolValue(true)},
+		{"a <> 7", BoolValue(false)},
+		{"n = 1", Null},
+		{"n IS NULL", BoolValue(true)},
+		{"a IS NOT NULL", BoolValue(true)},
+		{"n = 1 OR t", BoolValue(true)},
+		{"n = 1 AND t", Null},
+		{"n = 1 AND NOT t", BoolValue(false)},
+		{"a BETWEEN 1 AND 7", BoolValue(true)},
+		{"a NOT BETWEEN 1 AND 7", BoolValue(false)},
+		{"a IN (1, 2, 7)", BoolValue(true)},
+		{"s LIKE 'Hello%'", BoolValue(true)},
+		{"s LIKE 'hello%'", BoolValue(false)},
+		{"'100%' LIKE '100!%' ESCAPE '!'", BoolValue(true)},
+		{"'100x' LIKE '100!%' ESCAPE '!'", BoolValue(false)},
+		{"LOWER(s) || '!'", StringValue("hello world!")},
+		{"CHAR_LENGTH(s)", IntValue(11)},
+		{"SUBSTRING(s FROM 7)", StringValue("World")},
+		{"SUBSTRING(s, 0, 3)", StringValue("He")},
+		{"TRIM('  x ')", StringValue("x")},
+		{"COALESCE(n, a)", IntValue(7)},
+		{"NULLIF(a, 7)", Null},
+		{"CAST(b AS FLOAT)", FloatValue(2.5)},
+		{"CAST(b AS INT)", IntValue(2)},
+		{"CAST(a AS STRING)", StringValue("7")},
+		{"CAST('true' AS BOOL)", BoolValue(true)},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery("SELECT " + tt.sql + " FROM S3Object")
+		if !assert.NoError(t, err, tt.sql) {
+			continue
+		}
+		v, err := q.projections[0].expr.eval(record)
+		assert.NoError(t, err, tt.sql)
+		assert.Equal(t, tt.expected, v, tt.sql)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`select s."Name" as n, s.tags[0], count FROM s3object[*].items[*] AS s where s.x > 1 limit 10`)
+	if assert.NoError(t, err) {
+		assert.Len(t, q.projections, 3)
+		assert.Equal(t, "n", q.projections[0].outputName(0))
+		assert.Equal(t, []pathElement{{name: "Name", caseSensitive: true}}, q.projections[0].expr.(*columnExpr).path)
+		assert.Equal(t, "_2", q.projections[1].outputName(1))
+		assert.Equal(t, "count", q.projections[2].outputName(2))
+		assert.Equal(t, "s", q.fromAlias)
+		assert.Equal(t, []pathElement{{name: "items"}, {isWildcard: true}}, q.fromPath)
+		assert.Equal(t, int64(10), q.limit)
+		assert.False(t, q.IsAggregate())
+	}
+
+	for _, sql := range []string{
+		"",
+		"SELECT",
+		"SELECT * FROM",
+		"SELECT a FROM S3Object WHERE a = 'unterminated",
+		"SELECT a FROM S3Object LIMIT -1",
+		"SELECT a FROM S3Object WHERE COUNT(*) > 1",
+		"SELECT SUM(COUNT(a)) FROM S3Object",
+		"SELECT FOO(a) FROM S3Object",
+		"SELECT CAST(a AS BLOB) FROM S3Object",
+		"SELECT a FROM S3Object s JOIN S3Object t",
+		"SELECT a FROM S3Object WHERE a NOT 1",
+		"SELECT a ; FROM S3Object",
+	} {
+		_, err := ParseQuery(sql)
+		assert.Error(t, err, sql)
+	}
+}
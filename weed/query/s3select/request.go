@@ -0,0 +1,142 @@
+package s3select
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+)
+
+// SelectObjectContentRequest is the body of a SelectObjectContent request
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_SelectObjectContent.html
+type SelectObjectContentRequest struct {
+	XMLName             xml.Name            `xml:"SelectObjectContentRequest"`
+	Expression          string              `xml:"Expression"`
+	ExpressionType      string              `xml:"ExpressionType"`
+	RequestProgress     RequestProgress     `xml:"RequestProgress"`
+	InputSerialization  InputSerialization  `xml:"InputSerialization"`
+	OutputSerialization OutputSerialization `xml:"OutputSerialization"`
+	ScanRange           *ScanRange          `xml:"ScanRange"`
+}
+
+type RequestProgress struct {
+	Enabled bool `xml:"Enabled"`
+}
+
+type ScanRange struct {
+	Start *int64 `xml:"Start"`
+	End   *int64 `xml:"End"`
+}
+
+type InputSerialization struct {
+	CompressionType string        `xml:"CompressionType"`
+	CSV             *CSVInput     `xml:"CSV"`
+	JSON            *JSONInput    `xml:"JSON"`
+	Parquet         *ParquetInput `xml:"Parquet"`
+}
+
+type CSVInput struct {
+	FileHeaderInfo             string `xml:"FileHeaderInfo"`
+	Comments                   string `xml:"Comments"`
+	QuoteEscapeCharacter       string `xml:"QuoteEscapeCharacter"`
+	RecordDelimiter            string `xml:"RecordDelimiter"`
+	FieldDelimiter             string `xml:"FieldDelimiter"`
+	QuoteCharacter             string `xml:"QuoteCharacter"`
+	AllowQuotedRecordDelimiter bool   `xml:"AllowQuotedRecordDelimiter"`
+}
+
+type JSONInput struct {
+	Type string `xml:"Type"`
+}
+
+type ParquetInput struct {
+}
+
+type OutputSerialization struct {
+	CSV  *CSVOutput  `xml:"CSV"`
+	JSON *JSONOutput `xml:"JSON"`
+}
+
+type CSVOutput struct {
+	QuoteFields          string `xml:"QuoteFields"`
+	QuoteEscapeCharacter string `xml:"QuoteEscapeCharacter"`
+	RecordDelimiter      string `xml:"RecordDelimiter"`
+	FieldDelimiter       string `xml:"FieldDelimiter"`
+	QuoteCharacter       string `xml:"QuoteCharacter"`
+}
+
+type JSONOutput struct {
+	RecordDelimiter string `xml:"RecordDelimiter"`
+}
+
+// Validate checks the request, before the object is read
+func (req *SelectObjectContentRequest) Validate() error {
+	if strings.TrimSpace(req.Expression) == "" {
+		return errRequest("MissingRequiredParameter", "the Expression is missing")
+	}
+	if !strings.EqualFold(req.ExpressionType, "SQL") {
+		return errRequest("InvalidExpressionType", "the ExpressionType %q is not supported", req.ExpressionType)
+	}
+	if req.ScanRange != nil {
+		return &SelectError{Code: "NotImplemented", Message: "ScanRange is not supported", HTTPStatusCode: http.StatusNotImplemented}
+	}
+
+	in := req.InputSerialization
+	inputs := 0
+	for _, present := range []bool{in.CSV != nil, in.JSON != nil, in.Parquet != nil} {
+		if present {
+			inputs++
+		}
+	}
+	if inputs != 1 {
+		return errRequest("InvalidDataSource", "exactly one of CSV, JSON or Parquet input is expected")
+	}
+	switch strings.ToUpper(in.CompressionType) {
+	case "", "NONE":
+	case "GZIP", "BZIP2":
+		if in.Parquet != nil {
+			return errRequest("InvalidCompressionFormat", "Parquet objects can not be compressed as a whole")
+		}
+	default:
+		return errRequest("InvalidCompressionFormat", "the CompressionType %q is not supported", in.CompressionType)
+	}
+	if in.CSV != nil {
+		switch strings.ToUpper(in.CSV.FileHeaderInfo) {
+		case "", "NONE", "USE", "IGNORE":
+		default:
+			return errRequest("InvalidFileHeaderInfo", "the FileHeaderInfo %q is not supported", in.CSV.FileHeaderInfo)
+		}
+		for _, c := range []string{in.CSV.FieldDelimiter, in.CSV.QuoteCharacter, in.CSV.QuoteEscapeCharacter, in.CSV.Comments} {
+			if len(c) > 1 {
+				return errRequest("InvalidRequestParameter", "the character %q is not a single byte", c)
+			}
+		}
+		if len(in.CSV.RecordDelimiter) > 2 {
+			return errRequest("InvalidRequestParameter", "the RecordDelimiter %q is longer than 2 characters", in.CSV.RecordDelimiter)
+		}
+	}
+	if in.JSON != nil {
+		switch strings.ToUpper(in.JSON.Type) {
+		case "DOCUMENT", "LINES":
+		default:
+			return errRequest("InvalidJsonType", "the JSON Type %q is not supported", in.JSON.Type)
+		}
+	}
+
+	out := req.OutputSerialization
+	if (out.CSV == nil) == (out.JSON == nil) {
+		return errRequest("InvalidRequestParameter", "exactly one of CSV or JSON output is expected")
+	}
+	if out.CSV != nil {
+		switch strings.ToUpper(out.CSV.QuoteFields) {
+		case "", "ASNEEDED", "ALWAYS":
+		default:
+			return errRequest("InvalidQuoteFields", "the QuoteFields %q is not supported", out.CSV.QuoteFields)
+		}
+		for _, c := range []string{out.CSV.FieldDelimiter, out.CSV.QuoteCharacter, out.CSV.QuoteEscapeCharacter} {
+			if len(c) > 1 {
+				return errRequest("InvalidRequestParameter", "the character %q is not a single byte", c)
+			}
+		}
+	}
+	return nil
+}
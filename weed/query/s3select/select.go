@@ -0,0 +1,259 @@
+package s3select
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	recordsChunkSize  = 128 * 1024
+	keepAliveInterval = 2 * time.Second
+)
+
+// RecordReader reads the records of the queried object, until io.EOF
+type RecordReader interface {
+	Read() (Record, error)
+}
+
+// Selector runs a SelectObjectContent request over one object
+type Selector struct {
+	request *SelectObjectContentRequest
+	query   *Query
+
+	bytesScanned   atomic.Int64
+	bytesProcessed atomic.Int64
+	bytesReturned  int64
+}
+
+// NewSelector validates the request and parses its SQL expression
+func NewSelector(req *SelectObjectContentRequest) (*Selector, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	query, err := ParseQuery(req.Expression)
+	if err != nil {
+		return nil, err
+	}
+	if req.InputSerialization.JSON == nil && len(query.fromPath) > 0 {
+		return nil, errUnsupported("FROM S3Object[*] paths are only supported on JSON objects")
+	}
+	return &Selector{request: req, query: query}, nil
+}
+
+// IsParquet tells whether the object is read with OpenReaderAt, instead of OpenReader
+func (s *Selector) IsParquet() bool {
+	return s.request.InputSerialization.Parquet != nil
+}
+
+// OpenReader reads CSV or JSON records from the object content
+func (s *Selector) OpenReader(r io.Reader) (RecordReader, error) {
+	in := s.request.InputSerialization
+	r = &countingReader{Reader: r, count: &s.bytesScanned}
+	switch strings.ToUpper(in.CompressionType) {
+	case "GZIP":
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, newError("InvalidCompressionFormat", "the object is not in GZIP format: %v", err)
+		}
+		r = gz
+	case "BZIP2":
+		r = bzip2.NewReader(r)
+	}
+	r = &countingReader{Reader: r, count: &s.bytesProcessed}
+	if in.CSV != nil {
+		return newCsvReader(r, in.CSV)
+	}
+	return newJsonReader(r, s.query.fromPath), nil
+}
+
+// OpenReaderAt reads Parquet records from the object
+func (s *Selector) OpenReaderAt(r io.ReaderAt, size int64) (RecordReader, error) {
+	return newParquetReader(&countingReaderAt{ReaderAt: r, count: &s.bytesScanned}, size)
+}
+
+// Run evaluates the query over the records, and writes the response event stream.
+// Failures of the query are written as an error event, and only write errors are returned.
+func (s *Selector) Run(w io.Writer, records RecordReader) error {
+	stream := &eventWriter{w: w}
+	if err := s.run(stream, records); err != nil {
+		var selectErr *SelectError
+		if !errors.As(err, &selectErr) {
+			selectErr = errInternal(err)
+		}
+		stream.write(errorMessage(selectErr.Code, selectErr.Message))
+		return stream.err
+	}
+	stream.write(s.statsMessage("Stats"))
+	stream.write(endMessage())
+	return stream.err
+}
+
+// WriteRecords evaluates the query over the records, and writes the resulting records without any framing
+func (s *Selector) WriteRecords(w io.Writer, records RecordReader) error {
+	stream := &eventWriter{w: w, raw: true}
+	if err := s.run(stream, records); err != nil {
+		return err
+	}
+	return stream.err
+}
+
+func (s *Selector) run(stream *eventWriter, records RecordReader) error {
+	q := s.query
+	writer := newRecordWriter(s.request.OutputSerialization)
+	var names []string
+	if !q.selectAll {
+		for i, proj := range q.projections {
+			names = append(names, proj.outputName(i))
+		}
+	}
+	values := make([]Value, len(q.projections))
+
+	var buf []byte
+	flush := func() {
+		if len(buf) > 0 {
+			stream.writeRecords(buf)
+			s.bytesReturned += int64(len(buf))
+			buf = buf[:0]
+		}
+	}
+	// the records matched before a failure are still returned
+	defer flush()
+
+	lastSent := time.Now()
+	for count, rows := int64(0), 0; q.limit < 0 || count < q.limit; rows++ {
+		if stream.err != nil {
+			return nil
+		}
+		if rows%1000 == 0 && time.Since(lastSent) > keepAliveInterval {
+			if s.request.RequestProgress.Enabled {
+				stream.write(s.statsMessage("Progress"))
+			} else {
+				stream.write(continuationMessage())
+			}
+			lastSent = time.Now()
+		}
+
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if q.where != nil {
+			matched, err := truth(q.where, record)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				continue
+			}
+		}
+		count++
+
+		if q.IsAggregate() {
+			for _, ag := range q.aggregates {
+				if err := ag.accumulate(record); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if q.selectAll {
+			columns, columnValues := record.Columns()
+			buf = writer.appendRecord(buf, columns, columnValues)
+		} else {
+			for i, proj := range q.projections {
+				if values[i], err = proj.expr.eval(record); err != nil {
+					return err
+				}
+			}
+			buf = writer.appendRecord(buf, names, values)
+		}
+		if len(buf) >= recordsChunkSize {
+			flush()
+			lastSent = time.Now()
+		}
+	}
+
+	if q.IsAggregate() {
+		for i, proj := range q.projections {
+			var err error
+			if values[i], err = proj.expr.eval(nil); err != nil {
+				return err
+			}
+		}
+		buf = writer.appendRecord(buf, names, values)
+	}
+	flush()
+	if s.request.RequestProgress.Enabled {
+		stream.write(s.statsMessage("Progress"))
+	}
+	return nil
+}
+
+func (s *Selector) statsMessage(eventType string) []byte {
+	scanned := s.bytesScanned.Load()
+	processed := s.bytesProcessed.Load()
+	if s.IsParquet() {
+		processed = scanned
+	}
+	return statsMessage(eventType, scanned, processed, s.bytesReturned)
+}
+
+// eventWriter keeps the first write error, and flushes each message to the client.
+// In raw mode only the records are written, as is.
+type eventWriter struct {
+	w   io.Writer
+	raw bool
+	err error
+}
+
+func (e *eventWriter) writeRecords(buf []byte) {
+	if e.raw {
+		if e.err == nil {
+			_, e.err = e.w.Write(buf)
+		}
+		return
+	}
+	e.write(recordsMessage(buf))
+}
+
+func (e *eventWriter) write(msg []byte) {
+	if e.err != nil || e.raw {
+		return
+	}
+	if _, e.err = e.w.Write(msg); e.err == nil {
+		if flusher, ok := e.w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	count *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+type countingReaderAt struct {
+	io.ReaderAt
+	count *atomic.Int64
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.ReaderAt.ReadAt(p, off)
+	c.count.Add(int64(n))
+	return n, err
+}
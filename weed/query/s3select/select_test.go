@@ -0,0 +1,259 @@
+package s3select
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type decodedMessage struct {
+	headers map[string]string
+	payload []byte
+}
+
+func decodeMessages(t *testing.T, data []byte) (messages []decodedMessage) {
+	for len(data) > 0 {
+		if !assert.GreaterOrEqual(t, len(data), 16) {
+			return
+		}
+		totalLength := int(binary.BigEndian.Uint32(data[0:4]))
+		headersLength := int(binary.BigEndian.Uint32(data[4:8]))
+		assert.Equal(t, crc32.ChecksumIEEE(data[:8]), binary.BigEndian.Uint32(data[8:12]))
+		assert.Equal(t, crc32.ChecksumIEEE(data[:totalLength-4]), binary.BigEndian.Uint32(data[totalLength-4:totalLength]))
+
+		msg := decodedMessage{headers: make(map[string]string)}
+		headers := data[12 : 12+headersLength]
+		for len(headers) > 0 {
+			nameLength := int(headers[0])
+			name := string(headers[1 : 1+nameLength])
+			assert.Equal(t, byte(headerValueTypeString), headers[1+nameLength])
+			valueLength := int(binary.BigEndian.Uint16(headers[2+nameLength:]))
+			msg.headers[name] = string(headers[4+nameLength : 4+nameLength+valueLength])
+			headers = headers[4+nameLength+valueLength:]
+		}
+		msg.payload = data[12+headersLength : totalLength-4]
+		messages = append(messages, msg)
+		data = data[totalLength:]
+	}
+	return
+}
+
+// runSelect returns the records, and the error code if the query failed
+func runSelect(t *testing.T, req *SelectObjectContentRequest, content []byte) (string, string) {
+	selector, err := NewSelector(req)
+	if err != nil {
+		return "", err.(*SelectError).Code
+	}
+	var records RecordReader
+	if selector.IsParquet() {
+		records, err = selector.OpenReaderAt(bytes.NewReader(content), int64(len(content)))
+	} else {
+		records, err = selector.OpenReader(bytes.NewReader(content))
+	}
+	if err != nil {
+		return "", err.(*SelectError).Code
+	}
+	var out bytes.Buffer
+	assert.NoError(t, selector.Run(&out, records))
+
+	var result strings.Builder
+	for _, msg := range decodeMessages(t, out.Bytes()) {
+		if msg.headers[":message-type"] == "error" {
+			return result.String(), msg.headers[":error-code"]
+		}
+		switch msg.headers[":event-type"] {
+		case "Records":
+			result.Write(msg.payload)
+		case "Stats":
+			assert.Contains(t, string(msg.payload), "<BytesScanned>")
+		}
+	}
+	return result.String(), ""
+}
+
+func csvRequest(sql, headerInfo string) *SelectObjectContentRequest {
+	return &SelectObjectContentRequest{
+		Expression:          sql,
+		ExpressionType:      "SQL",
+		InputSerialization:  InputSerialization{CSV: &CSVInput{FileHeaderInfo: headerInfo}},
+		OutputSerialization: OutputSerialization{CSV: &CSVOutput{}},
+	}
+}
+
+func jsonRequest(sql string) *SelectObjectContentRequest {
+	return &SelectObjectContentRequest{
+		Expression:          sql,
+		ExpressionType:      "SQL",
+		InputSerialization:  InputSerialization{JSON: &JSONInput{Type: "LINES"}},
+		OutputSerialization: OutputSerialization{JSON: &JSONOutput{}},
+	}
+}
+
+const employees = `name,dept,salary,start
+alice,eng,120,2019-03-01
+bob,eng,95,2021-07-15
+# a comment line
+carol,"sales, west",70,2018-01-20
+"dan ""the man""",ops,,2022-11-02
+`
+
+func TestSelectCsv(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected string
+		errCode  string
+	}{
+		{sql: "SELECT * FROM S3Object", expected: "alice,eng,120,2019-03-01\nbob,eng,95,2021-07-15\ncarol,\"sales, west\",70,2018-01-20\n\"dan \"\"the man\"\"\",ops,,2022-11-02\n"},
+		{sql: "SELECT name FROM S3Object WHERE salary > 90", expected: "alice\nbob\n"},
+		{sql: "SELECT s.name, s.salary FROM S3Object s WHERE s.dept = 'eng' AND s.salary < 100", expected: "bob,95\n"},
+		{sql: `SELECT "NAME" FROM S3Object`, expected: "\n\n\n\n"},
+		{sql: "SELECT _1 FROM S3Object WHERE dept = 'ops' OR salary = 70", expected: "carol\n\"dan \"\"the man\"\"\"\n"},
+		{sql: "SELECT name FROM S3Object WHERE salary IS NULL OR salary = ''", expected: "\"dan \"\"the man\"\"\"\n"},
+		{sql: "SELECT name FROM S3Object WHERE name LIKE '_a%' AND NOT dept IN ('eng')", expected: "carol\n\"dan \"\"the man\"\"\"\n"},
+		{sql: "SELECT name FROM S3Object WHERE CAST(start AS TIMESTAMP) BETWEEN CAST('2019-01-01' AS TIMESTAMP) AND CAST('2021-12-31' AS TIMESTAMP)", expected: "alice\nbob\n"},
+		{sql: "SELECT UPPER(name), salary * 2 + 1 FROM S3Object LIMIT 2", expected: "ALICE,241\nBOB,191\n"},
+		{sql: "SELECT COUNT(*), SUM(salary), AVG(salary), MIN(name), MAX(CAST(salary AS INT)) FROM S3Object WHERE dept <> 'ops'", expected: "3,285,95,alice,120\n"},
+		{sql: "SELECT COUNT(salary), COUNT(NULLIF(salary, '')) FROM S3Object", expected: "4,3\n"},
+		{sql: "SELECT name FROM S3Object LIMIT 0", expected: ""},
+		{sql: "SELECT salary / 0 FROM S3Object", errCode: "EvaluatorDivisionByZero"},
+		{sql: "SELECT CAST(name AS INT) FROM S3Object", errCode: "CastFailed"},
+		{sql: "SELECT name, COUNT(*) FROM S3Object", errCode: "ParseUnsupportedSyntax"},
+		{sql: "SELECT name FROM S3Object ORDER BY name", errCode: "ParseUnsupportedSyntax"},
+		{sql: "SELECT name FROM S3Object WHERE", errCode: "ParseSelectFailure"},
+		{sql: "SELECT name FROM table", errCode: "ParseSelectFailure"},
+	}
+	for _, tt := range tests {
+		result, errCode := runSelect(t, csvRequest(tt.sql, "USE"), []byte(employees))
+		assert.Equal(t, tt.errCode, errCode, tt.sql)
+		if tt.errCode == "" {
+			assert.Equal(t, tt.expected, result, tt.sql)
+		}
+	}
+}
+
+func TestSelectCsvOptions(t *testing.T) {
+	req := csvRequest("SELECT _2, _1 FROM S3Object WHERE _3 = '1'", "NONE")
+	req.InputSerialization.CSV.FieldDelimiter = ";"
+	req.InputSerialization.CSV.RecordDelimiter = "\r\n"
+	req.InputSerialization.CSV.AllowQuotedRecordDelimiter = true
+	req.OutputSerialization.CSV = &CSVOutput{QuoteFields: "ALWAYS", FieldDelimiter: "\t", RecordDelimiter: "|"}
+	result, errCode := runSelect(t, req, []byte("a;\"multi\r\nline\";1\r\nb;x;2\r\nc;y;1"))
+	assert.Empty(t, errCode)
+	assert.Equal(t, "\"multi\r\nline\"\t\"a\"|\"y\"\t\"c\"|", result)
+
+	// the header line is skipped, but the columns are only positional
+	result, errCode = runSelect(t, csvRequest("SELECT _1 FROM S3Object WHERE name = 'bob' OR _1 = 'bob'", "IGNORE"), []byte(employees))
+	assert.Empty(t, errCode)
+	assert.Equal(t, "bob\n", result)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(employees))
+	gz.Close()
+	req = csvRequest("SELECT COUNT(*) FROM S3Object", "USE")
+	req.InputSerialization.CompressionType = "GZIP"
+	result, errCode = runSelect(t, req, compressed.Bytes())
+	assert.Empty(t, errCode)
+	assert.Equal(t, "4\n", result)
+
+	_, errCode = runSelect(t, req, []byte(employees))
+	assert.Equal(t, "InvalidCompressionFormat", errCode)
+}
+
+func TestSelectJson(t *testing.T) {
+	lines := `{"id": 1, "user": {"name": "alice", "tags": ["admin", "dev"]}, "score": 9.5, "active": true}
+{"id": 2, "user": {"name": "bob", "tags": []}, "score": 7, "active": false}
+{"id": 3, "user": {"name": "carol"}, "score": null}
+`
+	tests := []struct {
+		sql      string
+		expected string
+		errCode  string
+	}{
+		{sql: "SELECT * FROM S3Object s WHERE s.id = 2", expected: `{"id":2,"user":{"name": "bob", "tags": []},"score":7,"active":false}` + "\n"},
+		{sql: "SELECT s.user.name AS who, s.score FROM S3Object s WHERE s.active", expected: `{"who":"alice","score":9.5}` + "\n"},
+		{sql: "SELECT s.user.tags[1] FROM S3Object[*] s WHERE s.user.tags[0] = 'admin'", expected: `{"_1":"dev"}` + "\n"},
+		{sql: "SELECT s.id FROM S3Object s WHERE s.score IS NULL", expected: `{"id":3}` + "\n"},
+		{sql: "SELECT s.id FROM S3Object s WHERE s.\"ID\" = 1", expected: ""},
+		{sql: "SELECT s.id FROM S3Object s WHERE s.ID = 1", expected: `{"id":1}` + "\n"},
+		{sql: "SELECT COUNT(*) AS n, MAX(s.score) AS best, SUM(s.id) FROM S3Object s", expected: `{"n":3,"best":9.5,"_3":6}` + "\n"},
+		{sql: "SELECT s.id FROM S3Object s WHERE s.id > 1 AND (s.active OR s.score IS NULL)", expected: `{"id":3}` + "\n"},
+	}
+	for _, tt := range tests {
+		result, errCode := runSelect(t, jsonRequest(tt.sql), []byte(lines))
+		assert.Equal(t, tt.errCode, errCode, tt.sql)
+		assert.Equal(t, tt.expected, result, tt.sql)
+	}
+
+	req := jsonRequest("SELECT i.sku FROM S3Object[*].items[*] i WHERE i.qty >= 2")
+	req.InputSerialization.JSON.Type = "DOCUMENT"
+	result, errCode := runSelect(t, req, []byte(`{"items": [{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]} {"items": [{"sku": "c", "qty": 3}]}`))
+	assert.Empty(t, errCode)
+	assert.Equal(t, "{\"sku\":\"b\"}\n{\"sku\":\"c\"}\n", result)
+
+	result, errCode = runSelect(t, jsonRequest("SELECT s.id FROM S3Object s"), []byte("{\"id\": 1}\n{\"id\": "))
+	assert.Equal(t, "JSONParsingError", errCode)
+	assert.Equal(t, `{"id":1}`+"\n", result)
+}
+
+func TestSelectParquet(t *testing.T) {
+	type row struct {
+		City       string  `parquet:"city"`
+		Population int64   `parquet:"population"`
+		Area       float64 `parquet:"area"`
+	}
+	var content bytes.Buffer
+	assert.NoError(t, parquet.Write(&content, []row{
+		{City: "Oslo", Population: 709000, Area: 454},
+		{City: "Bergen", Population: 286000, Area: 465},
+		{City: "Trondheim", Population: 212000, Area: 342},
+	}))
+
+	req := &SelectObjectContentRequest{
+		Expression:          "SELECT * FROM S3Object WHERE population > 250000",
+		ExpressionType:      "SQL",
+		InputSerialization:  InputSerialization{Parquet: &ParquetInput{}},
+		OutputSerialization: OutputSerialization{JSON: &JSONOutput{RecordDelimiter: ","}},
+	}
+	result, errCode := runSelect(t, req, content.Bytes())
+	assert.Empty(t, errCode)
+	assert.Equal(t, `{"city":"Oslo","population":709000,"area":454},{"city":"Bergen","population":286000,"area":465},`, result)
+
+	req.Expression = "SELECT SUM(population), MIN(area) FROM S3Object WHERE city LIKE '%e%'"
+	req.OutputSerialization = OutputSerialization{CSV: &CSVOutput{}}
+	result, errCode = runSelect(t, req, content.Bytes())
+	assert.Empty(t, errCode)
+	assert.Equal(t, "498000,342\n", result)
+
+	_, errCode = runSelect(t, req, []byte("not a parquet file"))
+	assert.Equal(t, "InvalidParquetFile", errCode)
+}
+
+func TestValidateRequest(t *testing.T) {
+	req := csvRequest("SELECT * FROM S3Object", "")
+	req.ExpressionType = "XPATH"
+	assert.Error(t, req.Validate())
+
+	req = csvRequest("SELECT * FROM S3Object", "")
+	req.InputSerialization.JSON = &JSONInput{Type: "LINES"}
+	assert.Error(t, req.Validate())
+
+	req = csvRequest("SELECT * FROM S3Object", "")
+	req.OutputSerialization.JSON = &JSONOutput{}
+	assert.Error(t, req.Validate())
+
+	req = csvRequest("SELECT * FROM S3Object", "FIRST")
+	assert.Error(t, req.Validate())
+
+	req = csvRequest("SELECT * FROM S3Object", "USE")
+	req.InputSerialization.CompressionType = "ZSTD"
+	assert.Error(t, req.Validate())
+
+	assert.NoError(t, csvRequest("SELECT * FROM S3Object", "USE").Validate())
+}
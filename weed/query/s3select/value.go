@@ -0,0 +1,231 @@
+package s3select
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Kind int
+
+const (
+	KindNull Kind = iota
+	KindBool
+	KindInt
+	KindFloat
+	KindString
+	KindTimestamp
+	// KindObject is a nested JSON or Parquet value, kept as JSON text
+	KindObject
+)
+
+// Value is the value of a column or of an expression
+type Value struct {
+	kind Kind
+	b    bool
+	i    int64
+	f    float64
+	s    string
+	t    time.Time
+}
+
+var Null = Value{}
+
+func BoolValue(b bool) Value           { return Value{kind: KindBool, b: b} }
+func IntValue(i int64) Value           { return Value{kind: KindInt, i: i} }
+func FloatValue(f float64) Value       { return Value{kind: KindFloat, f: f} }
+func StringValue(s string) Value       { return Value{kind: KindString, s: s} }
+func TimestampValue(t time.Time) Value { return Value{kind: KindTimestamp, t: t} }
+func ObjectValue(raw string) Value     { return Value{kind: KindObject, s: raw} }
+
+func (v Value) Kind() Kind     { return v.kind }
+func (v Value) IsNull() bool   { return v.kind == KindNull }
+func (v Value) isNumber() bool { return v.kind == KindInt || v.kind == KindFloat }
+
+func (v Value) float() float64 {
+	if v.kind == KindInt {
+		return float64(v.i)
+	}
+	return v.f
+}
+
+// toNumber converts strings holding numbers, as CSV columns are always strings
+func (v Value) toNumber() (Value, bool) {
+	switch v.kind {
+	case KindInt, KindFloat:
+		return v, true
+	case KindString:
+		s := strings.TrimSpace(v.s)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return IntValue(i), true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return FloatValue(f), true
+		}
+	}
+	return Null, false
+}
+
+func (v Value) toBool() (bool, bool) {
+	switch v.kind {
+	case KindBool:
+		return v.b, true
+	case KindString:
+		b, err := strconv.ParseBool(strings.TrimSpace(v.s))
+		return b, err == nil
+	}
+	return false, false
+}
+
+func (v Value) toTimestamp() (time.Time, bool) {
+	switch v.kind {
+	case KindTimestamp:
+		return v.t, true
+	case KindString:
+		return parseTimestamp(strings.TrimSpace(v.s))
+	}
+	return time.Time{}, false
+}
+
+func parseTimestamp(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02", "2006-01T", "2006T"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// String formats the value as written in CSV output
+func (v Value) String() string {
+	switch v.kind {
+	case KindBool:
+		return strconv.FormatBool(v.b)
+	case KindInt:
+		return strconv.FormatInt(v.i, 10)
+	case KindFloat:
+		return strconv.FormatFloat(v.f, 'f', -1, 64)
+	case KindString, KindObject:
+		return v.s
+	case KindTimestamp:
+		return v.t.Format(time.RFC3339Nano)
+	}
+	return ""
+}
+
+// appendJson appends the value as JSON
+func (v Value) appendJson(buf []byte) []byte {
+	switch v.kind {
+	case KindNull:
+		return append(buf, "null"...)
+	case KindFloat:
+		if math.IsInf(v.f, 0) || math.IsNaN(v.f) {
+			return append(buf, "null"...)
+		}
+	case KindString, KindTimestamp:
+		data, _ := json.Marshal(v.String())
+		return append(buf, data...)
+	}
+	return append(buf, v.String()...)
+}
+
+// valueOf converts values decoded from JSON or Parquet
+func valueOf(x interface{}) Value {
+	switch x := x.(type) {
+	case nil:
+		return Null
+	case bool:
+		return BoolValue(x)
+	case int:
+		return IntValue(int64(x))
+	case int8:
+		return IntValue(int64(x))
+	case int16:
+		return IntValue(int64(x))
+	case int32:
+		return IntValue(int64(x))
+	case int64:
+		return IntValue(x)
+	case uint8:
+		return IntValue(int64(x))
+	case uint16:
+		return IntValue(int64(x))
+	case uint32:
+		return IntValue(int64(x))
+	case uint64:
+		if x > math.MaxInt64 {
+			return FloatValue(float64(x))
+		}
+		return IntValue(int64(x))
+	case float32:
+		return FloatValue(float64(x))
+	case float64:
+		return FloatValue(x)
+	case string:
+		return StringValue(x)
+	case []byte:
+		return StringValue(string(x))
+	case time.Time:
+		return TimestampValue(x)
+	}
+	data, err := json.Marshal(x)
+	if err != nil {
+		return StringValue(fmt.Sprint(x))
+	}
+	return ObjectValue(string(data))
+}
+
+// compareValues orders two values, comparing numbers and timestamps across
+// their string representations. It is not comparable if either is null.
+func compareValues(a, b Value) (int, bool) {
+	if a.IsNull() || b.IsNull() {
+		return 0, false
+	}
+	if a.isNumber() || b.isNumber() {
+		x, okA := a.toNumber()
+		y, okB := b.toNumber()
+		if !okA || !okB {
+			return 0, false
+		}
+		if x.kind == KindInt && y.kind == KindInt {
+			return compareOrdered(x.i, y.i), true
+		}
+		return compareOrdered(x.float(), y.float()), true
+	}
+	if a.kind == KindTimestamp || b.kind == KindTimestamp {
+		x, okA := a.toTimestamp()
+		y, okB := b.toTimestamp()
+		if !okA || !okB {
+			return 0, false
+		}
+		return x.Compare(y), true
+	}
+	if a.kind == KindBool || b.kind == KindBool {
+		x, okA := a.toBool()
+		y, okB := b.toBool()
+		if !okA || !okB {
+			return 0, false
+		}
+		switch {
+		case x == y:
+			return 0, true
+		case !x:
+			return -1, true
+		}
+		return 1, true
+	}
+	return strings.Compare(a.s, b.s), true
+}
+
+func compareOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -120,7 +120,7 @@ func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry, signatures [
 				glog.V(3).Infof("already replicated %s", key)
 				return nil
 			}
-			if resp.Entry.Attributes != nil && resp.Entry.Attributes.Mtime >= entry.Attributes.Mtime {
+			if resp.Entry.Attributes != nil && !resp.Entry.Attributes.ModTime().Before(entry.Attributes.ModTime()) {
 				glog.V(3).Infof("skip overwriting %s", key)
 				return nil
 			}
@@ -192,7 +192,7 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry *filer_pb.Entry, newParent
 
 	glog.V(4).Infof("oldEntry %+v, newEntry %+v, existingEntry: %+v", oldEntry, newEntry, existingEntry)
 
-	if existingEntry.Attributes.Mtime > newEntry.Attributes.Mtime {
+	if existingEntry.Attributes.ModTime().After(newEntry.Attributes.ModTime()) {
 		// skip if already changed
 		// this usually happens when the messages are not ordered
 		glog.V(2).Infof("late updates %s", key)
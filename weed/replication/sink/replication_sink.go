@@ -1,6 +1,9 @@
 package sink
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/replication/source"
 	"github.com/seaweedfs/seaweedfs/weed/util"
@@ -20,3 +23,18 @@ type ReplicationSink interface {
 var (
 	Sinks []ReplicationSink
 )
+
+// NewSink creates and initializes another sink of the type, for the configurations with several sinks of the same type
+func NewSink(sinkType string, configuration util.Configuration, prefix string) (ReplicationSink, error) {
+	for _, s := range Sinks {
+		if s.GetName() != sinkType {
+			continue
+		}
+		newSink := reflect.New(reflect.TypeOf(s).Elem()).Interface().(ReplicationSink)
+		if err := newSink.Initialize(configuration, prefix); err != nil {
+			return nil, fmt.Errorf("initialize %s sink: %v", sinkType, err)
+		}
+		return newSink, nil
+	}
+	return nil, fmt.Errorf("unknown sink type %s", sinkType)
+}
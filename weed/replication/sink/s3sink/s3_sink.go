@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -186,21 +187,30 @@ func (s3sink *S3Sink) CreateEntry(key string, entry *filer_pb.Entry, signatures
 	if doSaveMtime {
 		entry.Extended[s3_constants.AmzUserMetaMtime] = []byte(strconv.FormatInt(entry.Attributes.Mtime, 10))
 	}
-	// process tagging
-	tags := ""
+	// process tagging and the user metadata, the other extended attributes are kept by the filers only
+	tags := url.Values{}
+	metadata := make(map[string]*string)
 	for k, v := range entry.Extended {
-		if len(tags) > 0 {
-			tags = tags + "&"
+		if tag, found := strings.CutPrefix(k, s3_constants.AmzObjectTaggingPrefix); found {
+			tags.Set(tag, string(v))
+		} else if name, found := strings.CutPrefix(k, s3_constants.AmzUserMetaPrefix); found {
+			metadata[name] = aws.String(string(v))
 		}
-		tags = tags + k + "=" + string(v)
 	}
 
 	// Upload the file to S3.
 	uploadInput := s3manager.UploadInput{
-		Bucket:  aws.String(s3sink.bucket),
-		Key:     aws.String(key),
-		Body:    reader,
-		Tagging: aws.String(tags),
+		Bucket:   aws.String(s3sink.bucket),
+		Key:      aws.String(key),
+		Body:     reader,
+		Tagging:  aws.String(tags.Encode()),
+		Metadata: metadata,
+	}
+	if entry.Attributes.Mime != "" {
+		uploadInput.ContentType = aws.String(entry.Attributes.Mime)
+	}
+	if storageClass := string(entry.Extended[s3_constants.AmzStorageClass]); storageClass != "" {
+		uploadInput.StorageClass = aws.String(storageClass)
 	}
 	if len(entry.Attributes.Md5) > 0 {
 		uploadInput.ContentMD5 = aws.String(base64.StdEncoding.EncodeToString([]byte(entry.Attributes.Md5)))
@@ -14,6 +14,9 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
 type Action string
@@ -35,6 +38,12 @@ type IdentityAccessManagement struct {
 	hashMu            sync.RWMutex
 	domain            string
 	isAuthEnabled     bool
+
+	// bucketRegistry provides the bucket policies, it is nil if bucket policies are not evaluated
+	bucketRegistry *BucketRegistry
+
+	// sessionSigningKey verifies the session tokens of temporary credentials
+	sessionSigningKey security.SigningKey
 }
 
 type Identity struct {
@@ -42,6 +51,8 @@ type Identity struct {
 	Account     *Account
 	Credentials []*Credential
 	Actions     []Action
+	// Policies are the managed policies attached to the identity, directly or through its groups
+	Policies []*s3policy.PolicyDocument
 }
 
 // Account represents a system user, a system user can
@@ -115,9 +126,10 @@ func (action Action) getPermission() Permission {
 
 func NewIdentityAccessManagement(option *S3ApiServerOption) *IdentityAccessManagement {
 	iam := &IdentityAccessManagement{
-		domain:       option.DomainName,
-		hashes:       make(map[string]*sync.Pool),
-		hashCounters: make(map[string]*int32),
+		domain:            option.DomainName,
+		hashes:            make(map[string]*sync.Pool),
+		hashCounters:      make(map[string]*int32),
+		sessionSigningKey: security.SigningKey(util.GetViper().GetString("jwt.sts.key")),
 	}
 
 	if option.Config != "" {
@@ -221,6 +233,21 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(config *iam_pb.S3Api
 		accounts[AccountAnonymous.Id] = &AccountAnonymous
 		emailAccount[AccountAnonymous.EmailAddress] = &AccountAnonymous
 	}
+	policies := make(map[string]*s3policy.PolicyDocument)
+	for _, policy := range config.Policies {
+		document, err := s3policy.ParseIdentityPolicy([]byte(policy.Content))
+		if err != nil {
+			glog.Warningf("policy %s is ignored: %v", policy.Name, err)
+			continue
+		}
+		policies[policy.Name] = document
+	}
+	memberPolicyNames := make(map[string][]string)
+	for _, group := range config.Groups {
+		for _, member := range group.Members {
+			memberPolicyNames[member] = append(memberPolicyNames[member], group.PolicyNames...)
+		}
+	}
 	for _, ident := range config.Identities {
 		glog.V(3).Infof("loading identity %s", ident.Name)
 		t := &Identity{
@@ -246,6 +273,18 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(config *iam_pb.S3Api
 		for _, action := range ident.Actions {
 			t.Actions = append(t.Actions, Action(action))
 		}
+		attached := make(map[string]bool)
+		for _, policyName := range append(ident.PolicyNames, memberPolicyNames[ident.Name]...) {
+			if attached[policyName] {
+				continue
+			}
+			attached[policyName] = true
+			if policy, found := policies[policyName]; found {
+				t.Policies = append(t.Policies, policy)
+			} else {
+				glog.Warningf("identity %s is attached to a non exist policy %s", ident.Name, policyName)
+			}
+		}
 		for _, cred := range ident.Credentials {
 			t.Credentials = append(t.Credentials, &Credential{
 				AccessKey: cred.AccessKey,
@@ -289,6 +328,17 @@ func (iam *IdentityAccessManagement) lookupByAccessKey(accessKey string) (identi
 	return nil, nil, false
 }
 
+func (iam *IdentityAccessManagement) lookupByName(name string) (identity *Identity, found bool) {
+	iam.m.RLock()
+	defer iam.m.RUnlock()
+	for _, ident := range iam.identities {
+		if ident.Name == name {
+			return ident, true
+		}
+	}
+	return nil, false
+}
+
 func (iam *IdentityAccessManagement) lookupAnonymous() (identity *Identity, found bool) {
 	iam.m.RLock()
 	defer iam.m.RUnlock()
@@ -318,6 +368,10 @@ func (iam *IdentityAccessManagement) GetAccountIdByEmail(email string) string {
 
 func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// the identity headers are only set for the authenticated identity, never taken from the client
+		r.Header.Del(s3_constants.AmzIdentityId)
+		r.Header.Del(s3_constants.AmzIsAdmin)
+
 		if !iam.isEnabled() {
 			f(w, r)
 			return
@@ -330,8 +384,6 @@ func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) htt
 				r.Header.Set(s3_constants.AmzIdentityId, identity.Name)
 				if identity.isAdmin() {
 					r.Header.Set(s3_constants.AmzIsAdmin, "true")
-				} else if _, ok := r.Header[s3_constants.AmzIsAdmin]; ok {
-					r.Header.Del(s3_constants.AmzIsAdmin)
 				}
 			}
 			f(w, r)
@@ -341,6 +393,16 @@ func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) htt
 	}
 }
 
+// Authenticate verifies the signature of the request and returns the identity signing it,
+// without checking what the identity is allowed to do
+func (iam *IdentityAccessManagement) Authenticate(r *http.Request) (*Identity, s3err.ErrorCode) {
+	identity, errCode := iam.authUser(r)
+	if errCode == s3err.ErrNone && identity == nil {
+		return nil, s3err.ErrAccessDenied
+	}
+	return identity, errCode
+}
+
 // check whether the request has valid access keys
 func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action) (*Identity, s3err.ErrorCode) {
 	var identity *Identity
@@ -356,7 +418,7 @@ func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action)
 		glog.V(3).Infof("v2 auth type")
 		identity, s3Err = iam.isReqAuthenticatedV2(r)
 		authType = "SigV2"
-	case authTypeStreamingSigned, authTypeSigned, authTypePresigned:
+	case authTypeStreamingSigned, authTypeStreamingUnsigned, authTypeSigned, authTypePresigned:
 		glog.V(3).Infof("v4 auth type")
 		identity, s3Err = iam.reqSignatureV4Verify(r)
 		authType = "SigV4"
@@ -371,8 +433,11 @@ func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action)
 	case authTypeAnonymous:
 		authType = "Anonymous"
 		if identity, found = iam.lookupAnonymous(); !found {
-			r.Header.Set(s3_constants.AmzAuthType, authType)
-			return identity, s3err.ErrAccessDenied
+			// without a configured anonymous identity, only a bucket policy can grant access
+			identity = &Identity{
+				Name:    s3_constants.AccountAnonymousId,
+				Account: &AccountAnonymous,
+			}
 		}
 	default:
 		return identity, s3err.ErrNotImplemented
@@ -387,6 +452,7 @@ func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action)
 
 	glog.V(3).Infof("user name: %v actions: %v, action: %v", identity.Name, identity.Actions, action)
 	bucket, object := s3_constants.GetBucketAndObject(r)
+	requestObject := object
 	prefix := s3_constants.GetPrefix(r)
 
 	if object == "/" && prefix != "" {
@@ -395,9 +461,33 @@ func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action)
 		object = prefix
 	}
 
-	if !identity.canDo(action, bucket, object) {
+	// an explicit deny in the policies attached to the identity or in the bucket policy overrides
+	// the identity actions, an allow extends them.
+	// Admin identities are not subject to bucket policies, so that a policy can not lock them out.
+	identityDecision := iam.evaluateIdentityPolicies(r, identity, bucket, requestObject)
+	if identityDecision == s3policy.Deny {
+		return identity, s3err.ErrAccessDenied
+	}
+	policyDecision := s3policy.NotApplicable
+	if !identity.isAdmin() {
+		policyDecision = iam.evaluateBucketPolicy(r, identity, bucket, requestObject)
+	}
+	if policyDecision == s3policy.Deny {
 		return identity, s3err.ErrAccessDenied
 	}
+	if errCode := iam.checkPublicAccessBlock(r, bucket); errCode != s3err.ErrNone {
+		return identity, errCode
+	}
+	if policyDecision != s3policy.Allow && identityDecision != s3policy.Allow {
+		// the actions of the anonymous identity act as a public acl, which the bucket may ignore
+		if identity.isAnonymous() && iam.getPublicAccessBlock(bucket).ignorePublicAcls() {
+			return identity, s3err.ErrAccessDenied
+		}
+		// the acl of the object may grant the action when the identity actions do not
+		if !identity.canDo(action, bucket, object) && !iam.isAllowedByObjectAcl(r, identity, action, bucket, requestObject) {
+			return identity, s3err.ErrAccessDenied
+		}
+	}
 
 	r.Header.Set(s3_constants.AmzAccountId, identity.Account.Id)
 
@@ -421,7 +511,7 @@ func (iam *IdentityAccessManagement) authUser(r *http.Request) (*Identity, s3err
 		glog.V(3).Infof("v2 auth type")
 		identity, s3Err = iam.isReqAuthenticatedV2(r)
 		authType = "SigV2"
-	case authTypeSigned, authTypePresigned:
+	case authTypeSigned, authTypePresigned, authTypeStreamingUnsigned:
 		glog.V(3).Infof("v4 auth type")
 		identity, s3Err = iam.reqSignatureV4Verify(r)
 		authType = "SigV4"
@@ -1,6 +1,8 @@
 package s3api
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -264,3 +266,16 @@ func TestLoadS3ApiConfiguration(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthRemovesClientIdentityHeaders(t *testing.T) {
+	iam := &IdentityAccessManagement{}
+	r := httptest.NewRequest(http.MethodGet, "/bucket1/key", nil)
+	r.Header.Set(AmzIdentityId, "noisy")
+	r.Header.Set(AmzIsAdmin, "true")
+	var scopes [][]string
+	iam.Auth(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(AmzIsAdmin))
+		scopes = limitScopes(r, "bucket1")
+	}, ACTION_READ)(httptest.NewRecorder(), r)
+	assert.Equal(t, [][]string{{"bucket1"}, nil}, scopes)
+}
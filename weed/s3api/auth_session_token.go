@@ -0,0 +1,128 @@
+package s3api
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+)
+
+// Temporary credentials are issued by the STS actions of the iam api server, and are stateless:
+// the session token is a JWT signed with the [jwt.sts] key of security.toml, naming the assumed
+// identity, and the secret key is derived from the access key with the same signing key.
+// So any s3 gateway sharing the signing key verifies them without looking up any storage,
+// and the session gets the permissions the assumed identity has at the time of the request.
+
+const (
+	sessionAccessKeyPrefix  = "ASIA"
+	sessionAccessKeyCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	sessionAccessKeyLength  = 20
+)
+
+type SessionCredentials struct {
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+// NewSessionCredentials issues temporary credentials acting as the named identity
+func NewSessionCredentials(signingKey security.SigningKey, identityName, sessionName string, duration time.Duration) (*SessionCredentials, error) {
+	if len(signingKey) == 0 {
+		return nil, fmt.Errorf("no signing key for session tokens")
+	}
+	accessKey, err := newSessionAccessKey()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Truncate(time.Second)
+	expiration := now.Add(duration)
+	claims := security.SessionClaims{
+		AccessKey: accessKey,
+		Identity:  identityName,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   sessionName,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiration),
+		},
+	}
+	sessionToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(signingKey))
+	if err != nil {
+		return nil, fmt.Errorf("sign session token: %v", err)
+	}
+	return &SessionCredentials{
+		AccessKeyId:     accessKey,
+		SecretAccessKey: security.SessionSecretKey(signingKey, accessKey),
+		SessionToken:    sessionToken,
+		Expiration:      expiration,
+	}, nil
+}
+
+func newSessionAccessKey() (string, error) {
+	key := []byte(sessionAccessKeyPrefix)
+	max := big.NewInt(int64(len(sessionAccessKeyCharset)))
+	for len(key) < sessionAccessKeyLength {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("generate access key: %v", err)
+		}
+		key = append(key, sessionAccessKeyCharset[n.Int64()])
+	}
+	return string(key), nil
+}
+
+// lookupCredential finds the identity and credential of the access key,
+// which are temporary if the request carries a session token
+func (iam *IdentityAccessManagement) lookupCredential(accessKey, sessionToken string) (*Identity, *Credential, s3err.ErrorCode) {
+	if sessionToken != "" {
+		return iam.lookupBySessionToken(accessKey, sessionToken)
+	}
+	identity, cred, found := iam.lookupByAccessKey(accessKey)
+	if !found {
+		return nil, nil, s3err.ErrInvalidAccessKeyID
+	}
+	return identity, cred, s3err.ErrNone
+}
+
+func (iam *IdentityAccessManagement) lookupBySessionToken(accessKey, sessionToken string) (*Identity, *Credential, s3err.ErrorCode) {
+	if len(iam.sessionSigningKey) == 0 {
+		glog.V(1).Infof("session token of %s is not accepted without jwt.sts.key", accessKey)
+		return nil, nil, s3err.ErrInvalidToken
+	}
+	claims := &security.SessionClaims{}
+	if _, err := security.DecodeJwt(iam.sessionSigningKey, security.EncodedJwt(sessionToken), claims); err != nil {
+		glog.V(1).Infof("session token of %s: %v", accessKey, err)
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, nil, s3err.ErrExpiredToken
+		}
+		return nil, nil, s3err.ErrInvalidToken
+	}
+	if claims.ExpiresAt == nil {
+		return nil, nil, s3err.ErrInvalidToken
+	}
+	if claims.AccessKey != accessKey {
+		return nil, nil, s3err.ErrInvalidAccessKeyID
+	}
+	ident, found := iam.lookupByName(claims.Identity)
+	if !found {
+		glog.V(1).Infof("session %s assumes unknown identity %s", accessKey, claims.Identity)
+		return nil, nil, s3err.ErrInvalidToken
+	}
+	cred := &Credential{
+		AccessKey: accessKey,
+		SecretKey: security.SessionSecretKey(iam.sessionSigningKey, accessKey),
+	}
+	return &Identity{
+		Name:        ident.Name,
+		Account:     ident.Account,
+		Credentials: []*Credential{cred},
+		Actions:     ident.Actions,
+		Policies:    ident.Policies,
+	}, cred, s3err.ErrNone
+}
@@ -0,0 +1,110 @@
+package s3api
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSessionTestIam(signingKey string) *IdentityAccessManagement {
+	iam := &IdentityAccessManagement{
+		hashes:            make(map[string]*sync.Pool),
+		hashCounters:      make(map[string]*int32),
+		sessionSigningKey: []byte(signingKey),
+	}
+	_ = iam.loadS3ApiConfiguration(&iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{
+				Name:        "ci",
+				Credentials: []*iam_pb.Credential{{AccessKey: "access_key_1", SecretKey: "secret_key_1"}},
+				Actions:     []string{s3_constants.ACTION_READ},
+			},
+		},
+	})
+	return iam
+}
+
+func TestSessionCredentialsSignedRequest(t *testing.T) {
+	iam := newSessionTestIam("sts-key")
+	credentials, err := NewSessionCredentials(iam.sessionSigningKey, "ci", "build-1", time.Hour)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, credentials.AccessKeyId, sessionAccessKeyLength)
+	assert.Len(t, credentials.SecretAccessKey, 40)
+
+	signedRequest := func(accessKey, secretKey, sessionToken string) *http.Request {
+		req := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+		if sessionToken != "" {
+			req.Header.Set(s3_constants.AmzSecurityToken, sessionToken)
+		}
+		if err := signRequestV4(req, accessKey, secretKey); err != nil {
+			t.Fatalf("sign request: %v", err)
+		}
+		return req
+	}
+
+	identity, errCode := iam.reqSignatureV4Verify(signedRequest(credentials.AccessKeyId, credentials.SecretAccessKey, credentials.SessionToken))
+	if assert.Equal(t, s3err.ErrNone, errCode) {
+		assert.Equal(t, "ci", identity.Name)
+		assert.Equal(t, []Action{s3_constants.ACTION_READ}, identity.Actions)
+	}
+
+	// the session token is required, and must belong to the access key
+	_, errCode = iam.reqSignatureV4Verify(signedRequest(credentials.AccessKeyId, credentials.SecretAccessKey, ""))
+	assert.Equal(t, s3err.ErrInvalidAccessKeyID, errCode)
+	other, _ := NewSessionCredentials(iam.sessionSigningKey, "ci", "build-2", time.Hour)
+	_, errCode = iam.reqSignatureV4Verify(signedRequest(credentials.AccessKeyId, credentials.SecretAccessKey, other.SessionToken))
+	assert.Equal(t, s3err.ErrInvalidAccessKeyID, errCode)
+
+	// the secret key is bound to the access key
+	_, errCode = iam.reqSignatureV4Verify(signedRequest(credentials.AccessKeyId, other.SecretAccessKey, credentials.SessionToken))
+	assert.Equal(t, s3err.ErrSignatureDoesNotMatch, errCode)
+
+	// tokens signed with another key are rejected
+	forged, _ := NewSessionCredentials([]byte("other-key"), "ci", "build-1", time.Hour)
+	_, errCode = iam.reqSignatureV4Verify(signedRequest(forged.AccessKeyId, forged.SecretAccessKey, forged.SessionToken))
+	assert.Equal(t, s3err.ErrInvalidToken, errCode)
+
+	expired, _ := NewSessionCredentials(iam.sessionSigningKey, "ci", "build-1", -time.Minute)
+	_, errCode = iam.reqSignatureV4Verify(signedRequest(expired.AccessKeyId, expired.SecretAccessKey, expired.SessionToken))
+	assert.Equal(t, s3err.ErrExpiredToken, errCode)
+
+	unknown, _ := NewSessionCredentials(iam.sessionSigningKey, "deleted", "build-1", time.Hour)
+	_, errCode = iam.reqSignatureV4Verify(signedRequest(unknown.AccessKeyId, unknown.SecretAccessKey, unknown.SessionToken))
+	assert.Equal(t, s3err.ErrInvalidToken, errCode)
+
+	// temporary credentials are not accepted without a signing key
+	_, errCode = newSessionTestIam("").reqSignatureV4Verify(signedRequest(credentials.AccessKeyId, credentials.SecretAccessKey, credentials.SessionToken))
+	assert.Equal(t, s3err.ErrInvalidToken, errCode)
+}
+
+func TestSessionCredentialsPresignedRequest(t *testing.T) {
+	iam := newSessionTestIam("sts-key")
+	credentials, err := NewSessionCredentials(iam.sessionSigningKey, "ci", "build-1", time.Hour)
+	if !assert.NoError(t, err) {
+		return
+	}
+	req := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+	query := req.URL.Query()
+	query.Set(s3_constants.AmzSecurityToken, credentials.SessionToken)
+	req.URL.RawQuery = query.Encode()
+	if err := preSignV4(iam, req, credentials.AccessKeyId, credentials.SecretAccessKey, 600); err != nil {
+		t.Fatalf("presign request: %v", err)
+	}
+	identity, errCode := iam.reqSignatureV4Verify(req)
+	if assert.Equal(t, s3err.ErrNone, errCode) {
+		assert.Equal(t, "ci", identity.Name)
+	}
+}
+
+func TestNewSessionCredentialsWithoutSigningKey(t *testing.T) {
+	_, err := NewSessionCredentials(nil, "ci", "build-1", time.Hour)
+	assert.Error(t, err)
+}
@@ -36,7 +36,9 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3presign"
 )
 
 func (iam *IdentityAccessManagement) reqSignatureV4Verify(r *http.Request) (*Identity, s3err.ErrorCode) {
@@ -56,6 +58,11 @@ const (
 	streamingContentSHA256 = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
 	signV4ChunkedAlgorithm = "AWS4-HMAC-SHA256-PAYLOAD"
 
+	// streaming with trailing headers, the checksums of the payload are sent after the last chunk
+	streamingContentSHA256Trailer   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	streamingUnsignedPayloadTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+	signV4ChunkedAlgorithmTrailer   = "AWS4-HMAC-SHA256-TRAILER"
+
 	// http Header "x-amz-content-sha256" == "UNSIGNED-PAYLOAD" indicates that the
 	// client did not calculate sha256 of the payload.
 	unsignedPayload = "UNSIGNED-PAYLOAD"
@@ -116,9 +123,9 @@ func (iam *IdentityAccessManagement) doesSignatureMatch(hashedPayload string, r
 	}
 
 	// Verify if the access key id matches.
-	identity, cred, found := iam.lookupByAccessKey(signV4Values.Credential.accessKey)
-	if !found {
-		return nil, s3err.ErrInvalidAccessKeyID
+	identity, cred, errCode := iam.lookupCredential(signV4Values.Credential.accessKey, req.Header.Get(s3_constants.AmzSecurityToken))
+	if errCode != s3err.ErrNone {
+		return nil, errCode
 	}
 
 	// Extract date, if not present throw error.
@@ -335,18 +342,18 @@ func parseSignature(signElement string) (string, s3err.ErrorCode) {
 // doesPolicySignatureV4Match - Verify query headers with post policy
 //   - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-HTTPPOSTConstructPolicy.html
 //
-// returns ErrNone if the signature matches.
-func (iam *IdentityAccessManagement) doesPolicySignatureV4Match(formValues http.Header) s3err.ErrorCode {
+// returns the identity signing the policy if the signature matches.
+func (iam *IdentityAccessManagement) doesPolicySignatureV4Match(formValues http.Header) (*Identity, s3err.ErrorCode) {
 
 	// Parse credential tag.
 	credHeader, err := parseCredentialHeader("Credential=" + formValues.Get("X-Amz-Credential"))
 	if err != s3err.ErrNone {
-		return s3err.ErrMissingFields
+		return nil, s3err.ErrMissingFields
 	}
 
-	_, cred, found := iam.lookupByAccessKey(credHeader.accessKey)
-	if !found {
-		return s3err.ErrInvalidAccessKeyID
+	identity, cred, errCode := iam.lookupCredential(credHeader.accessKey, formValues.Get(s3_constants.AmzSecurityToken))
+	if errCode != s3err.ErrNone {
+		return nil, errCode
 	}
 
 	// Get signature.
@@ -360,11 +367,11 @@ func (iam *IdentityAccessManagement) doesPolicySignatureV4Match(formValues http.
 
 	// Verify signature.
 	if !compareSignatureV4(newSignature, formValues.Get("X-Amz-Signature")) {
-		return s3err.ErrSignatureDoesNotMatch
+		return nil, s3err.ErrSignatureDoesNotMatch
 	}
 
 	// Success.
-	return s3err.ErrNone
+	return identity, s3err.ErrNone
 }
 
 // check query headers with presigned signature
@@ -381,9 +388,9 @@ func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload s
 	}
 
 	// Verify if the access key id matches.
-	identity, cred, found := iam.lookupByAccessKey(pSignValues.Credential.accessKey)
-	if !found {
-		return nil, s3err.ErrInvalidAccessKeyID
+	identity, cred, errCode := iam.lookupCredential(pSignValues.Credential.accessKey, req.URL.Query().Get(s3_constants.AmzSecurityToken))
+	if errCode != s3err.ErrNone {
+		return nil, errCode
 	}
 
 	// Extract all the signed headers along with its values.
@@ -420,6 +427,9 @@ func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload s
 	query.Set("X-Amz-Expires", strconv.Itoa(expireSeconds))
 	query.Set("X-Amz-SignedHeaders", getSignedHeaders(extractedSignedHeaders))
 	query.Set("X-Amz-Credential", cred.AccessKey+"/"+getScope(t, pSignValues.Credential.scope.region))
+	if sessionToken := req.URL.Query().Get(s3_constants.AmzSecurityToken); sessionToken != "" {
+		query.Set(s3_constants.AmzSecurityToken, sessionToken)
+	}
 
 	// Save other headers available in the request parameters.
 	for k, v := range req.URL.Query() {
@@ -482,9 +492,33 @@ func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload s
 	if !compareSignatureV4(req.URL.Query().Get("X-Amz-Signature"), newSignature) {
 		return nil, s3err.ErrSignatureDoesNotMatch
 	}
+
+	// the signed content length range restricts the uploads
+	if lengthRange := req.URL.Query().Get(s3presign.ContentLengthRangeParam); lengthRange != "" {
+		if errCode = checkContentLengthRange(r, lengthRange); errCode != s3err.ErrNone {
+			return nil, errCode
+		}
+	}
 	return identity, s3err.ErrNone
 }
 
+func checkContentLengthRange(r *http.Request, lengthRange string) s3err.ErrorCode {
+	contentLengthRange, err := s3presign.ParseContentLengthRange(lengthRange)
+	if err != nil {
+		return s3err.ErrInvalidQueryParams
+	}
+	if r.ContentLength < 0 {
+		return s3err.ErrMissingContentLength
+	}
+	if r.ContentLength < contentLengthRange.Min {
+		return s3err.ErrEntityTooSmall
+	}
+	if r.ContentLength > contentLengthRange.Max {
+		return s3err.ErrEntityTooLarge
+	}
+	return s3err.ErrNone
+}
+
 func (iam *IdentityAccessManagement) getSignature(secretKey string, t time.Time, region string, service string, stringToSign string) string {
 	pool := iam.getSignatureHashPool(secretKey, t, region, service)
 	h := pool.Get().(hash.Hash)
@@ -7,6 +7,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"math"
 	"sync"
@@ -38,6 +39,31 @@ type BucketMetaData struct {
 
 	// A list of grants for access controls.
 	Acl []*s3.Grant `locationName:"AccessControlList" locationNameList:"Grant" type:"list"`
+
+	// The versioning state of the bucket: Enabled, Suspended,
+	// or empty if versioning has never been enabled.
+	Versioning string
+
+	// The bucket policy, nil if the bucket has no policy
+	Policy *s3policy.PolicyDocument
+
+	// The CORS configuration, nil if the bucket has no CORS rules
+	Cors *CORSConfiguration
+
+	// The object lock configuration, nil if object lock is not enabled
+	ObjectLock *ObjectLockConfiguration
+
+	// The default server side encryption, nil if objects are not encrypted by default
+	Encryption *ServerSideEncryptionConfiguration
+
+	// The public access block, nil if public ACLs and policies are allowed
+	PublicAccessBlock *PublicAccessBlockConfiguration
+
+	// Where the server access logs are delivered, nil if the requests are not logged
+	Logging *LoggingEnabled
+
+	// Whether an object ACL may grant access to another account than the bucket owner
+	HasObjectAcls bool
 }
 
 type BucketRegistry struct {
@@ -106,6 +132,81 @@ func buildBucketMetadata(accountManager AccountManager, entry *filer_pb.Entry) *
 			}
 		}
 
+		//versioning
+		versioning, ok := entry.Extended[s3_constants.ExtVersioningKey]
+		if ok {
+			bucketMetadata.Versioning = string(versioning)
+		}
+
+		//bucket policy
+		policyBytes, ok := entry.Extended[s3_constants.ExtBucketPolicyKey]
+		if ok && len(policyBytes) > 0 {
+			policy, err := s3policy.ParseBucketPolicy(policyBytes, entry.Name)
+			if err == nil {
+				bucketMetadata.Policy = policy
+			} else {
+				glog.Warningf("Invalid bucket policy: %s(%v), bucket: %s", string(policyBytes), err, bucketMetadata.Name)
+			}
+		}
+
+		//cors configuration
+		corsBytes, ok := entry.Extended[s3_constants.ExtCorsConfigKey]
+		if ok && len(corsBytes) > 0 {
+			cors, err := parseCorsConfiguration(corsBytes)
+			if err == nil {
+				bucketMetadata.Cors = cors
+			} else {
+				glog.Warningf("Invalid cors configuration: %s(%v), bucket: %s", string(corsBytes), err, bucketMetadata.Name)
+			}
+		}
+
+		//object lock configuration
+		objectLockBytes, ok := entry.Extended[s3_constants.ExtObjectLockConfigKey]
+		if ok && len(objectLockBytes) > 0 {
+			objectLock, err := parseObjectLockConfiguration(objectLockBytes)
+			if err == nil {
+				bucketMetadata.ObjectLock = objectLock
+			} else {
+				glog.Warningf("Invalid object lock configuration: %s(%v), bucket: %s", string(objectLockBytes), err, bucketMetadata.Name)
+			}
+		}
+
+		//default encryption configuration
+		encryptionBytes, ok := entry.Extended[s3_constants.ExtEncryptionConfigKey]
+		if ok && len(encryptionBytes) > 0 {
+			encryption, err := parseEncryptionConfiguration(encryptionBytes)
+			if err == nil {
+				bucketMetadata.Encryption = encryption
+			} else {
+				glog.Warningf("Invalid encryption configuration: %s(%v), bucket: %s", string(encryptionBytes), err, bucketMetadata.Name)
+			}
+		}
+
+		//public access block
+		publicAccessBlockBytes, ok := entry.Extended[s3_constants.ExtPublicAccessBlockKey]
+		if ok && len(publicAccessBlockBytes) > 0 {
+			publicAccessBlock, err := parsePublicAccessBlockConfiguration(publicAccessBlockBytes)
+			if err == nil {
+				bucketMetadata.PublicAccessBlock = publicAccessBlock
+			} else {
+				glog.Warningf("Invalid public access block: %s(%v), bucket: %s", string(publicAccessBlockBytes), err, bucketMetadata.Name)
+			}
+		}
+
+		//server access logging
+		loggingBytes, ok := entry.Extended[s3_constants.ExtLoggingKey]
+		if ok && len(loggingBytes) > 0 {
+			loggingStatus, err := parseBucketLoggingStatus(loggingBytes)
+			if err == nil {
+				bucketMetadata.Logging = loggingStatus.LoggingEnabled
+			} else {
+				glog.Warningf("Invalid logging status: %s(%v), bucket: %s", string(loggingBytes), err, bucketMetadata.Name)
+			}
+		}
+
+		//object acls granting access to other accounts
+		_, bucketMetadata.HasObjectAcls = entry.Extended[s3_constants.ExtObjectAclsKey]
+
 		//access control policy
 		//owner
 		acpOwnerBytes, ok := entry.Extended[s3_constants.ExtAmzOwnerKey]
@@ -132,6 +233,9 @@ func buildBucketMetadata(accountManager AccountManager, entry *filer_pb.Entry) *
 				glog.Warningf("Unmarshal ACP grants: %s(%v), bucket: %s", string(acpGrantsBytes), err, bucketMetadata.Name)
 			}
 		}
+		if bucketMetadata.PublicAccessBlock.ignorePublicAcls() {
+			bucketMetadata.Acl = RemovePublicGrants(bucketMetadata.Acl)
+		}
 	}
 	return bucketMetadata
 }
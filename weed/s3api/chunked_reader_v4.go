@@ -27,6 +27,8 @@ import (
 	"hash"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
@@ -55,10 +57,10 @@ func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cr
 	}
 
 	// Payload streaming.
-	payload := streamingContentSHA256
+	payload := req.Header.Get("X-Amz-Content-Sha256")
 
-	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
-	if payload != req.Header.Get("X-Amz-Content-Sha256") {
+	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD', with or without trailer
+	if payload != streamingContentSHA256 && payload != streamingContentSHA256Trailer {
 		return nil, "", "", time.Time{}, s3err.ErrContentSHA256Mismatch
 	}
 
@@ -68,13 +70,13 @@ func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cr
 		return nil, "", "", time.Time{}, errCode
 	}
 	// Verify if the access key id matches.
-	identity, cred, found := iam.lookupByAccessKey(signV4Values.Credential.accessKey)
-	if !found {
-		return nil, "", "", time.Time{}, s3err.ErrInvalidAccessKeyID
+	identity, cred, errCode := iam.lookupCredential(signV4Values.Credential.accessKey, req.Header.Get(s3_constants.AmzSecurityToken))
+	if errCode != s3err.ErrNone {
+		return nil, "", "", time.Time{}, errCode
 	}
 
 	bucket, object := s3_constants.GetBucketAndObject(r)
-	if !identity.canDo(s3_constants.ACTION_WRITE, bucket, object) {
+	if !identity.isAllowed(newPolicyRequest(r, identity, bucket, object), s3_constants.ACTION_WRITE, bucket, object) {
 		errCode = s3err.ErrAccessDenied
 		return
 	}
@@ -139,18 +141,55 @@ func (iam *IdentityAccessManagement) newSignV4ChunkedReader(req *http.Request) (
 	if errCode != s3err.ErrNone {
 		return nil, errCode
 	}
+	hasTrailer := req.Header.Get("X-Amz-Content-Sha256") == streamingContentSHA256Trailer
+	decodeChunkedRequestHeaders(req)
 	return &s3ChunkedReader{
 		cred:              ident,
 		reader:            bufio.NewReader(req.Body),
 		seedSignature:     seedSignature,
 		seedDate:          seedDate,
 		region:            region,
+		signed:            true,
+		hasTrailer:        hasTrailer,
+		trailer:           make(http.Header),
 		chunkSHA256Writer: sha256.New(),
 		state:             readChunkHeader,
 		iam:               iam,
 	}, s3err.ErrNone
 }
 
+// newUnsignedChunkedReader returns a new s3ChunkedReader for the 'STREAMING-UNSIGNED-PAYLOAD-TRAILER' payload,
+// whose chunks are not signed. The request itself must have been authenticated already.
+func newUnsignedChunkedReader(req *http.Request) io.ReadCloser {
+	decodeChunkedRequestHeaders(req)
+	return &s3ChunkedReader{
+		reader:     bufio.NewReader(req.Body),
+		hasTrailer: true,
+		trailer:    make(http.Header),
+		state:      readChunkHeader,
+	}
+}
+
+// decodeChunkedRequestHeaders replaces the content length and encoding of the aws-chunked payload
+// with the ones of the decoded data, which is passed on to the filer
+func decodeChunkedRequestHeaders(req *http.Request) {
+	req.ContentLength = -1
+	if decodedLength, err := strconv.ParseInt(req.Header.Get(s3_constants.AmzDecodedContentLength), 10, 64); err == nil {
+		req.ContentLength = decodedLength
+	}
+	var encodings []string
+	for _, encoding := range strings.Split(req.Header.Get("Content-Encoding"), ",") {
+		if encoding = strings.TrimSpace(encoding); encoding != "" && encoding != "aws-chunked" {
+			encodings = append(encodings, encoding)
+		}
+	}
+	if len(encodings) == 0 {
+		req.Header.Del("Content-Encoding")
+	} else {
+		req.Header.Set("Content-Encoding", strings.Join(encodings, ","))
+	}
+}
+
 // Represents the overall state that is required for decoding a
 // AWS Signature V4 chunked reader.
 type s3ChunkedReader struct {
@@ -161,6 +200,9 @@ type s3ChunkedReader struct {
 	region            string
 	state             chunkState
 	lastChunk         bool
+	signed            bool        // the chunks and the trailer are signed
+	hasTrailer        bool        // the last chunk is followed by trailing headers
+	trailer           http.Header // the trailing headers, available once the payload is read
 	chunkSignature    string
 	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
 	n                 uint64    // Unread bytes in chunk
@@ -195,6 +237,7 @@ const (
 	readChunkTrailer
 	readChunk
 	verifyChunk
+	readTrailingHeaders
 	eofChunk
 )
 
@@ -209,6 +252,8 @@ func (cs chunkState) String() string {
 		stateString = "readChunk"
 	case verifyChunk:
 		stateString = "verifyChunk"
+	case readTrailingHeaders:
+		stateString = "readTrailingHeaders"
 	case eofChunk:
 		stateString = "eofChunk"
 
@@ -229,7 +274,11 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			cr.readS3ChunkHeader()
 			// If we're at the end of a chunk.
 			if cr.n == 0 && cr.err == io.EOF {
+				// the trailing headers follow the last chunk without an empty line
 				cr.state = readChunkTrailer
+				if cr.hasTrailer {
+					cr.state = verifyChunk
+				}
 				cr.lastChunk = true
 				continue
 			}
@@ -265,7 +314,9 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			}
 
 			// Calculate sha256.
-			cr.chunkSHA256Writer.Write(rbuf[:n0])
+			if cr.signed {
+				cr.chunkSHA256Writer.Write(rbuf[:n0])
+			}
 
 			// Update the bytes read into request buffer so far.
 			n += n0
@@ -279,6 +330,13 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 				continue
 			}
 		case verifyChunk:
+			if !cr.signed {
+				cr.state = readChunkHeader
+				if cr.lastChunk {
+					cr.state = readTrailingHeaders
+				}
+				continue
+			}
 			// Calculate the hashed chunk.
 			hashedChunk := hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil))
 			// Calculate the chunk signature.
@@ -292,17 +350,82 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			// this follows the chaining.
 			cr.seedSignature = newSignature
 			cr.chunkSHA256Writer.Reset()
-			if cr.lastChunk {
+			if cr.lastChunk && cr.hasTrailer {
+				cr.state = readTrailingHeaders
+			} else if cr.lastChunk {
 				cr.state = eofChunk
 			} else {
 				cr.state = readChunkHeader
 			}
+		case readTrailingHeaders:
+			if cr.err = cr.readTrailingHeaders(); cr.err != nil {
+				return 0, cr.err
+			}
+			cr.state = eofChunk
 		case eofChunk:
 			return n, io.EOF
 		}
 	}
 }
 
+// readTrailingHeaders reads the "name:value" lines after the last chunk up to an empty line,
+// and verifies the trailer signature of a signed payload.
+func (cr *s3ChunkedReader) readTrailingHeaders() error {
+	var canonicalTrailer bytes.Buffer
+	var trailerSignature string
+	for {
+		line, err := cr.reader.ReadSlice('\n')
+		if err == io.EOF && len(line) == 0 {
+			break
+		}
+		if err != nil {
+			return errMalformedEncoding
+		}
+		line = trimTrailingWhitespace(line)
+		if len(line) == 0 {
+			break
+		}
+		name, value, found := bytes.Cut(line, []byte(":"))
+		if !found {
+			return errMalformedEncoding
+		}
+		if string(name) == "x-amz-trailer-signature" {
+			trailerSignature = string(value)
+			continue
+		}
+		cr.trailer.Set(string(name), string(value))
+		canonicalTrailer.Write(name)
+		canonicalTrailer.WriteByte(':')
+		canonicalTrailer.Write(value)
+		canonicalTrailer.WriteByte('\n')
+	}
+	if !cr.signed {
+		return nil
+	}
+	hashedTrailer := sha256.Sum256(canonicalTrailer.Bytes())
+	if !compareSignatureV4(trailerSignature, cr.getTrailerSignature(hex.EncodeToString(hashedTrailer[:]))) {
+		return errors.New("trailer signature does not match")
+	}
+	return nil
+}
+
+// getTrailerSignature - get the signature of the trailing headers, chained to the last chunk signature.
+func (cr *s3ChunkedReader) getTrailerSignature(hashedTrailer string) string {
+	stringToSign := signV4ChunkedAlgorithmTrailer + "\n" +
+		cr.seedDate.Format(iso8601Format) + "\n" +
+		getScope(cr.seedDate, cr.region) + "\n" +
+		cr.seedSignature + "\n" +
+		hashedTrailer
+
+	return cr.iam.getSignature(
+		cr.cred.SecretKey,
+		cr.seedDate,
+		cr.region,
+		"s3",
+		stringToSign,
+	)
+}
+
 // getChunkSignature - get chunk signature.
 func (cr *s3ChunkedReader) getChunkSignature(hashedChunk string) string {
 	// Calculate string to sign.
@@ -3,6 +3,7 @@ package s3api
 import (
 	"cmp"
 	"encoding/hex"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
@@ -13,7 +14,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -73,6 +73,9 @@ func (s3a *S3ApiServer) createMultipartUpload(input *s3.CreateMultipartUploadInp
 type CompleteMultipartUploadResult struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
 	s3.CompleteMultipartUploadOutput
+
+	// VersionId is returned as the x-amz-version-id header
+	VersionId *string `xml:"-"`
 }
 
 func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploadInput, parts *CompleteMultipartUpload) (output *CompleteMultipartUploadResult, code s3err.ErrorCode) {
@@ -84,11 +87,13 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 	}
 	completedPartNumbers := []int{}
 	completedPartMap := make(map[int][]string)
+	completedPartChecksums := make(map[int]CompletedPart)
 	for _, part := range parts.Parts {
 		if _, ok := completedPartMap[part.PartNumber]; !ok {
 			completedPartNumbers = append(completedPartNumbers, part.PartNumber)
 		}
 		completedPartMap[part.PartNumber] = append(completedPartMap[part.PartNumber], part.ETag)
+		completedPartChecksums[part.PartNumber] = part
 	}
 	sort.Ints(completedPartNumbers)
 
@@ -180,7 +185,9 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 		return nil, s3err.ErrEntityTooSmall
 	}
 	mime := pentry.Attributes.Mime
+	checksumAlgorithm := string(pentry.Extended[s3_constants.ExtChecksumAlgorithmKey])
 	var finalParts []*filer_pb.FileChunk
+	var objectParts []objectPart
 	var offset int64
 	for _, partNumber := range completedPartNumbers {
 		partEntriesByNumber, ok := partEntries[partNumber]
@@ -201,6 +208,16 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 				stats.S3HandlerCounter.WithLabelValues(stats.ErrorCompletedPartEntryMismatch).Inc()
 				continue
 			}
+			partChecksum := string(entry.Extended[s3_constants.ExtChecksumKey])
+			if expected := completedPartChecksums[partNumber].checksum(checksumAlgorithm); expected != "" && expected != partChecksum {
+				glog.Errorf("completeMultipartUpload %s checksum mismatch: %s part: %s", entry.Name, partChecksum, expected)
+				return nil, s3err.ErrInvalidPart
+			}
+			objectParts = append(objectParts, objectPart{
+				PartNumber: partNumber,
+				Size:       int64(filer.FileSize(entry)),
+				Checksum:   partChecksum,
+			})
 			for _, chunk := range entry.GetChunks() {
 				p := &filer_pb.FileChunk{
 					FileId:       chunk.GetFileIdString(),
@@ -218,6 +235,28 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 		}
 	}
 
+	var checksum string
+	if checksumAlgorithm != "" {
+		var partChecksums []string
+		for _, part := range objectParts {
+			partChecksums = append(partChecksums, part.Checksum)
+		}
+		if checksum, err = compositeChecksum(checksumAlgorithm, partChecksums); err != nil {
+			glog.Errorf("completeMultipartUpload %s %s checksum: %v", *input.Bucket, *input.UploadId, err)
+			return nil, s3err.ErrInvalidPart
+		}
+	}
+	objectPartsJson, err := json.Marshal(objectParts)
+	if err != nil {
+		glog.Errorf("completeMultipartUpload %s %s parts: %v", *input.Bucket, *input.UploadId, err)
+		return nil, s3err.ErrInternalError
+	}
+
+	versionId, code := s3a.prepareVersionedWrite(*input.Bucket, *input.Key)
+	if code != s3err.ErrNone {
+		return nil, code
+	}
+
 	entryName, dirName := s3a.getEntryNameAndDir(input)
 	err = s3a.mkFile(dirName, entryName, finalParts, func(entry *filer_pb.Entry) {
 		if entry.Extended == nil {
@@ -229,6 +268,15 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 				entry.Extended[k] = v
 			}
 		}
+		delete(entry.Extended, s3_constants.ExtDeleteMarkerKey)
+		delete(entry.Extended, s3_constants.ExtVersionIdKey)
+		entry.Extended[s3_constants.ExtMultipartPartsKey] = objectPartsJson
+		if checksum != "" {
+			entry.Extended[s3_constants.ExtChecksumKey] = []byte(checksum)
+		}
+		if versionId != "" {
+			entry.Extended[s3_constants.ExtVersionIdKey] = []byte(versionId)
+		}
 		if pentry.Attributes.Mime != "" {
 			entry.Attributes.Mime = pentry.Attributes.Mime
 		} else if mime != "" {
@@ -239,6 +287,9 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 
 	if err != nil {
 		glog.Errorf("completeMultipartUpload %s/%s error: %v", dirName, entryName, err)
+		if versionId != "" {
+			s3a.promoteLatestVersion(*input.Bucket, *input.Key)
+		}
 		return nil, s3err.ErrInternalError
 	}
 
@@ -250,6 +301,10 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 			Key:      objectKey(input.Key),
 		},
 	}
+	if versionId != "" {
+		output.VersionId = aws.String(versionId)
+	}
+	setChecksumOutput(checksumAlgorithm, checksum, &output.ChecksumCRC32, &output.ChecksumCRC32C, &output.ChecksumSHA1, &output.ChecksumSHA256)
 
 	for _, deleteEntry := range deleteEntries {
 		//delete unused part data
@@ -427,10 +482,14 @@ func (s3a *S3ApiServer) listObjectParts(input *s3.ListPartsInput) (output *ListP
 			}
 			output.Part = append(output.Part, &s3.Part{
 				PartNumber:   aws.Int64(int64(partNumber)),
-				LastModified: aws.Time(time.Unix(entry.Attributes.Mtime, 0).UTC()),
+				LastModified: aws.Time(entry.Attributes.ModTime().UTC()),
 				Size:         aws.Int64(int64(filer.FileSize(entry))),
 				ETag:         aws.String("\"" + filer.ETag(entry) + "\""),
 			})
+			if partChecksum := string(entry.Extended[s3_constants.ExtChecksumKey]); partChecksum != "" {
+				part := output.Part[len(output.Part)-1]
+				setChecksumOutput(string(entry.Extended[s3_constants.ExtChecksumAlgorithmKey]), partChecksum, &part.ChecksumCRC32, &part.ChecksumCRC32C, &part.ChecksumSHA1, &part.ChecksumSHA256)
+			}
 			if !isLast {
 				output.NextPartNumberMarker = aws.Int64(int64(partNumber))
 			}
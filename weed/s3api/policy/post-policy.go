@@ -143,7 +143,7 @@ func (p *PostPolicy) SetCondition(matchType, condition, value string) error {
 		condition: "$" + condition,
 		value:     value,
 	}
-	if condition == "X-Amz-Credential" || condition == "X-Amz-Date" || condition == "X-Amz-Algorithm" {
+	if condition == "X-Amz-Credential" || condition == "X-Amz-Date" || condition == "X-Amz-Algorithm" || condition == "X-Amz-Security-Token" {
 		if err := p.addNewPolicy(policyCond); err != nil {
 			return err
 		}
@@ -171,6 +171,24 @@ func (p *PostPolicy) SetContentType(contentType string) error {
 	return nil
 }
 
+// SetContentTypeStartsWith - Sets what content-type of the object for this policy
+// based upload can start with.
+func (p *PostPolicy) SetContentTypeStartsWith(contentTypeStartsWith string) error {
+	if strings.TrimSpace(contentTypeStartsWith) == "" || contentTypeStartsWith == "" {
+		return errInvalidArgument("No content type specified.")
+	}
+	policyCond := policyCondition{
+		matchType: "starts-with",
+		condition: "$Content-Type",
+		value:     contentTypeStartsWith,
+	}
+	if err := p.addNewPolicy(policyCond); err != nil {
+		return err
+	}
+	p.formData["Content-Type"] = contentTypeStartsWith
+	return nil
+}
+
 // SetContentLengthRange - Set new min and max content length
 // condition for all incoming uploads.
 func (p *PostPolicy) SetContentLengthRange(min, max int64) error {
@@ -277,6 +295,20 @@ func (p *PostPolicy) addNewPolicy(policyCond policyCondition) error {
 	return nil
 }
 
+// FormData - Returns the form fields to post along with the policy.
+func (p *PostPolicy) FormData() map[string]string {
+	formData := make(map[string]string, len(p.formData))
+	for k, v := range p.formData {
+		formData[k] = v
+	}
+	return formData
+}
+
+// Base64 - Returns the policy to post, to be signed.
+func (p PostPolicy) Base64() string {
+	return p.base64()
+}
+
 // String function for printing policy in json formatted string.
 func (p PostPolicy) String() string {
 	return string(p.marshalJSON())
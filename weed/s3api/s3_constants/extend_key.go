@@ -1,7 +1,51 @@
 package s3_constants
 
 const (
-	ExtAmzOwnerKey  = "Seaweed-X-Amz-Owner"
-	ExtAmzAclKey    = "Seaweed-X-Amz-Acl"
-	ExtOwnershipKey = "Seaweed-X-Amz-Ownership"
+	ExtAmzOwnerKey     = "Seaweed-X-Amz-Owner"
+	ExtAmzAclKey       = "Seaweed-X-Amz-Acl"
+	ExtOwnershipKey    = "Seaweed-X-Amz-Ownership"
+	ExtVersioningKey   = "Seaweed-X-Amz-Versioning"
+	ExtVersionIdKey    = "Seaweed-X-Amz-Version-Id"
+	ExtDeleteMarkerKey = "Seaweed-X-Amz-Delete-Marker"
+	ExtBucketPolicyKey = "Seaweed-X-Amz-Bucket-Policy"
+	ExtCorsConfigKey   = "Seaweed-X-Amz-Cors"
+	ExtLifecycleKey    = "Seaweed-X-Amz-Lifecycle"
+
+	ExtPublicAccessBlockKey = "Seaweed-X-Amz-Public-Access-Block"
+	ExtNotificationKey      = "Seaweed-X-Amz-Notification"
+	ExtLoggingKey           = "Seaweed-X-Amz-Logging"
+
+	// set on the bucket once an object ACL grants access to another account than the bucket owner
+	ExtObjectAclsKey = "Seaweed-X-Amz-Object-Acls"
+
+	// S3 bucket replication, the configuration is kept on the bucket, the replication status on the objects
+	ExtReplicationKey       = "Seaweed-X-Amz-Replication"
+	ExtReplicationStatusKey = "Seaweed-X-Amz-Replication-Status"
+
+	// S3 object lock, the configuration is kept on the bucket, the retention and legal hold on the object versions
+	ExtObjectLockConfigKey          = "Seaweed-X-Amz-Object-Lock-Configuration"
+	ExtObjectLockModeKey            = "Seaweed-X-Amz-Object-Lock-Mode"
+	ExtObjectLockRetainUntilDateKey = "Seaweed-X-Amz-Object-Lock-Retain-Until-Date"
+	ExtObjectLockLegalHoldKey       = "Seaweed-X-Amz-Object-Lock-Legal-Hold"
+
+	// server side encryption, the default encryption is kept on the bucket, the applied encryption on the objects
+	ExtEncryptionConfigKey     = "Seaweed-X-Amz-Encryption-Configuration"
+	ExtServerSideEncryptionKey = "Seaweed-X-Amz-Server-Side-Encryption"
+	ExtSSECustomerAlgorithmKey = "Seaweed-X-Amz-Server-Side-Encryption-Customer-Algorithm"
+	ExtSSECustomerKeyMD5Key    = "Seaweed-X-Amz-Server-Side-Encryption-Customer-Key-Md5"
+
+	// checksums of the objects and of the parts of multipart uploads, the part list is kept on multipart objects
+	ExtChecksumAlgorithmKey = "Seaweed-X-Amz-Checksum-Algorithm"
+	ExtChecksumKey          = "Seaweed-X-Amz-Checksum"
+	ExtMultipartPartsKey    = "Seaweed-X-Amz-Multipart-Parts"
+)
+
+const (
+	ObjectLockModeGovernance = "GOVERNANCE"
+	ObjectLockModeCompliance = "COMPLIANCE"
+	ObjectLockLegalHoldOn    = "ON"
+	ObjectLockLegalHoldOff   = "OFF"
+
+	ServerSideEncryptionAES256 = "AES256"
+	ServerSideEncryptionKMS    = "aws:kms"
 )
@@ -42,6 +42,8 @@ const (
 	SeaweedFSIsDirectoryKey = "X-Seaweedfs-Is-Directory-Key"
 	SeaweedFSPartNumber     = "X-Seaweedfs-Part-Number"
 	SeaweedFSUploadId       = "X-Seaweedfs-Upload-Id"
+	SeaweedFSCipher         = "X-Seaweedfs-Cipher"     // asks the filer to encrypt the chunks of an upload
+	SeaweedFSCipherKey      = "X-Seaweedfs-Cipher-Key" // base64 key protecting the chunk cipher keys
 
 	// S3 ACL headers
 	AmzCannedAcl      = "X-Amz-Acl"
@@ -52,6 +54,47 @@ const (
 	AmzAclWriteAcp    = "X-Amz-Grant-Write-Acp"
 
 	AmzMpPartsCount = "X-Amz-Mp-Parts-Count"
+
+	// S3 object versioning
+	AmzVersionId           = "X-Amz-Version-Id"
+	AmzDeleteMarker        = "X-Amz-Delete-Marker"
+	AmzCopySourceVersionId = "X-Amz-Copy-Source-Version-Id"
+
+	// S3 object lock
+	AmzBucketObjectLockEnabled   = "X-Amz-Bucket-Object-Lock-Enabled"
+	AmzObjectLockMode            = "X-Amz-Object-Lock-Mode"
+	AmzObjectLockRetainUntilDate = "X-Amz-Object-Lock-Retain-Until-Date"
+	AmzObjectLockLegalHold       = "X-Amz-Object-Lock-Legal-Hold"
+	AmzBypassGovernanceRetention = "X-Amz-Bypass-Governance-Retention"
+
+	// S3 server side encryption
+	AmzServerSideEncryption                            = "X-Amz-Server-Side-Encryption"
+	AmzServerSideEncryptionCustomerAlgorithm           = "X-Amz-Server-Side-Encryption-Customer-Algorithm"
+	AmzServerSideEncryptionCustomerKey                 = "X-Amz-Server-Side-Encryption-Customer-Key"
+	AmzServerSideEncryptionCustomerKeyMD5              = "X-Amz-Server-Side-Encryption-Customer-Key-Md5"
+	AmzCopySourceServerSideEncryptionCustomerAlgorithm = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Algorithm"
+	AmzCopySourceServerSideEncryptionCustomerKey       = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key"
+	AmzCopySourceServerSideEncryptionCustomerKeyMD5    = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key-Md5"
+
+	// session token of temporary credentials
+	AmzSecurityToken = "X-Amz-Security-Token"
+
+	// S3 bucket replication
+	AmzReplicationStatus = "X-Amz-Replication-Status"
+
+	// S3 checksums
+	AmzChecksumAlgorithm    = "X-Amz-Checksum-Algorithm"
+	AmzSdkChecksumAlgorithm = "X-Amz-Sdk-Checksum-Algorithm"
+	AmzChecksumPrefix       = "X-Amz-Checksum-"
+	AmzChecksumType         = "X-Amz-Checksum-Type"
+	AmzChecksumMode         = "X-Amz-Checksum-Mode"
+	AmzTrailer              = "X-Amz-Trailer"
+	AmzDecodedContentLength = "X-Amz-Decoded-Content-Length"
+
+	// S3 object attributes
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
 )
 
 // Non-Standard S3 HTTP request constants
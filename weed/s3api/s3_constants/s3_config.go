@@ -10,6 +10,8 @@ var (
 	AllowedActions           = []string{ACTION_READ, ACTION_READ_ACP, ACTION_WRITE, ACTION_WRITE_ACP, ACTION_LIST, ACTION_TAGGING, ACTION_ADMIN, ACTION_DELETE_BUCKET}
 	LimitTypeCount           = "Count"
 	LimitTypeBytes           = "MB"
+	LimitTypeCountPerSecond  = "CountPerSecond"
+	LimitTypeBytesPerSecond  = "MBPerSecond"
 	Separator                = ":"
 )
 
@@ -317,6 +317,51 @@ func ValidateAndTransferGrants(accountManager AccountManager, grants []*s3.Grant
 	return result, s3err.ErrNone
 }
 
+// ParseRequestAclGrants collects the grants of the canned acl and the custom acl headers of the request,
+// without validating the grantees
+func ParseRequestAclGrants(r *http.Request) (grants []*s3.Grant, errCode s3err.ErrorCode) {
+	if errCode = ParseCustomAclHeaders(r, &grants); errCode != s3err.ErrNone {
+		return nil, errCode
+	}
+	switch r.Header.Get(s3_constants.AmzCannedAcl) {
+	case s3_constants.CannedAclPublicRead:
+		grants = append(grants, s3_constants.PublicRead...)
+	case s3_constants.CannedAclPublicReadWrite:
+		grants = append(grants, s3_constants.PublicReadWrite...)
+	case s3_constants.CannedAclAuthenticatedRead:
+		grants = append(grants, s3_constants.AuthenticatedRead...)
+	}
+	return grants, s3err.ErrNone
+}
+
+// IsPublicGrant tells whether the grant gives access to everyone, or to every authenticated user
+func IsPublicGrant(grant *s3.Grant) bool {
+	if grant == nil || grant.Grantee == nil || grant.Grantee.URI == nil {
+		return false
+	}
+	uri := *grant.Grantee.URI
+	return uri == s3_constants.GranteeGroupAllUsers || uri == s3_constants.GranteeGroupAuthenticatedUsers
+}
+
+func HasPublicGrant(grants []*s3.Grant) bool {
+	for _, grant := range grants {
+		if IsPublicGrant(grant) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemovePublicGrants returns the grants which are not public
+func RemovePublicGrants(grants []*s3.Grant) (result []*s3.Grant) {
+	for _, grant := range grants {
+		if !IsPublicGrant(grant) {
+			result = append(result, grant)
+		}
+	}
+	return result
+}
+
 // DetermineReqGrants generates the grant set (Grants) according to accountId and reqPermission.
 func DetermineReqGrants(accountId, aclAction string) (grants []*s3.Grant) {
 	// group grantee (AllUsers)
@@ -371,8 +416,8 @@ func DetermineReqGrants(accountId, aclAction string) (grants []*s3.Grant) {
 	return
 }
 
-func SetAcpOwnerHeader(r *http.Request, acpOwnerId string) {
-	r.Header.Set(s3_constants.ExtAmzOwnerKey, acpOwnerId)
+func SetAcpOwnerHeader(header http.Header, acpOwnerId string) {
+	header.Set(s3_constants.ExtAmzOwnerKey, acpOwnerId)
 }
 
 func GetAcpOwner(entryExtended map[string][]byte, defaultOwner string) string {
@@ -383,11 +428,11 @@ func GetAcpOwner(entryExtended map[string][]byte, defaultOwner string) string {
 	return defaultOwner
 }
 
-func SetAcpGrantsHeader(r *http.Request, acpGrants []*s3.Grant) {
+func SetAcpGrantsHeader(header http.Header, acpGrants []*s3.Grant) {
 	if len(acpGrants) > 0 {
 		a, err := json.Marshal(acpGrants)
 		if err == nil {
-			r.Header.Set(s3_constants.ExtAmzAclKey, string(a))
+			header.Set(s3_constants.ExtAmzAclKey, string(a))
 		} else {
 			glog.Warning("Marshal acp grants err", err)
 		}
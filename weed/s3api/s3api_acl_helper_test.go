@@ -679,7 +679,7 @@ func TestSetAcpOwnerHeader(t *testing.T) {
 	req := &http.Request{
 		Header: make(map[string][]string),
 	}
-	SetAcpOwnerHeader(req, ownerId)
+	SetAcpOwnerHeader(req.Header, ownerId)
 
 	if req.Header.Get(s3_constants.ExtAmzOwnerKey) != ownerId {
 		t.Fatalf("owner unexpect")
@@ -700,7 +700,7 @@ func TestSetAcpGrantsHeader(t *testing.T) {
 			},
 		},
 	}
-	SetAcpGrantsHeader(req, grants)
+	SetAcpGrantsHeader(req.Header, grants)
 
 	grantsJson, _ := json.Marshal(grants)
 	if req.Header.Get(s3_constants.ExtAmzAclKey) != string(grantsJson) {
@@ -49,10 +49,17 @@ func isRequestPostPolicySignatureV4(r *http.Request) bool {
 
 // Verify if the request has AWS Streaming Signature Version '4'. This is only valid for 'PUT' operation.
 func isRequestSignStreamingV4(r *http.Request) bool {
-	return r.Header.Get("x-amz-content-sha256") == streamingContentSHA256 &&
+	contentSha256 := r.Header.Get("x-amz-content-sha256")
+	return (contentSha256 == streamingContentSHA256 || contentSha256 == streamingContentSHA256Trailer) &&
 		r.Method == http.MethodPut
 }
 
+// Verify if the request has an unsigned streaming payload with trailers, signed with AWS Signature Version '4'.
+func isRequestUnsignedStreamingV4(r *http.Request) bool {
+	return r.Header.Get("x-amz-content-sha256") == streamingUnsignedPayloadTrailer &&
+		r.Method == http.MethodPut && isRequestSignatureV4(r)
+}
+
 // Authorization type.
 type authType int
 
@@ -67,6 +74,7 @@ const (
 	authTypeSigned
 	authTypeSignedV2
 	authTypeJWT
+	authTypeStreamingUnsigned
 )
 
 // Get request authentication type.
@@ -77,6 +85,8 @@ func getRequestAuthType(r *http.Request) authType {
 		return authTypePresignedV2
 	} else if isRequestSignStreamingV4(r) {
 		return authTypeStreamingSigned
+	} else if isRequestUnsignedStreamingV4(r) {
+		return authTypeStreamingUnsigned
 	} else if isRequestSignatureV4(r) {
 		return authTypeSigned
 	} else if isRequestPresignedSignatureV4(r) {
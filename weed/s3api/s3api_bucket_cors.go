@@ -0,0 +1,379 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/cors.html
+
+const (
+	maxCorsRules      = 100
+	maxCorsConfigSize = 64 * 1024
+)
+
+var corsAllowedMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPut:    true,
+	http.MethodHead:   true,
+	http.MethodPost:   true,
+	http.MethodDelete: true,
+}
+
+type CORSRule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  *int     `xml:"MaxAgeSeconds,omitempty"`
+}
+
+type CORSConfiguration struct {
+	XMLName   xml.Name   `xml:"CORSConfiguration"`
+	Xmlns     string     `xml:"xmlns,attr,omitempty"`
+	CORSRules []CORSRule `xml:"CORSRule"`
+}
+
+func parseCorsConfiguration(data []byte) (*CORSConfiguration, error) {
+	config := &CORSConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *CORSConfiguration) validate() error {
+	if len(c.CORSRules) == 0 {
+		return fmt.Errorf("no CORS rule")
+	}
+	if len(c.CORSRules) > maxCorsRules {
+		return fmt.Errorf("more than %d CORS rules", maxCorsRules)
+	}
+	for i, rule := range c.CORSRules {
+		if len(rule.AllowedMethods) == 0 {
+			return fmt.Errorf("rule %d: missing AllowedMethod", i)
+		}
+		for _, method := range rule.AllowedMethods {
+			if !corsAllowedMethods[method] {
+				return fmt.Errorf("rule %d: unsupported method %s", i, method)
+			}
+		}
+		if len(rule.AllowedOrigins) == 0 {
+			return fmt.Errorf("rule %d: missing AllowedOrigin", i)
+		}
+		for _, origin := range rule.AllowedOrigins {
+			if origin == "" || strings.Count(origin, "*") > 1 {
+				return fmt.Errorf("rule %d: invalid AllowedOrigin %q", i, origin)
+			}
+		}
+		for _, header := range rule.AllowedHeaders {
+			if strings.Count(header, "*") > 1 {
+				return fmt.Errorf("rule %d: invalid AllowedHeader %q", i, header)
+			}
+		}
+		if rule.MaxAgeSeconds != nil && *rule.MaxAgeSeconds < 0 {
+			return fmt.Errorf("rule %d: negative MaxAgeSeconds", i)
+		}
+	}
+	return nil
+}
+
+// match returns the first rule allowing the origin, the method and all the request headers
+func (c *CORSConfiguration) match(origin, method string, requestHeaders []string) *CORSRule {
+	for i := range c.CORSRules {
+		rule := &c.CORSRules[i]
+		if rule.allowsOrigin(origin) && rule.allowsMethod(method) && rule.allowsHeaders(requestHeaders) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (rule *CORSRule) allowsOrigin(origin string) bool {
+	for _, allowedOrigin := range rule.AllowedOrigins {
+		if matchCorsWildcard(allowedOrigin, origin, false) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CORSRule) allowsMethod(method string) bool {
+	for _, allowedMethod := range rule.AllowedMethods {
+		if allowedMethod == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CORSRule) allowsHeaders(headers []string) bool {
+	for _, header := range headers {
+		allowed := false
+		for _, allowedHeader := range rule.AllowedHeaders {
+			if matchCorsWildcard(allowedHeader, header, true) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// writeHeaders sets the CORS response headers for a request matched by the rule
+func (rule *CORSRule) writeHeaders(w http.ResponseWriter, origin string, requestHeaders []string) {
+	allowedOrigin := origin
+	for _, o := range rule.AllowedOrigins {
+		if o == "*" {
+			allowedOrigin = "*"
+			break
+		}
+	}
+	w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+	if allowedOrigin != "*" {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(rule.AllowedMethods, ", "))
+	if len(requestHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(requestHeaders, ", "))
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(rule.ExposeHeaders, ", "))
+	}
+	if rule.MaxAgeSeconds != nil {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(*rule.MaxAgeSeconds))
+	}
+	w.Header().Add("Vary", "Origin, Access-Control-Request-Headers, Access-Control-Request-Method")
+}
+
+// matchCorsWildcard matches the value against a pattern with at most one '*'
+func matchCorsWildcard(pattern, value string, ignoreCase bool) bool {
+	if ignoreCase {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+	prefix, suffix, hasWildcard := strings.Cut(pattern, "*")
+	if !hasWildcard {
+		return pattern == value
+	}
+	return len(value) >= len(prefix)+len(suffix) && strings.HasPrefix(value, prefix) && strings.HasSuffix(value, suffix)
+}
+
+func parseCorsRequestHeaders(value string) (headers []string) {
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, header)
+		}
+	}
+	return
+}
+
+// getBucketCors returns the CORS configuration of the bucket, or nil if there is none
+func (s3a *S3ApiServer) getBucketCors(bucket string) *CORSConfiguration {
+	if bucket == "" || s3a.bucketRegistry == nil {
+		return nil
+	}
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		return nil
+	}
+	return bucketMetadata.Cors
+}
+
+// GetBucketCorsHandler Get bucket CORS
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketCors.html
+func (s3a *S3ApiServer) GetBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetBucketCorsHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	corsBytes, ok := bucketEntry.Extended[s3_constants.ExtCorsConfigKey]
+	if !ok || len(corsBytes) == 0 {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchCORSConfiguration)
+		return
+	}
+	config := &CORSConfiguration{}
+	if err = xml.Unmarshal(corsBytes, config); err != nil {
+		glog.Errorf("GetBucketCorsHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	config.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, r, config)
+}
+
+// PutBucketCorsHandler Put bucket CORS
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+func (s3a *S3ApiServer) PutBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutBucketCorsHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	corsBytes, err := io.ReadAll(io.LimitReader(r.Body, maxCorsConfigSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(corsBytes) > maxCorsConfigSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
+	config := &CORSConfiguration{}
+	if err = xml.Unmarshal(corsBytes, config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	if err = config.validate(); err != nil {
+		glog.V(1).Infof("PutBucketCorsHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+		return
+	}
+	config.Xmlns = ""
+	if corsBytes, err = xml.Marshal(config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtCorsConfigKey] = corsBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// DeleteBucketCorsHandler Delete bucket CORS
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketCors.html
+func (s3a *S3ApiServer) DeleteBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("DeleteBucketCorsHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		delete(extended, s3_constants.ExtCorsConfigKey)
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+}
+
+// BucketCorsPreflightHandler answers OPTIONS requests with the CORS rules of the bucket,
+// falling back to the server wide allowed origins if the bucket has no CORS configuration
+func (s3a *S3ApiServer) BucketCorsPreflightHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	cors := s3a.getBucketCors(bucket)
+	if cors == nil {
+		s3a.DefaultCorsPreflightHandler(w, r)
+		return
+	}
+	r = s3err.WithBucketCors(r)
+
+	origin := r.Header.Get("Origin")
+	method := r.Header.Get("Access-Control-Request-Method")
+	if origin == "" || method == "" {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+		return
+	}
+	requestHeaders := parseCorsRequestHeaders(r.Header.Get("Access-Control-Request-Headers"))
+	rule := cors.match(origin, method, requestHeaders)
+	if rule == nil {
+		glog.V(3).Infof("cors preflight %s: origin %s method %s headers %v not allowed", bucket, origin, method, requestHeaders)
+		s3err.WriteErrorResponse(w, r, s3err.ErrCORSForbidden)
+		return
+	}
+
+	rule.writeHeaders(w, origin, requestHeaders)
+	writeSuccessResponseEmpty(w, r)
+}
+
+// DefaultCorsPreflightHandler answers OPTIONS requests with the server wide allowed origins
+func (s3a *S3ApiServer) DefaultCorsPreflightHandler(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin != "" {
+		if s3a.option.AllowedOrigins == nil || len(s3a.option.AllowedOrigins) == 0 || s3a.option.AllowedOrigins[0] == "*" {
+			origin = "*"
+		} else {
+			originFound := false
+			for _, allowedOrigin := range s3a.option.AllowedOrigins {
+				if origin == allowedOrigin {
+					originFound = true
+				}
+			}
+			if !originFound {
+				writeFailureResponse(w, r, http.StatusForbidden)
+				return
+			}
+		}
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Expose-Headers", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	writeSuccessResponseEmpty(w, r)
+}
+
+// bucketCorsMiddleware adds the CORS headers to the actual, non preflight, requests
+// whose origin and method are allowed by the CORS rules of the bucket
+func (s3a *S3ApiServer) bucketCorsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && r.Method != http.MethodOptions {
+			bucket, _ := s3_constants.GetBucketAndObject(r)
+			if cors := s3a.getBucketCors(bucket); cors != nil {
+				if rule := cors.match(origin, r.Method, nil); rule != nil {
+					rule.writeHeaders(w, origin, nil)
+				}
+				r = s3err.WithBucketCors(r)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// removeCorsHeaders drops the CORS headers set by the filer, which would override the bucket CORS rules
+func removeCorsHeaders(header http.Header) {
+	for key := range header {
+		if strings.HasPrefix(key, "Access-Control-") {
+			delete(header, key)
+		}
+	}
+}
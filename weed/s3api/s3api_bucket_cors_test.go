@@ -0,0 +1,122 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCorsConfiguration = `<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <CORSRule>
+    <AllowedOrigin>https://www.example.com</AllowedOrigin>
+    <AllowedOrigin>https://*.example.org</AllowedOrigin>
+    <AllowedMethod>PUT</AllowedMethod>
+    <AllowedMethod>POST</AllowedMethod>
+    <AllowedHeader>Content-*</AllowedHeader>
+    <AllowedHeader>x-amz-date</AllowedHeader>
+    <ExposeHeader>ETag</ExposeHeader>
+    <MaxAgeSeconds>3000</MaxAgeSeconds>
+  </CORSRule>
+  <CORSRule>
+    <AllowedOrigin>*</AllowedOrigin>
+    <AllowedMethod>GET</AllowedMethod>
+  </CORSRule>
+</CORSConfiguration>`
+
+func TestParseCorsConfiguration(t *testing.T) {
+	config, err := parseCorsConfiguration([]byte(testCorsConfiguration))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(config.CORSRules))
+	assert.Equal(t, []string{"PUT", "POST"}, config.CORSRules[0].AllowedMethods)
+	assert.Equal(t, 3000, *config.CORSRules[0].MaxAgeSeconds)
+	assert.Nil(t, config.CORSRules[1].MaxAgeSeconds)
+
+	invalidConfigurations := []string{
+		`<CORSConfiguration></CORSConfiguration>`,
+		`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+		`<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+		`<CORSConfiguration><CORSRule><AllowedOrigin>*.*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+		`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><MaxAgeSeconds>-1</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+		`not xml`,
+	}
+	for _, invalidConfiguration := range invalidConfigurations {
+		_, err = parseCorsConfiguration([]byte(invalidConfiguration))
+		assert.NotNil(t, err, invalidConfiguration)
+	}
+}
+
+func TestCorsMatch(t *testing.T) {
+	config, err := parseCorsConfiguration([]byte(testCorsConfiguration))
+	assert.Nil(t, err)
+
+	assert.Equal(t, &config.CORSRules[0], config.match("https://www.example.com", "PUT", []string{"content-type", "X-Amz-Date"}))
+	assert.Equal(t, &config.CORSRules[0], config.match("https://app.example.org", "POST", nil))
+	assert.Equal(t, &config.CORSRules[1], config.match("https://www.example.com", "GET", nil))
+	assert.Nil(t, config.match("https://www.example.com", "PUT", []string{"authorization"}))
+	assert.Nil(t, config.match("https://evil.com", "PUT", nil))
+	assert.Nil(t, config.match("https://example.org", "PUT", nil))
+	assert.Nil(t, config.match("https://www.example.com", "DELETE", nil))
+}
+
+func TestBucketCorsPreflight(t *testing.T) {
+	config, err := parseCorsConfiguration([]byte(testCorsConfiguration))
+	assert.Nil(t, err)
+
+	s3a := &S3ApiServer{
+		option: &S3ApiServerOption{AllowedOrigins: []string{"*"}},
+		bucketRegistry: &BucketRegistry{
+			metadataCache: map[string]*BucketMetaData{
+				"bucket1": {Name: "bucket1", Cors: config},
+				"bucket2": {Name: "bucket2"},
+			},
+			notFound: make(map[string]struct{}),
+		},
+	}
+
+	preflight := func(bucket, origin, method, headers string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodOptions, "/"+bucket+"/key", nil)
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", method)
+		if headers != "" {
+			r.Header.Set("Access-Control-Request-Headers", headers)
+		}
+		r = mux.SetURLVars(r, map[string]string{"bucket": bucket, "object": "key"})
+		w := httptest.NewRecorder()
+		s3a.BucketCorsPreflightHandler(w, r)
+		return w
+	}
+
+	w := preflight("bucket1", "https://www.example.com", "PUT", "content-type, x-amz-date")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://www.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "PUT, POST", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "content-type, x-amz-date", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "ETag", w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "3000", w.Header().Get("Access-Control-Max-Age"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+
+	w = preflight("bucket1", "https://other.com", "GET", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = preflight("bucket1", "https://other.com", "PUT", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = preflight("bucket2", "https://other.com", "PUT", "")
+	assert.Equal(t, http.StatusOK, w.Code, "buckets without CORS rules use the server wide allowed origins")
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+	// actual request
+	r := httptest.NewRequest(http.MethodGet, "/bucket1/key", nil)
+	r.Header.Set("Origin", "https://www.example.com")
+	r = mux.SetURLVars(r, map[string]string{"bucket": "bucket1", "object": "key"})
+	w = httptest.NewRecorder()
+	s3a.bucketCorsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeSuccessResponseEmpty(w, r)
+	})).ServeHTTP(w, r)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET", w.Header().Get("Access-Control-Allow-Methods"))
+}
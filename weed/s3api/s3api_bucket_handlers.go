@@ -6,6 +6,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -56,7 +58,12 @@ func (s3a *S3ApiServer) ListBucketsHandler(w http.ResponseWriter, r *http.Reques
 	var listBuckets ListAllMyBucketsList
 	for _, entry := range entries {
 		if entry.IsDirectory {
-			if identity != nil && !identity.canDo(s3_constants.ACTION_LIST, entry.Name, "") {
+			if identity != nil && !identity.isAllowed(&s3policy.Request{
+				Principals: policyPrincipals(identity),
+				Action:     "s3:ListBucket",
+				Resource:   policyResource(entry.Name, ""),
+				Conditions: policyConditions(r, identity),
+			}, s3_constants.ACTION_LIST, entry.Name, "") {
 				continue
 			}
 			listBuckets.Bucket = append(listBuckets.Bucket, ListAllMyBucketsEntry{
@@ -135,6 +142,12 @@ func (s3a *S3ApiServer) PutBucketHandler(w http.ResponseWriter, r *http.Request)
 		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
 		return
 	}
+	if strings.EqualFold(r.Header.Get(s3_constants.AmzBucketObjectLockEnabled), "true") {
+		if errCode := s3a.enableBucketObjectLock(bucket); errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+	}
 	w.Header().Set("Location", "/"+bucket)
 	writeSuccessResponseEmpty(w, r)
 }
@@ -150,13 +163,15 @@ func (s3a *S3ApiServer) DeleteBucketHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	err := s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
-		if !s3a.option.AllowDeleteBucketNotEmpty {
-			entries, _, err := s3a.list(s3a.option.BucketsPath+"/"+bucket, "", "", false, 2)
+		// buckets with object lock must be emptied first, so that no locked object is removed with the bucket
+		if !s3a.option.AllowDeleteBucketNotEmpty || s3a.getObjectLockConfiguration(bucket) != nil {
+			entries, _, err := s3a.list(s3a.option.BucketsPath+"/"+bucket, "", "", false, 3)
 			if err != nil {
 				return fmt.Errorf("failed to list bucket %s: %v", bucket, err)
 			}
 			for _, entry := range entries {
-				if entry.Name != s3_constants.MultipartUploadsFolder {
+				// the trash of the bucket is removed with it
+				if entry.Name != s3_constants.MultipartUploadsFolder && entry.Name != filer.TrashFolder {
 					return errors.New(s3err.GetAPIError(s3err.ErrBucketNotEmpty).Code)
 				}
 			}
@@ -184,7 +199,8 @@ func (s3a *S3ApiServer) DeleteBucketHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = s3a.rm(s3a.option.BucketsPath, bucket, false, true)
+	// the objects transitioned to other collections are not deleted along with the bucket collection
+	err = s3a.rm(s3a.option.BucketsPath, bucket, s3a.hasStorageClassCollections(), true)
 
 	if err != nil {
 		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
@@ -294,6 +310,10 @@ func (s3a *S3ApiServer) PutBucketAclHandler(w http.ResponseWriter, r *http.Reque
 			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
 			return
 		}
+		if HasPublicGrant(acl.Grants) && s3a.iam.getPublicAccessBlock(bucket).blockPublicAcls() {
+			s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+			return
+		}
 		if len(acl.Grants) == 1 && acl.Grants[0].Permission != nil && *acl.Grants[0].Permission == s3_constants.PermissionFullControl {
 			writeSuccessResponseEmpty(w, r)
 			return
@@ -316,6 +336,25 @@ func (s3a *S3ApiServer) GetBucketLifecycleConfigurationHandler(w http.ResponseWr
 		s3err.WriteErrorResponse(w, r, err)
 		return
 	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if lifecycleBytes, found := bucketEntry.Extended[s3_constants.ExtLifecycleKey]; found {
+		response := Lifecycle{}
+		if err := xml.Unmarshal(lifecycleBytes, &response); err != nil {
+			glog.Errorf("GetBucketLifecycleConfigurationHandler %s: %v", bucket, err)
+			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+			return
+		}
+		response.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+		writeSuccessResponseXML(w, r, response)
+		return
+	}
+
+	// the expiration days set by older versions as filer.conf ttl
 	fc, err := filer.ReadFilerConf(s3a.option.Filer, s3a.option.GrpcDialOption, nil)
 	if err != nil {
 		glog.Errorf("GetBucketLifecycleConfigurationHandler: %s", err)
@@ -362,71 +401,53 @@ func (s3a *S3ApiServer) PutBucketLifecycleConfigurationHandler(w http.ResponseWr
 		return
 	}
 
+	defer util_http.CloseRequest(r)
+	lifecycleBytes, err := io.ReadAll(io.LimitReader(r.Body, maxLifecycleConfigSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(lifecycleBytes) > maxLifecycleConfigSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
 	lifeCycleConfig := Lifecycle{}
-	if err := xmlDecoder(r.Body, &lifeCycleConfig, r.ContentLength); err != nil {
+	if err := xml.Unmarshal(lifecycleBytes, &lifeCycleConfig); err != nil {
 		glog.Warningf("PutBucketLifecycleConfigurationHandler xml decode: %s", err)
 		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
 		return
 	}
-
-	fc, err := filer.ReadFilerConf(s3a.option.Filer, s3a.option.GrpcDialOption, nil)
-	if err != nil {
-		glog.Errorf("PutBucketLifecycleConfigurationHandler read filer config: %s", err)
-		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+	if err := lifeCycleConfig.validate(); err != nil {
+		glog.V(1).Infof("PutBucketLifecycleConfigurationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
 		return
 	}
-	collectionName := s3a.getCollectionName(bucket)
-	collectionTtls := fc.GetCollectionTtls(collectionName)
-	changed := false
-
 	for _, rule := range lifeCycleConfig.Rules {
-		if rule.Status != Enabled {
-			continue
-		}
-		var rulePrefix string
-		switch {
-		case rule.Filter.Prefix.set:
-			rulePrefix = rule.Filter.Prefix.val
-		case rule.Prefix.set:
-			rulePrefix = rule.Prefix.val
-		case !rule.Expiration.Date.IsZero() || rule.Transition.Days > 0 || !rule.Transition.Date.IsZero():
-			s3err.WriteErrorResponse(w, r, s3err.ErrNotImplemented)
-			return
-		}
-
-		if rule.Expiration.Days == 0 {
-			continue
+		for _, transition := range rule.Transitions {
+			if _, found := s3a.storageClasses[transition.StorageClass]; !found {
+				s3err.WriteErrorResponse(w, r, s3err.ErrInvalidStorageClass)
+				return
+			}
 		}
+	}
+	lifeCycleConfig.Xmlns = ""
+	if lifecycleBytes, err = xml.Marshal(lifeCycleConfig); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
 
-		locConf := &filer_pb.FilerConf_PathConf{
-			LocationPrefix: fmt.Sprintf("%s/%s/%s", s3a.option.BucketsPath, bucket, rulePrefix),
-			Collection:     collectionName,
-			Ttl:            fmt.Sprintf("%dd", rule.Expiration.Days),
-		}
-		if ttl, ok := collectionTtls[locConf.LocationPrefix]; ok && ttl == locConf.Ttl {
-			continue
-		}
-		if err := fc.AddLocationConf(locConf); err != nil {
-			glog.Errorf("PutBucketLifecycleConfigurationHandler add location config: %s", err)
-			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
-			return
-		}
-		changed = true
+	// the new rules replace the expiration days set by older versions as filer.conf ttl
+	if err := s3a.removeLifecycleTtls(bucket); err != nil {
+		glog.Errorf("PutBucketLifecycleConfigurationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
 	}
 
-	if changed {
-		var buf bytes.Buffer
-		if err := fc.ToText(&buf); err != nil {
-			glog.Errorf("PutBucketLifecycleConfigurationHandler save config to text: %s", err)
-			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
-		}
-		if err := s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
-			return filer.SaveInsideFiler(client, filer.DirectoryEtcSeaweedFS, filer.FilerConfName, buf.Bytes())
-		}); err != nil {
-			glog.Errorf("PutBucketLifecycleConfigurationHandler save config inside filer: %s", err)
-			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
-			return
-		}
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtLifecycleKey] = lifecycleBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
 	}
 
 	writeSuccessResponseEmpty(w, r)
@@ -444,12 +465,28 @@ func (s3a *S3ApiServer) DeleteBucketLifecycleHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	fc, err := filer.ReadFilerConf(s3a.option.Filer, s3a.option.GrpcDialOption, nil)
-	if err != nil {
-		glog.Errorf("DeleteBucketLifecycleHandler read filer config: %s", err)
+	if err := s3a.removeLifecycleTtls(bucket); err != nil {
+		glog.Errorf("DeleteBucketLifecycleHandler %s: %v", bucket, err)
 		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
 		return
 	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		delete(extended, s3_constants.ExtLifecycleKey)
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+}
+
+// removeLifecycleTtls removes the expiration days set as filer.conf ttl under the bucket
+func (s3a *S3ApiServer) removeLifecycleTtls(bucket string) error {
+	fc, err := filer.ReadFilerConf(s3a.option.Filer, s3a.option.GrpcDialOption, nil)
+	if err != nil {
+		return fmt.Errorf("read filer config: %v", err)
+	}
 	collectionTtls := fc.GetCollectionTtls(s3a.getCollectionName(bucket))
 	changed := false
 	for prefix, ttl := range collectionTtls {
@@ -463,23 +500,20 @@ func (s3a *S3ApiServer) DeleteBucketLifecycleHandler(w http.ResponseWriter, r *h
 			changed = true
 		}
 	}
-
-	if changed {
-		var buf bytes.Buffer
-		if err := fc.ToText(&buf); err != nil {
-			glog.Errorf("DeleteBucketLifecycleHandler save config to text: %s", err)
-			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
-		}
-		if err := s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
-			return filer.SaveInsideFiler(client, filer.DirectoryEtcSeaweedFS, filer.FilerConfName, buf.Bytes())
-		}); err != nil {
-			glog.Errorf("DeleteBucketLifecycleHandler save config inside filer: %s", err)
-			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
-			return
-		}
+	if !changed {
+		return nil
 	}
 
-	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+	var buf bytes.Buffer
+	if err := fc.ToText(&buf); err != nil {
+		return fmt.Errorf("save config to text: %v", err)
+	}
+	if err := s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.SaveInsideFiler(client, filer.DirectoryEtcSeaweedFS, filer.FilerConfName, buf.Bytes())
+	}); err != nil {
+		return fmt.Errorf("save config inside filer: %v", err)
+	}
+	return nil
 }
 
 // GetBucketLocationHandler Get bucket location
@@ -668,9 +702,78 @@ func (s3a *S3ApiServer) GetBucketVersioningHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchBucket)
+			return
+		}
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	// a bucket which never had versioning enabled has no status
+	versioningConfiguration := &s3.VersioningConfiguration{}
+	if versioning, ok := bucketEntry.Extended[s3_constants.ExtVersioningKey]; ok {
+		versioningConfiguration.Status = aws.String(string(versioning))
+	}
+
 	s3err.WriteAwsXMLResponse(w, r, http.StatusOK, &s3.PutBucketVersioningInput{
-		VersioningConfiguration: &s3.VersioningConfiguration{
-			Status: aws.String(s3.BucketVersioningStatusSuspended),
-		},
+		VersioningConfiguration: versioningConfiguration,
 	})
 }
+
+// PutBucketVersioningHandler Put bucket Versioning
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketVersioning.html
+func (s3a *S3ApiServer) PutBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutBucketVersioning %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	var v s3.VersioningConfiguration
+	defer util_http.CloseRequest(r)
+
+	if err := xmlutil.UnmarshalXML(&v, xml.NewDecoder(r.Body), ""); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+
+	status := aws.StringValue(v.Status)
+	if status != s3.BucketVersioningStatusEnabled && status != s3.BucketVersioningStatusSuspended {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	if status == s3.BucketVersioningStatusSuspended && s3a.getObjectLockConfiguration(bucket) != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidBucketState)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchBucket)
+			return
+		}
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	if oldStatus, ok := bucketEntry.Extended[s3_constants.ExtVersioningKey]; !ok || string(oldStatus) != status {
+		if bucketEntry.Extended == nil {
+			bucketEntry.Extended = make(map[string][]byte)
+		}
+		bucketEntry.Extended[s3_constants.ExtVersioningKey] = []byte(status)
+		if err = s3a.updateEntry(s3a.option.BucketsPath, bucketEntry); err != nil {
+			glog.Errorf("PutBucketVersioning %s: %v", bucket, err)
+			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+			return
+		}
+		s3a.bucketRegistry.LoadBucketMetadata(bucketEntry)
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
@@ -0,0 +1,342 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// The lifecycle configuration of a bucket is kept in its extended attributes, and applied by
+// the lifecycle worker of the s3 gateway, see s3api_bucket_lifecycle_worker.go:
+//
+//   - Expiration deletes the current version, or places a delete marker if the bucket is versioned,
+//     and removes expired delete markers without any noncurrent version left.
+//   - NoncurrentVersionExpiration deletes the noncurrent versions some days after they became noncurrent.
+//   - AbortIncompleteMultipartUpload removes the multipart uploads not completed in time.
+//   - Transition moves the data of the current version to the collection and disk type
+//     configured for the storage class with the -storageClasses option.
+//
+// Older gateways mapped Expiration.Days to a filer.conf TTL, which is still reported and
+// removed when the bucket has no lifecycle configuration of its own.
+
+const (
+	maxLifecycleRules      = 1000
+	maxLifecycleConfigSize = 1024 * 1024
+	maxLifecycleRuleIdLen  = 255
+)
+
+// storageClassPlacement is where the data of the objects transitioned to a storage class is kept
+type storageClassPlacement struct {
+	Collection string
+	DiskType   string
+}
+
+// parseStorageClassPlacements parses a comma separated list of <storageClass>=[<collection>:]<diskType>
+func parseStorageClassPlacements(value string) (map[string]storageClassPlacement, error) {
+	placements := make(map[string]storageClassPlacement)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		storageClass, target, found := strings.Cut(item, "=")
+		if !found || storageClass == "" || target == "" {
+			return nil, fmt.Errorf("invalid storage class %q, expecting <storageClass>=[<collection>:]<diskType>", item)
+		}
+		if storageClass == "STANDARD" {
+			return nil, fmt.Errorf("storage class STANDARD can not be a transition target")
+		}
+		var placement storageClassPlacement
+		if collection, diskType, hasCollection := strings.Cut(target, ":"); hasCollection {
+			placement = storageClassPlacement{Collection: collection, DiskType: diskType}
+		} else {
+			placement = storageClassPlacement{DiskType: target}
+		}
+		placements[storageClass] = placement
+	}
+	return placements, nil
+}
+
+// hasStorageClassCollections checks whether objects can be transitioned out of their bucket collection
+func (s3a *S3ApiServer) hasStorageClassCollections() bool {
+	for _, placement := range s3a.storageClasses {
+		if placement.Collection != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseLifecycleConfiguration(data []byte) (*Lifecycle, error) {
+	config := &Lifecycle{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *Lifecycle) validate() error {
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("no lifecycle rule")
+	}
+	if len(c.Rules) > maxLifecycleRules {
+		return fmt.Errorf("more than %d lifecycle rules", maxLifecycleRules)
+	}
+	ids := make(map[string]bool)
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if len(rule.ID) > maxLifecycleRuleIdLen {
+			return fmt.Errorf("rule %d: ID longer than %d characters", i, maxLifecycleRuleIdLen)
+		}
+		if rule.ID != "" {
+			if ids[rule.ID] {
+				return fmt.Errorf("rule %d: duplicated ID %s", i, rule.ID)
+			}
+			ids[rule.ID] = true
+		}
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func (rule *Rule) validate() error {
+	if rule.Status != Enabled && rule.Status != Disabled {
+		return fmt.Errorf("invalid status %q", rule.Status)
+	}
+	if rule.Filter.set && rule.Prefix.set {
+		return fmt.Errorf("both Filter and Prefix are specified")
+	}
+	if err := rule.Filter.validate(); err != nil {
+		return err
+	}
+	if !rule.Expiration.set && len(rule.Transitions) == 0 && !rule.NoncurrentVersionExpiration.set && !rule.AbortIncompleteMultipartUpload.set {
+		return fmt.Errorf("no lifecycle action")
+	}
+
+	if rule.Expiration.set {
+		expiration := rule.Expiration
+		actions := 0
+		if expiration.Days != 0 {
+			actions++
+		}
+		if !expiration.Date.IsZero() {
+			actions++
+		}
+		if expiration.DeleteMarker.set {
+			actions++
+		}
+		if actions != 1 {
+			return fmt.Errorf("Expiration needs exactly one of Days, Date and ExpiredObjectDeleteMarker")
+		}
+		if expiration.Days < 0 {
+			return fmt.Errorf("Expiration Days must be a positive integer")
+		}
+		if err := validateLifecycleDate(expiration.Date); err != nil {
+			return fmt.Errorf("Expiration %v", err)
+		}
+		if expiration.DeleteMarker.val && rule.hasTagFilter() {
+			return fmt.Errorf("ExpiredObjectDeleteMarker can not be used with tag filters")
+		}
+	}
+
+	for _, transition := range rule.Transitions {
+		if (transition.Days != 0) == !transition.Date.IsZero() {
+			return fmt.Errorf("Transition needs exactly one of Days and Date")
+		}
+		if transition.Days < 0 {
+			return fmt.Errorf("Transition Days must be a positive integer")
+		}
+		if err := validateLifecycleDate(transition.Date); err != nil {
+			return fmt.Errorf("Transition %v", err)
+		}
+		if transition.StorageClass == "" {
+			return fmt.Errorf("Transition has no StorageClass")
+		}
+	}
+
+	if rule.NoncurrentVersionExpiration.set {
+		if rule.NoncurrentVersionExpiration.NoncurrentDays <= 0 {
+			return fmt.Errorf("NoncurrentDays must be a positive integer")
+		}
+		if rule.NoncurrentVersionExpiration.NewerNoncurrentVersions < 0 {
+			return fmt.Errorf("NewerNoncurrentVersions must be a positive integer")
+		}
+	}
+
+	if rule.AbortIncompleteMultipartUpload.set {
+		if rule.AbortIncompleteMultipartUpload.DaysAfterInitiation <= 0 {
+			return fmt.Errorf("DaysAfterInitiation must be a positive integer")
+		}
+		if rule.hasTagFilter() {
+			return fmt.Errorf("AbortIncompleteMultipartUpload can not be used with tag filters")
+		}
+	}
+	return nil
+}
+
+func (f *Filter) validate() error {
+	if !f.set {
+		return nil
+	}
+	conditions := 0
+	if f.Prefix.set {
+		conditions++
+	}
+	if f.tagSet {
+		conditions++
+	}
+	if f.andSet {
+		conditions++
+	}
+	if f.ObjectSizeGreaterThan > 0 {
+		conditions++
+	}
+	if f.ObjectSizeLessThan > 0 {
+		conditions++
+	}
+	if conditions > 1 {
+		return fmt.Errorf("Filter conditions must be combined with And")
+	}
+	if f.tagSet && f.Tag.Key == "" {
+		return fmt.Errorf("Filter Tag has no Key")
+	}
+	for _, tag := range f.And.Tags {
+		if tag.Key == "" {
+			return fmt.Errorf("Filter And Tag has no Key")
+		}
+	}
+	greaterThan, lessThan := f.ObjectSizeGreaterThan, f.ObjectSizeLessThan
+	if f.andSet {
+		greaterThan, lessThan = f.And.ObjectSizeGreaterThan, f.And.ObjectSizeLessThan
+	}
+	if greaterThan < 0 || lessThan < 0 || (lessThan > 0 && lessThan <= greaterThan) {
+		return fmt.Errorf("invalid object size range")
+	}
+	return nil
+}
+
+// validateLifecycleDate checks the date, if any, is at midnight UTC
+func validateLifecycleDate(date ExpirationDate) error {
+	if date.IsZero() {
+		return nil
+	}
+	if !date.Equal(date.Truncate(24 * time.Hour)) {
+		return fmt.Errorf("Date must be at midnight UTC")
+	}
+	return nil
+}
+
+// prefix returns the key prefix of the objects the rule applies to
+func (rule *Rule) prefix() string {
+	if rule.Filter.set {
+		if rule.Filter.andSet {
+			return rule.Filter.And.Prefix.val
+		}
+		return rule.Filter.Prefix.val
+	}
+	return rule.Prefix.val
+}
+
+func (rule *Rule) tags() []Tag {
+	if rule.Filter.andSet {
+		return rule.Filter.And.Tags
+	}
+	if rule.Filter.tagSet {
+		return []Tag{rule.Filter.Tag}
+	}
+	return nil
+}
+
+func (rule *Rule) hasTagFilter() bool {
+	return len(rule.tags()) > 0
+}
+
+// match checks whether the rule applies to the object version stored in the entry
+func (rule *Rule) match(key string, entry *filer_pb.Entry) bool {
+	if rule.Status != Enabled || !strings.HasPrefix(key, rule.prefix()) {
+		return false
+	}
+	for _, tag := range rule.tags() {
+		if value, found := entry.Extended[S3TAG_PREFIX+tag.Key]; !found || string(value) != tag.Value {
+			return false
+		}
+	}
+	greaterThan, lessThan := rule.Filter.ObjectSizeGreaterThan, rule.Filter.ObjectSizeLessThan
+	if rule.Filter.andSet {
+		greaterThan, lessThan = rule.Filter.And.ObjectSizeGreaterThan, rule.Filter.And.ObjectSizeLessThan
+	}
+	size := int64(filer.FileSize(entry))
+	if greaterThan > 0 && size <= greaterThan {
+		return false
+	}
+	if lessThan > 0 && size >= lessThan {
+		return false
+	}
+	return true
+}
+
+// lifecycleDueTime returns when an action configured for some days after the given time is due.
+// Like S3, the time is rounded up to the next midnight UTC.
+func lifecycleDueTime(since time.Time, days int) time.Time {
+	due := since.UTC().Add(time.Duration(days) * 24 * time.Hour)
+	if midnight := due.Truncate(24 * time.Hour); midnight.Before(due) {
+		return midnight.Add(24 * time.Hour)
+	}
+	return due
+}
+
+// isExpired checks whether the current version, last modified at the given time, has expired
+func (rule *Rule) isExpired(modifiedAt, now time.Time) bool {
+	expiration := rule.Expiration
+	switch {
+	case !expiration.set:
+		return false
+	case expiration.Days > 0:
+		return !now.Before(lifecycleDueTime(modifiedAt, expiration.Days))
+	case !expiration.Date.IsZero():
+		return !now.Before(expiration.Date.Time)
+	}
+	return false
+}
+
+// transitionStorageClass returns the storage class of the latest transition due for the current version
+func (rule *Rule) transitionStorageClass(modifiedAt, now time.Time) (storageClass string) {
+	var latest time.Time
+	for _, transition := range rule.Transitions {
+		due := transition.Date.Time
+		if transition.Days > 0 {
+			due = lifecycleDueTime(modifiedAt, transition.Days)
+		}
+		if !now.Before(due) && !due.Before(latest) {
+			latest, storageClass = due, transition.StorageClass
+		}
+	}
+	return
+}
+
+// isNoncurrentExpired checks whether a noncurrent version has expired,
+// given when it became noncurrent and how many noncurrent versions are newer
+func (rule *Rule) isNoncurrentExpired(noncurrentSince, now time.Time, newerNoncurrentVersions int) bool {
+	expiration := rule.NoncurrentVersionExpiration
+	if !expiration.set || newerNoncurrentVersions < expiration.NewerNoncurrentVersions {
+		return false
+	}
+	return !now.Before(lifecycleDueTime(noncurrentSince, expiration.NoncurrentDays))
+}
+
+// isUploadAborted checks whether a multipart upload of the key, initiated at the given time, should be aborted
+func (rule *Rule) isUploadAborted(key string, initiatedAt, now time.Time) bool {
+	abort := rule.AbortIncompleteMultipartUpload
+	if rule.Status != Enabled || !abort.set || !strings.HasPrefix(key, rule.prefix()) {
+		return false
+	}
+	return !now.Before(lifecycleDueTime(initiatedAt, abort.DaysAfterInitiation))
+}
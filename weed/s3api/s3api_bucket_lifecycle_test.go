@@ -0,0 +1,182 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLifecycleConfiguration(t *testing.T) {
+	config, err := parseLifecycleConfiguration([]byte(`<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule>
+    <ID>logs</ID>
+    <Filter>
+      <And>
+        <Prefix>logs/</Prefix>
+        <Tag><Key>class</Key><Value>temp</Value></Tag>
+        <ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+      </And>
+    </Filter>
+    <Status>Enabled</Status>
+    <Transition><Days>30</Days><StorageClass>GLACIER</StorageClass></Transition>
+    <Expiration><Date>2030-01-01T00:00:00.000Z</Date></Expiration>
+    <NoncurrentVersionExpiration><NoncurrentDays>7</NoncurrentDays><NewerNoncurrentVersions>2</NewerNoncurrentVersions></NoncurrentVersionExpiration>
+  </Rule>
+  <Rule>
+    <ID>uploads</ID>
+    <Filter><Prefix>tmp/</Prefix></Filter>
+    <Status>Disabled</Status>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>3</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+    <Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration>
+  </Rule>
+</LifecycleConfiguration>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 2, len(config.Rules))
+
+	rule := config.Rules[0]
+	assert.Equal(t, "logs/", rule.prefix())
+	assert.Equal(t, []Tag{{Key: "class", Value: "temp"}}, rule.tags())
+	assert.Equal(t, int64(1024), rule.Filter.And.ObjectSizeGreaterThan)
+	assert.Equal(t, []Transition{{XMLName: xml.Name{Space: "http://s3.amazonaws.com/doc/2006-03-01/", Local: "Transition"}, Days: 30, StorageClass: "GLACIER", set: true}}, rule.Transitions)
+	assert.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), rule.Expiration.Date.Time)
+	assert.Equal(t, 7, rule.NoncurrentVersionExpiration.NoncurrentDays)
+	assert.Equal(t, 2, rule.NoncurrentVersionExpiration.NewerNoncurrentVersions)
+
+	rule = config.Rules[1]
+	assert.Equal(t, "tmp/", rule.prefix())
+	assert.Equal(t, 3, rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+	assert.True(t, rule.Expiration.DeleteMarker.val)
+
+	// the configuration survives being stored and read back
+	data, err := xml.Marshal(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	stored, err := parseLifecycleConfiguration(data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	restored, err := xml.Marshal(stored)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(restored))
+}
+
+func TestLifecycleValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		valid bool
+	}{
+		{"expiration days", `<Filter></Filter><Expiration><Days>1</Days></Expiration>`, true},
+		{"legacy prefix", `<Prefix>a/</Prefix><Expiration><Days>1</Days></Expiration>`, true},
+		{"no action", `<Filter><Prefix>a/</Prefix></Filter>`, false},
+		{"filter and prefix", `<Prefix>a/</Prefix><Filter><Prefix>a/</Prefix></Filter><Expiration><Days>1</Days></Expiration>`, false},
+		{"days and date", `<Expiration><Days>1</Days><Date>2030-01-01T00:00:00Z</Date></Expiration>`, false},
+		{"date not at midnight", `<Expiration><Date>2030-01-01T10:00:00Z</Date></Expiration>`, false},
+		{"conditions without and", `<Filter><Prefix>a/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></Filter><Expiration><Days>1</Days></Expiration>`, false},
+		{"tag filter with delete marker", `<Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration>`, false},
+		{"tag filter with abort upload", `<Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload>`, false},
+		{"transition without storage class", `<Transition><Days>1</Days></Transition>`, false},
+		{"invalid size range", `<Filter><And><ObjectSizeGreaterThan>10</ObjectSizeGreaterThan><ObjectSizeLessThan>5</ObjectSizeLessThan></And></Filter><Expiration><Days>1</Days></Expiration>`, false},
+		{"zero noncurrent days", `<NoncurrentVersionExpiration><NoncurrentDays>0</NoncurrentDays></NoncurrentVersionExpiration>`, false},
+	}
+	for _, tt := range tests {
+		_, err := parseLifecycleConfiguration([]byte("<LifecycleConfiguration><Rule><Status>Enabled</Status>" + tt.rule + "</Rule></LifecycleConfiguration>"))
+		assert.Equal(t, tt.valid, err == nil, "%s: %v", tt.name, err)
+	}
+}
+
+func TestLifecycleRuleMatch(t *testing.T) {
+	config, err := parseLifecycleConfiguration([]byte(`<LifecycleConfiguration><Rule><Status>Enabled</Status>
+<Filter><And><Prefix>logs/</Prefix><Tag><Key>class</Key><Value>temp</Value></Tag><ObjectSizeLessThan>100</ObjectSizeLessThan></And></Filter>
+<Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	rule := &config.Rules[0]
+
+	entry := &filer_pb.Entry{
+		Attributes: &filer_pb.FuseAttributes{FileSize: 10},
+		Extended:   map[string][]byte{S3TAG_PREFIX + "class": []byte("temp")},
+	}
+	assert.True(t, rule.match("logs/a.log", entry))
+	assert.False(t, rule.match("data/a.log", entry))
+
+	entry.Extended[S3TAG_PREFIX+"class"] = []byte("keep")
+	assert.False(t, rule.match("logs/a.log", entry))
+
+	entry.Extended[S3TAG_PREFIX+"class"] = []byte("temp")
+	entry.Attributes.FileSize = 100
+	assert.False(t, rule.match("logs/a.log", entry))
+
+	rule.Status = Disabled
+	entry.Attributes.FileSize = 10
+	assert.False(t, rule.match("logs/a.log", entry))
+}
+
+func TestLifecycleDueTime(t *testing.T) {
+	modifiedAt := time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), lifecycleDueTime(modifiedAt, 1))
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), lifecycleDueTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 1))
+
+	rule := &Rule{
+		Status:     Enabled,
+		Expiration: Expiration{Days: 1, set: true},
+		Transitions: []Transition{
+			{Days: 1, StorageClass: "STANDARD_IA", set: true},
+			{Days: 3, StorageClass: "GLACIER", set: true},
+		},
+		NoncurrentVersionExpiration:    NoncurrentVersionExpiration{NoncurrentDays: 1, NewerNoncurrentVersions: 1, set: true},
+		AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 2, set: true},
+	}
+	assert.False(t, rule.isExpired(modifiedAt, time.Date(2024, 3, 2, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, rule.isExpired(modifiedAt, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, "", rule.transitionStorageClass(modifiedAt, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "STANDARD_IA", rule.transitionStorageClass(modifiedAt, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "GLACIER", rule.transitionStorageClass(modifiedAt, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)))
+
+	now := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	assert.False(t, rule.isNoncurrentExpired(modifiedAt, now, 0))
+	assert.True(t, rule.isNoncurrentExpired(modifiedAt, now, 1))
+	assert.False(t, rule.isNoncurrentExpired(now, now, 1))
+
+	assert.True(t, rule.isUploadAborted("any/key", modifiedAt, now))
+	assert.False(t, rule.isUploadAborted("any/key", now, now))
+}
+
+func TestParseStorageClassPlacements(t *testing.T) {
+	placements, err := parseStorageClassPlacements("GLACIER=hdd, DEEP_ARCHIVE=archive:hdd,ONEZONE_IA=cold:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]storageClassPlacement{
+		"GLACIER":      {DiskType: "hdd"},
+		"DEEP_ARCHIVE": {Collection: "archive", DiskType: "hdd"},
+		"ONEZONE_IA":   {Collection: "cold"},
+	}, placements)
+
+	placements, err = parseStorageClassPlacements("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(placements))
+
+	_, err = parseStorageClassPlacements("GLACIER")
+	assert.Error(t, err)
+	_, err = parseStorageClassPlacements("STANDARD=ssd")
+	assert.Error(t, err)
+}
+
+func TestCommonRulePrefix(t *testing.T) {
+	rules := []*Rule{
+		{Prefix: Prefix{val: "logs/2024/", set: true}},
+		{Filter: Filter{set: true, Prefix: Prefix{val: "logs/2023/", set: true}}},
+	}
+	assert.Equal(t, "logs/202", commonRulePrefix(rules))
+	rules = append(rules, &Rule{})
+	assert.Equal(t, "", commonRulePrefix(rules))
+}
@@ -0,0 +1,381 @@
+package s3api
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/cluster"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// The lifecycle worker runs in every s3 gateway, but only the one holding the lifecycle lock
+// on the filer applies the lifecycle rules, once shortly after startup and then periodically.
+
+const (
+	lifecycleLockKey        = "s3.lifecycle"
+	lifecycleWorkerDelay    = time.Minute
+	lifecycleWorkerInterval = time.Hour
+)
+
+func (s3a *S3ApiServer) runLifecycleWorker() {
+	self := util.JoinHostPort(util.DetectedHostAddress(), s3a.option.Port)
+	lock := cluster.NewLockClient(s3a.option.GrpcDialOption, s3a.option.Filer).StartLongLivedLock(lifecycleLockKey, self, func(newLockOwner string) {
+		glog.V(0).Infof("s3 lifecycle worker is %s", newLockOwner)
+	})
+
+	time.Sleep(lifecycleWorkerDelay)
+	for {
+		if lock.LockOwner() == self {
+			s3a.applyLifecycles(time.Now())
+		}
+		time.Sleep(lifecycleWorkerInterval)
+	}
+}
+
+// applyLifecycles applies the lifecycle rules of all buckets
+func (s3a *S3ApiServer) applyLifecycles(now time.Time) {
+	lifecycles := make(map[string]*Lifecycle)
+	err := filer_pb.List(s3a, s3a.option.BucketsPath, "", func(entry *filer_pb.Entry, isLast bool) error {
+		lifecycleBytes, found := entry.Extended[s3_constants.ExtLifecycleKey]
+		if !entry.IsDirectory || !found {
+			return nil
+		}
+		lifecycle, err := parseLifecycleConfiguration(lifecycleBytes)
+		if err != nil {
+			glog.Warningf("bucket %s lifecycle configuration: %v", entry.Name, err)
+			return nil
+		}
+		lifecycles[entry.Name] = lifecycle
+		return nil
+	}, "", false, math.MaxUint32)
+	if err != nil {
+		glog.Errorf("list buckets under %s: %v", s3a.option.BucketsPath, err)
+		return
+	}
+
+	for bucket, lifecycle := range lifecycles {
+		glog.V(1).Infof("apply lifecycle rules of bucket %s", bucket)
+		if err := s3a.abortIncompleteMultipartUploads(bucket, lifecycle, now); err != nil {
+			glog.Errorf("bucket %s abort incomplete multipart uploads: %v", bucket, err)
+		}
+		if err := s3a.applyObjectLifecycle(bucket, lifecycle, now); err != nil {
+			glog.Errorf("bucket %s apply lifecycle: %v", bucket, err)
+		}
+	}
+}
+
+func (s3a *S3ApiServer) abortIncompleteMultipartUploads(bucket string, lifecycle *Lifecycle, now time.Time) error {
+	uploadsDir := s3a.genUploadsFolder(bucket)
+	var staleUploads []string
+	err := filer_pb.List(s3a, uploadsDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		key := strings.TrimPrefix(string(entry.Extended["key"]), "/")
+		initiatedAt := time.Unix(entry.Attributes.Crtime, 0)
+		for i := range lifecycle.Rules {
+			if lifecycle.Rules[i].isUploadAborted(key, initiatedAt, now) {
+				staleUploads = append(staleUploads, entry.Name)
+				break
+			}
+		}
+		return nil
+	}, "", false, math.MaxUint32)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			return nil
+		}
+		return fmt.Errorf("list uploads under %s: %v", uploadsDir, err)
+	}
+
+	for _, staleUpload := range staleUploads {
+		glog.V(1).Infof("abort incomplete multipart upload %s/%s", uploadsDir, staleUpload)
+		if err := s3a.rm(uploadsDir, staleUpload, true, true); err != nil {
+			return fmt.Errorf("abort upload %s/%s: %v", uploadsDir, staleUpload, err)
+		}
+	}
+	return nil
+}
+
+// applyObjectLifecycle expires and transitions the object versions of the bucket
+func (s3a *S3ApiServer) applyObjectLifecycle(bucket string, lifecycle *Lifecycle, now time.Time) error {
+	var rules []*Rule
+	for i := range lifecycle.Rules {
+		rule := &lifecycle.Rules[i]
+		if rule.Status == Enabled && (rule.Expiration.set || len(rule.Transitions) > 0 || rule.NoncurrentVersionExpiration.set) {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	bucketDir := fmt.Sprintf("%s/%s", s3a.option.BucketsPath, bucket)
+	prefix := commonRulePrefix(rules)
+	versioning := s3a.getVersioningState(bucket)
+
+	err := eachKeyVersions(s3a.listDirectory, bucketDir, prefix, versionsListPageSize, func(versions []objectVersion) {
+		s3a.applyKeyLifecycle(bucket, versions, rules, versioning, now)
+	})
+	if err != nil {
+		return fmt.Errorf("list object versions: %v", err)
+	}
+	return nil
+}
+
+func (s3a *S3ApiServer) applyKeyLifecycle(bucket string, versions []objectVersion, rules []*Rule, versioning string, now time.Time) {
+	latest := versions[0]
+	object := "/" + latest.key
+
+	for i := 1; i < len(versions); i++ {
+		version := versions[i]
+		noncurrentSince := time.Unix(0, versions[i-1].tsNs)
+		for _, rule := range rules {
+			if rule.match(version.key, version.entry) && rule.isNoncurrentExpired(noncurrentSince, now, i-1) {
+				glog.V(1).Infof("expire noncurrent version %s of %s%s", version.versionId, bucket, object)
+				if _, _, errCode := s3a.deleteVersionedObject(bucket, object, version.versionId, false); errCode != s3err.ErrNone {
+					glog.V(1).Infof("expire noncurrent version %s of %s%s: %v", version.versionId, bucket, object, s3err.GetAPIError(errCode).Code)
+				}
+				break
+			}
+		}
+	}
+
+	if isDeleteMarker(latest.entry) {
+		if len(versions) > 1 {
+			return
+		}
+		for _, rule := range rules {
+			if rule.Expiration.DeleteMarker.val && rule.match(latest.key, latest.entry) {
+				glog.V(1).Infof("remove expired delete marker of %s%s", bucket, object)
+				if _, _, errCode := s3a.deleteVersionedObject(bucket, object, latest.versionId, false); errCode != s3err.ErrNone {
+					glog.V(1).Infof("remove expired delete marker of %s%s: %v", bucket, object, s3err.GetAPIError(errCode).Code)
+				}
+				return
+			}
+		}
+		return
+	}
+
+	modifiedAt := time.Unix(0, latest.tsNs)
+	var storageClass string
+	for _, rule := range rules {
+		if !rule.match(latest.key, latest.entry) {
+			continue
+		}
+		if rule.isExpired(modifiedAt, now) {
+			glog.V(1).Infof("expire %s%s", bucket, object)
+			if err := s3a.expireObject(bucket, object, versioning); err != nil {
+				glog.Errorf("expire %s%s: %v", bucket, object, err)
+			}
+			return
+		}
+		if sc := rule.transitionStorageClass(modifiedAt, now); sc != "" {
+			storageClass = sc
+		}
+	}
+	if storageClass != "" && storageClass != string(latest.entry.Extended[s3_constants.AmzStorageClass]) {
+		glog.V(1).Infof("transition %s%s to %s", bucket, object, storageClass)
+		if err := s3a.transitionObject(bucket, object, latest.entry, storageClass); err != nil {
+			glog.Errorf("transition %s%s to %s: %v", bucket, object, storageClass, err)
+		}
+	}
+}
+
+// expireObject deletes the current version, or places a delete marker if the bucket is versioned
+func (s3a *S3ApiServer) expireObject(bucket, object, versioning string) error {
+	if versioning != "" {
+		if _, _, errCode := s3a.deleteVersionedObject(bucket, object, "", false); errCode != s3err.ErrNone {
+			return fmt.Errorf("place delete marker: %v", s3err.GetAPIError(errCode).Code)
+		}
+		return nil
+	}
+	dir, name := s3a.objectDirAndName(bucket, object)
+	return s3a.rm(dir, name, true, false)
+}
+
+// transitionObject moves the data of the current version to the placement of the storage class
+func (s3a *S3ApiServer) transitionObject(bucket, object string, entry *filer_pb.Entry, storageClass string) error {
+	placement, found := s3a.storageClasses[storageClass]
+	if !found {
+		return fmt.Errorf("storage class %s is not configured", storageClass)
+	}
+	dir, name := s3a.objectDirAndName(bucket, object)
+	assignRequest := &filer_pb.AssignVolumeRequest{
+		Count:      1,
+		Collection: placement.Collection,
+		DiskType:   placement.DiskType,
+		DataCenter: s3a.option.DataCenter,
+		Path:       string(util.NewFullPath(dir, name)),
+	}
+
+	// the chunk keys of objects encrypted with customer keys can not be read, so their manifests are kept as is
+	_, isCustomerEncrypted := entry.Extended[s3_constants.ExtSSECustomerKeyMD5Key]
+	chunks := entry.GetChunks()
+	if !isCustomerEncrypted {
+		dataChunks, _, err := filer.ResolveChunkManifest(filer.LookupFn(s3a), chunks, 0, math.MaxInt64)
+		if err != nil {
+			return fmt.Errorf("resolve chunk manifest: %v", err)
+		}
+		chunks = dataChunks
+	} else if filer.HasChunkManifest(chunks) {
+		return fmt.Errorf("large objects encrypted with customer keys can not be transitioned")
+	}
+
+	var newChunks []*filer_pb.FileChunk
+	for _, chunk := range chunks {
+		newChunk, err := s3a.copyChunk(chunk, assignRequest)
+		if err != nil {
+			s3a.deleteChunks(newChunks)
+			return err
+		}
+		newChunks = append(newChunks, newChunk)
+	}
+	if !isCustomerEncrypted {
+		manifestized, err := filer.MaybeManifestize(s3a.saveAsChunkFn(assignRequest, len(entry.GetChunks()) > 0 && len(entry.GetChunks()[0].CipherKey) > 0), newChunks)
+		if err != nil {
+			s3a.deleteChunks(newChunks)
+			return fmt.Errorf("manifestize chunks: %v", err)
+		}
+		newChunks = manifestized
+	}
+
+	// the object may have been overwritten while its data was copied
+	current, err := s3a.getEntry(dir, name)
+	if err != nil || !sameChunks(current.GetChunks(), entry.GetChunks()) {
+		s3a.deleteChunks(newChunks)
+		return fmt.Errorf("object changed during transition")
+	}
+	current.Chunks = newChunks
+	if current.Extended == nil {
+		current.Extended = make(map[string][]byte)
+	}
+	current.Extended[s3_constants.AmzStorageClass] = []byte(storageClass)
+	return s3a.updateEntry(dir, current)
+}
+
+// copyChunk copies the stored data of a chunk, which stays compressed or encrypted, to a newly assigned file id
+func (s3a *S3ApiServer) copyChunk(chunk *filer_pb.FileChunk, assignRequest *filer_pb.AssignVolumeRequest) (*filer_pb.FileChunk, error) {
+	fileId := chunk.GetFileIdString()
+	req, err := http.NewRequest(http.MethodGet, s3a.chunkProxyUrl(fileId), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := util_http.GetGlobalHttpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("read chunk %s: %v", fileId, err)
+	}
+	defer util_http.CloseResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("read chunk %s: %s", fileId, resp.Status)
+	}
+
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		return nil, err
+	}
+	newFileId, uploadResult, err, _ := uploader.UploadWithRetry(s3a, assignRequest, &operation.UploadOption{
+		IsInputCompressed: resp.Header.Get("Content-Encoding") == "gzip",
+		MimeType:          resp.Header.Get("Content-Type"),
+	}, func(host, fileId string) string {
+		return s3a.chunkProxyUrl(fileId)
+	}, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("copy chunk %s: %v", fileId, err)
+	}
+	if uploadResult.Error != "" {
+		return nil, fmt.Errorf("copy chunk %s: %s", fileId, uploadResult.Error)
+	}
+
+	fid, err := filer_pb.ToFileIdObject(newFileId)
+	if err != nil {
+		return nil, err
+	}
+	return &filer_pb.FileChunk{
+		FileId:       newFileId,
+		Fid:          fid,
+		Offset:       chunk.Offset,
+		Size:         chunk.Size,
+		ModifiedTsNs: chunk.ModifiedTsNs,
+		ETag:         chunk.ETag,
+		CipherKey:    chunk.CipherKey,
+		IsCompressed: chunk.IsCompressed,
+	}, nil
+}
+
+// saveAsChunkFn saves the manifest chunks, encrypted if the object data is
+func (s3a *S3ApiServer) saveAsChunkFn(assignRequest *filer_pb.AssignVolumeRequest, cipher bool) filer.SaveDataAsChunkFunctionType {
+	return func(reader io.Reader, name string, offset int64, tsNs int64) (*filer_pb.FileChunk, error) {
+		uploader, err := operation.NewUploader()
+		if err != nil {
+			return nil, err
+		}
+		fileId, uploadResult, err, _ := uploader.UploadWithRetry(s3a, assignRequest, &operation.UploadOption{
+			Filename: name,
+			Cipher:   cipher,
+		}, func(host, fileId string) string {
+			return s3a.chunkProxyUrl(fileId)
+		}, reader)
+		if err != nil {
+			return nil, err
+		}
+		if uploadResult.Error != "" {
+			return nil, fmt.Errorf("upload result: %s", uploadResult.Error)
+		}
+		return uploadResult.ToPbFileChunk(fileId, offset, tsNs), nil
+	}
+}
+
+func (s3a *S3ApiServer) chunkProxyUrl(fileId string) string {
+	return fmt.Sprintf("http://%s/?proxyChunkId=%s", s3a.option.Filer.ToHttpAddress(), fileId)
+}
+
+func (s3a *S3ApiServer) deleteChunks(chunks []*filer_pb.FileChunk) {
+	for _, chunk := range chunks {
+		req, err := http.NewRequest(http.MethodDelete, s3a.chunkProxyUrl(chunk.GetFileIdString()), nil)
+		if err != nil {
+			continue
+		}
+		resp, err := util_http.GetGlobalHttpClient().Do(req)
+		if err != nil {
+			glog.V(1).Infof("delete chunk %s: %v", chunk.GetFileIdString(), err)
+			continue
+		}
+		util_http.CloseResponse(resp)
+	}
+}
+
+func sameChunks(a, b []*filer_pb.FileChunk) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].GetFileIdString() != b[i].GetFileIdString() {
+			return false
+		}
+	}
+	return true
+}
+
+// commonRulePrefix returns the longest key prefix shared by all rules
+func commonRulePrefix(rules []*Rule) string {
+	prefix := rules[0].prefix()
+	for _, rule := range rules[1:] {
+		rulePrefix := rule.prefix()
+		i := 0
+		for i < len(prefix) && i < len(rulePrefix) && prefix[i] == rulePrefix[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return prefix
+}
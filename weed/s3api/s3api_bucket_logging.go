@@ -0,0 +1,169 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+const maxBucketLoggingSize = 16 * 1024
+
+// BucketLoggingConfiguration is the BucketLoggingStatus of a bucket, which tells where its server access logs
+// are delivered. The logging is disabled when LoggingEnabled is missing.
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/ServerLogs.html
+type BucketLoggingConfiguration struct {
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	Xmlns          string          `xml:"xmlns,attr,omitempty"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+type LoggingEnabled struct {
+	// the bucket the log objects are written to, which may be the logged bucket itself
+	TargetBucket string `xml:"TargetBucket"`
+	// the prefix of the keys of the log objects, e.g. "logs/"
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+func parseBucketLoggingStatus(data []byte) (*BucketLoggingConfiguration, error) {
+	status := &BucketLoggingConfiguration{}
+	if err := xml.Unmarshal(data, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetBucketLoggingHandler Returns the logging status of a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLogging.html
+func (s3a *S3ApiServer) GetBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetBucketLoggingHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	status := &BucketLoggingConfiguration{}
+	if statusBytes, ok := bucketEntry.Extended[s3_constants.ExtLoggingKey]; ok && len(statusBytes) > 0 {
+		if status, err = parseBucketLoggingStatus(statusBytes); err != nil {
+			glog.Errorf("GetBucketLoggingHandler %s: %v", bucket, err)
+			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+			return
+		}
+	}
+	status.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, r, status)
+}
+
+// PutBucketLoggingHandler Enables the server access logs of a bucket, or disables them with an empty logging status
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLogging.html
+func (s3a *S3ApiServer) PutBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutBucketLoggingHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	statusBytes, err := io.ReadAll(io.LimitReader(r.Body, maxBucketLoggingSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(statusBytes) > maxBucketLoggingSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
+	status, err := parseBucketLoggingStatus(statusBytes)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+
+	if status.LoggingEnabled == nil {
+		if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+			delete(extended, s3_constants.ExtLoggingKey)
+		}); errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		writeSuccessResponseEmpty(w, r)
+		return
+	}
+
+	status.LoggingEnabled.TargetPrefix = strings.TrimPrefix(status.LoggingEnabled.TargetPrefix, "/")
+	if errCode := s3a.checkLoggingTarget(r, status.LoggingEnabled); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	status.Xmlns = ""
+	if statusBytes, err = xml.Marshal(status); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtLoggingKey] = statusBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// checkLoggingTarget checks the target bucket exists, and the caller may write the log objects into it
+func (s3a *S3ApiServer) checkLoggingTarget(r *http.Request, target *LoggingEnabled) s3err.ErrorCode {
+	if target.TargetBucket == "" {
+		return s3err.ErrInvalidTargetBucketForLogging
+	}
+	if _, err := s3a.getEntry(s3a.option.BucketsPath, target.TargetBucket); err != nil {
+		if err == filer_pb.ErrNotFound {
+			return s3err.ErrInvalidTargetBucketForLogging
+		}
+		return s3err.ErrInternalError
+	}
+	if !s3a.iam.isEnabled() {
+		return s3err.ErrNone
+	}
+	identity, found := s3a.iam.lookupByName(r.Header.Get(s3_constants.AmzIdentityId))
+	if !found {
+		return s3err.ErrAccessDenied
+	}
+	object := "/" + target.TargetPrefix
+	targetReq := r.Clone(r.Context())
+	targetReq.URL.Path = "/" + target.TargetBucket + object
+	targetReq.URL.RawQuery = ""
+	if !identity.isAllowed(newPolicyRequest(targetReq, identity, target.TargetBucket, object), s3_constants.ACTION_WRITE, target.TargetBucket, object) {
+		return s3err.ErrInvalidTargetBucketForLogging
+	}
+	return s3err.ErrNone
+}
+
+// getBucketLogging returns where the server access logs of the bucket are delivered, nil if they are disabled
+func (s3a *S3ApiServer) getBucketLogging(bucket string) *LoggingEnabled {
+	if bucket == "" || s3a.bucketRegistry == nil {
+		return nil
+	}
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		return nil
+	}
+	return bucketMetadata.Logging
+}
@@ -0,0 +1,349 @@
+package s3api
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	weed_server "github.com/seaweedfs/seaweedfs/weed/server"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// The server access logs are collected in memory per target bucket and prefix,
+// and written as one object per target and flush, like the S3 log delivery does.
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/LogFormat.html
+
+const (
+	accessLogFlushInterval = 5 * time.Minute
+	// a target is flushed early once it has this many bytes of logs
+	accessLogMaxBatchSize = 4 * 1024 * 1024
+	// the logs which could not be written are kept for the next flush, up to this size per target
+	accessLogMaxPendingSize = 64 * 1024 * 1024
+	accessLogTimeFormat     = "[02/Jan/2006:15:04:05 -0700]"
+	accessLogKeyTimeFormat  = "2006-01-02-15-04-05"
+)
+
+type accessLogTarget struct {
+	bucket string
+	prefix string
+}
+
+type accessLogDelivery struct {
+	sync.Mutex
+	batches  map[accessLogTarget]*bytes.Buffer
+	flushNow chan struct{}
+	write    func(target accessLogTarget, key string, data []byte) error
+}
+
+func newAccessLogDelivery(write func(target accessLogTarget, key string, data []byte) error) *accessLogDelivery {
+	return &accessLogDelivery{
+		batches:  make(map[accessLogTarget]*bytes.Buffer),
+		flushNow: make(chan struct{}, 1),
+		write:    write,
+	}
+}
+
+func (d *accessLogDelivery) add(target accessLogTarget, line string) {
+	d.Lock()
+	batch, found := d.batches[target]
+	if !found {
+		batch = &bytes.Buffer{}
+		d.batches[target] = batch
+	}
+	batch.WriteString(line)
+	batch.WriteByte('\n')
+	full := batch.Len() >= accessLogMaxBatchSize
+	d.Unlock()
+
+	if full {
+		select {
+		case d.flushNow <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// run flushes the logs periodically, or as soon as a target has enough of them
+func (d *accessLogDelivery) run() {
+	ticker := time.NewTicker(accessLogFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.flushNow:
+		}
+		d.flush(time.Now())
+	}
+}
+
+// flush writes one log object per target, and keeps the logs of the failed targets for the next flush
+func (d *accessLogDelivery) flush(now time.Time) {
+	d.Lock()
+	batches := d.batches
+	d.batches = make(map[accessLogTarget]*bytes.Buffer)
+	d.Unlock()
+
+	for target, batch := range batches {
+		key := target.prefix + now.UTC().Format(accessLogKeyTimeFormat) + fmt.Sprintf("-%016X", rand.Uint64())
+		err := d.write(target, key, batch.Bytes())
+		if err == nil {
+			glog.V(3).Infof("delivered %d bytes of access logs to %s/%s", batch.Len(), target.bucket, key)
+			continue
+		}
+		glog.Errorf("deliver access logs to %s/%s: %v", target.bucket, key, err)
+		d.Lock()
+		if pending, found := d.batches[target]; found {
+			batch.Write(pending.Bytes())
+		}
+		if batch.Len() <= accessLogMaxPendingSize {
+			d.batches[target] = batch
+		} else {
+			glog.Errorf("drop %d bytes of access logs for %s/%s", batch.Len(), target.bucket, target.prefix)
+			delete(d.batches, target)
+		}
+		d.Unlock()
+	}
+}
+
+// writeAccessLogObject writes the logs as an object of the target bucket, through the filer
+func (s3a *S3ApiServer) writeAccessLogObject(target accessLogTarget, key string, data []byte) error {
+	uploadUrl := fmt.Sprintf("http://%s%s/%s%s", s3a.option.Filer.ToHttpAddress(), s3a.option.BucketsPath, target.bucket, urlEscapeObject(key))
+	if s3a.option.FilerGroup != "" {
+		uploadUrl += "?collection=" + s3a.getCollectionName(target.bucket)
+	}
+	req, err := http.NewRequest(http.MethodPut, uploadUrl, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	s3a.maybeAddFilerJwtAuthorization(req, true)
+	resp, err := s3a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer util_http.CloseResponse(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var ret weed_server.FilerPostResult
+	if err = json.Unmarshal(body, &ret); err != nil {
+		return fmt.Errorf("%s: %s", resp.Status, string(body))
+	}
+	if ret.Error != "" {
+		return fmt.Errorf("%s", ret.Error)
+	}
+	return nil
+}
+
+// bucketLoggingMiddleware records the requests to the buckets with server access logging
+func (s3a *S3ApiServer) bucketLoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucket, _ := s3_constants.GetBucketAndObject(r)
+		logging := s3a.getBucketLogging(bucket)
+		if logging == nil || s3a.accessLogs == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &accessLogRecorder{ResponseWriter: w, start: time.Now()}
+		r = s3err.WithErrorCodeRecorder(r, &recorder.errorCode)
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &accessLogBodyReader{ReadCloser: r.Body, recorder: recorder}
+		}
+		next.ServeHTTP(recorder, r)
+
+		var owner string
+		if bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket); errCode == s3err.ErrNone && bucketMetadata.Owner != nil && bucketMetadata.Owner.ID != nil {
+			owner = *bucketMetadata.Owner.ID
+		}
+		target := accessLogTarget{bucket: logging.TargetBucket, prefix: logging.TargetPrefix}
+		s3a.accessLogs.add(target, formatAccessLogLine(r, recorder, owner, time.Now()))
+	})
+}
+
+// accessLogRecorder records the status, the size and the timing of a response
+type accessLogRecorder struct {
+	http.ResponseWriter
+	start     time.Time
+	firstByte time.Time
+	status    int
+	bytesSent int64
+	errorCode string
+	// the body may be read by the goroutine proxying it to the filer
+	requestReadTsNs atomic.Int64
+}
+
+func (rec *accessLogRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+		rec.firstByte = time.Now()
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *accessLogRecorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+		rec.firstByte = time.Now()
+	}
+	n, err := rec.ResponseWriter.Write(p)
+	rec.bytesSent += int64(n)
+	return n, err
+}
+
+func (rec *accessLogRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// accessLogBodyReader records when the request body is read, where the turn around time starts
+type accessLogBodyReader struct {
+	io.ReadCloser
+	recorder *accessLogRecorder
+}
+
+func (r *accessLogBodyReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		r.recorder.requestReadTsNs.CompareAndSwap(0, time.Now().UnixNano())
+	}
+	return n, err
+}
+
+// formatAccessLogLine formats a request in the S3 server access log format, with "-" for the unknown fields
+func formatAccessLogLine(r *http.Request, rec *accessLogRecorder, owner string, end time.Time) string {
+	status := rec.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	accessLog := s3err.GetAccessLog(r, status, s3err.ErrNone)
+
+	remoteIP := accessLog.RemoteIP
+	if host, _, err := net.SplitHostPort(remoteIP); err == nil {
+		remoteIP = host
+	}
+	key := strings.TrimPrefix(accessLog.Key, "/")
+	if key != "" {
+		key = urlPathEscape(key)
+	}
+	versionId := rec.Header().Get(s3_constants.AmzVersionId)
+	if versionId == "" {
+		versionId = r.URL.Query().Get("versionId")
+	}
+	turnAroundTime := "-"
+	if !rec.firstByte.IsZero() {
+		turnAroundStart := rec.start
+		if requestRead := time.Unix(0, rec.requestReadTsNs.Load()); requestRead.After(turnAroundStart) {
+			turnAroundStart = requestRead
+		}
+		turnAroundTime = strconv.FormatInt(rec.firstByte.Sub(turnAroundStart).Milliseconds(), 10)
+	}
+	signatureVersion := accessLog.SignatureVersion
+	if !strings.HasPrefix(signatureVersion, "SigV") {
+		signatureVersion = ""
+	}
+	var cipherSuite, tlsVersion string
+	if r.TLS != nil {
+		cipherSuite = tls.CipherSuiteName(r.TLS.CipherSuite)
+		tlsVersion = strings.Replace(tls.VersionName(r.TLS.Version), "TLS 1", "TLSv1", 1)
+	}
+
+	fields := []string{
+		orDash(owner),
+		orDash(accessLog.Bucket),
+		rec.start.Format(accessLogTimeFormat),
+		orDash(remoteIP),
+		orDash(accessLog.Requester),
+		orDash(rec.Header().Get("x-amz-request-id")),
+		orDash(accessLog.Operation),
+		orDash(key),
+		quoteAccessLogField(fmt.Sprintf("%s %s %s", r.Method, r.RequestURI, r.Proto)),
+		strconv.Itoa(status),
+		orDash(rec.errorCode),
+		formatAccessLogSize(rec.bytesSent, false),
+		formatAccessLogSize(accessLogObjectSize(r, rec), true),
+		strconv.FormatInt(end.Sub(rec.start).Milliseconds(), 10),
+		turnAroundTime,
+		quoteAccessLogField(r.Header.Get("Referer")),
+		quoteAccessLogField(r.Header.Get("User-Agent")),
+		orDash(versionId),
+		orDash(accessLog.HostId),
+		orDash(signatureVersion),
+		orDash(cipherSuite),
+		orDash(accessLog.AuthenticationType),
+		orDash(accessLog.HostHeader),
+		orDash(tlsVersion),
+		"-", // access point arn
+		"-", // acl required
+	}
+	return strings.Join(fields, " ")
+}
+
+// accessLogObjectSize is the size of the object uploaded or downloaded, -1 if the request is not about the content of an object
+func accessLogObjectSize(r *http.Request, rec *accessLogRecorder) int64 {
+	if _, object := s3_constants.GetBucketAndObject(r); object == "/" {
+		return -1
+	}
+	switch r.Method {
+	case http.MethodPut, http.MethodPost:
+		if decoded := r.Header.Get("X-Amz-Decoded-Content-Length"); decoded != "" {
+			if size, err := strconv.ParseInt(decoded, 10, 64); err == nil {
+				return size
+			}
+		}
+		return r.ContentLength
+	case http.MethodGet, http.MethodHead:
+		if status := rec.status; status != 0 && status != http.StatusOK && status != http.StatusPartialContent {
+			return -1
+		}
+		// the size of the whole object, not of the range
+		if contentRange := rec.Header().Get("Content-Range"); contentRange != "" {
+			if i := strings.LastIndex(contentRange, "/"); i >= 0 {
+				if size, err := strconv.ParseInt(contentRange[i+1:], 10, 64); err == nil {
+					return size
+				}
+			}
+		}
+		if size, err := strconv.ParseInt(rec.Header().Get("Content-Length"), 10, 64); err == nil {
+			return size
+		}
+	}
+	return -1
+}
+
+func formatAccessLogSize(size int64, zeroIsKnown bool) string {
+	if size < 0 || size == 0 && !zeroIsKnown {
+		return "-"
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func quoteAccessLogField(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
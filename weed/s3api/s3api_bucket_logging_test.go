@@ -0,0 +1,106 @@
+package s3api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBucketLoggingStatus(t *testing.T) {
+	status, err := parseBucketLoggingStatus([]byte(`<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <LoggingEnabled>
+    <TargetBucket>logs</TargetBucket>
+    <TargetPrefix>photos/</TargetPrefix>
+  </LoggingEnabled>
+</BucketLoggingStatus>`))
+	assert.Nil(t, err)
+	assert.Equal(t, &LoggingEnabled{TargetBucket: "logs", TargetPrefix: "photos/"}, status.LoggingEnabled)
+
+	status, err = parseBucketLoggingStatus([]byte(`<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/" />`))
+	assert.Nil(t, err)
+	assert.Nil(t, status.LoggingEnabled)
+}
+
+func TestFormatAccessLogLine(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest(http.MethodGet, "/photos/summer%20trip/a.jpg?versionId=v1", nil)
+	r = mux.SetURLVars(r, map[string]string{"bucket": "photos", "object": "summer trip/a.jpg"})
+	r.RemoteAddr = "192.0.2.3:50000"
+	r.Header.Set("User-Agent", `curl/8.0 "test"`)
+	r.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=...")
+	r.Header.Set(s3_constants.AmzIdentityId, "app")
+	r.Header.Set(s3_constants.AmzAuthType, "SigV4")
+
+	rec := &accessLogRecorder{ResponseWriter: httptest.NewRecorder(), start: start}
+	rec.Header().Set("x-amz-request-id", "1714557600000000000")
+	rec.Header().Set("Content-Range", "bytes 0-4/1024")
+	rec.WriteHeader(http.StatusPartialContent)
+	rec.firstByte = start.Add(3 * time.Millisecond)
+	_, _ = rec.Write([]byte("hello"))
+
+	fields := strings.Split(formatAccessLogLine(r, rec, "admin_account", start.Add(12*time.Millisecond)), " ")
+	assert.Equal(t, []string{
+		"admin_account", "photos", "[01/May/2024:10:00:00", "+0000]", "192.0.2.3", "app", "1714557600000000000",
+		"REST.GET.OBJECT", "summer%20trip/a.jpg", `"GET`, "/photos/summer%20trip/a.jpg?versionId=v1", `HTTP/1.1"`,
+		"206", "-", "5", "1024", "12", "3", "-", `"curl/8.0`, `\"test\""`, "v1", "-", "SigV4", "-", "AuthHeader",
+		"example.com", "-", "-", "-",
+	}, fields)
+
+	// a failed upload of an object
+	r = httptest.NewRequest(http.MethodPut, "/photos/a.jpg", strings.NewReader("hello"))
+	r = mux.SetURLVars(r, map[string]string{"bucket": "photos", "object": "a.jpg"})
+	rec = &accessLogRecorder{ResponseWriter: httptest.NewRecorder(), start: start}
+	r = s3err.WithErrorCodeRecorder(r, &rec.errorCode)
+	r.Body = &accessLogBodyReader{ReadCloser: r.Body, recorder: rec}
+	_, _ = io.ReadAll(r.Body)
+	s3err.WriteErrorResponse(rec, r, s3err.ErrAccessDenied)
+	fields = strings.Split(formatAccessLogLine(r, rec, "", time.Now()), " ")
+	assert.Equal(t, "403", fields[12])
+	assert.Equal(t, "AccessDenied", fields[13])
+	assert.NotEqual(t, "-", fields[14])
+	assert.Equal(t, "5", fields[15])
+	assert.Equal(t, "-", fields[0])
+	assert.Equal(t, "-", fields[5])
+}
+
+func TestAccessLogDelivery(t *testing.T) {
+	written := make(map[string]string)
+	var fail bool
+	delivery := newAccessLogDelivery(func(target accessLogTarget, key string, data []byte) error {
+		if fail {
+			return fmt.Errorf("filer is down")
+		}
+		written[target.bucket+"/"+key] = string(data)
+		return nil
+	})
+	logs := accessLogTarget{bucket: "logs", prefix: "photos/"}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	delivery.add(logs, "line 1")
+	fail = true
+	delivery.flush(now)
+	assert.Equal(t, 0, len(written))
+
+	// the logs are written on the next flush
+	delivery.add(logs, "line 2")
+	fail = false
+	delivery.flush(now)
+	assert.Equal(t, 1, len(written))
+	for key, data := range written {
+		assert.True(t, strings.HasPrefix(key, "logs/photos/2024-05-01-10-00-00-"), key)
+		assert.Equal(t, len("logs/photos/2024-05-01-10-00-00-")+16, len(key))
+		assert.Equal(t, "line 1\nline 2\n", data)
+	}
+
+	delivery.flush(now)
+	assert.Equal(t, 1, len(written))
+}
@@ -0,0 +1,105 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3event"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// The notification configuration is kept on the bucket, the filers send the matching object events
+// to their targets configured in the [s3_notification] section of notification.toml
+
+// GetBucketNotificationConfigurationHandler Returns the notification configuration of a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketNotificationConfiguration.html
+func (s3a *S3ApiServer) GetBucketNotificationConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetBucketNotificationConfigurationHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	// a bucket without notification configuration has an empty one
+	config := &s3event.NotificationConfiguration{}
+	if configBytes, ok := bucketEntry.Extended[s3_constants.ExtNotificationKey]; ok && len(configBytes) > 0 {
+		if config, err = s3event.ParseConfiguration(configBytes); err != nil {
+			glog.Errorf("GetBucketNotificationConfigurationHandler %s: %v", bucket, err)
+			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+			return
+		}
+	}
+	config.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, r, config)
+}
+
+// PutBucketNotificationConfigurationHandler Enables the notifications of the events of a bucket,
+// an empty configuration disables them
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketNotificationConfiguration.html
+func (s3a *S3ApiServer) PutBucketNotificationConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutBucketNotificationConfigurationHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	configBytes, err := io.ReadAll(io.LimitReader(r.Body, s3event.MaxConfigurationSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(configBytes) > s3event.MaxConfigurationSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
+	config := &s3event.NotificationConfiguration{}
+	if err = xml.Unmarshal(configBytes, config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	if _, err = config.Rules(); err != nil {
+		glog.V(1).Infof("PutBucketNotificationConfigurationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidNotificationConfiguration)
+		return
+	}
+
+	if config.IsEmpty() {
+		configBytes = nil
+	} else {
+		config.Xmlns = ""
+		config.SetDefaultIds()
+		if configBytes, err = xml.Marshal(config); err != nil {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+			return
+		}
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		if configBytes == nil {
+			delete(extended, s3_constants.ExtNotificationKey)
+		} else {
+			extended[s3_constants.ExtNotificationKey] = configBytes
+		}
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
@@ -0,0 +1,326 @@
+package s3api
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// GetBucketPolicyHandler Get bucket Policy
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketPolicy.html
+func (s3a *S3ApiServer) GetBucketPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetBucketPolicyHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchBucket)
+			return
+		}
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	policy, ok := bucketEntry.Extended[s3_constants.ExtBucketPolicyKey]
+	if !ok || len(policy) == 0 {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchBucketPolicy)
+		return
+	}
+
+	writeSuccessResponseJSON(w, r, policy)
+}
+
+// PutBucketPolicyHandler Put bucket Policy
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketPolicy.html
+func (s3a *S3ApiServer) PutBucketPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutBucketPolicyHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	policyBytes, err := io.ReadAll(io.LimitReader(r.Body, s3policy.MaxPolicySize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	policy, err := s3policy.ParseBucketPolicy(policyBytes, bucket)
+	if err != nil {
+		glog.V(1).Infof("PutBucketPolicyHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedPolicy)
+		return
+	}
+	if policy.IsPublic() && s3a.iam.getPublicAccessBlock(bucket).blockPublicPolicy() {
+		glog.V(1).Infof("PutBucketPolicyHandler %s: public policy is blocked", bucket)
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtBucketPolicyKey] = policyBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+}
+
+// DeleteBucketPolicyHandler Delete bucket Policy
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketPolicy.html
+func (s3a *S3ApiServer) DeleteBucketPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("DeleteBucketPolicyHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		delete(extended, s3_constants.ExtBucketPolicyKey)
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+}
+
+// updateBucketExtended changes the extended attributes of the bucket entry and refreshes the bucket metadata
+func (s3a *S3ApiServer) updateBucketExtended(bucket string, fn func(extended map[string][]byte)) s3err.ErrorCode {
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			return s3err.ErrNoSuchBucket
+		}
+		return s3err.ErrInternalError
+	}
+	if bucketEntry.Extended == nil {
+		bucketEntry.Extended = make(map[string][]byte)
+	}
+	fn(bucketEntry.Extended)
+	if err = s3a.updateEntry(s3a.option.BucketsPath, bucketEntry); err != nil {
+		glog.Errorf("update bucket %s: %v", bucket, err)
+		return s3err.ErrInternalError
+	}
+	s3a.bucketRegistry.LoadBucketMetadata(bucketEntry)
+	return s3err.ErrNone
+}
+
+// evaluateBucketPolicy checks the request against the policy of the bucket, if any
+func (iam *IdentityAccessManagement) evaluateBucketPolicy(r *http.Request, identity *Identity, bucket, object string) s3policy.Decision {
+	if bucket == "" || iam.bucketRegistry == nil {
+		return s3policy.NotApplicable
+	}
+	bucketMetadata, errCode := iam.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone || bucketMetadata.Policy == nil {
+		return s3policy.NotApplicable
+	}
+	req := newPolicyRequest(r, identity, bucket, object)
+	decision := bucketMetadata.Policy.Evaluate(req)
+	if decision == s3policy.Allow && isPublicPolicyRestricted(bucketMetadata, identity) {
+		decision = s3policy.NotApplicable
+	}
+	glog.V(3).Infof("bucket policy of %s: %+v => %d", bucket, req, decision)
+	return decision
+}
+
+func newPolicyRequest(r *http.Request, identity *Identity, bucket, object string) *s3policy.Request {
+	return &s3policy.Request{
+		Principals: policyPrincipals(identity),
+		Action:     policyAction(r, object),
+		Resource:   policyResource(bucket, object),
+		Conditions: policyConditions(r, identity),
+	}
+}
+
+func policyPrincipals(identity *Identity) []string {
+	if identity == nil || identity.Account == nil || identity.isAnonymous() {
+		return nil
+	}
+	accountId := identity.Account.Id
+	return []string{
+		identity.Name,
+		accountId,
+		fmt.Sprintf("arn:aws:iam::%s:root", accountId),
+		fmt.Sprintf("arn:aws:iam::%s:user/%s", accountId, identity.Name),
+	}
+}
+
+func policyResource(bucket, object string) string {
+	if object == "" || object == "/" {
+		return s3policy.ResourceArnPrefix + bucket
+	}
+	return s3policy.ResourceArnPrefix + bucket + object
+}
+
+// policyAction maps the request to the S3 action name used in policies
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/using-with-s3-actions.html
+func policyAction(r *http.Request, object string) string {
+	query := r.URL.Query()
+	has := func(key string) bool {
+		_, found := query[key]
+		return found
+	}
+	isObject := object != "" && object != "/"
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+
+	switch {
+	case has("policy"):
+		return map[string]string{http.MethodGet: "s3:GetBucketPolicy", http.MethodPut: "s3:PutBucketPolicy", http.MethodDelete: "s3:DeleteBucketPolicy"}[method]
+	case has("acl"):
+		if isObject {
+			if has("versionId") {
+				return map[string]string{http.MethodGet: "s3:GetObjectVersionAcl", http.MethodPut: "s3:PutObjectVersionAcl"}[method]
+			}
+			return map[string]string{http.MethodGet: "s3:GetObjectAcl", http.MethodPut: "s3:PutObjectAcl"}[method]
+		}
+		return map[string]string{http.MethodGet: "s3:GetBucketAcl", http.MethodPut: "s3:PutBucketAcl"}[method]
+	case has("tagging"):
+		if isObject {
+			return map[string]string{http.MethodGet: "s3:GetObjectTagging", http.MethodPut: "s3:PutObjectTagging", http.MethodDelete: "s3:DeleteObjectTagging"}[method]
+		}
+		return map[string]string{http.MethodGet: "s3:GetBucketTagging", http.MethodPut: "s3:PutBucketTagging", http.MethodDelete: "s3:PutBucketTagging"}[method]
+	case has("versioning"):
+		return map[string]string{http.MethodGet: "s3:GetBucketVersioning", http.MethodPut: "s3:PutBucketVersioning"}[method]
+	case has("versions"):
+		return "s3:ListBucketVersions"
+	case has("lifecycle"):
+		return map[string]string{http.MethodGet: "s3:GetLifecycleConfiguration", http.MethodPut: "s3:PutLifecycleConfiguration", http.MethodDelete: "s3:PutLifecycleConfiguration"}[method]
+	case has("cors"):
+		return map[string]string{http.MethodGet: "s3:GetBucketCORS", http.MethodPut: "s3:PutBucketCORS", http.MethodDelete: "s3:PutBucketCORS"}[method]
+	case has("encryption"):
+		return map[string]string{http.MethodGet: "s3:GetEncryptionConfiguration", http.MethodPut: "s3:PutEncryptionConfiguration", http.MethodDelete: "s3:PutEncryptionConfiguration"}[method]
+	case has("publicAccessBlock"):
+		return map[string]string{http.MethodGet: "s3:GetBucketPublicAccessBlock", http.MethodPut: "s3:PutBucketPublicAccessBlock", http.MethodDelete: "s3:PutBucketPublicAccessBlock"}[method]
+	case has("notification"):
+		return map[string]string{http.MethodGet: "s3:GetBucketNotification", http.MethodPut: "s3:PutBucketNotification"}[method]
+	case has("logging"):
+		return map[string]string{http.MethodGet: "s3:GetBucketLogging", http.MethodPut: "s3:PutBucketLogging"}[method]
+	case has("replication"):
+		return map[string]string{http.MethodGet: "s3:GetReplicationConfiguration", http.MethodPut: "s3:PutReplicationConfiguration", http.MethodDelete: "s3:PutReplicationConfiguration"}[method]
+	case has("ownershipControls"):
+		return map[string]string{http.MethodGet: "s3:GetBucketOwnershipControls", http.MethodPut: "s3:PutBucketOwnershipControls", http.MethodDelete: "s3:PutBucketOwnershipControls"}[method]
+	case has("location"):
+		return "s3:GetBucketLocation"
+	case has("requestPayment"):
+		return "s3:GetBucketRequestPayment"
+	case has("retention"):
+		return map[string]string{http.MethodGet: "s3:GetObjectRetention", http.MethodPut: "s3:PutObjectRetention"}[method]
+	case has("legal-hold"):
+		return map[string]string{http.MethodGet: "s3:GetObjectLegalHold", http.MethodPut: "s3:PutObjectLegalHold"}[method]
+	case has("attributes"):
+		if has("versionId") {
+			return "s3:GetObjectVersionAttributes"
+		}
+		return "s3:GetObjectAttributes"
+	case has("object-lock"):
+		return map[string]string{http.MethodGet: "s3:GetBucketObjectLockConfiguration", http.MethodPut: "s3:PutBucketObjectLockConfiguration"}[method]
+	case has("delete") && method == http.MethodPost:
+		return "s3:DeleteObject"
+	case has("uploads") && !isObject:
+		return "s3:ListBucketMultipartUploads"
+	case has("uploadId"):
+		switch method {
+		case http.MethodGet:
+			return "s3:ListMultipartUploadParts"
+		case http.MethodDelete:
+			return "s3:AbortMultipartUpload"
+		}
+		return "s3:PutObject"
+	}
+
+	if !isObject {
+		switch method {
+		case http.MethodGet:
+			return "s3:ListBucket"
+		case http.MethodPut:
+			return "s3:CreateBucket"
+		case http.MethodDelete:
+			return "s3:DeleteBucket"
+		case http.MethodPost:
+			return "s3:PutObject"
+		}
+		return ""
+	}
+	switch method {
+	case http.MethodGet:
+		if has("versionId") {
+			return "s3:GetObjectVersion"
+		}
+		return "s3:GetObject"
+	case http.MethodPut, http.MethodPost:
+		return "s3:PutObject"
+	case http.MethodDelete:
+		if has("versionId") {
+			return "s3:DeleteObjectVersion"
+		}
+		return "s3:DeleteObject"
+	}
+	return ""
+}
+
+// policyConditions collects the condition keys supported in bucket policies
+func policyConditions(r *http.Request, identity *Identity) map[string][]string {
+	now := time.Now().UTC()
+	conditions := map[string][]string{
+		"aws:sourceip":        {requestSourceIp(r)},
+		"aws:securetransport": {strconv.FormatBool(r.TLS != nil)},
+		"aws:currenttime":     {now.Format(time.RFC3339)},
+		"aws:epochtime":       {strconv.FormatInt(now.Unix(), 10)},
+	}
+	if userAgent := r.Header.Get("User-Agent"); userAgent != "" {
+		conditions["aws:useragent"] = []string{userAgent}
+	}
+	if referer := r.Header.Get("Referer"); referer != "" {
+		conditions["aws:referer"] = []string{referer}
+	}
+	if identity != nil && identity.Account != nil && !identity.isAnonymous() {
+		conditions["aws:username"] = []string{identity.Name}
+		conditions["aws:principalaccount"] = []string{identity.Account.Id}
+	}
+	query := r.URL.Query()
+	for _, key := range []string{"prefix", "delimiter", "max-keys", "versionId"} {
+		if values, found := query[key]; found {
+			conditions["s3:"+strings.ToLower(key)] = values
+		}
+	}
+	for _, header := range []string{s3_constants.AmzCannedAcl, s3_constants.AmzStorageClass, "X-Amz-Server-Side-Encryption", "X-Amz-Copy-Source", "X-Amz-Metadata-Directive"} {
+		if value := r.Header.Get(header); value != "" {
+			conditions["s3:"+strings.ToLower(header)] = []string{value}
+		}
+	}
+	return conditions
+}
+
+// requestSourceIp is the address of the connection, the forwarding headers are set by the clients
+func requestSourceIp(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,99 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyAction(t *testing.T) {
+	tests := []struct {
+		method   string
+		target   string
+		object   string
+		expected string
+	}{
+		{http.MethodGet, "/bucket/key", "/key", "s3:GetObject"},
+		{http.MethodHead, "/bucket/key", "/key", "s3:GetObject"},
+		{http.MethodGet, "/bucket/key?versionId=1", "/key", "s3:GetObjectVersion"},
+		{http.MethodPut, "/bucket/key", "/key", "s3:PutObject"},
+		{http.MethodDelete, "/bucket/key", "/key", "s3:DeleteObject"},
+		{http.MethodDelete, "/bucket/key?versionId=1", "/key", "s3:DeleteObjectVersion"},
+		{http.MethodGet, "/bucket/key?tagging", "/key", "s3:GetObjectTagging"},
+		{http.MethodPut, "/bucket/key?acl", "/key", "s3:PutObjectAcl"},
+		{http.MethodPut, "/bucket/key?partNumber=1&uploadId=2", "/key", "s3:PutObject"},
+		{http.MethodDelete, "/bucket/key?uploadId=2", "/key", "s3:AbortMultipartUpload"},
+		{http.MethodGet, "/bucket", "/", "s3:ListBucket"},
+		{http.MethodGet, "/bucket?list-type=2&prefix=a", "/", "s3:ListBucket"},
+		{http.MethodGet, "/bucket?versions", "/", "s3:ListBucketVersions"},
+		{http.MethodGet, "/bucket?uploads", "/", "s3:ListBucketMultipartUploads"},
+		{http.MethodPost, "/bucket?delete", "/", "s3:DeleteObject"},
+		{http.MethodPut, "/bucket?policy", "/", "s3:PutBucketPolicy"},
+		{http.MethodGet, "/bucket?acl", "/", "s3:GetBucketAcl"},
+		{http.MethodDelete, "/bucket?replication", "/", "s3:PutReplicationConfiguration"},
+		{http.MethodPut, "/bucket", "/", "s3:CreateBucket"},
+		{http.MethodDelete, "/bucket", "/", "s3:DeleteBucket"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.target, nil)
+		assert.Equal(t, tt.expected, policyAction(r, tt.object), tt.method+" "+tt.target)
+	}
+}
+
+func TestPolicyResource(t *testing.T) {
+	assert.Equal(t, "arn:aws:s3:::bucket", policyResource("bucket", "/"))
+	assert.Equal(t, "arn:aws:s3:::bucket/a/b", policyResource("bucket", "/a/b"))
+}
+
+func TestAuthRequestWithBucketPolicy(t *testing.T) {
+	policy, err := s3policy.ParseBucketPolicy([]byte(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/public/*"},
+    {"Effect": "Deny", "Principal": {"AWS": "reader"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/secret/*"}
+  ]
+}`), "bucket1")
+	assert.Nil(t, err)
+
+	iam := &IdentityAccessManagement{
+		bucketRegistry: &BucketRegistry{
+			metadataCache: map[string]*BucketMetaData{
+				"bucket1": {Name: "bucket1", Policy: policy},
+			},
+			notFound: make(map[string]struct{}),
+		},
+	}
+	err = iam.loadS3ApiConfiguration(&iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{
+				Name:        "reader",
+				Credentials: []*iam_pb.Credential{{AccessKey: "reader_access_key", SecretKey: "reader_secret_key"}},
+				Actions:     []string{"Read:bucket1"},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	newRequest := func(object string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/bucket1"+object, nil)
+		return mux.SetURLVars(r, map[string]string{"bucket": "bucket1", "object": object})
+	}
+
+	_, errCode := iam.authRequest(newRequest("/public/a.txt"), s3_constants.ACTION_READ)
+	assert.Equal(t, s3err.ErrNone, errCode, "anonymous access allowed by the bucket policy")
+
+	_, errCode = iam.authRequest(newRequest("/private/a.txt"), s3_constants.ACTION_READ)
+	assert.Equal(t, s3err.ErrAccessDenied, errCode, "anonymous access not granted by the bucket policy")
+
+	identity, _, found := iam.lookupByAccessKey("reader_access_key")
+	assert.True(t, found)
+	assert.Equal(t, s3policy.Deny, iam.evaluateBucketPolicy(newRequest("/secret/a.txt"), identity, "bucket1", "/secret/a.txt"))
+	assert.Equal(t, s3policy.NotApplicable, iam.evaluateBucketPolicy(newRequest("/private/a.txt"), identity, "bucket1", "/private/a.txt"))
+}
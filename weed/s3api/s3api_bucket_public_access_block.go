@@ -0,0 +1,190 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+const maxPublicAccessBlockSize = 16 * 1024
+
+// PublicAccessBlockConfiguration keeps a bucket from being exposed by a mistaken ACL or bucket policy
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/access-control-block-public-access.html
+type PublicAccessBlockConfiguration struct {
+	XMLName xml.Name `xml:"PublicAccessBlockConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+
+	// reject requests setting public ACLs on the bucket or its objects
+	BlockPublicAcls bool `xml:"BlockPublicAcls"`
+	// public ACLs, including the anonymous identity, do not grant any access
+	IgnorePublicAcls bool `xml:"IgnorePublicAcls"`
+	// reject bucket policies granting public access
+	BlockPublicPolicy bool `xml:"BlockPublicPolicy"`
+	// a public bucket policy only grants access to the identities of the bucket owner account
+	RestrictPublicBuckets bool `xml:"RestrictPublicBuckets"`
+}
+
+func parsePublicAccessBlockConfiguration(data []byte) (*PublicAccessBlockConfiguration, error) {
+	config := &PublicAccessBlockConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *PublicAccessBlockConfiguration) blockPublicAcls() bool {
+	return c != nil && c.BlockPublicAcls
+}
+
+func (c *PublicAccessBlockConfiguration) ignorePublicAcls() bool {
+	return c != nil && c.IgnorePublicAcls
+}
+
+func (c *PublicAccessBlockConfiguration) blockPublicPolicy() bool {
+	return c != nil && c.BlockPublicPolicy
+}
+
+func (c *PublicAccessBlockConfiguration) restrictPublicBuckets() bool {
+	return c != nil && c.RestrictPublicBuckets
+}
+
+// GetPublicAccessBlockHandler Retrieves the PublicAccessBlock configuration for an S3 bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetPublicAccessBlock.html
+func (s3a *S3ApiServer) GetPublicAccessBlockHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetPublicAccessBlockHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	configBytes, ok := bucketEntry.Extended[s3_constants.ExtPublicAccessBlockKey]
+	if !ok || len(configBytes) == 0 {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchPublicAccessBlockConfiguration)
+		return
+	}
+	config, err := parsePublicAccessBlockConfiguration(configBytes)
+	if err != nil {
+		glog.Errorf("GetPublicAccessBlockHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	config.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, r, config)
+}
+
+// PutPublicAccessBlockHandler Creates or modifies the PublicAccessBlock configuration for an S3 bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutPublicAccessBlock.html
+func (s3a *S3ApiServer) PutPublicAccessBlockHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutPublicAccessBlockHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	configBytes, err := io.ReadAll(io.LimitReader(r.Body, maxPublicAccessBlockSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(configBytes) > maxPublicAccessBlockSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
+	config, err := parsePublicAccessBlockConfiguration(configBytes)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	config.Xmlns = ""
+	if configBytes, err = xml.Marshal(config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtPublicAccessBlockKey] = configBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// DeletePublicAccessBlockHandler Removes the PublicAccessBlock configuration for an S3 bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeletePublicAccessBlock.html
+func (s3a *S3ApiServer) DeletePublicAccessBlockHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("DeletePublicAccessBlockHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		delete(extended, s3_constants.ExtPublicAccessBlockKey)
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+}
+
+// getPublicAccessBlock returns the public access block of the bucket, nil if there is none
+func (iam *IdentityAccessManagement) getPublicAccessBlock(bucket string) *PublicAccessBlockConfiguration {
+	if bucket == "" || iam.bucketRegistry == nil {
+		return nil
+	}
+	bucketMetadata, errCode := iam.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		return nil
+	}
+	return bucketMetadata.PublicAccessBlock
+}
+
+// checkPublicAccessBlock rejects the requests setting public ACLs on a bucket that blocks them
+func (iam *IdentityAccessManagement) checkPublicAccessBlock(r *http.Request, bucket string) s3err.ErrorCode {
+	if !iam.getPublicAccessBlock(bucket).blockPublicAcls() {
+		return s3err.ErrNone
+	}
+	grants, errCode := ParseRequestAclGrants(r)
+	if errCode != s3err.ErrNone {
+		return errCode
+	}
+	if HasPublicGrant(grants) {
+		glog.V(1).Infof("public acl of %s %s is blocked", r.Method, r.URL.Path)
+		return s3err.ErrAccessDenied
+	}
+	return s3err.ErrNone
+}
+
+// isPublicPolicyRestricted tells whether a public bucket policy must not grant access to the identity,
+// which is only honored for the identities of the bucket owner account
+func isPublicPolicyRestricted(bucketMetadata *BucketMetaData, identity *Identity) bool {
+	if !bucketMetadata.PublicAccessBlock.restrictPublicBuckets() || bucketMetadata.Policy == nil || !bucketMetadata.Policy.IsPublic() {
+		return false
+	}
+	if identity == nil || identity.Account == nil || identity.isAnonymous() {
+		return true
+	}
+	return bucketMetadata.Owner == nil || bucketMetadata.Owner.ID == nil || *bucketMetadata.Owner.ID != identity.Account.Id
+}
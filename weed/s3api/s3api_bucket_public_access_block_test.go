@@ -0,0 +1,125 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePublicAccessBlockConfiguration(t *testing.T) {
+	config, err := parsePublicAccessBlockConfiguration([]byte(`<PublicAccessBlockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <BlockPublicAcls>true</BlockPublicAcls>
+  <RestrictPublicBuckets>true</RestrictPublicBuckets>
+</PublicAccessBlockConfiguration>`))
+	assert.Nil(t, err)
+	assert.True(t, config.blockPublicAcls())
+	assert.False(t, config.ignorePublicAcls())
+	assert.False(t, config.blockPublicPolicy())
+	assert.True(t, config.restrictPublicBuckets())
+
+	var nilConfig *PublicAccessBlockConfiguration
+	assert.False(t, nilConfig.blockPublicAcls())
+
+	_, err = parsePublicAccessBlockConfiguration([]byte(`<PublicAccessBlockConfiguration><BlockPublicAcls>maybe</BlockPublicAcls></PublicAccessBlockConfiguration>`))
+	assert.NotNil(t, err)
+}
+
+func TestBuildBucketMetadataIgnoresPublicAcls(t *testing.T) {
+	grants := append([]*s3.Grant{{
+		Grantee: &s3.Grantee{
+			Type: &s3_constants.GrantTypeCanonicalUser,
+			ID:   &AccountAdmin.Id,
+		},
+		Permission: &s3_constants.PermissionFullControl,
+	}}, s3_constants.PublicReadWrite...)
+	entry := &filer_pb.Entry{
+		Name: "bucket1",
+		Extended: map[string][]byte{
+			s3_constants.ExtPublicAccessBlockKey: []byte(`<PublicAccessBlockConfiguration><IgnorePublicAcls>true</IgnorePublicAcls></PublicAccessBlockConfiguration>`),
+		},
+	}
+	AssembleEntryWithAcp(entry, "", grants)
+
+	bucketMetadata := buildBucketMetadata(&IdentityAccessManagement{}, entry)
+	assert.True(t, bucketMetadata.PublicAccessBlock.ignorePublicAcls())
+	assert.Equal(t, grants[:1], bucketMetadata.Acl)
+}
+
+func TestAuthRequestWithPublicAccessBlock(t *testing.T) {
+	policy, err := s3policy.ParseBucketPolicy([]byte(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/public/*"}
+  ]
+}`), "bucket1")
+	assert.Nil(t, err)
+
+	bucketMetadata := &BucketMetaData{
+		Name:   "bucket1",
+		Policy: policy,
+		Owner:  &s3.Owner{ID: &AccountAdmin.Id},
+	}
+	iam := &IdentityAccessManagement{
+		bucketRegistry: &BucketRegistry{
+			metadataCache: map[string]*BucketMetaData{"bucket1": bucketMetadata},
+			notFound:      map[string]struct{}{"bucket2": {}},
+		},
+	}
+	err = iam.loadS3ApiConfiguration(&iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{
+				Name:    "anonymous",
+				Actions: []string{"Read:bucket1/shared/*"},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	newRequest := func(method, object string, header http.Header) *http.Request {
+		r := httptest.NewRequest(method, "/bucket1"+object, nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		return mux.SetURLVars(r, map[string]string{"bucket": "bucket1", "object": object})
+	}
+	authRead := func(object string) s3err.ErrorCode {
+		_, errCode := iam.authRequest(newRequest(http.MethodGet, object, nil), s3_constants.ACTION_READ)
+		return errCode
+	}
+
+	assert.Equal(t, s3err.ErrNone, authRead("/public/a.txt"))
+	assert.Equal(t, s3err.ErrNone, authRead("/shared/a.txt"))
+
+	bucketMetadata.PublicAccessBlock = &PublicAccessBlockConfiguration{IgnorePublicAcls: true}
+	assert.Equal(t, s3err.ErrNone, authRead("/public/a.txt"), "the bucket policy still applies")
+	assert.Equal(t, s3err.ErrAccessDenied, authRead("/shared/a.txt"), "the anonymous identity is ignored")
+
+	bucketMetadata.PublicAccessBlock = &PublicAccessBlockConfiguration{RestrictPublicBuckets: true}
+	assert.Equal(t, s3err.ErrAccessDenied, authRead("/public/a.txt"), "the public bucket policy is restricted")
+	assert.Equal(t, s3err.ErrNone, authRead("/shared/a.txt"))
+	assert.True(t, isPublicPolicyRestricted(bucketMetadata, &Identity{Name: "other", Account: &Account{Id: "other"}}))
+	assert.False(t, isPublicPolicyRestricted(bucketMetadata, &Identity{Name: "owner", Account: &AccountAdmin}))
+
+	bucketMetadata.PublicAccessBlock = &PublicAccessBlockConfiguration{BlockPublicAcls: true}
+	assert.Equal(t, s3err.ErrAccessDenied, iam.checkPublicAccessBlock(newRequest(http.MethodPut, "/a.txt", http.Header{
+		"X-Amz-Acl": {s3_constants.CannedAclPublicRead},
+	}), "bucket1"))
+	assert.Equal(t, s3err.ErrAccessDenied, iam.checkPublicAccessBlock(newRequest(http.MethodPut, "/a.txt", http.Header{
+		"X-Amz-Grant-Read": {`uri="` + s3_constants.GranteeGroupAuthenticatedUsers + `"`},
+	}), "bucket1"))
+	assert.Equal(t, s3err.ErrNone, iam.checkPublicAccessBlock(newRequest(http.MethodPut, "/a.txt", http.Header{
+		"X-Amz-Acl": {s3_constants.CannedAclPrivate},
+	}), "bucket1"))
+	assert.Equal(t, s3err.ErrNone, iam.checkPublicAccessBlock(newRequest(http.MethodPut, "/a.txt", http.Header{
+		"X-Amz-Acl": {s3_constants.CannedAclPublicRead},
+	}), "bucket2"), "other buckets are not blocked")
+}
@@ -0,0 +1,127 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3replication"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// The replication configuration is kept on the bucket, the filers copy the matching objects
+// to their targets configured in the [s3_replication] section of replication.toml
+
+// GetBucketReplicationHandler Returns the replication configuration of a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketReplication.html
+func (s3a *S3ApiServer) GetBucketReplicationHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetBucketReplicationHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	configBytes, ok := bucketEntry.Extended[s3_constants.ExtReplicationKey]
+	if !ok || len(configBytes) == 0 {
+		s3err.WriteErrorResponse(w, r, s3err.ErrReplicationConfigurationNotFound)
+		return
+	}
+	config, err := s3replication.ParseConfiguration(configBytes)
+	if err != nil {
+		glog.Errorf("GetBucketReplicationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	config.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, r, config)
+}
+
+// PutBucketReplicationHandler Sets the replication configuration of a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketReplication.html
+func (s3a *S3ApiServer) PutBucketReplicationHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutBucketReplicationHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	configBytes, err := io.ReadAll(io.LimitReader(r.Body, s3replication.MaxConfigurationSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(configBytes) > s3replication.MaxConfigurationSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
+	config := &s3replication.ReplicationConfiguration{}
+	if err = xml.Unmarshal(configBytes, config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	if _, err = config.EnabledRules(); err != nil {
+		glog.V(1).Infof("PutBucketReplicationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidReplicationConfiguration)
+		return
+	}
+
+	config.Xmlns = ""
+	config.SetDefaultIds()
+	if configBytes, err = xml.Marshal(config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtReplicationKey] = configBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// DeleteBucketReplicationHandler Stops the replication of a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketReplication.html
+func (s3a *S3ApiServer) DeleteBucketReplicationHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("DeleteBucketReplicationHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		delete(extended, s3_constants.ExtReplicationKey)
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+}
+
+// setReplicationStatusHeaders replaces the stored replication status with the S3 response header
+func setReplicationStatusHeaders(resp *http.Response) {
+	if status := resp.Header.Get(s3_constants.ExtReplicationStatusKey); status != "" {
+		resp.Header.Set(s3_constants.AmzReplicationStatus, status)
+		resp.Header.Del(s3_constants.ExtReplicationStatusKey)
+	}
+}
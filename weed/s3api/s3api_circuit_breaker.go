@@ -11,22 +11,31 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/s3_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type CircuitBreaker struct {
 	sync.RWMutex
-	Enabled     bool
-	counters    map[string]*int64
-	limitations map[string]int64
+	Enabled      bool
+	counters     map[string]*int64
+	limitations  map[string]int64
+	rateLimiters map[string]*rateLimiter
 }
 
+// the limits of an identity are keyed by this prefix, the identity name, the action and the limit type
+const identityLimitPrefix = "identity"
+
 func NewCircuitBreaker(option *S3ApiServerOption) *CircuitBreaker {
 	cb := &CircuitBreaker{
-		counters:    make(map[string]*int64),
-		limitations: make(map[string]int64),
+		counters:     make(map[string]*int64),
+		limitations:  make(map[string]int64),
+		rateLimiters: make(map[string]*rateLimiter),
 	}
 
 	err := pb.WithFilerClient(false, 0, option.Filer, option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
@@ -66,7 +75,7 @@ func (cb *CircuitBreaker) loadCircuitBreakerConfig(cfg *s3_pb.S3CircuitBreakerCo
 	globalEnabled := false
 	globalOptions := cfg.Global
 	limitations := make(map[string]int64)
-	if globalOptions != nil && globalOptions.Enabled && len(globalOptions.Actions) > 0 {
+	if globalOptions != nil && globalOptions.Enabled {
 		globalEnabled = globalOptions.Enabled
 		for action, limit := range globalOptions.Actions {
 			limitations[action] = limit
@@ -83,7 +92,35 @@ func (cb *CircuitBreaker) loadCircuitBreakerConfig(cfg *s3_pb.S3CircuitBreakerCo
 		}
 	}
 
+	//identities
+	for identity, cbOptions := range cfg.Identities {
+		if cbOptions.Enabled {
+			for action, limit := range cbOptions.Actions {
+				limitations[s3_constants.Concat(identityLimitPrefix, identity, action)] = limit
+			}
+		}
+	}
+
+	// keep the tokens of the unchanged rate limits
+	rateLimiters := make(map[string]*rateLimiter)
+	for key, limit := range limitations {
+		if !strings.HasSuffix(key, s3_constants.Separator+s3_constants.LimitTypeCountPerSecond) &&
+			!strings.HasSuffix(key, s3_constants.Separator+s3_constants.LimitTypeBytesPerSecond) {
+			continue
+		}
+		cb.RLock()
+		limiter, found := cb.rateLimiters[key]
+		cb.RUnlock()
+		if !found || limiter.rate != limit {
+			limiter = newRateLimiter(limit)
+		}
+		rateLimiters[key] = limiter
+	}
+
+	cb.Lock()
 	cb.limitations = limitations
+	cb.rateLimiters = rateLimiters
+	cb.Unlock()
 	return nil
 }
 
@@ -105,6 +142,15 @@ func (cb *CircuitBreaker) Limit(f func(w http.ResponseWriter, r *http.Request),
 		}()
 
 		if errCode == s3err.ErrNone {
+			bytesLimiters, retryAfter := cb.throttle(r, bucket, action)
+			if retryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+				s3err.WriteErrorResponse(w, r, s3err.ErrSlowDown)
+				return
+			}
+			if len(bytesLimiters) > 0 {
+				w = &rateLimitedResponseWriter{ResponseWriter: w, limiters: bytesLimiters}
+			}
 			f(w, r)
 			return
 		}
@@ -112,44 +158,75 @@ func (cb *CircuitBreaker) Limit(f func(w http.ResponseWriter, r *http.Request),
 	}, Action(action)
 }
 
+// limitScopes returns the key prefixes of the limits applying to the request: the bucket, the identity, and global
+func limitScopes(r *http.Request, bucket string) (scopes [][]string) {
+	scopes = append(scopes, []string{bucket})
+	if identity := r.Header.Get(s3_constants.AmzIdentityId); identity != "" {
+		scopes = append(scopes, []string{identityLimitPrefix, identity})
+	}
+	return append(scopes, nil)
+}
+
 func (cb *CircuitBreaker) limit(r *http.Request, bucket string, action string) (rollback []func(), errCode s3err.ErrorCode) {
 
-	//bucket simultaneous request count
-	bucketCountRollBack, errCode := cb.loadCounterAndCompare(s3_constants.Concat(bucket, action, s3_constants.LimitTypeCount), 1, s3err.ErrTooManyRequest)
-	if bucketCountRollBack != nil {
-		rollback = append(rollback, bucketCountRollBack)
-	}
-	if errCode != s3err.ErrNone {
-		return
-	}
+	for _, scope := range limitScopes(r, bucket) {
+		//simultaneous request count
+		countRollBack, errCode := cb.loadCounterAndCompare(s3_constants.Concat(append(scope, action, s3_constants.LimitTypeCount)...), 1, s3err.ErrTooManyRequest)
+		if countRollBack != nil {
+			rollback = append(rollback, countRollBack)
+		}
+		if errCode != s3err.ErrNone {
+			return rollback, errCode
+		}
 
-	//bucket simultaneous request content bytes
-	bucketContentLengthRollBack, errCode := cb.loadCounterAndCompare(s3_constants.Concat(bucket, action, s3_constants.LimitTypeBytes), r.ContentLength, s3err.ErrRequestBytesExceed)
-	if bucketContentLengthRollBack != nil {
-		rollback = append(rollback, bucketContentLengthRollBack)
+		//simultaneous request content bytes
+		contentLengthRollBack, errCode := cb.loadCounterAndCompare(s3_constants.Concat(append(scope, action, s3_constants.LimitTypeBytes)...), r.ContentLength, s3err.ErrRequestBytesExceed)
+		if contentLengthRollBack != nil {
+			rollback = append(rollback, contentLengthRollBack)
+		}
+		if errCode != s3err.ErrNone {
+			return rollback, errCode
+		}
 	}
-	if errCode != s3err.ErrNone {
-		return
+	return rollback, s3err.ErrNone
+}
+
+// throttle takes the tokens of the request from the request rate and bytes rate limits,
+// or tells how long to wait if any of the limits is exhausted.
+// The uploaded bytes are taken upfront, the downloaded bytes by the returned limiters while being written.
+func (cb *CircuitBreaker) throttle(r *http.Request, bucket string, action string) (bytesLimiters []*rateLimiter, retryAfter time.Duration) {
+	var countLimiters []*rateLimiter
+	cb.RLock()
+	for _, scope := range limitScopes(r, bucket) {
+		if limiter, found := cb.rateLimiters[s3_constants.Concat(append(scope, action, s3_constants.LimitTypeCountPerSecond)...)]; found {
+			countLimiters = append(countLimiters, limiter)
+		}
+		if limiter, found := cb.rateLimiters[s3_constants.Concat(append(scope, action, s3_constants.LimitTypeBytesPerSecond)...)]; found {
+			bytesLimiters = append(bytesLimiters, limiter)
+		}
 	}
+	cb.RUnlock()
 
-	//global simultaneous request count
-	globalCountRollBack, errCode := cb.loadCounterAndCompare(s3_constants.Concat(action, s3_constants.LimitTypeCount), 1, s3err.ErrTooManyRequest)
-	if globalCountRollBack != nil {
-		rollback = append(rollback, globalCountRollBack)
+	now := time.Now()
+	for _, limiter := range countLimiters {
+		retryAfter = max(retryAfter, limiter.retryAfter(now))
 	}
-	if errCode != s3err.ErrNone {
-		return
+	for _, limiter := range bytesLimiters {
+		retryAfter = max(retryAfter, limiter.retryAfter(now))
+	}
+	if retryAfter > 0 {
+		return nil, retryAfter
 	}
 
-	//global simultaneous request content bytes
-	globalContentLengthRollBack, errCode := cb.loadCounterAndCompare(s3_constants.Concat(action, s3_constants.LimitTypeBytes), r.ContentLength, s3err.ErrRequestBytesExceed)
-	if globalContentLengthRollBack != nil {
-		rollback = append(rollback, globalContentLengthRollBack)
+	for _, limiter := range countLimiters {
+		limiter.take(now, 1)
 	}
-	if errCode != s3err.ErrNone {
-		return
+	if r.ContentLength > 0 {
+		for _, limiter := range bytesLimiters {
+			limiter.take(now, r.ContentLength)
+		}
 	}
-	return
+	return bytesLimiters, 0
 }
 
 func (cb *CircuitBreaker) loadCounterAndCompare(key string, inc int64, errCode s3err.ErrorCode) (f func(), e s3err.ErrorCode) {
@@ -186,3 +263,70 @@ func (cb *CircuitBreaker) loadCounterAndCompare(key string, inc int64, errCode s
 	}
 	return
 }
+
+// rateLimiter is a token bucket refilled at the rate of tokens per second, holding up to one second of tokens.
+// A request is let through as long as a token is left, and may take more tokens than left,
+// so the requests larger than the bucket are not starved, and the following requests wait for the refill.
+type rateLimiter struct {
+	sync.Mutex
+	rate   int64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate int64) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	if now.After(l.last) {
+		l.tokens = math.Min(float64(l.rate), l.tokens+now.Sub(l.last).Seconds()*float64(l.rate))
+		l.last = now
+	}
+}
+
+// retryAfter returns how long to wait for a token to be refilled, or 0 if a token is left
+func (l *rateLimiter) retryAfter(now time.Time) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	l.refill(now)
+	if l.tokens >= 1 {
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - l.tokens) / float64(l.rate) * float64(time.Second))
+}
+
+func (l *rateLimiter) take(now time.Time, n int64) {
+	l.Lock()
+	defer l.Unlock()
+	l.refill(now)
+	l.tokens -= float64(n)
+}
+
+// rateLimitedResponseWriter takes the tokens of the written bytes
+type rateLimitedResponseWriter struct {
+	http.ResponseWriter
+	limiters []*rateLimiter
+}
+
+func (w *rateLimitedResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	now := time.Now()
+	for _, limiter := range w.limiters {
+		limiter.take(now, int64(n))
+	}
+	return n, err
+}
+
+func (w *rateLimitedResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type TestLimitCase struct {
@@ -105,3 +106,75 @@ func doLimit(circuitBreaker *CircuitBreaker, routineCount int, r *http.Request,
 	}
 	return successCounter
 }
+
+func TestRateLimit(t *testing.T) {
+	circuitBreaker := &CircuitBreaker{}
+	err := circuitBreaker.loadCircuitBreakerConfig(&s3_pb.S3CircuitBreakerConfig{
+		Global: &s3_pb.S3CircuitBreakerOptions{
+			Enabled: true,
+		},
+		Buckets: map[string]*s3_pb.S3CircuitBreakerOptions{
+			bucket: {
+				Enabled: true,
+				Actions: map[string]int64{
+					s3_constants.Concat(action, s3_constants.LimitTypeBytesPerSecond): 1000,
+				},
+			},
+		},
+		Identities: map[string]*s3_pb.S3CircuitBreakerOptions{
+			"noisy": {
+				Enabled: true,
+				Actions: map[string]int64{
+					s3_constants.Concat(action, s3_constants.LimitTypeCountPerSecond): 5,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(identity string, contentLength int64) *http.Request {
+		r := &http.Request{Header: http.Header{}, ContentLength: contentLength}
+		r.Header.Set(s3_constants.AmzIdentityId, identity)
+		return r
+	}
+
+	// the request rate of the noisy identity
+	var throttled int
+	for i := 0; i < 8; i++ {
+		if _, retryAfter := circuitBreaker.throttle(newRequest("noisy", 0), bucket, action); retryAfter > 0 {
+			throttled++
+		}
+	}
+	if throttled != 3 {
+		t.Errorf("throttled %d requests of 8, expected 3", throttled)
+	}
+	if _, retryAfter := circuitBreaker.throttle(newRequest("quiet", 0), bucket, action); retryAfter != 0 {
+		t.Errorf("another identity is throttled for %v", retryAfter)
+	}
+
+	// the bytes rate of the bucket, a request may take more than the remaining tokens
+	if _, retryAfter := circuitBreaker.throttle(newRequest("quiet", 1500), bucket, action); retryAfter != 0 {
+		t.Errorf("first upload is throttled for %v", retryAfter)
+	}
+	_, retryAfter := circuitBreaker.throttle(newRequest("quiet", 10), bucket, action)
+	if retryAfter < 400*time.Millisecond || retryAfter > time.Second {
+		t.Errorf("second upload retry after %v, expected about half a second", retryAfter)
+	}
+
+	// the tokens of the unchanged limits are kept when reloading the config
+	limiter := circuitBreaker.rateLimiters[s3_constants.Concat(identityLimitPrefix, "noisy", action, s3_constants.LimitTypeCountPerSecond)]
+	if err = circuitBreaker.loadCircuitBreakerConfig(&s3_pb.S3CircuitBreakerConfig{
+		Global:     &s3_pb.S3CircuitBreakerOptions{Enabled: true},
+		Identities: map[string]*s3_pb.S3CircuitBreakerOptions{"noisy": {Enabled: true, Actions: map[string]int64{s3_constants.Concat(action, s3_constants.LimitTypeCountPerSecond): 5}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if circuitBreaker.rateLimiters[s3_constants.Concat(identityLimitPrefix, "noisy", action, s3_constants.LimitTypeCountPerSecond)] != limiter {
+		t.Errorf("rate limiter is replaced")
+	}
+	if len(circuitBreaker.rateLimiters) != 1 {
+		t.Errorf("rate limiters %v, expected only the identity", circuitBreaker.rateLimiters)
+	}
+}
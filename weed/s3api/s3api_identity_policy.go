@@ -0,0 +1,47 @@
+package s3api
+
+import (
+	"net/http"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
+)
+
+// evaluateIdentityPolicies checks the request against the managed policies attached to the identity
+// and its groups. Only the requests on buckets and objects are subject to them.
+func (iam *IdentityAccessManagement) evaluateIdentityPolicies(r *http.Request, identity *Identity, bucket, object string) s3policy.Decision {
+	if bucket == "" || len(identity.Policies) == 0 {
+		return s3policy.NotApplicable
+	}
+	req := newPolicyRequest(r, identity, bucket, object)
+	decision := identity.evaluatePolicies(req)
+	glog.V(3).Infof("policies of identity %s: %+v => %d", identity.Name, req, decision)
+	return decision
+}
+
+// evaluatePolicies returns Deny if any attached policy denies the request,
+// Allow if some attached policy allows it, and NotApplicable otherwise
+func (identity *Identity) evaluatePolicies(req *s3policy.Request) s3policy.Decision {
+	decision := s3policy.NotApplicable
+	for _, policy := range identity.Policies {
+		switch policy.Evaluate(req) {
+		case s3policy.Deny:
+			return s3policy.Deny
+		case s3policy.Allow:
+			decision = s3policy.Allow
+		}
+	}
+	return decision
+}
+
+// isAllowed combines the attached policies with the actions of the identity:
+// an explicit deny wins, otherwise an allowing policy or a matching action grants the access
+func (identity *Identity) isAllowed(req *s3policy.Request, action Action, bucket, object string) bool {
+	switch identity.evaluatePolicies(req) {
+	case s3policy.Deny:
+		return false
+	case s3policy.Allow:
+		return true
+	}
+	return identity.canDo(action, bucket, object)
+}
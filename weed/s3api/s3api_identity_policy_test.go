@@ -0,0 +1,75 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityPolicies(t *testing.T) {
+	iam := &IdentityAccessManagement{}
+	err := iam.loadS3ApiConfiguration(&iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{Name: "alice", PolicyNames: []string{"NoDelete"}},
+			{Name: "bob", Actions: []string{"Write:scratch"}},
+			{Name: "carol", Actions: []string{s3_constants.ACTION_ADMIN}, PolicyNames: []string{"NoDelete", "Unknown"}},
+		},
+		Groups: []*iam_pb.Group{
+			{Name: "analysts", Members: []string{"alice", "bob"}, PolicyNames: []string{"ReadReports", "NoDelete"}},
+		},
+		Policies: []*iam_pb.Policy{
+			{Name: "ReadReports", Content: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Action": ["s3:GetObject", "s3:ListBucket"], "Resource": ["arn:aws:s3:::reports", "arn:aws:s3:::reports/*"]}]}`},
+			{Name: "NoDelete", Content: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Deny", "Action": "s3:Delete*", "Resource": "*"}]}`},
+			{Name: "Invalid", Content: `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow"}]}`},
+		},
+	})
+	assert.Nil(t, err)
+
+	alice, _ := iam.lookupByName("alice")
+	bob, _ := iam.lookupByName("bob")
+	carol, _ := iam.lookupByName("carol")
+	assert.Equal(t, 2, len(alice.Policies), "the policies attached twice count once")
+	assert.Equal(t, 2, len(bob.Policies))
+	assert.Equal(t, 1, len(carol.Policies), "the unknown policies are skipped")
+
+	newRequest := func(method, bucket, object string) *http.Request {
+		r := httptest.NewRequest(method, "/"+bucket+object, nil)
+		return mux.SetURLVars(r, map[string]string{"bucket": bucket, "object": object})
+	}
+	tests := []struct {
+		identity *Identity
+		method   string
+		bucket   string
+		object   string
+		expected s3policy.Decision
+	}{
+		{alice, http.MethodGet, "reports", "/2024/q1.csv", s3policy.Allow},
+		{alice, http.MethodGet, "reports", "/", s3policy.Allow},
+		{alice, http.MethodPut, "reports", "/2024/q1.csv", s3policy.NotApplicable},
+		{alice, http.MethodDelete, "reports", "/2024/q1.csv", s3policy.Deny},
+		{bob, http.MethodGet, "other", "/a.txt", s3policy.NotApplicable},
+		{bob, http.MethodDelete, "scratch", "/a.txt", s3policy.Deny},
+		{carol, http.MethodDelete, "scratch", "/a.txt", s3policy.Deny},
+		{carol, http.MethodGet, "", "", s3policy.NotApplicable},
+	}
+	for _, tt := range tests {
+		r := newRequest(tt.method, tt.bucket, tt.object)
+		assert.Equal(t, tt.expected, iam.evaluateIdentityPolicies(r, tt.identity, tt.bucket, tt.object), tt.identity.Name+" "+tt.method+" "+r.URL.Path)
+	}
+
+	// the policies are combined with the actions
+	putScratch := newPolicyRequest(newRequest(http.MethodPut, "scratch", "/a.txt"), bob, "scratch", "/a.txt")
+	assert.True(t, bob.isAllowed(putScratch, s3_constants.ACTION_WRITE, "scratch", "/a.txt"))
+	putReports := newPolicyRequest(newRequest(http.MethodPut, "reports", "/a.txt"), bob, "reports", "/a.txt")
+	assert.False(t, bob.isAllowed(putReports, s3_constants.ACTION_WRITE, "reports", "/a.txt"))
+	getReports := newPolicyRequest(newRequest(http.MethodGet, "reports", "/a.txt"), bob, "reports", "/a.txt")
+	assert.True(t, bob.isAllowed(getReports, s3_constants.ACTION_READ, "reports", "/a.txt"))
+}
@@ -0,0 +1,334 @@
+package s3api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+const maxObjectAclSize = 64 * 1024
+
+// The object ACLs are kept in the extended attributes of the object versions, the owner as ExtAmzOwnerKey
+// and the grants as ExtAmzAclKey. They only apply to the buckets whose object ownership allows ACLs,
+// with BucketOwnerEnforced the bucket owner owns all the objects and the ACLs are ignored.
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/acl-overview.html
+
+// objectAclPermissions are the ACL permissions granting the actions on an object
+var objectAclPermissions = map[Action]string{
+	s3_constants.ACTION_READ:      s3_constants.PermissionRead,
+	s3_constants.ACTION_READ_ACP:  s3_constants.PermissionReadAcp,
+	s3_constants.ACTION_WRITE_ACP: s3_constants.PermissionWriteAcp,
+}
+
+// isAclCompatibleWithOwnerEnforced tells whether the ACL headers of a request are accepted by a bucket with ACLs disabled,
+// which is when they only give the full control to the bucket owner
+func isAclCompatibleWithOwnerEnforced(r *http.Request) bool {
+	for _, header := range []string{s3_constants.AmzAclFullControl, s3_constants.AmzAclRead, s3_constants.AmzAclReadAcp, s3_constants.AmzAclWrite, s3_constants.AmzAclWriteAcp} {
+		if r.Header.Get(header) != "" {
+			return false
+		}
+	}
+	switch r.Header.Get(s3_constants.AmzCannedAcl) {
+	case "", s3_constants.CannedAclPrivate, s3_constants.CannedAclBucketOwnerFullControl:
+		return true
+	}
+	return false
+}
+
+// objectAclForWrite returns the owner and the grants of a new object, given by the canned ACL or the grant headers
+func (s3a *S3ApiServer) objectAclForWrite(r *http.Request, bucket string) (ownerId string, grants []*s3.Grant, errCode s3err.ErrorCode) {
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		return "", nil, errCode
+	}
+	bucketOwnerId := *bucketMetadata.Owner.ID
+	if bucketMetadata.ObjectOwnership == s3_constants.OwnershipBucketOwnerEnforced {
+		if !isAclCompatibleWithOwnerEnforced(r) {
+			return "", nil, s3err.ErrAccessControlListNotSupported
+		}
+		return bucketOwnerId, nil, s3err.ErrNone
+	}
+	ownerId, grants, errCode = ParseAndValidateAclHeadersOrElseDefault(r, s3a.iam, bucketMetadata.ObjectOwnership, bucketOwnerId, getAccountId(r), false)
+	if errCode != s3err.ErrNone {
+		return "", nil, errCode
+	}
+	return ownerId, grants, s3a.markObjectAcls(bucketMetadata, ownerId, grants)
+}
+
+// markObjectAcls records on the bucket that an object ACL grants access to another account than the bucket owner,
+// before the ACL is stored, so that the objects of the other buckets are not looked up to check their ACLs
+func (s3a *S3ApiServer) markObjectAcls(bucketMetadata *BucketMetaData, ownerId string, grants []*s3.Grant) s3err.ErrorCode {
+	if bucketMetadata.HasObjectAcls || !grantsOtherAccounts(*bucketMetadata.Owner.ID, ownerId, grants) {
+		return s3err.ErrNone
+	}
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucketMetadata.Name)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			return s3err.ErrNoSuchBucket
+		}
+		return s3err.ErrInternalError
+	}
+	if bucketEntry.Extended == nil {
+		bucketEntry.Extended = make(map[string][]byte)
+	}
+	bucketEntry.Extended[s3_constants.ExtObjectAclsKey] = []byte("true")
+	if err = s3a.updateEntry(s3a.option.BucketsPath, bucketEntry); err != nil {
+		glog.Errorf("mark object acls of bucket %s: %v", bucketMetadata.Name, err)
+		return s3err.ErrInternalError
+	}
+	s3a.bucketRegistry.LoadBucketMetadata(bucketEntry)
+	return s3err.ErrNone
+}
+
+// grantsOtherAccounts tells whether an object ACL gives access to another account or group than the bucket owner
+func grantsOtherAccounts(bucketOwnerId, ownerId string, grants []*s3.Grant) bool {
+	if ownerId != bucketOwnerId {
+		return true
+	}
+	for _, grant := range grants {
+		if grant.Grantee == nil || grant.Grantee.ID == nil || *grant.Grantee.ID != bucketOwnerId {
+			return true
+		}
+	}
+	return false
+}
+
+// setObjectAclHeader passes the object ACL to the filer, which keeps it in the entry's extended attributes
+func setObjectAclHeader(header http.Header, ownerId string, grants []*s3.Grant) {
+	header.Del(s3_constants.ExtAmzOwnerKey)
+	header.Del(s3_constants.ExtAmzAclKey)
+	if ownerId != "" {
+		SetAcpOwnerHeader(header, ownerId)
+	}
+	SetAcpGrantsHeader(header, grants)
+}
+
+// removeObjectAclHeaders keeps the stored object ACL out of the S3 responses, it is read with GetObjectAcl
+func removeObjectAclHeaders(resp *http.Response) {
+	resp.Header.Del(s3_constants.ExtAmzOwnerKey)
+	resp.Header.Del(s3_constants.ExtAmzAclKey)
+}
+
+// getObjectAclTarget finds the object version whose ACL is read or changed
+func (s3a *S3ApiServer) getObjectAclTarget(r *http.Request, bucket, object string) (dir, name string, entry *filer_pb.Entry, errCode s3err.ErrorCode) {
+	if versionId := r.URL.Query().Get("versionId"); versionId != "" {
+		dir, name, entry, errCode = s3a.getObjectVersion(bucket, object, versionId)
+		if errCode != s3err.ErrNone {
+			return
+		}
+	} else {
+		dir, name = s3a.objectDirAndName(bucket, object)
+		var err error
+		if entry, err = s3a.getEntry(dir, name); err != nil {
+			if err == filer_pb.ErrNotFound {
+				return "", "", nil, s3err.ErrNoSuchKey
+			}
+			return "", "", nil, s3err.ErrInternalError
+		}
+	}
+	if entry.IsDirectory {
+		return "", "", nil, s3err.ErrNoSuchKey
+	}
+	if isDeleteMarker(entry) {
+		return "", "", nil, s3err.ErrMethodNotAllowed
+	}
+	return dir, name, entry, s3err.ErrNone
+}
+
+// GetObjectAclHandler Get object ACL
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAcl.html
+func (s3a *S3ApiServer) GetObjectAclHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetObjectAclHandler %s %s", bucket, object)
+
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	_, _, entry, errCode := s3a.getObjectAclTarget(r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	ownerId := *bucketMetadata.Owner.ID
+	var grants []*s3.Grant
+	if bucketMetadata.ObjectOwnership != s3_constants.OwnershipBucketOwnerEnforced {
+		ownerId = GetAcpOwner(entry.Extended, ownerId)
+		grants = GetAcpGrants(entry.Extended)
+	}
+	if len(grants) == 0 {
+		grants = []*s3.Grant{{
+			Grantee: &s3.Grantee{
+				Type: &s3_constants.GrantTypeCanonicalUser,
+				ID:   &ownerId,
+			},
+			Permission: &s3_constants.PermissionFullControl,
+		}}
+	}
+
+	response := AccessControlPolicy{
+		Owner: CanonicalUser{
+			ID:          ownerId,
+			DisplayName: s3a.iam.GetAccountNameById(ownerId),
+		},
+	}
+	for _, grant := range grants {
+		response.AccessControlList.Grant = append(response.AccessControlList.Grant, s3a.toXmlGrant(grant))
+	}
+	writeSuccessResponseXML(w, r, response)
+}
+
+func (s3a *S3ApiServer) toXmlGrant(grant *s3.Grant) Grant {
+	grantee := Grantee{
+		XMLNS: "http://www.w3.org/2001/XMLSchema-instance",
+	}
+	if grant.Grantee != nil {
+		if grant.Grantee.Type != nil {
+			grantee.Type, grantee.XMLXSI = *grant.Grantee.Type, *grant.Grantee.Type
+		}
+		if grant.Grantee.ID != nil {
+			grantee.ID = *grant.Grantee.ID
+			grantee.DisplayName = s3a.iam.GetAccountNameById(grantee.ID)
+		}
+		if grant.Grantee.URI != nil {
+			grantee.URI = *grant.Grantee.URI
+		}
+	}
+	var permission Permission
+	if grant.Permission != nil {
+		permission = Permission(*grant.Permission)
+	}
+	return Grant{Grantee: grantee, Permission: permission}
+}
+
+// PutObjectAclHandler Put object ACL, given by the request body, or else by the canned ACL header
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectAcl.html
+func (s3a *S3ApiServer) PutObjectAclHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutObjectAclHandler %s %s", bucket, object)
+
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	dir, _, entry, errCode := s3a.getObjectAclTarget(r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	bucketOwnerId := *bucketMetadata.Owner.ID
+	ownerId := GetAcpOwner(entry.Extended, bucketOwnerId)
+	if bucketMetadata.ObjectOwnership == s3_constants.OwnershipBucketOwnerEnforced {
+		ownerId = bucketOwnerId
+	}
+	// the ACL is given by the request body, or else by the headers
+	defer util_http.CloseRequest(r)
+	aclBytes, err := io.ReadAll(io.LimitReader(r.Body, maxObjectAclSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(aclBytes) > maxObjectAclSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
+	if len(aclBytes) == 0 {
+		r.Body = http.NoBody
+	} else {
+		r.Body = io.NopCloser(bytes.NewReader(aclBytes))
+	}
+	grants, errCode := ExtractAcl(r, s3a.iam, bucketMetadata.ObjectOwnership, bucketOwnerId, ownerId, getAccountId(r))
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	if bucketMetadata.ObjectOwnership == s3_constants.OwnershipBucketOwnerEnforced {
+		// the ACL of a bucket with ACLs disabled can only be set to the full control of the bucket owner
+		if len(grants) != 1 || grants[0].Permission == nil || *grants[0].Permission != s3_constants.PermissionFullControl ||
+			grants[0].Grantee.ID == nil || *grants[0].Grantee.ID != bucketOwnerId {
+			s3err.WriteErrorResponse(w, r, s3err.ErrAccessControlListNotSupported)
+			return
+		}
+		writeSuccessResponseEmpty(w, r)
+		return
+	}
+	if HasPublicGrant(grants) && bucketMetadata.PublicAccessBlock.blockPublicAcls() {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
+	if errCode = s3a.markObjectAcls(bucketMetadata, ownerId, grants); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	if errCode = AssembleEntryWithAcp(entry, ownerId, grants); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	if err := s3a.updateEntry(dir, entry); err != nil {
+		glog.Errorf("PutObjectAclHandler %s %s: %v", bucket, object, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// isAllowedByObjectAcl tells whether the ACL of the object grants the action to the identity.
+// The object owner can always read and change the ACL.
+// The object is only looked up if an object ACL of the bucket may grant the action to the account of the identity.
+func (iam *IdentityAccessManagement) isAllowedByObjectAcl(r *http.Request, identity *Identity, action Action, bucket, object string) bool {
+	permission, found := objectAclPermissions[action]
+	if !found || object == "/" || strings.HasSuffix(object, "/") || iam.bucketRegistry == nil || iam.bucketRegistry.s3a == nil || identity.Account == nil {
+		return false
+	}
+	bucketMetadata, errCode := iam.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone || bucketMetadata.ObjectOwnership == s3_constants.OwnershipBucketOwnerEnforced {
+		return false
+	}
+	if !bucketMetadata.HasObjectAcls && identity.Account.Id != *bucketMetadata.Owner.ID {
+		return false
+	}
+	_, _, entry, errCode := iam.bucketRegistry.s3a.getObjectAclTarget(r, bucket, object)
+	if errCode != s3err.ErrNone {
+		return false
+	}
+
+	if isGrantedByObjectAcl(entry.Extended, identity.Account.Id, permission, bucketMetadata.PublicAccessBlock.ignorePublicAcls()) {
+		glog.V(3).Infof("acl of %s%s grants %s to %s", bucket, object, permission, identity.Account.Id)
+		return true
+	}
+	return false
+}
+
+// isGrantedByObjectAcl tells whether the stored object ACL grants the permission to the account
+func isGrantedByObjectAcl(extended map[string][]byte, accountId, permission string, ignorePublicAcls bool) bool {
+	if permission != s3_constants.PermissionRead && GetAcpOwner(extended, "") == accountId {
+		return true
+	}
+	grants := GetAcpGrants(extended)
+	if ignorePublicAcls {
+		grants = RemovePublicGrants(grants)
+	}
+	for _, required := range DetermineReqGrants(accountId, permission) {
+		for _, grant := range grants {
+			if GrantEquals(required, grant) {
+				return true
+			}
+		}
+	}
+	return false
+}
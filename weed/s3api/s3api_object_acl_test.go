@@ -0,0 +1,110 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectAclForWrite(t *testing.T) {
+	bucketMetadata := &BucketMetaData{
+		Name:            "bucket1",
+		ObjectOwnership: s3_constants.OwnershipBucketOwnerEnforced,
+		Owner:           &s3.Owner{ID: &AccountAdmin.Id},
+	}
+	s3a := &S3ApiServer{
+		iam: accountManager,
+		bucketRegistry: &BucketRegistry{
+			metadataCache: map[string]*BucketMetaData{"bucket1": bucketMetadata},
+			notFound:      map[string]struct{}{"bucket2": {}},
+		},
+	}
+	newRequest := func(header http.Header) *http.Request {
+		r := httptest.NewRequest(http.MethodPut, "/bucket1/a.txt", nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		r.Header.Set(s3_constants.AmzAccountId, "accountA")
+		return r
+	}
+
+	// the bucket owner owns the objects of a bucket with ACLs disabled
+	ownerId, grants, errCode := s3a.objectAclForWrite(newRequest(nil), "bucket1")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, AccountAdmin.Id, ownerId)
+	assert.Nil(t, grants)
+	_, _, errCode = s3a.objectAclForWrite(newRequest(http.Header{"X-Amz-Acl": {s3_constants.CannedAclBucketOwnerFullControl}}), "bucket1")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	_, _, errCode = s3a.objectAclForWrite(newRequest(http.Header{"X-Amz-Acl": {s3_constants.CannedAclPublicRead}}), "bucket1")
+	assert.Equal(t, s3err.ErrAccessControlListNotSupported, errCode)
+	_, _, errCode = s3a.objectAclForWrite(newRequest(http.Header{"X-Amz-Grant-Read": {`id="accountB"`}}), "bucket1")
+	assert.Equal(t, s3err.ErrAccessControlListNotSupported, errCode)
+
+	// the writer owns the objects, and grants the access to them, the bucket is already marked to have object ACLs
+	bucketMetadata.ObjectOwnership = s3_constants.OwnershipObjectWriter
+	bucketMetadata.HasObjectAcls = true
+	ownerId, grants, errCode = s3a.objectAclForWrite(newRequest(http.Header{"X-Amz-Acl": {s3_constants.CannedAclPublicRead}}), "bucket1")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "accountA", ownerId)
+	assert.True(t, HasPublicGrant(grants))
+
+	ownerId, grants, errCode = s3a.objectAclForWrite(newRequest(http.Header{"X-Amz-Grant-Read": {`id="accountB"`}}), "bucket1")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "accountA", ownerId)
+	assert.Equal(t, 1, len(grants))
+	assert.Equal(t, "accountB", *grants[0].Grantee.ID)
+	assert.Equal(t, s3_constants.PermissionRead, *grants[0].Permission)
+
+	_, _, errCode = s3a.objectAclForWrite(newRequest(nil), "bucket2")
+	assert.Equal(t, s3err.ErrNoSuchBucket, errCode)
+}
+
+func TestGrantsOtherAccounts(t *testing.T) {
+	ownerFullControl := []*s3.Grant{{
+		Grantee:    &s3.Grantee{Type: &s3_constants.GrantTypeCanonicalUser, ID: aws.String("accountA")},
+		Permission: &s3_constants.PermissionFullControl,
+	}}
+	assert.False(t, grantsOtherAccounts("accountA", "accountA", nil))
+	assert.False(t, grantsOtherAccounts("accountA", "accountA", ownerFullControl))
+	assert.True(t, grantsOtherAccounts("accountA", "accountB", ownerFullControl), "the object owner is another account")
+	assert.True(t, grantsOtherAccounts("accountA", "accountA", append(ownerFullControl, s3_constants.PublicRead...)))
+	assert.True(t, grantsOtherAccounts("accountA", "accountA", append(ownerFullControl, &s3.Grant{
+		Grantee:    &s3.Grantee{Type: &s3_constants.GrantTypeCanonicalUser, ID: aws.String("accountB")},
+		Permission: &s3_constants.PermissionRead,
+	})))
+
+	assert.False(t, buildBucketMetadata(accountManager, &filer_pb.Entry{Name: "bucket1"}).HasObjectAcls)
+	assert.True(t, buildBucketMetadata(accountManager, &filer_pb.Entry{
+		Name:     "bucket1",
+		Extended: map[string][]byte{s3_constants.ExtObjectAclsKey: []byte("true")},
+	}).HasObjectAcls)
+}
+
+func TestIsGrantedByObjectAcl(t *testing.T) {
+	entry := &filer_pb.Entry{}
+	AssembleEntryWithAcp(entry, "accountA", append([]*s3.Grant{{
+		Grantee: &s3.Grantee{
+			Type: &s3_constants.GrantTypeCanonicalUser,
+			ID:   aws.String("accountB"),
+		},
+		Permission: &s3_constants.PermissionReadAcp,
+	}}, s3_constants.PublicRead...))
+
+	assert.True(t, isGrantedByObjectAcl(entry.Extended, "accountB", s3_constants.PermissionRead, false))
+	assert.True(t, isGrantedByObjectAcl(entry.Extended, "accountB", s3_constants.PermissionReadAcp, false))
+	assert.False(t, isGrantedByObjectAcl(entry.Extended, "accountB", s3_constants.PermissionWriteAcp, false))
+	assert.True(t, isGrantedByObjectAcl(entry.Extended, AccountAnonymous.Id, s3_constants.PermissionRead, false))
+	assert.False(t, isGrantedByObjectAcl(entry.Extended, AccountAnonymous.Id, s3_constants.PermissionRead, true), "the public grants are ignored")
+	assert.True(t, isGrantedByObjectAcl(entry.Extended, "accountB", s3_constants.PermissionReadAcp, true))
+
+	// the owner may always read and change the acl
+	assert.True(t, isGrantedByObjectAcl(entry.Extended, "accountA", s3_constants.PermissionWriteAcp, false))
+	assert.False(t, isGrantedByObjectAcl(nil, "accountA", s3_constants.PermissionRead, false))
+}
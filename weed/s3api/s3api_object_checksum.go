@@ -0,0 +1,276 @@
+package s3api
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+// the checksum types of the objects
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html
+const (
+	checksumTypeComposite  = "COMPOSITE"
+	checksumTypeFullObject = "FULL_OBJECT"
+)
+
+var (
+	crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+	// checksumAlgorithms are the supported x-amz-checksum-algorithm values
+	checksumAlgorithms = map[string]func() hash.Hash{
+		"CRC32":  func() hash.Hash { return crc32.NewIEEE() },
+		"CRC32C": func() hash.Hash { return crc32.New(crc32cTable) },
+		"SHA1":   sha1.New,
+		"SHA256": sha256.New,
+	}
+
+	errChecksumMismatch = errors.New("checksum mismatch")
+	errChecksumMissing  = errors.New("checksum trailer is missing")
+)
+
+// checksumHeader returns the x-amz-checksum-* header carrying the checksum of the algorithm
+func checksumHeader(algorithm string) string {
+	return http.CanonicalHeaderKey(s3_constants.AmzChecksumPrefix + strings.ToLower(algorithm))
+}
+
+// isChecksumValue tells whether the x-amz-checksum-* header carries a checksum,
+// rather than describing it like x-amz-checksum-algorithm
+func isChecksumValue(header string) bool {
+	switch header {
+	case s3_constants.AmzChecksumAlgorithm, s3_constants.AmzChecksumType, s3_constants.AmzChecksumMode:
+		return false
+	}
+	return strings.HasPrefix(header, s3_constants.AmzChecksumPrefix)
+}
+
+func checksumAlgorithmOf(header string) string {
+	return strings.ToUpper(strings.TrimPrefix(http.CanonicalHeaderKey(header), s3_constants.AmzChecksumPrefix))
+}
+
+func validateChecksumAlgorithm(algorithm string) s3err.ErrorCode {
+	if _, ok := checksumAlgorithms[algorithm]; !ok {
+		glog.V(1).Infof("unsupported checksum algorithm %s", algorithm)
+		return s3err.ErrInvalidRequest
+	}
+	return s3err.ErrNone
+}
+
+// checksumReader computes the checksum of the uploaded data while it is passed on to the filer,
+// and fails the upload if it does not match the checksum sent by the client.
+type checksumReader struct {
+	reader    io.Reader
+	algorithm string
+	expected  string      // the checksum of the x-amz-checksum-* header, if sent upfront
+	trailer   http.Header // the trailing headers of the aws-chunked payload, carrying the checksum otherwise
+	hash      hash.Hash
+	checksum  string // the computed checksum, once the data is read
+
+	// filerTrailer is sent after the data to store the verified checksum on the entry
+	filerTrailer http.Header
+}
+
+// parseRequestChecksum returns the checksum to verify for the uploaded data, nil if there is none.
+// The defaultAlgorithm is used to compute the checksum if the client did not send any, e.g. for the
+// parts of a multipart upload created with a checksum algorithm.
+func parseRequestChecksum(r *http.Request, dataReader io.Reader, defaultAlgorithm string) (*checksumReader, s3err.ErrorCode) {
+	var algorithms []string
+	var expected string
+	for header := range r.Header {
+		if isChecksumValue(header) {
+			algorithms = append(algorithms, checksumAlgorithmOf(header))
+			expected = r.Header.Get(header)
+		}
+	}
+	var trailer http.Header
+	for _, header := range strings.Split(r.Header.Get(s3_constants.AmzTrailer), ",") {
+		if header = http.CanonicalHeaderKey(strings.TrimSpace(header)); isChecksumValue(header) {
+			algorithms = append(algorithms, checksumAlgorithmOf(header))
+			chunkedReader, ok := dataReader.(*s3ChunkedReader)
+			if !ok || !chunkedReader.hasTrailer {
+				glog.V(1).Infof("checksum trailer %s without aws-chunked trailer payload", header)
+				return nil, s3err.ErrInvalidRequest
+			}
+			trailer = chunkedReader.trailer
+		}
+	}
+	if len(algorithms) > 1 {
+		glog.V(1).Infof("multiple checksums %v", algorithms)
+		return nil, s3err.ErrInvalidRequest
+	}
+
+	declaredAlgorithm := strings.ToUpper(r.Header.Get(s3_constants.AmzSdkChecksumAlgorithm))
+	if len(algorithms) == 0 {
+		if declaredAlgorithm != "" {
+			glog.V(1).Infof("%s %s without checksum", s3_constants.AmzSdkChecksumAlgorithm, declaredAlgorithm)
+			return nil, s3err.ErrInvalidRequest
+		}
+		if defaultAlgorithm == "" {
+			return nil, s3err.ErrNone
+		}
+		algorithms = append(algorithms, defaultAlgorithm)
+	}
+	algorithm := algorithms[0]
+	if declaredAlgorithm != "" && declaredAlgorithm != algorithm {
+		glog.V(1).Infof("%s %s does not match checksum %s", s3_constants.AmzSdkChecksumAlgorithm, declaredAlgorithm, algorithm)
+		return nil, s3err.ErrInvalidRequest
+	}
+	if defaultAlgorithm != "" && defaultAlgorithm != algorithm {
+		glog.V(1).Infof("checksum %s does not match the upload checksum algorithm %s", algorithm, defaultAlgorithm)
+		return nil, s3err.ErrInvalidRequest
+	}
+	if errCode := validateChecksumAlgorithm(algorithm); errCode != s3err.ErrNone {
+		return nil, errCode
+	}
+
+	c := &checksumReader{
+		algorithm: algorithm,
+		expected:  expected,
+		trailer:   trailer,
+		hash:      checksumAlgorithms[algorithm](),
+		filerTrailer: http.Header{
+			s3_constants.ExtChecksumAlgorithmKey: nil,
+			s3_constants.ExtChecksumKey:          nil,
+		},
+	}
+	if expected != "" && !c.isValidChecksum(expected) {
+		glog.V(1).Infof("invalid %s checksum %s", algorithm, expected)
+		return nil, s3err.ErrInvalidRequest
+	}
+	return c, s3err.ErrNone
+}
+
+func (c *checksumReader) isValidChecksum(checksum string) bool {
+	data, err := base64.StdEncoding.DecodeString(checksum)
+	return err == nil && len(data) == c.hash.Size()
+}
+
+// wrap returns the reader verifying the checksum of the data
+func (c *checksumReader) wrap(dataReader io.Reader) io.Reader {
+	if c == nil {
+		return dataReader
+	}
+	c.reader = dataReader
+	return c
+}
+
+func (c *checksumReader) Read(p []byte) (n int, err error) {
+	n, err = c.reader.Read(p)
+	c.hash.Write(p[:n])
+	if err == io.EOF {
+		if verifyErr := c.verify(); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
+}
+
+func (c *checksumReader) verify() error {
+	c.checksum = base64.StdEncoding.EncodeToString(c.hash.Sum(nil))
+	expected := c.expected
+	if c.trailer != nil {
+		if expected = c.trailer.Get(checksumHeader(c.algorithm)); expected == "" {
+			return errChecksumMissing
+		}
+	}
+	if expected != "" && expected != c.checksum {
+		glog.V(1).Infof("%s checksum mismatch: expected %s, actual %s", c.algorithm, expected, c.checksum)
+		return errChecksumMismatch
+	}
+	c.filerTrailer.Set(s3_constants.ExtChecksumAlgorithmKey, c.algorithm)
+	c.filerTrailer.Set(s3_constants.ExtChecksumKey, c.checksum)
+	return nil
+}
+
+// setResponseHeader returns the verified checksum to the client
+func (c *checksumReader) setResponseHeader(w http.ResponseWriter) {
+	if c == nil || c.checksum == "" {
+		return
+	}
+	w.Header().Set(checksumHeader(c.algorithm), c.checksum)
+}
+
+// objectPart records the size and checksum of each part of a multipart object
+type objectPart struct {
+	PartNumber int
+	Size       int64
+	Checksum   string `json:",omitempty"`
+}
+
+func parseObjectParts(extended map[string][]byte) (parts []objectPart) {
+	if data, ok := extended[s3_constants.ExtMultipartPartsKey]; ok {
+		if err := json.Unmarshal(data, &parts); err != nil {
+			glog.Warningf("parse object parts %s: %v", string(data), err)
+		}
+	}
+	return
+}
+
+// compositeChecksum returns the checksum of the concatenated part checksums, with the part count suffix
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html#large-object-checksums
+func compositeChecksum(algorithm string, partChecksums []string) (string, error) {
+	newHash, ok := checksumAlgorithms[algorithm]
+	if !ok {
+		return "", fmt.Errorf("unsupported checksum algorithm %s", algorithm)
+	}
+	h := newHash()
+	for _, partChecksum := range partChecksums {
+		data, err := base64.StdEncoding.DecodeString(partChecksum)
+		if err != nil {
+			return "", fmt.Errorf("part checksum %s: %v", partChecksum, err)
+		}
+		h.Write(data)
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(partChecksums)), nil
+}
+
+// checksumType tells whether the stored checksum is computed over the whole object or over its parts
+func checksumType(checksum string) string {
+	if strings.Contains(checksum, "-") {
+		return checksumTypeComposite
+	}
+	return checksumTypeFullObject
+}
+
+// setChecksumOutput sets the checksum field of the aws output struct matching the algorithm
+func setChecksumOutput(algorithm, checksum string, crc32Field, crc32cField, sha1Field, sha256Field **string) {
+	switch algorithm {
+	case "CRC32":
+		*crc32Field = aws.String(checksum)
+	case "CRC32C":
+		*crc32cField = aws.String(checksum)
+	case "SHA1":
+		*sha1Field = aws.String(checksum)
+	case "SHA256":
+		*sha256Field = aws.String(checksum)
+	}
+}
+
+// setChecksumHeaders replaces the stored checksum attributes with the S3 response headers,
+// the checksum is only returned if requested with x-amz-checksum-mode and the whole object is read
+func setChecksumHeaders(r *http.Request, resp *http.Response) {
+	algorithm := resp.Header.Get(s3_constants.ExtChecksumAlgorithmKey)
+	checksum := resp.Header.Get(s3_constants.ExtChecksumKey)
+	resp.Header.Del(s3_constants.ExtChecksumAlgorithmKey)
+	resp.Header.Del(s3_constants.ExtChecksumKey)
+	resp.Header.Del(s3_constants.ExtMultipartPartsKey)
+	if algorithm == "" || checksum == "" || resp.Header.Get("Content-Range") != "" ||
+		!strings.EqualFold(r.Header.Get(s3_constants.AmzChecksumMode), "ENABLED") {
+		return
+	}
+	resp.Header.Set(checksumHeader(algorithm), checksum)
+	resp.Header.Set(s3_constants.AmzChecksumType, checksumType(checksum))
+}
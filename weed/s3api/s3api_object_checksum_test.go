@@ -0,0 +1,220 @@
+package s3api
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func crc32cBase64(data string) string {
+	h := crc32.New(crc32cTable)
+	h.Write([]byte(data))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func TestParseRequestChecksum(t *testing.T) {
+	newRequest := func(header http.Header) *http.Request {
+		r := httptest.NewRequest(http.MethodPut, "/bucket/object", strings.NewReader("hello"))
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		return r
+	}
+
+	checksum, errCode := parseRequestChecksum(newRequest(nil), nil, "")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Nil(t, checksum)
+
+	checksum, errCode = parseRequestChecksum(newRequest(http.Header{
+		"X-Amz-Checksum-Crc32c":        {crc32cBase64("hello")},
+		"X-Amz-Sdk-Checksum-Algorithm": {"crc32c"},
+	}), nil, "")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "CRC32C", checksum.algorithm)
+
+	data, err := io.ReadAll(checksum.wrap(strings.NewReader("hello")))
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, crc32cBase64("hello"), checksum.filerTrailer.Get(s3_constants.ExtChecksumKey))
+	assert.Equal(t, "CRC32C", checksum.filerTrailer.Get(s3_constants.ExtChecksumAlgorithmKey))
+
+	checksum, _ = parseRequestChecksum(newRequest(http.Header{
+		"X-Amz-Checksum-Crc32c": {crc32cBase64("hello")},
+	}), nil, "")
+	_, err = io.ReadAll(checksum.wrap(strings.NewReader("hellO")))
+	assert.Equal(t, errChecksumMismatch, err)
+	assert.Equal(t, "", checksum.filerTrailer.Get(s3_constants.ExtChecksumKey))
+
+	checksum, errCode = parseRequestChecksum(newRequest(nil), nil, "SHA256")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	_, err = io.ReadAll(checksum.wrap(strings.NewReader("hello")))
+	assert.Nil(t, err)
+	sum := sha256.Sum256([]byte("hello"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(sum[:]), checksum.checksum, "computed with the upload algorithm")
+
+	for _, header := range []http.Header{
+		{"X-Amz-Checksum-Crc32c": {"not a checksum"}},
+		{"X-Amz-Checksum-Crc32c": {crc32cBase64("hello")}, "X-Amz-Checksum-Sha256": {base64.StdEncoding.EncodeToString(sum[:])}},
+		{"X-Amz-Checksum-Md4": {crc32cBase64("hello")}},
+		{"X-Amz-Sdk-Checksum-Algorithm": {"CRC32"}},
+		{"X-Amz-Checksum-Crc32c": {crc32cBase64("hello")}, "X-Amz-Sdk-Checksum-Algorithm": {"CRC32"}},
+		{"X-Amz-Trailer": {"x-amz-checksum-crc32c"}},
+	} {
+		_, errCode = parseRequestChecksum(newRequest(header), nil, "")
+		assert.Equal(t, s3err.ErrInvalidRequest, errCode, "%v", header)
+	}
+}
+
+func TestCompositeChecksum(t *testing.T) {
+	part1, part2 := crc32cBase64("hello "), crc32cBase64("world")
+	raw1, _ := base64.StdEncoding.DecodeString(part1)
+	raw2, _ := base64.StdEncoding.DecodeString(part2)
+
+	checksum, err := compositeChecksum("CRC32C", []string{part1, part2})
+	assert.Nil(t, err)
+	assert.Equal(t, crc32cBase64(string(raw1)+string(raw2))+"-2", checksum)
+	assert.Equal(t, checksumTypeComposite, checksumType(checksum))
+	assert.Equal(t, checksumTypeFullObject, checksumType(part1))
+
+	_, err = compositeChecksum("MD4", []string{part1})
+	assert.NotNil(t, err)
+}
+
+func TestSetChecksumHeaders(t *testing.T) {
+	newResponse := func(header http.Header) *http.Response {
+		resp := &http.Response{Header: http.Header{
+			s3_constants.ExtChecksumAlgorithmKey: {"CRC32C"},
+			s3_constants.ExtChecksumKey:          {"AAAAAA==-2"},
+			s3_constants.ExtMultipartPartsKey:    {`[{"PartNumber":1,"Size":5}]`},
+		}}
+		for k, v := range header {
+			resp.Header[k] = v
+		}
+		return resp
+	}
+	request := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	request.Header.Set(s3_constants.AmzChecksumMode, "ENABLED")
+
+	resp := newResponse(nil)
+	setChecksumHeaders(request, resp)
+	assert.Equal(t, "AAAAAA==-2", resp.Header.Get("X-Amz-Checksum-Crc32c"))
+	assert.Equal(t, checksumTypeComposite, resp.Header.Get(s3_constants.AmzChecksumType))
+	assert.Equal(t, "", resp.Header.Get(s3_constants.ExtChecksumKey))
+	assert.Equal(t, "", resp.Header.Get(s3_constants.ExtMultipartPartsKey))
+
+	resp = newResponse(http.Header{"Content-Range": {"bytes 0-1/5"}})
+	setChecksumHeaders(request, resp)
+	assert.Equal(t, "", resp.Header.Get("X-Amz-Checksum-Crc32c"), "not for a range")
+
+	resp = newResponse(nil)
+	setChecksumHeaders(httptest.NewRequest(http.MethodGet, "/bucket/object", nil), resp)
+	assert.Equal(t, "", resp.Header.Get("X-Amz-Checksum-Crc32c"), "only when requested")
+}
+
+func TestUnsignedChunkedReaderTrailer(t *testing.T) {
+	payload := "5\r\nhello\r\n6\r\n world\r\n0\r\nx-amz-checksum-crc32c:" + crc32cBase64("hello world") + "\r\n\r\n"
+	r := httptest.NewRequest(http.MethodPut, "/bucket/object", strings.NewReader(payload))
+	r.Header.Set("X-Amz-Content-Sha256", streamingUnsignedPayloadTrailer)
+	r.Header.Set("Content-Encoding", "aws-chunked,gzip")
+	r.Header.Set(s3_constants.AmzDecodedContentLength, "11")
+	r.Header.Set(s3_constants.AmzTrailer, "x-amz-checksum-crc32c")
+
+	dataReader := newUnsignedChunkedReader(r)
+	assert.Equal(t, int64(11), r.ContentLength)
+	assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+
+	checksum, errCode := parseRequestChecksum(r, dataReader, "")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	data, err := io.ReadAll(checksum.wrap(dataReader))
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", string(data))
+	assert.Equal(t, crc32cBase64("hello world"), checksum.checksum)
+
+	r = httptest.NewRequest(http.MethodPut, "/bucket/object", strings.NewReader(strings.Replace(payload, "world", "World", 1)))
+	r.Header.Set(s3_constants.AmzTrailer, "x-amz-checksum-crc32c")
+	dataReader = newUnsignedChunkedReader(r)
+	checksum, _ = parseRequestChecksum(r, dataReader, "")
+	_, err = io.ReadAll(checksum.wrap(dataReader))
+	assert.Equal(t, errChecksumMismatch, err)
+}
+
+func TestSignedChunkedReaderTrailer(t *testing.T) {
+	iam := &IdentityAccessManagement{
+		hashes:       make(map[string]*sync.Pool),
+		hashCounters: make(map[string]*int32),
+	}
+	signer := &s3ChunkedReader{
+		cred:          &Credential{AccessKey: "access_key_1", SecretKey: "secret_key_1"},
+		seedSignature: "seed",
+		seedDate:      time.Now().UTC(),
+		region:        "us-east-1",
+		iam:           iam,
+	}
+	newReader := func(payload string) *s3ChunkedReader {
+		return &s3ChunkedReader{
+			cred:              signer.cred,
+			reader:            bufio.NewReader(strings.NewReader(payload)),
+			seedSignature:     "seed",
+			seedDate:          signer.seedDate,
+			region:            signer.region,
+			signed:            true,
+			hasTrailer:        true,
+			trailer:           make(http.Header),
+			chunkSHA256Writer: sha256.New(),
+			state:             readChunkHeader,
+			iam:               iam,
+		}
+	}
+	hashed := func(data string) string {
+		sum := sha256.Sum256([]byte(data))
+		return hex.EncodeToString(sum[:])
+	}
+
+	checksum := crc32cBase64("hello")
+	chunkSignature := signer.getChunkSignature(hashed("hello"))
+	signer.seedSignature = chunkSignature
+	lastChunkSignature := signer.getChunkSignature(hashed(""))
+	signer.seedSignature = lastChunkSignature
+	trailerSignature := signer.getTrailerSignature(hashed("x-amz-checksum-crc32c:" + checksum + "\n"))
+	payload := fmt.Sprintf("5;chunk-signature=%s\r\nhello\r\n0;chunk-signature=%s\r\nx-amz-checksum-crc32c:%s\r\nx-amz-trailer-signature:%s\r\n\r\n",
+		chunkSignature, lastChunkSignature, checksum, trailerSignature)
+
+	cr := newReader(payload)
+	data, err := io.ReadAll(cr)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, checksum, cr.trailer.Get("X-Amz-Checksum-Crc32c"))
+
+	_, err = io.ReadAll(newReader(strings.Replace(payload, checksum, crc32cBase64("hellO"), 1)))
+	assert.NotNil(t, err, "the trailer is signed")
+}
+
+func TestParseObjectAttributes(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object?attributes", nil)
+	r.Header.Set(s3_constants.AmzObjectAttributes, "ETag, Checksum,ObjectParts")
+	attributes, errCode := parseObjectAttributes(r)
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, map[string]bool{"ETag": true, "Checksum": true, "ObjectParts": true}, attributes)
+
+	r.Header.Set(s3_constants.AmzObjectAttributes, "ETag,Owner")
+	_, errCode = parseObjectAttributes(r)
+	assert.Equal(t, s3err.ErrInvalidObjectAttributes, errCode)
+
+	r.Header.Del(s3_constants.AmzObjectAttributes)
+	_, errCode = parseObjectAttributes(r)
+	assert.Equal(t, s3err.ErrInvalidObjectAttributes, errCode)
+}
@@ -72,6 +72,12 @@ func removeDuplicateSlashes(object string) string {
 	return result.String()
 }
 
+// isReservedObjectKey tells whether the key is inside the folders keeping the object versions or the deleted objects
+func isReservedObjectKey(object string) bool {
+	firstSegment, _, _ := strings.Cut(strings.TrimLeft(object, "/"), "/")
+	return firstSegment == s3_constants.VersionsFolder || firstSegment == filer.TrashFolder
+}
+
 func newListEntry(entry *filer_pb.Entry, key string, dir string, name string, bucketPrefix string, fetchOwner bool, isDirectory bool, encodingTypeUrl bool) (listEntry ListEntry) {
 	storageClass := "STANDARD"
 	if v, ok := entry.Extended[s3_constants.AmzStorageClass]; ok {
@@ -89,7 +95,7 @@ func newListEntry(entry *filer_pb.Entry, key string, dir string, name string, bu
 	}
 	listEntry = ListEntry{
 		Key:          key,
-		LastModified: time.Unix(entry.Attributes.Mtime, 0).UTC(),
+		LastModified: entry.Attributes.ModTime().UTC(),
 		ETag:         "\"" + filer.ETag(entry) + "\"",
 		Size:         int64(filer.FileSize(entry)),
 		StorageClass: StorageClass(storageClass),
@@ -120,7 +126,18 @@ func (s3a *S3ApiServer) GetObjectHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	destUrl := s3a.toFilerUrl(bucket, object)
+	destUrl, errCode := s3a.toFilerObjectUrl(w, r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	customerKey, errCode := parseRequestSSECustomerKey(r)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setCipherKeyHeader(r.Header, customerKey)
 
 	s3a.proxyToFiler(w, r, destUrl, false, passThroughResponse)
 }
@@ -130,11 +147,40 @@ func (s3a *S3ApiServer) HeadObjectHandler(w http.ResponseWriter, r *http.Request
 	bucket, object := s3_constants.GetBucketAndObject(r)
 	glog.V(3).Infof("HeadObjectHandler %s %s", bucket, object)
 
-	destUrl := s3a.toFilerUrl(bucket, object)
+	destUrl, errCode := s3a.toFilerObjectUrl(w, r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	customerKey, errCode := parseRequestSSECustomerKey(r)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setCipherKeyHeader(r.Header, customerKey)
 
 	s3a.proxyToFiler(w, r, destUrl, false, passThroughResponse)
 }
 
+// toFilerObjectUrl returns the filer url of the object, or of the version requested by the versionId query parameter
+func (s3a *S3ApiServer) toFilerObjectUrl(w http.ResponseWriter, r *http.Request, bucket, object string) (string, s3err.ErrorCode) {
+	versionId := r.URL.Query().Get("versionId")
+	if versionId == "" {
+		return s3a.toFilerUrl(bucket, object), s3err.ErrNone
+	}
+	dir, name, entry, errCode := s3a.getObjectVersion(bucket, object, versionId)
+	if errCode != s3err.ErrNone {
+		return "", errCode
+	}
+	if isDeleteMarker(entry) {
+		w.Header().Set(s3_constants.AmzVersionId, versionId)
+		w.Header().Set(s3_constants.AmzDeleteMarker, "true")
+		return "", s3err.ErrMethodNotAllowed
+	}
+	return s3a.toFilerVersionUrl(dir, name), s3err.ErrNone
+}
+
 func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, destUrl string, isWrite bool, responseFn func(proxyResponse *http.Response, w http.ResponseWriter) (statusCode int, bytesTransferred int64)) {
 
 	glog.V(3).Infof("s3 proxying %s to %s", r.Method, destUrl)
@@ -187,6 +233,11 @@ func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, des
 		return
 	}
 
+	if resp.StatusCode == http.StatusForbidden {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	if r.Method == http.MethodDelete {
 		if resp.StatusCode == http.StatusNotFound {
 			// this is normal
@@ -212,13 +263,6 @@ func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, des
 		return
 	}
 
-	// when HEAD a directory, it should be reported as no such key
-	// https://github.com/seaweedfs/seaweedfs/issues/3457
-	if resp.ContentLength == -1 && resp.StatusCode != http.StatusNotModified {
-		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
-		return
-	}
-
 	if resp.StatusCode == http.StatusBadRequest {
 		resp_body, _ := io.ReadAll(resp.Body)
 		switch string(resp_body) {
@@ -231,7 +275,23 @@ func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, des
 		return
 	}
 
+	// when HEAD a directory, it should be reported as no such key
+	// https://github.com/seaweedfs/seaweedfs/issues/3457
+	if resp.ContentLength == -1 && resp.StatusCode != http.StatusNotModified {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
+		return
+	}
+
 	setUserMetadataKeyToLowercase(resp)
+	setVersionHeaders(resp)
+	setObjectLockHeaders(resp)
+	removeObjectAclHeaders(resp)
+	setServerSideEncryptionHeaders(resp)
+	setReplicationStatusHeaders(resp)
+	setChecksumHeaders(r, resp)
+	if s3err.HasBucketCors(r) {
+		removeCorsHeaders(resp.Header)
+	}
 
 	responseStatusCode, bytesTransferred := responseFn(resp, w)
 	BucketTrafficSent(bytesTransferred, r)
@@ -0,0 +1,183 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+const defaultObjectAttributesMaxParts = 1000
+
+type GetObjectAttributesResponse struct {
+	XMLName      xml.Name                  `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse"`
+	ETag         *string                   `xml:"ETag,omitempty"`
+	Checksum     *ObjectAttributesChecksum `xml:"Checksum,omitempty"`
+	ObjectParts  *ObjectAttributesParts    `xml:"ObjectParts,omitempty"`
+	StorageClass *string                   `xml:"StorageClass,omitempty"`
+	ObjectSize   *int64                    `xml:"ObjectSize,omitempty"`
+}
+
+type ObjectAttributesChecksum struct {
+	s3.Checksum
+	ChecksumType string `xml:"ChecksumType,omitempty"`
+}
+
+// copied from s3.GetObjectAttributesParts, the Parts is not converting to <Part></Part>
+type ObjectAttributesParts struct {
+	TotalPartsCount      int `xml:"PartsCount"`
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+	IsTruncated          bool
+	Part                 []*s3.ObjectPart
+}
+
+// parseObjectAttributes parses the x-amz-object-attributes header listing the attributes to return
+func parseObjectAttributes(r *http.Request) (map[string]bool, s3err.ErrorCode) {
+	attributes := make(map[string]bool)
+	for _, values := range r.Header.Values(s3_constants.AmzObjectAttributes) {
+		for _, attribute := range strings.Split(values, ",") {
+			switch attribute = strings.TrimSpace(attribute); attribute {
+			case s3.ObjectAttributesEtag, s3.ObjectAttributesChecksum, s3.ObjectAttributesObjectParts,
+				s3.ObjectAttributesStorageClass, s3.ObjectAttributesObjectSize:
+				attributes[attribute] = true
+			case "":
+			default:
+				glog.V(1).Infof("invalid object attribute %s", attribute)
+				return nil, s3err.ErrInvalidObjectAttributes
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		return nil, s3err.ErrInvalidObjectAttributes
+	}
+	return attributes, s3err.ErrNone
+}
+
+// GetObjectAttributesHandler Retrieves all the metadata from an object without returning the object itself
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAttributes.html
+func (s3a *S3ApiServer) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetObjectAttributesHandler %s %s", bucket, object)
+
+	attributes, errCode := parseObjectAttributes(r)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	maxParts := defaultObjectAttributesMaxParts
+	if value := r.Header.Get(s3_constants.AmzMaxParts); value != "" {
+		var err error
+		if maxParts, err = strconv.Atoi(value); err != nil || maxParts < 0 {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidMaxParts)
+			return
+		}
+		maxParts = min(maxParts, defaultObjectAttributesMaxParts)
+	}
+	var partNumberMarker int
+	if value := r.Header.Get(s3_constants.AmzPartNumberMarker); value != "" {
+		var err error
+		if partNumberMarker, err = strconv.Atoi(value); err != nil || partNumberMarker < 0 {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidPartNumberMarker)
+			return
+		}
+	}
+
+	versionId := r.URL.Query().Get("versionId")
+	var entry *filer_pb.Entry
+	if versionId != "" {
+		if _, _, entry, errCode = s3a.getObjectVersion(bucket, object, versionId); errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+	} else {
+		dir, name := s3a.objectDirAndName(bucket, object)
+		var err error
+		if entry, err = s3a.getEntry(dir, name); err != nil {
+			if err == filer_pb.ErrNotFound {
+				s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
+				return
+			}
+			s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+			return
+		}
+	}
+	if entry.IsDirectory {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
+		return
+	}
+	if isDeleteMarker(entry) {
+		w.Header().Set(s3_constants.AmzDeleteMarker, "true")
+		if versionId != "" {
+			w.Header().Set(s3_constants.AmzVersionId, versionId)
+			s3err.WriteErrorResponse(w, r, s3err.ErrMethodNotAllowed)
+			return
+		}
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
+		return
+	}
+
+	response := GetObjectAttributesResponse{}
+	checksumAlgorithm := string(entry.Extended[s3_constants.ExtChecksumAlgorithmKey])
+	if attributes[s3.ObjectAttributesEtag] {
+		response.ETag = aws.String(filer.ETag(entry))
+	}
+	if checksum := string(entry.Extended[s3_constants.ExtChecksumKey]); attributes[s3.ObjectAttributesChecksum] && checksum != "" {
+		response.Checksum = &ObjectAttributesChecksum{ChecksumType: checksumType(checksum)}
+		// the part count is only part of the checksum in the object headers
+		checksum, _, _ = strings.Cut(checksum, "-")
+		setChecksumOutput(checksumAlgorithm, checksum,
+			&response.Checksum.ChecksumCRC32, &response.Checksum.ChecksumCRC32C, &response.Checksum.ChecksumSHA1, &response.Checksum.ChecksumSHA256)
+	}
+	if parts := parseObjectParts(entry.Extended); attributes[s3.ObjectAttributesObjectParts] && len(parts) > 0 {
+		response.ObjectParts = &ObjectAttributesParts{
+			TotalPartsCount:  len(parts),
+			PartNumberMarker: partNumberMarker,
+			MaxParts:         maxParts,
+		}
+		for _, part := range parts {
+			if part.PartNumber <= partNumberMarker {
+				continue
+			}
+			if len(response.ObjectParts.Part) >= maxParts {
+				response.ObjectParts.IsTruncated = true
+				break
+			}
+			objectPart := &s3.ObjectPart{
+				PartNumber: aws.Int64(int64(part.PartNumber)),
+				Size:       aws.Int64(part.Size),
+			}
+			if part.Checksum != "" {
+				setChecksumOutput(checksumAlgorithm, part.Checksum,
+					&objectPart.ChecksumCRC32, &objectPart.ChecksumCRC32C, &objectPart.ChecksumSHA1, &objectPart.ChecksumSHA256)
+			}
+			response.ObjectParts.Part = append(response.ObjectParts.Part, objectPart)
+			response.ObjectParts.NextPartNumberMarker = part.PartNumber
+		}
+	}
+	if attributes[s3.ObjectAttributesStorageClass] {
+		storageClass := "STANDARD"
+		if v, ok := entry.Extended[s3_constants.AmzStorageClass]; ok {
+			storageClass = string(v)
+		}
+		response.StorageClass = aws.String(storageClass)
+	}
+	if attributes[s3.ObjectAttributesObjectSize] {
+		response.ObjectSize = aws.Int64(int64(filer.FileSize(entry)))
+	}
+
+	w.Header().Set("Last-Modified", entry.Attributes.ModTime().UTC().Format(http.TimeFormat))
+	if entryVersionId := entryVersionId(entry); entryVersionId != nullVersionId || versionId != "" {
+		w.Header().Set(s3_constants.AmzVersionId, entryVersionId)
+	}
+	writeSuccessResponseXML(w, r, response)
+}
@@ -11,6 +11,7 @@ import (
 	"modernc.org/strutil"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
 	"github.com/seaweedfs/seaweedfs/weed/util"
@@ -33,13 +34,23 @@ func (s3a *S3ApiServer) CopyObjectHandler(w http.ResponseWriter, r *http.Request
 		cpSrcPath = r.Header.Get("X-Amz-Copy-Source")
 	}
 
+	srcVersionId := ""
+	if i := strings.Index(cpSrcPath, "?versionId="); i >= 0 {
+		cpSrcPath, srcVersionId = cpSrcPath[:i], cpSrcPath[i+len("?versionId="):]
+	}
+
 	srcBucket, srcObject := pathToBucketAndObject(cpSrcPath)
 
 	glog.V(3).Infof("CopyObjectHandler %s %s => %s %s", srcBucket, srcObject, dstBucket, dstObject)
 
+	if isReservedObjectKey(srcObject) || isReservedObjectKey(dstObject) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	replaceMeta, replaceTagging := replaceDirective(r.Header)
 
-	if (srcBucket == dstBucket && srcObject == dstObject || cpSrcPath == "") && (replaceMeta || replaceTagging) {
+	if (srcBucket == dstBucket && srcObject == dstObject && srcVersionId == "" || cpSrcPath == "") && (replaceMeta || replaceTagging) {
 		fullPath := util.FullPath(fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, dstBucket, dstObject))
 		dir, name := fullPath.DirAndName()
 		entry, err := s3a.getEntry(dir, name)
@@ -48,12 +59,20 @@ func (s3a *S3ApiServer) CopyObjectHandler(w http.ResponseWriter, r *http.Request
 			return
 		}
 		entry.Extended, err = processMetadataBytes(r.Header, entry.Extended, replaceMeta, replaceTagging)
-		entry.Attributes.Mtime = time.Now().Unix()
+		entry.Attributes.SetModTime(time.Now())
 		if err != nil {
 			glog.Errorf("CopyObjectHandler ValidateTags error %s: %v", r.URL, err)
 			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidTag)
 			return
 		}
+		ownerId, grants, errCode := s3a.objectAclForWrite(r, dstBucket)
+		if errCode == s3err.ErrNone {
+			errCode = AssembleEntryWithAcp(entry, ownerId, grants)
+		}
+		if errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
 		err = s3a.touch(dir, name, entry)
 		if err != nil {
 			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidCopySource)
@@ -73,24 +92,32 @@ func (s3a *S3ApiServer) CopyObjectHandler(w http.ResponseWriter, r *http.Request
 	}
 	srcPath := util.FullPath(fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, srcBucket, srcObject))
 	dir, name := srcPath.DirAndName()
-	if entry, err := s3a.getEntry(dir, name); err != nil || entry.IsDirectory {
+	srcUrl := fmt.Sprintf("http://%s%s/%s%s",
+		s3a.option.Filer.ToHttpAddress(), s3a.option.BucketsPath, srcBucket, urlEscapeObject(srcObject))
+	if srcVersionId != "" {
+		var entry *filer_pb.Entry
+		var errCode s3err.ErrorCode
+		if dir, name, entry, errCode = s3a.getObjectVersion(srcBucket, srcObject, srcVersionId); errCode != s3err.ErrNone || isDeleteMarker(entry) {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidCopySource)
+			return
+		}
+		srcUrl = s3a.toFilerVersionUrl(dir, name)
+	} else if entry, err := s3a.getEntry(dir, name); err != nil || entry.IsDirectory {
 		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidCopySource)
 		return
 	}
 
-	if srcBucket == dstBucket && srcObject == dstObject {
+	if srcBucket == dstBucket && srcObject == dstObject && srcVersionId == "" {
 		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidCopyDest)
 		return
 	}
 
 	dstUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer.ToHttpAddress(), s3a.option.BucketsPath, dstBucket, urlEscapeObject(dstObject))
-	srcUrl := fmt.Sprintf("http://%s%s/%s%s",
-		s3a.option.Filer.ToHttpAddress(), s3a.option.BucketsPath, srcBucket, urlEscapeObject(srcObject))
 
-	_, _, resp, err := util_http.DownloadFile(srcUrl, s3a.maybeGetFilerJwtAuthorizationToken(false))
-	if err != nil {
-		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidCopySource)
+	resp, errCode := s3a.readCopySource(r, srcUrl, "")
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
 		return
 	}
 	defer util_http.CloseResponse(resp)
@@ -100,16 +127,55 @@ func (s3a *S3ApiServer) CopyObjectHandler(w http.ResponseWriter, r *http.Request
 		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidCopySource)
 		return
 	}
+	filerHeader := make(http.Header)
+	lock, errCode := s3a.objectLockForWrite(r, dstBucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setObjectLockHeader(filerHeader, lock)
+
+	ownerId, grants, errCode := s3a.objectAclForWrite(r, dstBucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setObjectAclHeader(filerHeader, ownerId, grants)
+
+	sse, errCode := s3a.serverSideEncryptionForWrite(r, dstBucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setServerSideEncryptionHeader(filerHeader, sse)
+
+	versionId, errCode := s3a.prepareVersionedWrite(dstBucket, dstObject)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setVersionIdHeader(filerHeader, versionId)
+
 	glog.V(2).Infof("copy from %s to %s", srcUrl, dstUrl)
 	destination := fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, dstBucket, dstObject)
-	etag, errCode := s3a.putToFiler(r, dstUrl, resp.Body, destination, dstBucket)
+	etag, errCode := s3a.putToFiler(r, dstUrl, resp.Body, destination, dstBucket, filerHeader)
 
 	if errCode != s3err.ErrNone {
+		if versionId != "" {
+			s3a.promoteLatestVersion(dstBucket, dstObject)
+		}
 		s3err.WriteErrorResponse(w, r, errCode)
 		return
 	}
 
 	setEtag(w, etag)
+	if versionId != "" {
+		w.Header().Set(s3_constants.AmzVersionId, versionId)
+	}
+	if srcVersionId != "" {
+		w.Header().Set(s3_constants.AmzCopySourceVersionId, srcVersionId)
+	}
+	setServerSideEncryptionResponseHeaders(w, sse)
 
 	response := CopyObjectResult{
 		ETag:         etag,
@@ -153,6 +219,11 @@ func (s3a *S3ApiServer) CopyObjectPartHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if isReservedObjectKey(srcObject) || isReservedObjectKey(dstObject) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	uploadID := r.URL.Query().Get("uploadId")
 	partIDString := r.URL.Query().Get("partNumber")
 
@@ -176,17 +247,24 @@ func (s3a *S3ApiServer) CopyObjectPartHandler(w http.ResponseWriter, r *http.Req
 	srcUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer.ToHttpAddress(), s3a.option.BucketsPath, srcBucket, urlEscapeObject(srcObject))
 
-	resp, dataReader, err := util_http.ReadUrlAsReaderCloser(srcUrl, s3a.maybeGetFilerJwtAuthorizationToken(false), rangeHeader)
-	if err != nil {
-		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidCopySource)
+	filerHeader := make(http.Header)
+	sse, errCode := s3a.serverSideEncryptionForUploadPart(r, dstBucket, uploadID)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setServerSideEncryptionHeader(filerHeader, sse)
+
+	resp, errCode := s3a.readCopySource(r, srcUrl, rangeHeader)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
 		return
 	}
 	defer util_http.CloseResponse(resp)
-	defer dataReader.Close()
 
 	glog.V(2).Infof("copy from %s to %s", srcUrl, dstUrl)
 	destination := fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, dstBucket, dstObject)
-	etag, errCode := s3a.putToFiler(r, dstUrl, dataReader, destination, dstBucket)
+	etag, errCode := s3a.putToFiler(r, dstUrl, resp.Body, destination, dstBucket, filerHeader)
 
 	if errCode != s3err.ErrNone {
 		s3err.WriteErrorResponse(w, r, errCode)
@@ -194,6 +272,7 @@ func (s3a *S3ApiServer) CopyObjectPartHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	setEtag(w, etag)
+	setServerSideEncryptionResponseHeaders(w, sse)
 
 	response := CopyPartResult{
 		ETag:         etag,
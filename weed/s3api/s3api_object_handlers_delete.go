@@ -28,13 +28,34 @@ func (s3a *S3ApiServer) DeleteObjectHandler(w http.ResponseWriter, r *http.Reque
 	bucket, object := s3_constants.GetBucketAndObject(r)
 	glog.V(3).Infof("DeleteObjectHandler %s %s", bucket, object)
 
+	if isReservedObjectKey(object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	target := util.FullPath(fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, bucket, object))
 	dir, name := target.DirAndName()
 
+	versionId := r.URL.Query().Get("versionId")
+	isVersioned := versionId != "" || s3a.getVersioningState(bucket) != ""
+	if isVersioned {
+		deletedVersionId, deleteMarker, errCode := s3a.deleteVersionedObject(bucket, object, versionId, s3a.canBypassGovernance(r))
+		if errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		w.Header().Set(s3_constants.AmzVersionId, deletedVersionId)
+		if deleteMarker {
+			w.Header().Set(s3_constants.AmzDeleteMarker, "true")
+		}
+	}
+
 	err := s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
 
-		if err := doDeleteEntry(client, dir, name, true, false); err != nil {
-			return err
+		if !isVersioned {
+			if err := doDeleteEntry(client, dir, name, true, false); err != nil {
+				return err
+			}
 		}
 
 		if s3a.option.AllowEmptyFolder {
@@ -63,7 +84,10 @@ func (s3a *S3ApiServer) DeleteObjectHandler(w http.ResponseWriter, r *http.Reque
 
 // / ObjectIdentifier carries key name for the object to delete.
 type ObjectIdentifier struct {
-	ObjectName string `xml:"Key"`
+	ObjectName            string `xml:"Key"`
+	VersionId             string `xml:"VersionId,omitempty"`
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionId string `xml:"DeleteMarkerVersionId,omitempty"`
 }
 
 // DeleteObjectsRequest - xml carrying the object key names which needs to be deleted.
@@ -76,9 +100,10 @@ type DeleteObjectsRequest struct {
 
 // DeleteError structure.
 type DeleteError struct {
-	Code    string
-	Message string
-	Key     string
+	Code      string
+	Message   string
+	Key       string
+	VersionId string `xml:"VersionId,omitempty"`
 }
 
 // DeleteObjectsResponse container for multiple object deletes.
@@ -124,6 +149,8 @@ func (s3a *S3ApiServer) DeleteMultipleObjectsHandler(w http.ResponseWriter, r *h
 	if s3err.Logger != nil {
 		auditLog = s3err.GetAccessLog(r, http.StatusNoContent, s3err.ErrNone)
 	}
+	versioning := s3a.getVersioningState(bucket)
+	bypassGovernance := s3a.canBypassGovernance(r)
 	s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
 
 		// delete file entries
@@ -131,6 +158,40 @@ func (s3a *S3ApiServer) DeleteMultipleObjectsHandler(w http.ResponseWriter, r *h
 			if object.ObjectName == "" {
 				continue
 			}
+			if isReservedObjectKey(object.ObjectName) {
+				deleteErrors = append(deleteErrors, DeleteError{
+					Code:      s3err.GetAPIError(s3err.ErrAccessDenied).Code,
+					Message:   s3err.GetAPIError(s3err.ErrAccessDenied).Description,
+					Key:       object.ObjectName,
+					VersionId: object.VersionId,
+				})
+				continue
+			}
+			if object.VersionId != "" || versioning != "" {
+				deletedVersionId, deleteMarker, errCode := s3a.deleteVersionedObject(bucket, "/"+object.ObjectName, object.VersionId, bypassGovernance)
+				if errCode != s3err.ErrNone {
+					deleteErrors = append(deleteErrors, DeleteError{
+						Code:      s3err.GetAPIError(errCode).Code,
+						Message:   s3err.GetAPIError(errCode).Description,
+						Key:       object.ObjectName,
+						VersionId: object.VersionId,
+					})
+					continue
+				}
+				if deleteMarker {
+					object.DeleteMarker = true
+					object.DeleteMarkerVersionId = deletedVersionId
+				}
+				deletedObjects = append(deletedObjects, object)
+				if lastSeparator := strings.LastIndex(object.ObjectName, "/"); lastSeparator > 0 {
+					directoriesWithDeletion[fmt.Sprintf("%s/%s/%s", s3a.option.BucketsPath, bucket, object.ObjectName[:lastSeparator])]++
+				}
+				if auditLog != nil {
+					auditLog.Key = object.ObjectName
+					s3err.PostAccessLog(*auditLog)
+				}
+				continue
+			}
 			lastSeparator := strings.LastIndex(object.ObjectName, "/")
 			parentDirectoryPath, entryName, isDeleteData, isRecursive := "", object.ObjectName, true, false
 			if lastSeparator > 0 && lastSeparator+1 < len(object.ObjectName) {
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
@@ -340,7 +341,7 @@ func (s3a *S3ApiServer) doListFilerEntries(client filer_pb.SeaweedFilerClient, d
 	request := &filer_pb.ListEntriesRequest{
 		Directory:          dir,
 		Prefix:             prefix,
-		Limit:              uint32(cursor.maxKeys + 2), // bucket root directory needs to skip additional s3_constants.MultipartUploadsFolder folder
+		Limit:              uint32(cursor.maxKeys + 5), // bucket root directory needs to skip additional s3_constants.MultipartUploadsFolder, s3_constants.VersionsFolder, filer.TrashFolder and filer.SnapshotsFolder folders
 		StartFromFileName:  marker,
 		InclusiveStartFrom: inclusiveStartFrom,
 	}
@@ -383,7 +384,11 @@ func (s3a *S3ApiServer) doListFilerEntries(client filer_pb.SeaweedFilerClient, d
 		}
 		if entry.IsDirectory {
 			// glog.V(4).Infof("List Dir Entries %s, file: %s, maxKeys %d", dir, entry.Name, cursor.maxKeys)
-			if entry.Name == s3_constants.MultipartUploadsFolder { // FIXME no need to apply to all directories. this extra also affects maxKeys
+			if entry.Name == s3_constants.MultipartUploadsFolder || entry.Name == s3_constants.VersionsFolder || entry.Name == filer.TrashFolder { // FIXME no need to apply to all directories. this extra also affects maxKeys
+				continue
+			}
+			// the snapshots are listed only with their prefix
+			if entry.Name == filer.SnapshotsFolder && prefix != filer.SnapshotsFolder {
 				continue
 			}
 			if delimiter != "/" || cursor.prefixEndsOnDelimiter {
@@ -31,13 +31,44 @@ const (
 func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http.Request) {
 	bucket, object := s3_constants.GetBucketAndObject(r)
 
+	if isReservedObjectKey(object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	createMultipartUploadInput := &s3.CreateMultipartUploadInput{
 		Bucket:   aws.String(bucket),
 		Key:      objectKey(aws.String(object)),
 		Metadata: make(map[string]*string),
 	}
 
+	ownerId, grants, errCode := s3a.objectAclForWrite(r, bucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setObjectAclHeader(r.Header, ownerId, grants)
 	metadata := weed_server.SaveAmzMetaData(r, nil, false)
+	lock, errCode := s3a.objectLockForWrite(r, bucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	lock.toExtended(metadata)
+	sse, errCode := s3a.serverSideEncryptionForWrite(r, bucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	sse.toExtended(metadata)
+	checksumAlgorithm := strings.ToUpper(r.Header.Get(s3_constants.AmzChecksumAlgorithm))
+	if checksumAlgorithm != "" {
+		if errCode = validateChecksumAlgorithm(checksumAlgorithm); errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		metadata[s3_constants.ExtChecksumAlgorithmKey] = []byte(checksumAlgorithm)
+	}
 	for k, v := range metadata {
 		createMultipartUploadInput.Metadata[k] = aws.String(string(v))
 	}
@@ -55,6 +86,10 @@ func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	setServerSideEncryptionResponseHeaders(w, sse)
+	if checksumAlgorithm != "" {
+		w.Header().Set(s3_constants.AmzChecksumAlgorithm, checksumAlgorithm)
+	}
 	writeSuccessResponseXML(w, r, response)
 
 }
@@ -65,6 +100,11 @@ func (s3a *S3ApiServer) CompleteMultipartUploadHandler(w http.ResponseWriter, r
 
 	bucket, object := s3_constants.GetBucketAndObject(r)
 
+	if isReservedObjectKey(object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	parts := &CompleteMultipartUpload{}
 	if err := xmlDecoder(r.Body, parts, r.ContentLength); err != nil {
 		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
@@ -92,6 +132,9 @@ func (s3a *S3ApiServer) CompleteMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 	stats_collect.S3UploadedObjectsCounter.WithLabelValues(bucket).Inc()
+	if response.VersionId != nil {
+		w.Header().Set(s3_constants.AmzVersionId, *response.VersionId)
+	}
 
 	writeSuccessResponseXML(w, r, response)
 
@@ -210,6 +253,11 @@ func (s3a *S3ApiServer) ListObjectPartsHandler(w http.ResponseWriter, r *http.Re
 func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Request) {
 	bucket, object := s3_constants.GetBucketAndObject(r)
 
+	if isReservedObjectKey(object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	uploadID := r.URL.Query().Get("uploadId")
 	err := s3a.checkUploadId(object, uploadID)
 	if err != nil {
@@ -235,6 +283,10 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 		switch rAuthType {
 		case authTypeStreamingSigned:
 			dataReader, s3ErrCode = s3a.iam.newSignV4ChunkedReader(r)
+		case authTypeStreamingUnsigned:
+			if _, s3ErrCode = s3a.iam.reqSignatureV4Verify(r); s3ErrCode == s3err.ErrNone {
+				dataReader = newUnsignedChunkedReader(r)
+			}
 		case authTypeSignedV2, authTypePresignedV2:
 			_, s3ErrCode = s3a.iam.isReqAuthenticatedV2(r)
 		case authTypePresigned, authTypeSigned:
@@ -249,11 +301,32 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 			s3err.WriteErrorResponse(w, r, s3err.ErrAuthNotSetup)
 			return
 		}
+		if authTypeStreamingUnsigned == rAuthType {
+			dataReader = newUnsignedChunkedReader(r)
+		}
 	}
 	defer dataReader.Close()
 
 	glog.V(2).Infof("PutObjectPartHandler %s %s %04d", bucket, uploadID, partID)
 
+	filerHeader := make(http.Header)
+	sse, errCode := s3a.serverSideEncryptionForUploadPart(r, bucket, uploadID)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setServerSideEncryptionHeader(filerHeader, sse)
+
+	var uploadChecksumAlgorithm string
+	if uploadEntry, err := s3a.getEntry(s3a.genUploadsFolder(bucket), uploadID); err == nil {
+		uploadChecksumAlgorithm = string(uploadEntry.Extended[s3_constants.ExtChecksumAlgorithmKey])
+	}
+	checksum, errCode := parseRequestChecksum(r, dataReader, uploadChecksumAlgorithm)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
 	uploadUrl := s3a.genPartUploadUrl(bucket, uploadID, partID)
 
 	if partID == 1 && r.Header.Get("Content-Type") == "" {
@@ -261,13 +334,15 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 	}
 	destination := fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, bucket, object)
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader, destination, bucket)
+	etag, errCode := s3a.putToFiler(r, uploadUrl, checksum.wrap(dataReader), destination, bucket, filerHeader)
 	if errCode != s3err.ErrNone {
 		s3err.WriteErrorResponse(w, r, errCode)
 		return
 	}
 
 	setEtag(w, etag)
+	setServerSideEncryptionResponseHeaders(w, sse)
+	checksum.setResponseHeader(w)
 
 	writeSuccessResponseEmpty(w, r)
 
@@ -350,6 +425,25 @@ type CompleteMultipartUpload struct {
 	Parts []CompletedPart `xml:"Part"`
 }
 type CompletedPart struct {
-	ETag       string
-	PartNumber int
+	ETag           string
+	PartNumber     int
+	ChecksumCRC32  string
+	ChecksumCRC32C string
+	ChecksumSHA1   string
+	ChecksumSHA256 string
+}
+
+// checksum returns the part checksum sent by the client for the algorithm
+func (p CompletedPart) checksum(algorithm string) string {
+	switch algorithm {
+	case "CRC32":
+		return p.ChecksumCRC32
+	case "CRC32C":
+		return p.ChecksumCRC32C
+	case "SHA1":
+		return p.ChecksumSHA1
+	case "SHA256":
+		return p.ChecksumSHA256
+	}
+	return ""
 }
@@ -58,6 +58,11 @@ func (s3a *S3ApiServer) PostPolicyBucketHandler(w http.ResponseWriter, r *http.R
 	}
 	object := formValues.Get("Key")
 
+	if isReservedObjectKey(object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	successRedirect := formValues.Get("success_action_redirect")
 	successStatus := formValues.Get("success_action_status")
 	var redirectURL *url.URL
@@ -70,12 +75,19 @@ func (s3a *S3ApiServer) PostPolicyBucketHandler(w http.ResponseWriter, r *http.R
 	}
 
 	// Verify policy signature.
-	errCode := s3a.iam.doesPolicySignatureMatch(formValues)
+	identity, errCode := s3a.iam.doesPolicySignatureMatch(formValues)
 	if errCode != s3err.ErrNone {
 		s3err.WriteErrorResponse(w, r, errCode)
 		return
 	}
 
+	// the policy uploads on behalf of the identity signing it
+	r.Header.Set(s3_constants.AmzIdentityId, identity.Name)
+	if !identity.isAllowed(newPolicyRequest(r, identity, bucket, object), s3_constants.ACTION_WRITE, bucket, object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	policyBytes, err := base64.StdEncoding.DecodeString(formValues.Get("Policy"))
 	if err != nil {
 		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedPOSTRequest)
@@ -123,6 +135,8 @@ func (s3a *S3ApiServer) PostPolicyBucketHandler(w http.ResponseWriter, r *http.R
 		contentType = fileContentType
 	}
 	r.Header.Set("Content-Type", contentType)
+	// the file is uploaded, not the whole form
+	r.ContentLength = fileSize
 
 	// Add s3 postpolicy support header
 	for k, _ := range formValues {
@@ -134,14 +148,58 @@ func (s3a *S3ApiServer) PostPolicyBucketHandler(w http.ResponseWriter, r *http.R
 		if strings.HasPrefix(k, s3_constants.AmzUserMetaPrefix) {
 			r.Header.Set(k, formValues.Get(k))
 		}
+
+		if strings.HasPrefix(k, s3_constants.AmzServerSideEncryption) {
+			r.Header.Set(k, formValues.Get(k))
+		}
+
+		if k == "Acl" {
+			r.Header.Set(s3_constants.AmzCannedAcl, formValues.Get(k))
+		}
 	}
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, fileBody, "", bucket)
+	filerHeader := make(http.Header)
+	lock, errCode := s3a.objectLockForWrite(r, bucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setObjectLockHeader(filerHeader, lock)
 
+	ownerId, grants, errCode := s3a.objectAclForWrite(r, bucket)
 	if errCode != s3err.ErrNone {
 		s3err.WriteErrorResponse(w, r, errCode)
 		return
 	}
+	setObjectAclHeader(filerHeader, ownerId, grants)
+
+	sse, errCode := s3a.serverSideEncryptionForWrite(r, bucket)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setServerSideEncryptionHeader(filerHeader, sse)
+
+	versionId, errCode := s3a.prepareVersionedWrite(bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	setVersionIdHeader(filerHeader, versionId)
+
+	etag, errCode := s3a.putToFiler(r, uploadUrl, fileBody, "", bucket, filerHeader)
+
+	if errCode != s3err.ErrNone {
+		if versionId != "" {
+			s3a.promoteLatestVersion(bucket, object)
+		}
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	if versionId != "" {
+		w.Header().Set(s3_constants.AmzVersionId, versionId)
+	}
+	setServerSideEncryptionResponseHeaders(w, sse)
 
 	if successRedirect != "" {
 		// Replace raw query params..
@@ -261,8 +319,8 @@ func getRedirectPostRawQuery(bucket, key, etag string) string {
 	return redirectValues.Encode()
 }
 
-// Check to see if Policy is signed correctly.
-func (iam *IdentityAccessManagement) doesPolicySignatureMatch(formValues http.Header) s3err.ErrorCode {
+// Check to see if Policy is signed correctly, and returns the identity signing it.
+func (iam *IdentityAccessManagement) doesPolicySignatureMatch(formValues http.Header) (*Identity, s3err.ErrorCode) {
 	// For SignV2 - Signature field will be valid
 	if _, ok := formValues["Signature"]; ok {
 		return iam.doesPolicySignatureV2Match(formValues)
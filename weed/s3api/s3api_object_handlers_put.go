@@ -3,6 +3,7 @@ package s3api
 import (
 	"crypto/md5"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
 	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
@@ -27,6 +29,11 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 	bucket, object := s3_constants.GetBucketAndObject(r)
 	glog.V(3).Infof("PutObjectHandler %s %s", bucket, object)
 
+	if isReservedObjectKey(object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
 	_, err := validateContentMd5(r.Header)
 	if err != nil {
 		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidDigest)
@@ -54,6 +61,10 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 		switch rAuthType {
 		case authTypeStreamingSigned:
 			dataReader, s3ErrCode = s3a.iam.newSignV4ChunkedReader(r)
+		case authTypeStreamingUnsigned:
+			if _, s3ErrCode = s3a.iam.reqSignatureV4Verify(r); s3ErrCode == s3err.ErrNone {
+				dataReader = newUnsignedChunkedReader(r)
+			}
 		case authTypeSignedV2, authTypePresignedV2:
 			_, s3ErrCode = s3a.iam.isReqAuthenticatedV2(r)
 		case authTypePresigned, authTypeSigned:
@@ -68,9 +79,18 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 			s3err.WriteErrorResponse(w, r, s3err.ErrAuthNotSetup)
 			return
 		}
+		if authTypeStreamingUnsigned == rAuthType {
+			dataReader = newUnsignedChunkedReader(r)
+		}
 	}
 	defer dataReader.Close()
 
+	checksum, errCode := parseRequestChecksum(r, dataReader, "")
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
 	objectContentType := r.Header.Get("Content-Type")
 	if strings.HasSuffix(object, "/") && r.ContentLength <= 1024 {
 		if err := s3a.mkdir(
@@ -93,21 +113,60 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 			dataReader = mimeDetect(r, dataReader)
 		}
 
-		etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader, "", bucket)
+		filerHeader := make(http.Header)
+		lock, errCode := s3a.objectLockForWrite(r, bucket)
+		if errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		setObjectLockHeader(filerHeader, lock)
+
+		ownerId, grants, errCode := s3a.objectAclForWrite(r, bucket)
+		if errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		setObjectAclHeader(filerHeader, ownerId, grants)
+
+		sse, errCode := s3a.serverSideEncryptionForWrite(r, bucket)
+		if errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		setServerSideEncryptionHeader(filerHeader, sse)
 
+		versionId, errCode := s3a.prepareVersionedWrite(bucket, object)
 		if errCode != s3err.ErrNone {
 			s3err.WriteErrorResponse(w, r, errCode)
 			return
 		}
+		setVersionIdHeader(filerHeader, versionId)
+
+		etag, errCode := s3a.putToFiler(r, uploadUrl, checksum.wrap(dataReader), "", bucket, filerHeader)
+
+		if errCode != s3err.ErrNone {
+			if versionId != "" {
+				s3a.promoteLatestVersion(bucket, object)
+			}
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
 
 		setEtag(w, etag)
+		if versionId != "" {
+			w.Header().Set(s3_constants.AmzVersionId, versionId)
+		}
+		setServerSideEncryptionResponseHeaders(w, sse)
+		checksum.setResponseHeader(w)
 	}
 	stats_collect.S3UploadedObjectsCounter.WithLabelValues(bucket).Inc()
 
 	writeSuccessResponseEmpty(w, r)
 }
 
-func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.Reader, destination string, bucket string) (etag string, code s3err.ErrorCode) {
+// putToFiler uploads the data with the headers of the request, the extended attributes of the new entry
+// are only given by filerHeader, the ones sent by the client are dropped
+func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.Reader, destination string, bucket string, filerHeader http.Header) (etag string, code s3err.ErrorCode) {
 
 	hash := md5.New()
 	var body = io.TeeReader(dataReader, hash)
@@ -132,16 +191,34 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 	proxyReq.ContentLength = r.ContentLength
 
 	for header, values := range r.Header {
+		if strings.HasPrefix(header, needle.PairNamePrefix) || header == s3_constants.SeaweedFSCipher || header == s3_constants.SeaweedFSCipherKey {
+			continue
+		}
 		for _, value := range values {
 			proxyReq.Header.Add(header, value)
 		}
 	}
+	for header, values := range filerHeader {
+		proxyReq.Header[header] = values
+	}
+	// the checksum is verified at the end of the data, and only stored by the trailer if it matches
+	checksum, hasChecksum := dataReader.(*checksumReader)
+	if hasChecksum {
+		proxyReq.ContentLength = -1
+		proxyReq.Trailer = checksum.filerTrailer
+	}
 	// ensure that the Authorization header is overriding any previous
 	// Authorization header which might be already present in proxyReq
 	s3a.maybeAddFilerJwtAuthorization(proxyReq, true)
 	resp, postErr := s3a.client.Do(proxyReq)
 
 	if postErr != nil {
+		if hasChecksum && errors.Is(postErr, errChecksumMismatch) {
+			return "", s3err.ErrBadDigest
+		}
+		if hasChecksum && errors.Is(postErr, errChecksumMissing) {
+			return "", s3err.ErrInvalidRequest
+		}
 		glog.Errorf("post to filer: %v", postErr)
 		return "", s3err.ErrInternalError
 	}
@@ -154,6 +231,10 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 		glog.Errorf("upload to filer response read %d: %v", resp.StatusCode, ra_err)
 		return etag, s3err.ErrInternalError
 	}
+	if resp.StatusCode == http.StatusInsufficientStorage {
+		glog.V(1).Infof("upload to filer %s: %s", uploadUrl, string(resp_body))
+		return "", s3err.ErrQuotaExceeded
+	}
 	var ret weed_server.FilerPostResult
 	unmarshal_err := json.Unmarshal(resp_body, &ret)
 	if unmarshal_err != nil {
@@ -0,0 +1,70 @@
+package s3api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/stretchr/testify/assert"
+)
+
+// putToTestFiler uploads with putToFiler, and returns the headers received by the filer
+func putToTestFiler(t *testing.T, r *http.Request, filerHeader http.Header) http.Header {
+	var received http.Header
+	filer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		received = r.Header.Clone()
+		fmt.Fprint(w, `{"size":4}`)
+	}))
+	defer filer.Close()
+
+	s3a := &S3ApiServer{
+		option:     &S3ApiServerOption{},
+		client:     &http.Client{},
+		filerGuard: security.NewGuard(nil, "", 0, "", 0),
+	}
+	_, errCode := s3a.putToFiler(r, filer.URL+"/buckets/bucket/object", strings.NewReader("data"), "", "bucket", filerHeader)
+	assert.Equal(t, s3err.ErrNone, errCode)
+	return received
+}
+
+func TestPutToFilerDropsClientExtendedAttributes(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/bucket/object", strings.NewReader("data"))
+	r.Header.Set("Content-Type", "text/plain")
+	r.Header.Set(s3_constants.AmzUserMetaPrefix+"Color", "blue")
+	for _, header := range []string{
+		s3_constants.ExtChecksumAlgorithmKey,
+		s3_constants.ExtChecksumKey,
+		s3_constants.ExtMultipartPartsKey,
+		s3_constants.ExtReplicationStatusKey,
+		s3_constants.ExtObjectLockModeKey,
+		s3_constants.ExtObjectLockRetainUntilDateKey,
+		s3_constants.ExtVersionIdKey,
+		s3_constants.ExtDeleteMarkerKey,
+		s3_constants.ExtAmzOwnerKey,
+		s3_constants.ExtAmzAclKey,
+		s3_constants.SeaweedFSCipher,
+		s3_constants.SeaweedFSCipherKey,
+	} {
+		r.Header.Set(header, "forged")
+	}
+
+	filerHeader := make(http.Header)
+	setVersionIdHeader(filerHeader, "v1")
+	received := putToTestFiler(t, r, filerHeader)
+
+	assert.Equal(t, "text/plain", received.Get("Content-Type"))
+	assert.Equal(t, "blue", received.Get(s3_constants.AmzUserMetaPrefix+"Color"))
+	assert.Equal(t, "v1", received.Get(s3_constants.ExtVersionIdKey))
+	for header := range received {
+		if header != s3_constants.ExtVersionIdKey {
+			assert.NotEqualf(t, "forged", received.Get(header), "client header %s", header)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package s3api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/query/s3select"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+const maxSelectRequestSize = 256 * 1024
+
+// SelectObjectContentHandler Filters the content of an object with a SQL statement
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_SelectObjectContent.html
+func (s3a *S3ApiServer) SelectObjectContentHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("SelectObjectContentHandler %s %s", bucket, object)
+
+	if r.URL.Query().Get("select-type") != "2" {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+		return
+	}
+	req := &s3select.SelectObjectContentRequest{}
+	if err := xmlDecoder(io.LimitReader(r.Body, maxSelectRequestSize), req, r.ContentLength); err != nil {
+		glog.Errorf("SelectObjectContentHandler %s %s: %v", bucket, object, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	selector, err := s3select.NewSelector(req)
+	if err != nil {
+		writeSelectError(w, r, err)
+		return
+	}
+
+	destUrl, errCode := s3a.toFilerObjectUrl(w, r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	customerKey, errCode := parseRequestSSECustomerKey(r)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	var records s3select.RecordReader
+	if selector.IsParquet() {
+		resp, errCode := s3a.readSelectObject(http.MethodHead, destUrl, customerKey)
+		if errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		util_http.CloseResponse(resp)
+		records, err = selector.OpenReaderAt(&filerObjectReaderAt{s3a: s3a, destUrl: destUrl, customerKey: customerKey}, resp.ContentLength)
+	} else {
+		resp, errCode := s3a.readSelectObject(http.MethodGet, destUrl, customerKey)
+		if errCode != s3err.ErrNone {
+			s3err.WriteErrorResponse(w, r, errCode)
+			return
+		}
+		defer util_http.CloseResponse(resp)
+		records, err = selector.OpenReader(resp.Body)
+	}
+	if err != nil {
+		writeSelectError(w, r, err)
+		return
+	}
+
+	// the query errors from now on are reported as error events of the stream
+	s3err.WriteResponse(w, r, http.StatusOK, nil, "application/octet-stream")
+	if err = selector.Run(w, records); err != nil {
+		glog.V(1).Infof("SelectObjectContentHandler %s %s: %v", bucket, object, err)
+	}
+}
+
+func writeSelectError(w http.ResponseWriter, r *http.Request, err error) {
+	var selectErr *s3select.SelectError
+	if !errors.As(err, &selectErr) {
+		glog.Errorf("select %s: %v", r.URL.Path, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	s3err.WriteErrorMessageResponse(w, r, selectErr.HTTPStatusCode, selectErr.Code, selectErr.Message)
+}
+
+func (s3a *S3ApiServer) readSelectObject(method, destUrl string, customerKey *sseCustomerKey) (*http.Response, s3err.ErrorCode) {
+	resp, err := s3a.readFilerObject(method, destUrl, "", customerKey)
+	if err != nil {
+		glog.Errorf("read %s: %v", destUrl, err)
+		return nil, s3err.ErrInternalError
+	}
+	errCode := s3err.ErrNone
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		errCode = s3err.ErrNoSuchKey
+	case resp.StatusCode == http.StatusBadRequest:
+		errCode = s3err.ErrInvalidRequest
+	case resp.StatusCode == http.StatusForbidden:
+		errCode = s3err.ErrAccessDenied
+	case resp.StatusCode >= 300:
+		errCode = s3err.ErrInternalError
+	}
+	if errCode != s3err.ErrNone {
+		util_http.CloseResponse(resp)
+		return nil, errCode
+	}
+	return resp, s3err.ErrNone
+}
+
+// filerObjectReaderAt reads ranges of the object, as Parquet files are read from the footer
+type filerObjectReaderAt struct {
+	s3a         *S3ApiServer
+	destUrl     string
+	customerKey *sseCustomerKey
+}
+
+func (f *filerObjectReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	rangeHeader := fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1)
+	resp, err := f.s3a.readFilerObject(http.MethodGet, f.destUrl, rangeHeader, f.customerKey)
+	if err != nil {
+		return 0, err
+	}
+	defer util_http.CloseResponse(resp)
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusRequestedRangeNotSatisfiable:
+		return 0, io.EOF
+	default:
+		return 0, fmt.Errorf("read %s %s: %s", f.destUrl, rangeHeader, strings.TrimSpace(resp.Status))
+	}
+	n, err := io.ReadFull(resp.Body, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
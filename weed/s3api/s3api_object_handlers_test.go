@@ -1,8 +1,11 @@
 package s3api
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -80,3 +83,56 @@ func TestS3ApiServer_toFilerUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReservedObjectKey(t *testing.T) {
+	tests := []struct {
+		object string
+		want   bool
+	}{
+		{"/.versions", true},
+		{"/.versions/key/v1", true},
+		{"//.versions/key", true},
+		{".trash/key", true},
+		{"/dir/.versions/key", false},
+		{"/.versions-key", false},
+		{"/key", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, isReservedObjectKey(tt.object), "reserved %v", tt.object)
+	}
+}
+
+func TestReservedObjectKeyRejected(t *testing.T) {
+	s3a := &S3ApiServer{}
+	request := func(method, object string) *http.Request {
+		r := httptest.NewRequest(method, "/bucket1/"+object, nil)
+		return mux.SetURLVars(r, map[string]string{"bucket": "bucket1", "object": object})
+	}
+	handlers := map[string]struct {
+		method  string
+		handler http.HandlerFunc
+	}{
+		"put":       {http.MethodPut, s3a.PutObjectHandler},
+		"delete":    {http.MethodDelete, s3a.DeleteObjectHandler},
+		"multipart": {http.MethodPost, s3a.NewMultipartUploadHandler},
+		"complete":  {http.MethodPost, s3a.CompleteMultipartUploadHandler},
+		"part":      {http.MethodPut, s3a.PutObjectPartHandler},
+	}
+	for name, h := range handlers {
+		for _, object := range []string{".versions/key/v1", ".trash/key"} {
+			w := httptest.NewRecorder()
+			h.handler(w, request(h.method, object))
+			assert.Equalf(t, http.StatusForbidden, w.Code, "%s %s", name, object)
+		}
+	}
+
+	// copying into or out of the reserved folders
+	for copySource, object := range map[string]string{"/bucket1/key": ".versions/key/v2", "/bucket1/.versions/key/v1": "key"} {
+		r := request(http.MethodPut, object)
+		r.Header.Set("X-Amz-Copy-Source", copySource)
+		w := httptest.NewRecorder()
+		s3a.CopyObjectHandler(w, r)
+		assert.Equalf(t, http.StatusForbidden, w.Code, "copy %s to %s", copySource, object)
+	}
+}
@@ -0,0 +1,530 @@
+package s3api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+// S3 Object Lock is built on the worm support of the filer:
+//
+//   - enabling object lock on a bucket adds a worm rule for the bucket folder to filer.conf,
+//     with the default retention, if any, as the worm retention time.
+//   - the retention mode, retain-until date and legal hold of each object version are kept in its
+//     extended attributes, and the filer refuses to change or delete an entry while they are in effect.
+//
+// Since object lock requires versioning, overwriting or deleting an object through S3 only creates a new version
+// or a delete marker. Permanently deleting a locked version is refused, unless its retention is in GOVERNANCE mode
+// and the request is allowed to bypass it.
+
+const (
+	objectLockEnabled = "Enabled"
+)
+
+type ObjectLockConfiguration struct {
+	XMLName           xml.Name        `xml:"ObjectLockConfiguration"`
+	Xmlns             string          `xml:"xmlns,attr,omitempty"`
+	ObjectLockEnabled string          `xml:"ObjectLockEnabled,omitempty"`
+	Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+}
+
+type ObjectLockRule struct {
+	DefaultRetention *DefaultRetention `xml:"DefaultRetention,omitempty"`
+}
+
+type DefaultRetention struct {
+	Mode  string `xml:"Mode,omitempty"`
+	Days  int    `xml:"Days,omitempty"`
+	Years int    `xml:"Years,omitempty"`
+}
+
+type ObjectRetention struct {
+	XMLName         xml.Name   `xml:"Retention"`
+	Xmlns           string     `xml:"xmlns,attr,omitempty"`
+	Mode            string     `xml:"Mode,omitempty"`
+	RetainUntilDate *time.Time `xml:"RetainUntilDate,omitempty"`
+}
+
+type ObjectLegalHold struct {
+	XMLName xml.Name `xml:"LegalHold"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Status  string   `xml:"Status"`
+}
+
+func isValidObjectLockMode(mode string) bool {
+	return mode == s3_constants.ObjectLockModeGovernance || mode == s3_constants.ObjectLockModeCompliance
+}
+
+func parseObjectLockConfiguration(data []byte) (*ObjectLockConfiguration, error) {
+	config := &ObjectLockConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *ObjectLockConfiguration) validate() error {
+	if c.ObjectLockEnabled != objectLockEnabled {
+		return fmt.Errorf("invalid ObjectLockEnabled %q", c.ObjectLockEnabled)
+	}
+	if c.Rule == nil {
+		return nil
+	}
+	retention := c.Rule.DefaultRetention
+	if retention == nil {
+		return fmt.Errorf("missing DefaultRetention")
+	}
+	if !isValidObjectLockMode(retention.Mode) {
+		return fmt.Errorf("invalid retention mode %q", retention.Mode)
+	}
+	if (retention.Days > 0) == (retention.Years > 0) || retention.Days < 0 || retention.Years < 0 {
+		return fmt.Errorf("exactly one of Days or Years must be a positive number")
+	}
+	return nil
+}
+
+// defaultRetention returns the retention applied to new object versions, or nil if there is none
+func (c *ObjectLockConfiguration) defaultRetention() *DefaultRetention {
+	if c == nil || c.Rule == nil {
+		return nil
+	}
+	return c.Rule.DefaultRetention
+}
+
+func (d *DefaultRetention) duration() time.Duration {
+	if d.Years > 0 {
+		return time.Duration(d.Years) * 365 * 24 * time.Hour
+	}
+	return time.Duration(d.Days) * 24 * time.Hour
+}
+
+// objectLock is the retention and legal hold of an object version
+type objectLock struct {
+	mode        string
+	retainUntil time.Time
+	legalHold   bool
+}
+
+func objectLockFromExtended(extended map[string][]byte) (lock objectLock) {
+	lock.mode = string(extended[s3_constants.ExtObjectLockModeKey])
+	if retainUntilDate, found := extended[s3_constants.ExtObjectLockRetainUntilDateKey]; found {
+		lock.retainUntil, _ = time.Parse(time.RFC3339, string(retainUntilDate))
+	}
+	lock.legalHold = string(extended[s3_constants.ExtObjectLockLegalHoldKey]) == s3_constants.ObjectLockLegalHoldOn
+	return
+}
+
+// toExtended replaces the object lock kept in the extended attributes
+func (lock objectLock) toExtended(extended map[string][]byte) {
+	delete(extended, s3_constants.ExtObjectLockModeKey)
+	delete(extended, s3_constants.ExtObjectLockRetainUntilDateKey)
+	delete(extended, s3_constants.ExtObjectLockLegalHoldKey)
+	if lock.mode != "" {
+		extended[s3_constants.ExtObjectLockModeKey] = []byte(lock.mode)
+		extended[s3_constants.ExtObjectLockRetainUntilDateKey] = []byte(lock.retainUntil.UTC().Format(time.RFC3339))
+	}
+	if lock.legalHold {
+		extended[s3_constants.ExtObjectLockLegalHoldKey] = []byte(s3_constants.ObjectLockLegalHoldOn)
+	}
+}
+
+func (lock objectLock) isRetained(now time.Time) bool {
+	return lock.mode != "" && now.Before(lock.retainUntil)
+}
+
+// checkRemoval refuses to remove an object version under legal hold or retention
+func (lock objectLock) checkRemoval(bypassGovernance bool) s3err.ErrorCode {
+	if lock.legalHold {
+		return s3err.ErrObjectLocked
+	}
+	if lock.isRetained(time.Now()) && !(lock.mode == s3_constants.ObjectLockModeGovernance && bypassGovernance) {
+		return s3err.ErrObjectLocked
+	}
+	return s3err.ErrNone
+}
+
+// canBypassGovernance checks the x-amz-bypass-governance-retention header, which is only honored for admins
+func (s3a *S3ApiServer) canBypassGovernance(r *http.Request) bool {
+	if !strings.EqualFold(r.Header.Get(s3_constants.AmzBypassGovernanceRetention), "true") {
+		return false
+	}
+	return !s3a.iam.isEnabled() || r.Header.Get(s3_constants.AmzIsAdmin) != ""
+}
+
+func (s3a *S3ApiServer) getObjectLockConfiguration(bucket string) *ObjectLockConfiguration {
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		return nil
+	}
+	return bucketMetadata.ObjectLock
+}
+
+// objectLockForWrite returns the object lock of a new object version,
+// taken from the request headers or else from the default retention of the bucket
+func (s3a *S3ApiServer) objectLockForWrite(r *http.Request, bucket string) (lock objectLock, errCode s3err.ErrorCode) {
+	mode := r.Header.Get(s3_constants.AmzObjectLockMode)
+	retainUntilDate := r.Header.Get(s3_constants.AmzObjectLockRetainUntilDate)
+	legalHold := r.Header.Get(s3_constants.AmzObjectLockLegalHold)
+
+	config := s3a.getObjectLockConfiguration(bucket)
+	if config == nil {
+		if mode != "" || retainUntilDate != "" || legalHold != "" {
+			return lock, s3err.ErrInvalidRequest
+		}
+		return lock, s3err.ErrNone
+	}
+
+	now := time.Now()
+	if mode != "" || retainUntilDate != "" {
+		retainUntil, err := time.Parse(time.RFC3339, retainUntilDate)
+		if err != nil || !isValidObjectLockMode(mode) || !retainUntil.After(now) {
+			return lock, s3err.ErrInvalidRetentionPeriod
+		}
+		lock.mode, lock.retainUntil = mode, retainUntil
+	} else if retention := config.defaultRetention(); retention != nil {
+		lock.mode, lock.retainUntil = retention.Mode, now.Add(retention.duration())
+	}
+
+	switch legalHold {
+	case "", s3_constants.ObjectLockLegalHoldOff:
+	case s3_constants.ObjectLockLegalHoldOn:
+		lock.legalHold = true
+	default:
+		return lock, s3err.ErrInvalidRequest
+	}
+	return lock, s3err.ErrNone
+}
+
+// setObjectLockHeader passes the object lock to the filer, which keeps it in the entry's extended attributes
+func setObjectLockHeader(header http.Header, lock objectLock) {
+	extended := make(map[string][]byte)
+	lock.toExtended(extended)
+	for k, v := range extended {
+		header.Set(k, string(v))
+	}
+}
+
+// setObjectLockHeaders replaces the stored object lock attributes with the S3 response headers
+func setObjectLockHeaders(resp *http.Response) {
+	for extKey, amzKey := range map[string]string{
+		s3_constants.ExtObjectLockModeKey:            s3_constants.AmzObjectLockMode,
+		s3_constants.ExtObjectLockRetainUntilDateKey: s3_constants.AmzObjectLockRetainUntilDate,
+		s3_constants.ExtObjectLockLegalHoldKey:       s3_constants.AmzObjectLockLegalHold,
+	} {
+		if value := resp.Header.Get(extKey); value != "" {
+			resp.Header.Set(amzKey, value)
+			resp.Header.Del(extKey)
+		}
+	}
+}
+
+// updateBucketWormConf adds or updates the worm rule of the bucket folder in filer.conf
+func (s3a *S3ApiServer) updateBucketWormConf(bucket string, config *ObjectLockConfiguration) error {
+	fc, err := filer.ReadFilerConf(s3a.option.Filer, s3a.option.GrpcDialOption, nil)
+	if err != nil {
+		return fmt.Errorf("read filer config: %v", err)
+	}
+
+	locationPrefix := fmt.Sprintf("%s/%s/", s3a.option.BucketsPath, bucket)
+	locConf, found := fc.GetLocationConf(locationPrefix)
+	if !found {
+		locConf = &filer_pb.FilerConf_PathConf{
+			LocationPrefix: locationPrefix,
+		}
+	}
+	locConf.Worm = true
+	locConf.WormRetentionTimeSeconds = 0
+	if retention := config.defaultRetention(); retention != nil {
+		locConf.WormRetentionTimeSeconds = uint64(retention.duration().Seconds())
+	}
+	if err = fc.SetLocationConf(locConf); err != nil {
+		return fmt.Errorf("set location config: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = fc.ToText(&buf); err != nil {
+		return fmt.Errorf("save config to text: %v", err)
+	}
+	return s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.SaveInsideFiler(client, filer.DirectoryEtcSeaweedFS, filer.FilerConfName, buf.Bytes())
+	})
+}
+
+// enableBucketObjectLock turns on versioning and object lock for a bucket, used when creating the bucket
+func (s3a *S3ApiServer) enableBucketObjectLock(bucket string) s3err.ErrorCode {
+	config := &ObjectLockConfiguration{ObjectLockEnabled: objectLockEnabled}
+	configBytes, err := xml.Marshal(config)
+	if err != nil {
+		return s3err.ErrInternalError
+	}
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtVersioningKey] = []byte(s3.BucketVersioningStatusEnabled)
+		extended[s3_constants.ExtObjectLockConfigKey] = configBytes
+	}); errCode != s3err.ErrNone {
+		return errCode
+	}
+	if err = s3a.updateBucketWormConf(bucket, config); err != nil {
+		glog.Errorf("enable object lock for bucket %s: %v", bucket, err)
+		return s3err.ErrInternalError
+	}
+	return s3err.ErrNone
+}
+
+// GetObjectLockConfigurationHandler Get object Lock configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLockConfiguration.html
+func (s3a *S3ApiServer) GetObjectLockConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetObjectLockConfigurationHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	configBytes, ok := bucketEntry.Extended[s3_constants.ExtObjectLockConfigKey]
+	if !ok || len(configBytes) == 0 {
+		s3err.WriteErrorResponse(w, r, s3err.ErrObjectLockConfigurationNotFound)
+		return
+	}
+	config := &ObjectLockConfiguration{}
+	if err = xml.Unmarshal(configBytes, config); err != nil {
+		glog.Errorf("GetObjectLockConfigurationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	config.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, r, config)
+}
+
+// PutObjectLockConfigurationHandler Put object Lock configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLockConfiguration.html
+func (s3a *S3ApiServer) PutObjectLockConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutObjectLockConfigurationHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	config := &ObjectLockConfiguration{}
+	if err := xmlDecoder(r.Body, config, r.ContentLength); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	if err := config.validate(); err != nil {
+		glog.V(1).Infof("PutObjectLockConfigurationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+
+	if s3a.getVersioningState(bucket) != s3.BucketVersioningStatusEnabled {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidBucketState)
+		return
+	}
+
+	config.Xmlns = ""
+	configBytes, err := xml.Marshal(config)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtObjectLockConfigKey] = configBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	if err = s3a.updateBucketWormConf(bucket, config); err != nil {
+		glog.Errorf("PutObjectLockConfigurationHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// getObjectLockTarget finds the object version whose retention or legal hold is read or changed
+func (s3a *S3ApiServer) getObjectLockTarget(r *http.Request, bucket, object string) (dir, name string, entry *filer_pb.Entry, errCode s3err.ErrorCode) {
+	if s3a.getObjectLockConfiguration(bucket) == nil {
+		return "", "", nil, s3err.ErrInvalidRequest
+	}
+	if versionId := r.URL.Query().Get("versionId"); versionId != "" {
+		dir, name, entry, errCode = s3a.getObjectVersion(bucket, object, versionId)
+		if errCode != s3err.ErrNone {
+			return
+		}
+	} else {
+		dir, name = s3a.objectDirAndName(bucket, object)
+		var err error
+		if entry, err = s3a.getEntry(dir, name); err != nil {
+			if err == filer_pb.ErrNotFound {
+				return "", "", nil, s3err.ErrNoSuchKey
+			}
+			return "", "", nil, s3err.ErrInternalError
+		}
+	}
+	if entry.IsDirectory {
+		return "", "", nil, s3err.ErrNoSuchKey
+	}
+	if isDeleteMarker(entry) {
+		return "", "", nil, s3err.ErrMethodNotAllowed
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	return dir, name, entry, s3err.ErrNone
+}
+
+// GetObjectRetentionHandler Get object Retention
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectRetention.html
+func (s3a *S3ApiServer) GetObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetObjectRetentionHandler %s %s", bucket, object)
+
+	_, _, entry, errCode := s3a.getObjectLockTarget(r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	lock := objectLockFromExtended(entry.Extended)
+	if lock.mode == "" {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchObjectLockConfiguration)
+		return
+	}
+
+	writeSuccessResponseXML(w, r, ObjectRetention{
+		Xmlns:           "http://s3.amazonaws.com/doc/2006-03-01/",
+		Mode:            lock.mode,
+		RetainUntilDate: &lock.retainUntil,
+	})
+}
+
+// PutObjectRetentionHandler Put object Retention
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+func (s3a *S3ApiServer) PutObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutObjectRetentionHandler %s %s", bucket, object)
+
+	defer util_http.CloseRequest(r)
+	retention := &ObjectRetention{}
+	if err := xmlDecoder(r.Body, retention, r.ContentLength); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+
+	dir, _, entry, errCode := s3a.getObjectLockTarget(r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	now := time.Now()
+	lock := objectLockFromExtended(entry.Extended)
+	newLock := objectLock{legalHold: lock.legalHold}
+	if retention.Mode != "" || retention.RetainUntilDate != nil {
+		if !isValidObjectLockMode(retention.Mode) || retention.RetainUntilDate == nil || !retention.RetainUntilDate.After(now) {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRetentionPeriod)
+			return
+		}
+		newLock.mode, newLock.retainUntil = retention.Mode, *retention.RetainUntilDate
+	}
+
+	// an active retention can only be extended, or changed from GOVERNANCE to COMPLIANCE,
+	// unless the GOVERNANCE retention is bypassed
+	if lock.isRetained(now) {
+		weakened := newLock.mode == "" || newLock.retainUntil.Before(lock.retainUntil) ||
+			(lock.mode == s3_constants.ObjectLockModeCompliance && newLock.mode != s3_constants.ObjectLockModeCompliance)
+		if weakened && !(lock.mode == s3_constants.ObjectLockModeGovernance && s3a.canBypassGovernance(r)) {
+			s3err.WriteErrorResponse(w, r, s3err.ErrObjectLocked)
+			return
+		}
+	}
+
+	newLock.toExtended(entry.Extended)
+	if err := s3a.updateEntry(dir, entry); err != nil {
+		glog.Errorf("PutObjectRetentionHandler %s %s: %v", bucket, object, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// GetObjectLegalHoldHandler Get object Legal Hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLegalHold.html
+func (s3a *S3ApiServer) GetObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetObjectLegalHoldHandler %s %s", bucket, object)
+
+	_, _, entry, errCode := s3a.getObjectLockTarget(r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	status := s3_constants.ObjectLockLegalHoldOff
+	if objectLockFromExtended(entry.Extended).legalHold {
+		status = s3_constants.ObjectLockLegalHoldOn
+	}
+
+	writeSuccessResponseXML(w, r, ObjectLegalHold{
+		Xmlns:  "http://s3.amazonaws.com/doc/2006-03-01/",
+		Status: status,
+	})
+}
+
+// PutObjectLegalHoldHandler Put object Legal Hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+func (s3a *S3ApiServer) PutObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutObjectLegalHoldHandler %s %s", bucket, object)
+
+	defer util_http.CloseRequest(r)
+	legalHold := &ObjectLegalHold{}
+	if err := xmlDecoder(r.Body, legalHold, r.ContentLength); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	if legalHold.Status != s3_constants.ObjectLockLegalHoldOn && legalHold.Status != s3_constants.ObjectLockLegalHoldOff {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+
+	dir, _, entry, errCode := s3a.getObjectLockTarget(r, bucket, object)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	lock := objectLockFromExtended(entry.Extended)
+	lock.legalHold = legalHold.Status == s3_constants.ObjectLockLegalHoldOn
+	lock.toExtended(entry.Extended)
+	if err := s3a.updateEntry(dir, entry); err != nil {
+		glog.Errorf("PutObjectLegalHoldHandler %s %s: %v", bucket, object, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
@@ -0,0 +1,93 @@
+package s3api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseObjectLockConfiguration(t *testing.T) {
+	config, err := parseObjectLockConfiguration([]byte(`<ObjectLockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <ObjectLockEnabled>Enabled</ObjectLockEnabled>
+  <Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>3</Days></DefaultRetention></Rule>
+</ObjectLockConfiguration>`))
+	assert.Nil(t, err)
+	assert.Equal(t, s3_constants.ObjectLockModeGovernance, config.defaultRetention().Mode)
+	assert.Equal(t, 3*24*time.Hour, config.defaultRetention().duration())
+
+	config, err = parseObjectLockConfiguration([]byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+	assert.Nil(t, err)
+	assert.Nil(t, config.defaultRetention())
+
+	invalidConfigurations := []string{
+		`<ObjectLockConfiguration></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule></Rule></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>NONE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>1</Days><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>`,
+	}
+	for _, invalidConfiguration := range invalidConfigurations {
+		_, err = parseObjectLockConfiguration([]byte(invalidConfiguration))
+		assert.NotNil(t, err, invalidConfiguration)
+	}
+}
+
+func TestObjectLockForWrite(t *testing.T) {
+	config, err := parseObjectLockConfiguration([]byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`))
+	assert.Nil(t, err)
+	s3a := &S3ApiServer{
+		bucketRegistry: &BucketRegistry{
+			metadataCache: map[string]*BucketMetaData{
+				"locked":   {Name: "locked", ObjectLock: config},
+				"unlocked": {Name: "unlocked"},
+			},
+			notFound: make(map[string]struct{}),
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodPut, "/locked/key", nil)
+	lock, errCode := s3a.objectLockForWrite(r, "locked")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, s3_constants.ObjectLockModeCompliance, lock.mode, "default retention applies")
+	assert.True(t, lock.isRetained(time.Now()))
+	assert.False(t, lock.isRetained(time.Now().Add(25*time.Hour)))
+
+	retainUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	r.Header.Set(s3_constants.AmzObjectLockMode, s3_constants.ObjectLockModeGovernance)
+	r.Header.Set(s3_constants.AmzObjectLockRetainUntilDate, retainUntil.Format(time.RFC3339))
+	r.Header.Set(s3_constants.AmzObjectLockLegalHold, s3_constants.ObjectLockLegalHoldOn)
+	lock, errCode = s3a.objectLockForWrite(r, "locked")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, objectLock{mode: s3_constants.ObjectLockModeGovernance, retainUntil: retainUntil, legalHold: true}, lock)
+
+	extended := make(map[string][]byte)
+	lock.toExtended(extended)
+	restored := objectLockFromExtended(extended)
+	assert.True(t, restored.retainUntil.Equal(retainUntil))
+	assert.Equal(t, lock.mode, restored.mode)
+	assert.True(t, restored.legalHold)
+
+	_, errCode = s3a.objectLockForWrite(r, "unlocked")
+	assert.Equal(t, s3err.ErrInvalidRequest, errCode, "object lock headers on a bucket without object lock")
+
+	r.Header.Set(s3_constants.AmzObjectLockRetainUntilDate, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	_, errCode = s3a.objectLockForWrite(r, "locked")
+	assert.Equal(t, s3err.ErrInvalidRetentionPeriod, errCode)
+}
+
+func TestObjectLockCheckRemoval(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	assert.Equal(t, s3err.ErrNone, objectLock{}.checkRemoval(false))
+	assert.Equal(t, s3err.ErrNone, objectLock{mode: s3_constants.ObjectLockModeCompliance, retainUntil: past}.checkRemoval(false))
+	assert.Equal(t, s3err.ErrObjectLocked, objectLock{mode: s3_constants.ObjectLockModeCompliance, retainUntil: future}.checkRemoval(true))
+	assert.Equal(t, s3err.ErrObjectLocked, objectLock{mode: s3_constants.ObjectLockModeGovernance, retainUntil: future}.checkRemoval(false))
+	assert.Equal(t, s3err.ErrNone, objectLock{mode: s3_constants.ObjectLockModeGovernance, retainUntil: future}.checkRemoval(true))
+	assert.Equal(t, s3err.ErrObjectLocked, objectLock{legalHold: true}.checkRemoval(true))
+}
@@ -0,0 +1,669 @@
+package s3api
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// Object versions are kept in the filer as follows:
+//
+//	/buckets/<bucket>/<key>                        the current version, unless it is a delete marker
+//	/buckets/<bucket>/.versions/<key>/<versionId>  noncurrent versions and delete markers
+//
+// Version ids start with the inverted creation time, so that newer versions sort first.
+// Objects written while versioning was never enabled or is suspended have the "null" version id.
+
+const (
+	nullVersionId = "null"
+)
+
+type ListObjectVersionsResult struct {
+	XMLName             xml.Name            `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+	Name                string              `xml:"Name"`
+	Prefix              string              `xml:"Prefix"`
+	KeyMarker           string              `xml:"KeyMarker"`
+	VersionIdMarker     string              `xml:"VersionIdMarker"`
+	NextKeyMarker       string              `xml:"NextKeyMarker,omitempty"`
+	NextVersionIdMarker string              `xml:"NextVersionIdMarker,omitempty"`
+	MaxKeys             int                 `xml:"MaxKeys"`
+	Delimiter           string              `xml:"Delimiter,omitempty"`
+	EncodingType        string              `xml:"EncodingType,omitempty"`
+	IsTruncated         bool                `xml:"IsTruncated"`
+	Versions            []VersionEntry      `xml:"Version,omitempty"`
+	DeleteMarkers       []DeleteMarkerEntry `xml:"DeleteMarker,omitempty"`
+	CommonPrefixes      []PrefixEntry       `xml:"CommonPrefixes,omitempty"`
+}
+
+type objectVersion struct {
+	key       string
+	versionId string
+	tsNs      int64
+	isCurrent bool
+	entry     *filer_pb.Entry
+	// the common prefix of the keys under the delimiter, in place of their versions
+	isCommonPrefix bool
+}
+
+func newVersionId() string {
+	return fmt.Sprintf("%016x%016x", math.MaxInt64-time.Now().UnixNano(), rand.Uint64())
+}
+
+// versionTsNs returns the creation time of a version, used to order versions of the same key
+func versionTsNs(versionId string, entry *filer_pb.Entry) int64 {
+	if len(versionId) == 32 {
+		if inverted, err := strconv.ParseInt(versionId[:16], 16, 64); err == nil {
+			return math.MaxInt64 - inverted
+		}
+	}
+	if entry.Attributes == nil {
+		return 0
+	}
+	return entry.Attributes.ModTime().UnixNano()
+}
+
+func entryVersionId(entry *filer_pb.Entry) string {
+	if versionId, ok := entry.Extended[s3_constants.ExtVersionIdKey]; ok && len(versionId) > 0 {
+		return string(versionId)
+	}
+	return nullVersionId
+}
+
+func isDeleteMarker(entry *filer_pb.Entry) bool {
+	_, ok := entry.Extended[s3_constants.ExtDeleteMarkerKey]
+	return ok
+}
+
+func (s3a *S3ApiServer) getVersioningState(bucket string) string {
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		return ""
+	}
+	return bucketMetadata.Versioning
+}
+
+func (s3a *S3ApiServer) objectDirAndName(bucket, object string) (string, string) {
+	return util.FullPath(fmt.Sprintf("%s/%s%s", s3a.option.BucketsPath, bucket, removeDuplicateSlashes("/"+object))).DirAndName()
+}
+
+func (s3a *S3ApiServer) versionsDir(bucket, object string) string {
+	return fmt.Sprintf("%s/%s/%s%s", s3a.option.BucketsPath, bucket, s3_constants.VersionsFolder, strings.TrimSuffix(removeDuplicateSlashes("/"+object), "/"))
+}
+
+func (s3a *S3ApiServer) renameEntry(oldDir, oldName, newDir, newName string) error {
+	return s3a.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDir,
+			OldName:      oldName,
+			NewDirectory: newDir,
+			NewName:      newName,
+		}
+		glog.V(3).Infof("rename entry %s/%s => %s/%s", oldDir, oldName, newDir, newName)
+		_, err := client.AtomicRenameEntry(context.Background(), request)
+		return err
+	})
+}
+
+// prepareVersionedWrite moves the current version of the object out of the way before it is overwritten,
+// and returns the version id for the new object. An empty version id means the bucket is not versioned.
+func (s3a *S3ApiServer) prepareVersionedWrite(bucket, object string) (versionId string, errCode s3err.ErrorCode) {
+	if strings.HasSuffix(object, "/") {
+		return "", s3err.ErrNone
+	}
+	versioning := s3a.getVersioningState(bucket)
+	if versioning == "" {
+		return "", s3err.ErrNone
+	}
+	if err := s3a.archiveCurrentVersion(bucket, object, versioning); err != nil {
+		glog.Errorf("archive current version of %s%s: %v", bucket, object, err)
+		return "", s3err.ErrInternalError
+	}
+	if versioning == s3.BucketVersioningStatusEnabled {
+		return newVersionId(), s3err.ErrNone
+	}
+	// while suspended, the new object replaces the existing null version
+	if err := s3a.rm(s3a.versionsDir(bucket, object), nullVersionId, true, false); err != nil {
+		glog.V(3).Infof("remove null version of %s%s: %v", bucket, object, err)
+	}
+	return nullVersionId, s3err.ErrNone
+}
+
+// setVersionIdHeader passes the version id to the filer, which keeps it in the entry's extended attributes
+func setVersionIdHeader(header http.Header, versionId string) {
+	if versionId != "" {
+		header.Set(s3_constants.ExtVersionIdKey, versionId)
+	}
+}
+
+// archiveCurrentVersion moves the current version into the versions folder.
+// When versioning is suspended, a current null version is left in place to be overwritten.
+func (s3a *S3ApiServer) archiveCurrentVersion(bucket, object string, versioning string) error {
+	dir, name := s3a.objectDirAndName(bucket, object)
+	entry, err := s3a.getEntry(dir, name)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	if entry.IsDirectory {
+		return nil
+	}
+	versionId := entryVersionId(entry)
+	if versioning == s3.BucketVersioningStatusSuspended && versionId == nullVersionId {
+		return nil
+	}
+	return s3a.renameEntry(dir, name, s3a.versionsDir(bucket, object), versionId)
+}
+
+// promoteLatestVersion restores the newest noncurrent version as the current version,
+// unless there is already a current version or the newest version is a delete marker.
+func (s3a *S3ApiServer) promoteLatestVersion(bucket, object string) error {
+	dir, name := s3a.objectDirAndName(bucket, object)
+	if _, err := s3a.getEntry(dir, name); err != filer_pb.ErrNotFound {
+		return err
+	}
+	versionsDir := s3a.versionsDir(bucket, object)
+	var latest *filer_pb.Entry
+	err := filer_pb.ReadDirAllEntries(s3a, util.FullPath(versionsDir), "", func(entry *filer_pb.Entry, isLast bool) error {
+		if entry.IsDirectory {
+			return nil
+		}
+		if latest == nil || versionTsNs(entry.Name, entry) > versionTsNs(latest.Name, latest) {
+			latest = entry
+		}
+		return nil
+	})
+	if err != nil && err != filer_pb.ErrNotFound {
+		return err
+	}
+	if latest == nil || isDeleteMarker(latest) {
+		return nil
+	}
+	return s3a.renameEntry(versionsDir, latest.Name, dir, name)
+}
+
+// removeEmptyVersionsDirs removes the versions folders left empty after the last version of the object is gone
+func (s3a *S3ApiServer) removeEmptyVersionsDirs(bucket, object string) {
+	bucketDir := fmt.Sprintf("%s/%s", s3a.option.BucketsPath, bucket)
+	for dir := s3a.versionsDir(bucket, object); dir != bucketDir; {
+		parentDir, dirName := util.FullPath(dir).DirAndName()
+		if err := s3a.rm(parentDir, dirName, false, false); err != nil {
+			glog.V(4).Infof("versions folder %s is not empty: %v", dir, err)
+			return
+		}
+		dir = parentDir
+	}
+}
+
+// getObjectVersion finds the entry holding a specific version of the object
+func (s3a *S3ApiServer) getObjectVersion(bucket, object, versionId string) (dir, name string, entry *filer_pb.Entry, errCode s3err.ErrorCode) {
+	dir, name = s3a.objectDirAndName(bucket, object)
+	entry, err := s3a.getEntry(dir, name)
+	if err == nil && !entry.IsDirectory && entryVersionId(entry) == versionId {
+		return dir, name, entry, s3err.ErrNone
+	}
+	if err != nil && err != filer_pb.ErrNotFound {
+		return "", "", nil, s3err.ErrInternalError
+	}
+	dir, name = s3a.versionsDir(bucket, object), versionId
+	entry, err = s3a.getEntry(dir, name)
+	if err != nil {
+		if err == filer_pb.ErrNotFound {
+			return "", "", nil, s3err.ErrNoSuchVersion
+		}
+		return "", "", nil, s3err.ErrInternalError
+	}
+	return dir, name, entry, s3err.ErrNone
+}
+
+// deleteVersionedObject deletes a specific version, or places a delete marker if versionId is empty.
+// A specific version under object lock is only deleted once its retention allows it.
+// It returns the version id of the deleted version or of the new delete marker.
+func (s3a *S3ApiServer) deleteVersionedObject(bucket, object, versionId string, bypassGovernance bool) (resultVersionId string, deleteMarker bool, errCode s3err.ErrorCode) {
+	if versionId != "" {
+		dir, name, entry, errCode := s3a.getObjectVersion(bucket, object, versionId)
+		if errCode == s3err.ErrNoSuchVersion {
+			return versionId, false, s3err.ErrNone
+		}
+		if errCode != s3err.ErrNone {
+			return "", false, errCode
+		}
+		if errCode := objectLockFromExtended(entry.Extended).checkRemoval(bypassGovernance); errCode != s3err.ErrNone {
+			return "", false, errCode
+		}
+		if err := s3a.rm(dir, name, true, false); err != nil {
+			glog.Errorf("delete version %s of %s%s: %v", versionId, bucket, object, err)
+			return "", false, s3err.ErrInternalError
+		}
+		if err := s3a.promoteLatestVersion(bucket, object); err != nil {
+			glog.Errorf("promote latest version of %s%s: %v", bucket, object, err)
+			return "", false, s3err.ErrInternalError
+		}
+		s3a.removeEmptyVersionsDirs(bucket, object)
+		return versionId, isDeleteMarker(entry), s3err.ErrNone
+	}
+
+	versioning := s3a.getVersioningState(bucket)
+	if err := s3a.archiveCurrentVersion(bucket, object, versioning); err != nil {
+		glog.Errorf("archive current version of %s%s: %v", bucket, object, err)
+		return "", false, s3err.ErrInternalError
+	}
+	markerVersionId := newVersionId()
+	if versioning == s3.BucketVersioningStatusSuspended {
+		// the delete marker replaces the null version
+		markerVersionId = nullVersionId
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if err := s3a.rm(dir, name, true, false); err != nil {
+			glog.V(3).Infof("delete null version of %s%s: %v", bucket, object, err)
+		}
+		if err := s3a.rm(s3a.versionsDir(bucket, object), nullVersionId, true, false); err != nil {
+			glog.V(3).Infof("delete null version of %s%s: %v", bucket, object, err)
+		}
+	}
+	if err := s3a.mkFile(s3a.versionsDir(bucket, object), markerVersionId, nil, func(entry *filer_pb.Entry) {
+		if entry.Extended == nil {
+			entry.Extended = make(map[string][]byte)
+		}
+		entry.Extended[s3_constants.ExtVersionIdKey] = []byte(markerVersionId)
+		entry.Extended[s3_constants.ExtDeleteMarkerKey] = []byte("true")
+	}); err != nil {
+		glog.Errorf("create delete marker of %s%s: %v", bucket, object, err)
+		return "", false, s3err.ErrInternalError
+	}
+	return markerVersionId, true, s3err.ErrNone
+}
+
+// toFilerVersionUrl returns the filer url to read the object version stored at dir/name
+func (s3a *S3ApiServer) toFilerVersionUrl(dir, name string) string {
+	return fmt.Sprintf("http://%s%s", s3a.option.Filer.ToHttpAddress(), urlPathEscape(string(util.NewFullPath(dir, name))))
+}
+
+// setVersionHeaders replaces the stored version attributes with the S3 response headers
+func setVersionHeaders(resp *http.Response) {
+	if versionId := resp.Header.Get(s3_constants.ExtVersionIdKey); versionId != "" {
+		resp.Header.Set(s3_constants.AmzVersionId, versionId)
+		resp.Header.Del(s3_constants.ExtVersionIdKey)
+	}
+	resp.Header.Del(s3_constants.ExtDeleteMarkerKey)
+}
+
+// ListObjectVersionsHandler List all versions of the objects in a bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+func (s3a *S3ApiServer) ListObjectVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("ListObjectVersionsHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	values := r.URL.Query()
+	prefix := strings.TrimPrefix(values.Get("prefix"), "/")
+	delimiter := values.Get("delimiter")
+	keyMarker := values.Get("key-marker")
+	versionIdMarker := values.Get("version-id-marker")
+	encodingTypeUrl := values.Get("encoding-type") == s3.EncodingTypeUrl
+	maxKeys := maxObjectListSizeLimit
+	if values.Get("max-keys") != "" {
+		var err error
+		if maxKeys, err = strconv.Atoi(values.Get("max-keys")); err != nil || maxKeys < 0 {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidMaxKeys)
+			return
+		}
+	}
+	if versionIdMarker != "" && keyMarker == "" {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+		return
+	}
+
+	bucketDir := fmt.Sprintf("%s/%s", s3a.option.BucketsPath, bucket)
+	versions, isTruncated, err := listObjectVersions(s3a.listDirectory, bucketDir, prefix, delimiter, keyMarker, versionIdMarker, maxKeys)
+	if err != nil {
+		glog.Errorf("ListObjectVersionsHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	response := ListObjectVersionsResult{
+		Name:            bucket,
+		Prefix:          prefix,
+		KeyMarker:       keyMarker,
+		VersionIdMarker: versionIdMarker,
+		MaxKeys:         maxKeys,
+		Delimiter:       delimiter,
+		IsTruncated:     isTruncated,
+	}
+	if encodingTypeUrl {
+		response.EncodingType = s3.EncodingTypeUrl
+		response.Prefix = urlPathEscape(prefix)
+	}
+
+	// the versions of the key marker following the version id marker are not the latest
+	lastKey := ""
+	if versionIdMarker != "" {
+		lastKey = keyMarker
+	}
+	for _, version := range versions {
+		response.NextKeyMarker, response.NextVersionIdMarker = version.key, version.versionId
+		key := version.key
+		if encodingTypeUrl {
+			key = urlPathEscape(key)
+		}
+		if version.isCommonPrefix {
+			response.CommonPrefixes = append(response.CommonPrefixes, PrefixEntry{Prefix: key})
+			continue
+		}
+		isLatest := version.key != lastKey
+		lastKey = version.key
+		owner := CanonicalUser{}
+		if version.entry.Attributes != nil {
+			owner = CanonicalUser{
+				ID:          fmt.Sprintf("%x", version.entry.Attributes.Uid),
+				DisplayName: version.entry.Attributes.UserName,
+			}
+		}
+		if isDeleteMarker(version.entry) {
+			response.DeleteMarkers = append(response.DeleteMarkers, DeleteMarkerEntry{
+				Key:          key,
+				VersionId:    version.versionId,
+				IsLatest:     isLatest,
+				LastModified: time.Unix(0, version.tsNs).UTC(),
+				Owner:        owner,
+			})
+		} else {
+			storageClass := "STANDARD"
+			if v, ok := version.entry.Extended[s3_constants.AmzStorageClass]; ok {
+				storageClass = string(v)
+			}
+			response.Versions = append(response.Versions, VersionEntry{
+				Key:          key,
+				VersionId:    version.versionId,
+				IsLatest:     isLatest,
+				LastModified: version.entry.Attributes.ModTime().UTC(),
+				ETag:         "\"" + filer.ETag(version.entry) + "\"",
+				Size:         int64(filer.FileSize(version.entry)),
+				Owner:        owner,
+				StorageClass: StorageClass(storageClass),
+			})
+		}
+	}
+	if !response.IsTruncated {
+		response.NextKeyMarker, response.NextVersionIdMarker = "", ""
+	} else if encodingTypeUrl {
+		response.NextKeyMarker = url.QueryEscape(response.NextKeyMarker)
+	}
+
+	writeSuccessResponseXML(w, r, response)
+}
+
+// the entries listed at once from a folder while listing the versions
+const versionsListPageSize = 1024
+
+// listDirectoryFunc lists a page of the entries of the folder, whose names start with the prefix, from the start name
+type listDirectoryFunc func(dir, namePrefix, startFrom string, inclusive bool, limit uint32) ([]*filer_pb.Entry, error)
+
+func (s3a *S3ApiServer) listDirectory(dir, namePrefix, startFrom string, inclusive bool, limit uint32) (entries []*filer_pb.Entry, err error) {
+	err = filer_pb.List(s3a, dir, namePrefix, func(entry *filer_pb.Entry, isLast bool) error {
+		entries = append(entries, entry)
+		return nil
+	}, startFrom, inclusive, limit)
+	return entries, err
+}
+
+// eachKeyVersions lists the object versions page by page, and calls fn with the versions of each key,
+// sorted from the latest to the oldest. The versions of the last key of a page are kept until all of them are listed.
+func eachKeyVersions(list listDirectoryFunc, bucketDir, prefix string, pageSize int, fn func(keyVersions []objectVersion)) error {
+	var versions []objectVersion
+	keyMarker, versionIdMarker := "", ""
+	for {
+		page, isTruncated, err := listObjectVersions(list, bucketDir, prefix, "", keyMarker, versionIdMarker, pageSize)
+		if err != nil {
+			return err
+		}
+		versions = append(versions, page...)
+		for len(versions) > 0 {
+			end := 1
+			for end < len(versions) && versions[end].key == versions[0].key {
+				end++
+			}
+			if end == len(versions) && isTruncated {
+				break
+			}
+			fn(versions[:end])
+			versions = versions[end:]
+		}
+		if !isTruncated || len(page) == 0 {
+			return nil
+		}
+		last := page[len(page)-1]
+		keyMarker, versionIdMarker = last.key, last.versionId
+	}
+}
+
+// listObjectVersions lists the current and noncurrent versions of the objects under the prefix following the markers,
+// in the listing order of the keys, newest version first, and the common prefixes of the keys under the delimiter.
+// The current versions and the versions folder are walked from the key marker, until more than maxKeys are found.
+func listObjectVersions(list listDirectoryFunc, bucketDir, prefix, delimiter, keyMarker, versionIdMarker string, maxKeys int) (versions []objectVersion, isTruncated bool, err error) {
+	collect := func(dir string, inVersionsFolder bool) (collected []objectVersion, hasMore bool, err error) {
+		lastCommonPrefix := ""
+		completed, err := walkVersions(list, dir, "", prefix, keyMarker, inVersionsFolder, func(key string, keyVersions []objectVersion) bool {
+			if delimiter != "" {
+				if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+					commonPrefix := key[:len(prefix)+i+len(delimiter)]
+					if commonPrefix != lastCommonPrefix && commonPrefix != keyMarker {
+						collected = append(collected, objectVersion{key: commonPrefix, isCommonPrefix: true})
+					}
+					lastCommonPrefix = commonPrefix
+					return len(collected) <= maxKeys
+				}
+			}
+			if key == keyMarker {
+				keyVersions = versionsAfterMarker(keyVersions, versionIdMarker)
+			}
+			collected = append(collected, keyVersions...)
+			return len(collected) <= maxKeys
+		})
+		return collected, !completed && err == nil, err
+	}
+	current, currentHasMore, err := collect(bucketDir, false)
+	if err != nil {
+		return nil, false, err
+	}
+	noncurrent, noncurrentHasMore, err := collect(bucketDir+"/"+s3_constants.VersionsFolder, true)
+	if err != nil && err != filer_pb.ErrNotFound {
+		return nil, false, err
+	}
+
+	versions = append(current, noncurrent...)
+	sortObjectVersions(versions)
+	versions = slices.CompactFunc(versions, func(a, b objectVersion) bool {
+		return a.isCommonPrefix && b.isCommonPrefix && a.key == b.key
+	})
+	// the versions after the last one collected from a folder with more versions may be missing
+	cut := len(versions)
+	if currentHasMore {
+		cut = min(cut, countNotAfter(versions, current[len(current)-1]))
+	}
+	if noncurrentHasMore {
+		cut = min(cut, countNotAfter(versions, noncurrent[len(noncurrent)-1]))
+	}
+	isTruncated = currentHasMore || noncurrentHasMore || cut > maxKeys
+	return versions[:min(cut, maxKeys)], isTruncated, nil
+}
+
+// versionsAfterMarker drops the versions of the key marker listed before, the current version comes first
+func versionsAfterMarker(keyVersions []objectVersion, versionIdMarker string) []objectVersion {
+	if versionIdMarker == "" || keyVersions[0].isCurrent {
+		return nil
+	}
+	for i, version := range keyVersions {
+		if version.versionId == versionIdMarker {
+			return keyVersions[i+1:]
+		}
+	}
+	return keyVersions
+}
+
+// countNotAfter counts the sorted versions up to the version
+func countNotAfter(versions []objectVersion, last objectVersion) int {
+	return sort.Search(len(versions), func(i int) bool {
+		return compareObjectVersions(last, versions[i]) < 0
+	})
+}
+
+func sortObjectVersions(versions []objectVersion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareObjectVersions(versions[i], versions[j]) < 0
+	})
+}
+
+// compareObjectVersions orders the versions by key, the current version first, then the newest first
+func compareObjectVersions(a, b objectVersion) int {
+	if c := compareObjectKeys(a.key, b.key); c != 0 {
+		return c
+	}
+	if a.isCurrent != b.isCurrent {
+		if a.isCurrent {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Compare(b.tsNs, a.tsNs)
+}
+
+// compareObjectKeys orders the keys as the filer lists them, folder by folder
+func compareObjectKeys(a, b string) int {
+	for {
+		aName, aRest, aHasMore := strings.Cut(a, "/")
+		bName, bRest, bHasMore := strings.Cut(b, "/")
+		if c := strings.Compare(aName, bName); c != 0 {
+			return c
+		}
+		if !aHasMore || !bHasMore {
+			if aHasMore == bHasMore {
+				return 0
+			}
+			if aHasMore {
+				return 1
+			}
+			return -1
+		}
+		a, b = aRest, bRest
+	}
+}
+
+// walkVersions visits in the listing order the versions of the keys under dir matching the prefix, from the start key,
+// until fn returns false, and tells whether all were visited.
+// In the versions folder, the files are versions of the key named by their parent directory, visited before the keys under it.
+func walkVersions(list listDirectoryFunc, dir, dirKey, prefix, startKey string, inVersionsFolder bool, fn func(key string, keyVersions []objectVersion) bool) (completed bool, err error) {
+	if inVersionsFolder && dirKey != "" && strings.HasPrefix(dirKey, prefix) && (startKey == "" || compareObjectKeys(dirKey, startKey) >= 0) {
+		var keyVersions []objectVersion
+		if err = listAll(list, dir, func(entry *filer_pb.Entry) {
+			if !entry.IsDirectory {
+				keyVersions = append(keyVersions, objectVersion{key: dirKey, versionId: entry.Name, tsNs: versionTsNs(entry.Name, entry), entry: entry})
+			}
+		}); err != nil {
+			return false, err
+		}
+		if len(keyVersions) > 0 {
+			sortObjectVersions(keyVersions)
+			if !fn(dirKey, keyVersions) {
+				return false, nil
+			}
+		}
+	}
+
+	// list the names of this folder from the start key, and matching the prefix
+	namePrefix, startFrom := "", ""
+	if rest, found := cutDirKey(prefix, dirKey); found {
+		namePrefix, _, _ = strings.Cut(rest, "/")
+	}
+	if rest, found := cutDirKey(startKey, dirKey); found {
+		startFrom, _, _ = strings.Cut(rest, "/")
+	}
+	inclusive := true
+	for {
+		entries, err := list(dir, namePrefix, startFrom, inclusive, versionsListPageSize)
+		if err != nil {
+			return false, err
+		}
+		for _, entry := range entries {
+			startFrom = entry.Name
+			key := entry.Name
+			if dirKey != "" {
+				key = dirKey + "/" + entry.Name
+			}
+			if entry.IsDirectory {
+				if dirKey == "" && !inVersionsFolder && (entry.Name == s3_constants.MultipartUploadsFolder || entry.Name == s3_constants.VersionsFolder || entry.Name == filer.TrashFolder || entry.Name == filer.SnapshotsFolder) {
+					continue
+				}
+				if !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+					continue
+				}
+				if startKey != "" && compareObjectKeys(key, startKey) < 0 && !strings.HasPrefix(startKey, key+"/") {
+					continue
+				}
+				if completed, err := walkVersions(list, dir+"/"+entry.Name, key, prefix, startKey, inVersionsFolder, fn); err != nil || !completed {
+					return false, err
+				}
+				continue
+			}
+			if inVersionsFolder || !strings.HasPrefix(key, prefix) || startKey != "" && compareObjectKeys(key, startKey) < 0 {
+				continue
+			}
+			versionId := entryVersionId(entry)
+			if !fn(key, []objectVersion{{key: key, versionId: versionId, tsNs: versionTsNs(versionId, entry), isCurrent: true, entry: entry}}) {
+				return false, nil
+			}
+		}
+		if len(entries) < versionsListPageSize {
+			return true, nil
+		}
+		inclusive = false
+	}
+}
+
+// cutDirKey returns the rest of the key under the folder key
+func cutDirKey(key, dirKey string) (string, bool) {
+	if dirKey == "" {
+		return key, key != ""
+	}
+	return strings.CutPrefix(key, dirKey+"/")
+}
+
+func listAll(list listDirectoryFunc, dir string, fn func(entry *filer_pb.Entry)) error {
+	startFrom := ""
+	for {
+		entries, err := list(dir, "", startFrom, false, versionsListPageSize)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			startFrom = entry.Name
+			fn(entry)
+		}
+		if len(entries) < versionsListPageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package s3api
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVersionIdOrdering(t *testing.T) {
+	older := newVersionId()
+	time.Sleep(time.Millisecond)
+	newer := newVersionId()
+
+	assert.Equal(t, 32, len(older))
+	assert.True(t, newer < older, "newer version ids should sort first")
+	assert.Greater(t, versionTsNs(newer, &filer_pb.Entry{}), versionTsNs(older, &filer_pb.Entry{}))
+}
+
+func TestVersionTsNsOfNullVersion(t *testing.T) {
+	entry := &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Mtime: 1700000000}}
+	assert.Equal(t, int64(1700000000)*int64(time.Second), versionTsNs(nullVersionId, entry))
+	assert.Equal(t, int64(0), versionTsNs(nullVersionId, &filer_pb.Entry{}))
+}
+
+func TestSortObjectVersions(t *testing.T) {
+	versions := []objectVersion{
+		{key: "b", versionId: "3", tsNs: 3},
+		{key: "a", versionId: "1", tsNs: 1},
+		{key: "a", versionId: "null", tsNs: 0, isCurrent: true},
+		{key: "a", versionId: "2", tsNs: 2},
+	}
+	sortObjectVersions(versions)
+
+	var got []string
+	for _, v := range versions {
+		got = append(got, v.key+"/"+v.versionId)
+	}
+	assert.Equal(t, []string{"a/null", "a/2", "a/1", "b/3"}, got)
+}
+
+func TestListObjectVersionsResponse(t *testing.T) {
+	response := ListObjectVersionsResult{
+		Name:    "bucket",
+		MaxKeys: 1000,
+		Versions: []VersionEntry{
+			{Key: "a", VersionId: "2", IsLatest: true, LastModified: time.Unix(2, 0).UTC()},
+		},
+		DeleteMarkers: []DeleteMarkerEntry{
+			{Key: "b", VersionId: "1", IsLatest: true, LastModified: time.Unix(1, 0).UTC()},
+		},
+	}
+	encoded := string(s3err.EncodeXMLResponse(response))
+	assert.True(t, strings.Contains(encoded, "<ListVersionsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\">"))
+	assert.True(t, strings.Contains(encoded, "<Version><Key>a</Key><VersionId>2</VersionId><IsLatest>true</IsLatest>"))
+	assert.True(t, strings.Contains(encoded, "<DeleteMarker><Key>b</Key><VersionId>1</VersionId><IsLatest>true</IsLatest>"))
+}
+
+func TestCompleteMultipartUploadResultOmitsVersionId(t *testing.T) {
+	response := CompleteMultipartUploadResult{
+		CompleteMultipartUploadOutput: s3.CompleteMultipartUploadOutput{
+			Key: aws.String("a"),
+		},
+		VersionId: aws.String("1"),
+	}
+	encoded := string(s3err.EncodeXMLResponse(response))
+	assert.False(t, strings.Contains(encoded, "VersionId"))
+}
+
+func TestListObjectVersionsPages(t *testing.T) {
+	versionId := func(tsNs int64) string {
+		return fmt.Sprintf("%016x%016x", math.MaxInt64-tsNs, 0)
+	}
+	file := func(name string, extended map[string][]byte) *filer_pb.Entry {
+		return &filer_pb.Entry{Name: name, Attributes: &filer_pb.FuseAttributes{Mtime: 1}, Extended: extended}
+	}
+	dir := func(name string) *filer_pb.Entry {
+		return &filer_pb.Entry{Name: name, IsDirectory: true}
+	}
+	tree := map[string][]*filer_pb.Entry{
+		"/buckets/b":               {dir(".versions"), file("a", nil), dir("b"), file("d", map[string][]byte{s3_constants.ExtVersionIdKey: []byte(versionId(9))})},
+		"/buckets/b/b":             {file("c", nil), file("e", nil)},
+		"/buckets/b/.versions":     {dir("a"), dir("b"), dir("f")},
+		"/buckets/b/.versions/a":   {file(versionId(5), nil), file(versionId(3), nil)},
+		"/buckets/b/.versions/b":   {dir("c")},
+		"/buckets/b/.versions/b/c": {file(versionId(4), nil)},
+		"/buckets/b/.versions/f":   {file(versionId(8), map[string][]byte{s3_constants.ExtDeleteMarkerKey: []byte("true")}), file(versionId(7), nil)},
+	}
+	var listed []string
+	list := func(dir, namePrefix, startFrom string, inclusive bool, limit uint32) (entries []*filer_pb.Entry, err error) {
+		listed = append(listed, dir)
+		for _, entry := range tree[dir] {
+			if strings.HasPrefix(entry.Name, namePrefix) && (entry.Name > startFrom || inclusive && entry.Name == startFrom) && len(entries) < int(limit) {
+				entries = append(entries, entry)
+			}
+		}
+		return entries, nil
+	}
+	names := func(versions []objectVersion) (got []string) {
+		for _, v := range versions {
+			got = append(got, v.key+"@"+v.versionId)
+		}
+		return got
+	}
+
+	all, isTruncated, err := listObjectVersions(list, "/buckets/b", "", "", "", "", 1000)
+	assert.NoError(t, err)
+	assert.False(t, isTruncated)
+	assert.Equal(t, []string{"a@null", "a@" + versionId(5), "a@" + versionId(3), "b/c@null", "b/c@" + versionId(4), "b/e@null", "d@" + versionId(9), "f@" + versionId(8), "f@" + versionId(7)}, names(all))
+
+	// the pages continue from the markers
+	var paged []objectVersion
+	keyMarker, versionIdMarker := "", ""
+	for {
+		page, isTruncated, err := listObjectVersions(list, "/buckets/b", "", "", keyMarker, versionIdMarker, 2)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(page), 2)
+		paged = append(paged, page...)
+		if !isTruncated {
+			break
+		}
+		keyMarker, versionIdMarker = page[len(page)-1].key, page[len(page)-1].versionId
+	}
+	assert.Equal(t, names(all), names(paged))
+
+	// the versions of a key are kept together across the pages
+	var grouped [][]string
+	err = eachKeyVersions(list, "/buckets/b", "", 2, func(keyVersions []objectVersion) {
+		grouped = append(grouped, names(keyVersions))
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"a@null", "a@" + versionId(5), "a@" + versionId(3)},
+		{"b/c@null", "b/c@" + versionId(4)},
+		{"b/e@null"},
+		{"d@" + versionId(9)},
+		{"f@" + versionId(8), "f@" + versionId(7)},
+	}, grouped)
+
+	// the walk stops once the page is full
+	listed = nil
+	page, isTruncated, err := listObjectVersions(list, "/buckets/b", "", "", "", "", 1)
+	assert.NoError(t, err)
+	assert.True(t, isTruncated)
+	assert.Equal(t, []string{"a@null"}, names(page))
+	assert.NotContains(t, listed, "/buckets/b/.versions/f")
+
+	page, isTruncated, err = listObjectVersions(list, "/buckets/b", "", "/", "", "", 1000)
+	assert.NoError(t, err)
+	assert.False(t, isTruncated)
+	assert.Equal(t, []string{"a@null", "a@" + versionId(5), "a@" + versionId(3), "b/@", "d@" + versionId(9), "f@" + versionId(8), "f@" + versionId(7)}, names(page))
+	assert.True(t, page[3].isCommonPrefix)
+
+	page, _, err = listObjectVersions(list, "/buckets/b", "b/", "", "b/c", "", 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b/e@null"}, names(page))
+}
@@ -17,6 +17,7 @@ const (
 // Lifecycle - Configuration for bucket lifecycle.
 type Lifecycle struct {
 	XMLName xml.Name `xml:"LifecycleConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
 	Rules   []Rule   `xml:"Rule"`
 }
 
@@ -28,7 +29,10 @@ type Rule struct {
 	Filter     Filter     `xml:"Filter,omitempty"`
 	Prefix     Prefix     `xml:"Prefix,omitempty"`
 	Expiration Expiration `xml:"Expiration,omitempty"`
-	Transition Transition `xml:"Transition,omitempty"`
+
+	Transitions                    []Transition                   `xml:"Transition,omitempty"`
+	NoncurrentVersionExpiration    NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
 }
 
 // Filter - a filter for a lifecycle configuration Rule.
@@ -43,6 +47,9 @@ type Filter struct {
 
 	Tag    Tag
 	tagSet bool
+
+	ObjectSizeGreaterThan int64
+	ObjectSizeLessThan    int64
 }
 
 // Prefix holds the prefix xml tag in <Rule> and <Filter>
@@ -83,14 +90,64 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
 		return err
 	}
+	if f.tagSet {
+		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
+			return err
+		}
+	}
+	if f.andSet {
+		if err := e.EncodeElement(f.And, xml.StartElement{Name: xml.Name{Local: "And"}}); err != nil {
+			return err
+		}
+	}
+	if f.ObjectSizeGreaterThan > 0 {
+		if err := e.EncodeElement(f.ObjectSizeGreaterThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeGreaterThan"}}); err != nil {
+			return err
+		}
+	}
+	if f.ObjectSizeLessThan > 0 {
+		if err := e.EncodeElement(f.ObjectSizeLessThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeLessThan"}}); err != nil {
+			return err
+		}
+	}
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
+// UnmarshalXML decodes Filter field from an XML form, remembering which conditions are present.
+func (f *Filter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type filterWrapper struct {
+		Prefix                Prefix `xml:"Prefix"`
+		Tag                   *Tag   `xml:"Tag"`
+		And                   *And   `xml:"And"`
+		ObjectSizeGreaterThan int64  `xml:"ObjectSizeGreaterThan"`
+		ObjectSizeLessThan    int64  `xml:"ObjectSizeLessThan"`
+	}
+	var filter filterWrapper
+	if err := d.DecodeElement(&filter, &start); err != nil {
+		return err
+	}
+	*f = Filter{
+		set:                   true,
+		Prefix:                filter.Prefix,
+		ObjectSizeGreaterThan: filter.ObjectSizeGreaterThan,
+		ObjectSizeLessThan:    filter.ObjectSizeLessThan,
+	}
+	if filter.Tag != nil {
+		f.Tag, f.tagSet = *filter.Tag, true
+	}
+	if filter.And != nil {
+		f.And, f.andSet = *filter.And, true
+	}
+	return nil
+}
+
 // And - a tag to combine a prefix and multiple tags for lifecycle configuration rule.
 type And struct {
-	XMLName xml.Name `xml:"And"`
-	Prefix  Prefix   `xml:"Prefix,omitempty"`
-	Tags    []Tag    `xml:"Tag,omitempty"`
+	XMLName               xml.Name `xml:"And"`
+	Prefix                Prefix   `xml:"Prefix,omitempty"`
+	Tags                  []Tag    `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan int64    `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64    `xml:"ObjectSizeLessThan,omitempty"`
 }
 
 // Expiration - expiration actions for a rule in lifecycle configuration.
@@ -112,6 +169,18 @@ func (e Expiration) MarshalXML(enc *xml.Encoder, startElement xml.StartElement)
 	return enc.EncodeElement(expirationWrapper(e), startElement)
 }
 
+// UnmarshalXML decodes expiration field from an XML form.
+func (e *Expiration) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	type expirationWrapper Expiration
+	var expiration expirationWrapper
+	if err := d.DecodeElement(&expiration, &startElement); err != nil {
+		return err
+	}
+	*e = Expiration(expiration)
+	e.set = true
+	return nil
+}
+
 // ExpireDeleteMarker represents value of ExpiredObjectDeleteMarker field in Expiration XML element.
 type ExpireDeleteMarker struct {
 	val bool
@@ -126,6 +195,16 @@ func (b ExpireDeleteMarker) MarshalXML(e *xml.Encoder, startElement xml.StartEle
 	return e.EncodeElement(b.val, startElement)
 }
 
+// UnmarshalXML decodes delete marker boolean from an XML form.
+func (b *ExpireDeleteMarker) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var val bool
+	if err := d.DecodeElement(&val, &startElement); err != nil {
+		return err
+	}
+	*b = ExpireDeleteMarker{val: val, set: true}
+	return nil
+}
+
 // ExpirationDate is a embedded type containing time.Time to unmarshal
 // Date in Expiration
 type ExpirationDate struct {
@@ -141,12 +220,28 @@ func (eDate ExpirationDate) MarshalXML(e *xml.Encoder, startElement xml.StartEle
 	return e.EncodeElement(eDate.Format(time.RFC3339), startElement)
 }
 
+// UnmarshalXML decodes the date in RFC3339 format, or as a plain date
+func (eDate *ExpirationDate) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &startElement); err != nil {
+		return err
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return err
+		}
+	}
+	eDate.Time = t.UTC()
+	return nil
+}
+
 // Transition - transition actions for a rule in lifecycle configuration.
 type Transition struct {
-	XMLName      xml.Name  `xml:"Transition"`
-	Days         int       `xml:"Days,omitempty"`
-	Date         time.Time `xml:"Date,omitempty"`
-	StorageClass string    `xml:"StorageClass,omitempty"`
+	XMLName      xml.Name       `xml:"Transition"`
+	Days         int            `xml:"Days,omitempty"`
+	Date         ExpirationDate `xml:"Date,omitempty"`
+	StorageClass string         `xml:"StorageClass,omitempty"`
 
 	set bool
 }
@@ -160,5 +255,76 @@ func (t Transition) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.EncodeElement(transitionWrapper(t), start)
 }
 
+// UnmarshalXML decodes transition field from an XML form.
+func (t *Transition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type transitionWrapper Transition
+	var transition transitionWrapper
+	if err := d.DecodeElement(&transition, &start); err != nil {
+		return err
+	}
+	*t = Transition(transition)
+	t.set = true
+	return nil
+}
+
 // TransitionDays is a type alias to unmarshal Days in Transition
 type TransitionDays int
+
+// NoncurrentVersionExpiration - expiration of the noncurrent versions of a versioned object.
+type NoncurrentVersionExpiration struct {
+	XMLName                 xml.Name `xml:"NoncurrentVersionExpiration"`
+	NoncurrentDays          int      `xml:"NoncurrentDays,omitempty"`
+	NewerNoncurrentVersions int      `xml:"NewerNoncurrentVersions,omitempty"`
+
+	set bool
+}
+
+// MarshalXML encodes noncurrent version expiration field into an XML form.
+func (n NoncurrentVersionExpiration) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if !n.set {
+		return nil
+	}
+	type noncurrentVersionExpirationWrapper NoncurrentVersionExpiration
+	return enc.EncodeElement(noncurrentVersionExpirationWrapper(n), start)
+}
+
+// UnmarshalXML decodes noncurrent version expiration field from an XML form.
+func (n *NoncurrentVersionExpiration) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type noncurrentVersionExpirationWrapper NoncurrentVersionExpiration
+	var expiration noncurrentVersionExpirationWrapper
+	if err := d.DecodeElement(&expiration, &start); err != nil {
+		return err
+	}
+	*n = NoncurrentVersionExpiration(expiration)
+	n.set = true
+	return nil
+}
+
+// AbortIncompleteMultipartUpload - days after which an incomplete multipart upload is aborted.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation int      `xml:"DaysAfterInitiation,omitempty"`
+
+	set bool
+}
+
+// MarshalXML encodes abort incomplete multipart upload field into an XML form.
+func (a AbortIncompleteMultipartUpload) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if !a.set {
+		return nil
+	}
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	return enc.EncodeElement(abortIncompleteMultipartUploadWrapper(a), start)
+}
+
+// UnmarshalXML decodes abort incomplete multipart upload field from an XML form.
+func (a *AbortIncompleteMultipartUpload) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	var abort abortIncompleteMultipartUploadWrapper
+	if err := d.DecodeElement(&abort, &start); err != nil {
+		return err
+	}
+	*a = AbortIncompleteMultipartUpload(abort)
+	a.set = true
+	return nil
+}
@@ -0,0 +1,190 @@
+package s3api
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3presign"
+)
+
+// PresignHandler issues presigned urls and post forms, for the applications which do not sign the requests themselves.
+// The caller authenticates with an access key and secret key as basic auth, or signs the request,
+// and gets a url signed on behalf of its identity. An admin may ask for a url signed on behalf of another identity.
+//
+//	POST /?presign  bucket=photos&key=a.jpg&method=PUT&expires=600&contentType=image/jpeg&contentLengthRange=1,1048576
+func (s3a *S3ApiServer) PresignHandler(w http.ResponseWriter, r *http.Request) {
+	if !s3a.iam.isEnabled() {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNotImplemented)
+		return
+	}
+	caller, errCode := s3a.iam.authenticatePresign(r)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	r.Header.Set(s3_constants.AmzIdentityId, caller.Name)
+
+	if err := r.ParseForm(); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+		return
+	}
+	req, err := newPresignRequest(r)
+	if err == nil {
+		err = req.Validate()
+	}
+	if err != nil {
+		s3err.WriteErrorMessageResponse(w, r, http.StatusBadRequest, "InvalidArgument", err.Error())
+		return
+	}
+
+	identity := caller
+	if name := r.Form.Get("identity"); name != "" && name != caller.Name {
+		if !caller.isAdmin() {
+			s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+			return
+		}
+		var found bool
+		if identity, found = s3a.iam.lookupByName(name); !found {
+			s3err.WriteErrorMessageResponse(w, r, http.StatusBadRequest, "InvalidArgument", "unknown identity "+name)
+			return
+		}
+	}
+	if len(identity.Credentials) == 0 {
+		s3err.WriteErrorMessageResponse(w, r, http.StatusBadRequest, "InvalidArgument", "identity "+identity.Name+" has no credentials")
+		return
+	}
+
+	// fail early if the identity can not do what the url is for, the use of the url is authorized again
+	object := "/" + req.Key + req.KeyPrefix
+	if !identity.isAllowed(newPolicyRequest(presignedHttpRequest(r, req), identity, req.Bucket, object), presignAction(req.Method), req.Bucket, object) {
+		s3err.WriteErrorResponse(w, r, s3err.ErrAccessDenied)
+		return
+	}
+
+	// the temporary credentials of a session caller need the session token along
+	credential, sessionToken := identity.Credentials[0], ""
+	if identity == caller && r.Header.Get(s3_constants.AmzAuthType) != "Basic" {
+		sessionToken = requestSessionToken(r)
+	}
+	presigned, err := s3presign.Presign(req, credential.AccessKey, credential.SecretKey, sessionToken, time.Now())
+	if err != nil {
+		s3err.WriteErrorMessageResponse(w, r, http.StatusBadRequest, "InvalidArgument", err.Error())
+		return
+	}
+	glog.V(0).Infof("%s presigned %s %s%s on behalf of %s until %v", caller.Name, req.Method, req.Bucket, object, identity.Name, presigned.Expiration)
+
+	// keep the urls readable, without escaping their & as \u0026
+	var response bytes.Buffer
+	encoder := json.NewEncoder(&response)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(presigned); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	writeSuccessResponseJSON(w, r, response.Bytes())
+}
+
+func newPresignRequest(r *http.Request) (req *s3presign.Request, err error) {
+	req = &s3presign.Request{
+		Endpoint:    r.Form.Get("endpoint"),
+		Method:      strings.ToUpper(r.Form.Get("method")),
+		Bucket:      r.Form.Get("bucket"),
+		Key:         strings.TrimPrefix(r.Form.Get("key"), "/"),
+		KeyPrefix:   strings.TrimPrefix(r.Form.Get("keyPrefix"), "/"),
+		Expires:     s3presign.DefaultExpires,
+		ContentType: r.Form.Get("contentType"),
+	}
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+	if req.Endpoint == "" {
+		req.Endpoint = requestEndpoint(r)
+	}
+	if expires := r.Form.Get("expires"); expires != "" {
+		seconds, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		req.Expires = time.Duration(seconds) * time.Second
+	}
+	if lengthRange := r.Form.Get("contentLengthRange"); lengthRange != "" {
+		if req.ContentLengthRange, err = s3presign.ParseContentLengthRange(lengthRange); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// requestEndpoint is the endpoint the caller reached the gateway with
+func requestEndpoint(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	host := r.Host
+	if forwardedHost := r.Header.Get("X-Forwarded-Host"); forwardedHost != "" {
+		host = forwardedHost
+	}
+	return scheme + "://" + host
+}
+
+// authenticatePresign authenticates the caller with the access key, or the identity name, and the secret key
+// given as basic auth, or with the signature of the request
+func (iam *IdentityAccessManagement) authenticatePresign(r *http.Request) (*Identity, s3err.ErrorCode) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return iam.Authenticate(r)
+	}
+	r.Header.Set(s3_constants.AmzAuthType, "Basic")
+	identity, credential, found := iam.lookupByAccessKey(username)
+	if !found {
+		if identity, found = iam.lookupByName(username); !found {
+			return nil, s3err.ErrInvalidAccessKeyID
+		}
+	}
+	for _, c := range identity.Credentials {
+		if (credential == nil || c == credential) && subtle.ConstantTimeCompare([]byte(password), []byte(c.SecretKey)) == 1 {
+			return identity, s3err.ErrNone
+		}
+	}
+	return nil, s3err.ErrSignatureDoesNotMatch
+}
+
+// requestSessionToken is the session token the request is signed with, if signed with temporary credentials
+func requestSessionToken(r *http.Request) string {
+	if sessionToken := r.Header.Get(s3_constants.AmzSecurityToken); sessionToken != "" {
+		return sessionToken
+	}
+	return r.URL.Query().Get(s3_constants.AmzSecurityToken)
+}
+
+// presignedHttpRequest is the request made with the presigned url, to evaluate the policies with
+func presignedHttpRequest(r *http.Request, req *s3presign.Request) *http.Request {
+	presignedReq := r.Clone(r.Context())
+	presignedReq.Method = req.Method
+	if req.Method == http.MethodPost {
+		presignedReq.Method = http.MethodPut
+	}
+	presignedReq.URL = &url.URL{Path: "/" + req.Bucket + "/" + req.Key + req.KeyPrefix}
+	return presignedReq
+}
+
+func presignAction(method string) Action {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return s3_constants.ACTION_READ
+	}
+	return s3_constants.ACTION_WRITE
+}
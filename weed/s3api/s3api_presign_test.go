@@ -0,0 +1,140 @@
+package s3api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3presign"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPresignTestServer() *S3ApiServer {
+	iam := &IdentityAccessManagement{
+		hashes:       make(map[string]*sync.Pool),
+		hashCounters: make(map[string]*int32),
+	}
+	_ = iam.loadS3ApiConfiguration(&iam_pb.S3ApiConfiguration{
+		Identities: []*iam_pb.Identity{
+			{
+				Name:        "app",
+				Credentials: []*iam_pb.Credential{{AccessKey: "app_key", SecretKey: "app_secret"}},
+				Actions:     []string{"Read:photos", "Write:photos"},
+			},
+			{
+				Name:        "viewer",
+				Credentials: []*iam_pb.Credential{{AccessKey: "viewer_key", SecretKey: "viewer_secret"}},
+				Actions:     []string{"Read"},
+			},
+			{
+				Name:        "admin",
+				Credentials: []*iam_pb.Credential{{AccessKey: "admin_key", SecretKey: "admin_secret"}},
+				Actions:     []string{"Admin"},
+			},
+		},
+	})
+	return &S3ApiServer{iam: iam}
+}
+
+func presign(t *testing.T, s3a *S3ApiServer, username, password string, form url.Values) (*s3presign.Presigned, int) {
+	r := httptest.NewRequest(http.MethodPost, "http://localhost:8333/?presign", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.SetBasicAuth(username, password)
+	w := httptest.NewRecorder()
+	s3a.PresignHandler(w, r)
+	if w.Code != http.StatusOK {
+		return nil, w.Code
+	}
+	presigned := &s3presign.Presigned{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), presigned))
+	return presigned, w.Code
+}
+
+func TestPresignHandler(t *testing.T) {
+	s3a := newPresignTestServer()
+	upload := url.Values{
+		"bucket":             {"photos"},
+		"key":                {"summer trip/a+b.jpg"},
+		"method":             {"put"},
+		"expires":            {"600"},
+		"contentType":        {"image/jpeg"},
+		"contentLengthRange": {"1,5"},
+	}
+
+	presigned, code := presign(t, s3a, "app_key", "app_secret", upload)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, strings.HasPrefix(presigned.Url, "http://localhost:8333/photos/summer%20trip/a%2Bb.jpg?"))
+
+	put := func(contentType, body string) s3err.ErrorCode {
+		r := httptest.NewRequest(http.MethodPut, presigned.Url, strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		_, errCode := s3a.iam.reqSignatureV4Verify(r)
+		return errCode
+	}
+	assert.Equal(t, s3err.ErrNone, put("image/jpeg", "hello"))
+	assert.Equal(t, s3err.ErrEntityTooLarge, put("image/jpeg", "hello!"))
+	assert.Equal(t, s3err.ErrEntityTooSmall, put("image/jpeg", ""))
+	assert.Equal(t, s3err.ErrSignatureDoesNotMatch, put("image/png", "hello"))
+
+	// the signed length range can not be changed
+	tampered := strings.Replace(presigned.Url, "Range=1%2C5", "Range=1%2C50", 1)
+	r := httptest.NewRequest(http.MethodPut, tampered, strings.NewReader("hello!"))
+	r.Header.Set("Content-Type", "image/jpeg")
+	_, errCode := s3a.iam.reqSignatureV4Verify(r)
+	assert.Equal(t, s3err.ErrSignatureDoesNotMatch, errCode)
+
+	// the identity name also authenticates
+	_, code = presign(t, s3a, "app", "app_secret", upload)
+	assert.Equal(t, http.StatusOK, code)
+	_, code = presign(t, s3a, "app_key", "wrong", upload)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// the identity must be allowed to do what the url is for
+	_, code = presign(t, s3a, "viewer_key", "viewer_secret", upload)
+	assert.Equal(t, http.StatusForbidden, code)
+	presigned, code = presign(t, s3a, "viewer_key", "viewer_secret", url.Values{"bucket": {"photos"}, "key": {"a.jpg"}})
+	assert.Equal(t, http.StatusOK, code)
+	identity, errCode := s3a.iam.reqSignatureV4Verify(httptest.NewRequest(http.MethodGet, presigned.Url, nil))
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "viewer", identity.Name)
+
+	// only an admin signs on behalf of another identity
+	upload.Set("identity", "app")
+	_, code = presign(t, s3a, "viewer_key", "viewer_secret", upload)
+	assert.Equal(t, http.StatusForbidden, code)
+	presigned, code = presign(t, s3a, "admin_key", "admin_secret", upload)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, presigned.Url, "X-Amz-Credential=app_key")
+
+	_, code = presign(t, s3a, "app_key", "app_secret", url.Values{"bucket": {"photos"}, "key": {"a.jpg"}, "expires": {"604801"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestPresignPostPolicy(t *testing.T) {
+	s3a := newPresignTestServer()
+	presigned, code := presign(t, s3a, "app_key", "app_secret", url.Values{
+		"bucket":      {"photos"},
+		"keyPrefix":   {"uploads/"},
+		"method":      {"POST"},
+		"contentType": {"image/*"},
+	})
+	assert.Equal(t, http.StatusOK, code)
+
+	formValues := make(http.Header)
+	for k, v := range presigned.Fields {
+		formValues.Set(k, v)
+	}
+	identity, errCode := s3a.iam.doesPolicySignatureMatch(formValues)
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "app", identity.Name)
+
+	formValues.Set("Policy", formValues.Get("Policy")+"=")
+	_, errCode = s3a.iam.doesPolicySignatureMatch(formValues)
+	assert.Equal(t, s3err.ErrSignatureDoesNotMatch, errCode)
+}
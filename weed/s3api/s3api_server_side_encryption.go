@@ -0,0 +1,372 @@
+package s3api
+
+// Server side encryption is done by the filer, which encrypts every chunk with its own random key.
+//
+// With SSE-S3, the chunk keys are kept in the filer store, the same as for filer's -encryptVolumeData option.
+// With SSE-C, the chunk keys are in turn encrypted with the key provided by the customer. The customer key
+// itself is never stored, only its MD5, so that the filer can check the key presented by later reads.
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+)
+
+const maxEncryptionConfigSize = 64 * 1024
+
+type ServerSideEncryptionConfiguration struct {
+	XMLName xml.Name                   `xml:"ServerSideEncryptionConfiguration"`
+	Xmlns   string                     `xml:"xmlns,attr,omitempty"`
+	Rules   []ServerSideEncryptionRule `xml:"Rule"`
+}
+
+type ServerSideEncryptionRule struct {
+	ApplyServerSideEncryptionByDefault *ServerSideEncryptionByDefault `xml:"ApplyServerSideEncryptionByDefault"`
+	BucketKeyEnabled                   *bool                          `xml:"BucketKeyEnabled,omitempty"`
+}
+
+type ServerSideEncryptionByDefault struct {
+	SSEAlgorithm   string `xml:"SSEAlgorithm"`
+	KMSMasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+}
+
+func parseEncryptionConfiguration(data []byte) (*ServerSideEncryptionConfiguration, error) {
+	config := &ServerSideEncryptionConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *ServerSideEncryptionConfiguration) validate() error {
+	if len(c.Rules) != 1 {
+		return fmt.Errorf("exactly one rule is required, found %d", len(c.Rules))
+	}
+	byDefault := c.Rules[0].ApplyServerSideEncryptionByDefault
+	if byDefault == nil {
+		return fmt.Errorf("missing ApplyServerSideEncryptionByDefault")
+	}
+	if byDefault.SSEAlgorithm != s3_constants.ServerSideEncryptionAES256 {
+		// there is no key management service to use aws:kms with
+		return fmt.Errorf("unsupported SSEAlgorithm %q", byDefault.SSEAlgorithm)
+	}
+	return nil
+}
+
+// sseCustomerKey is the key provided with SSE-C requests
+type sseCustomerKey struct {
+	key    []byte
+	keyMd5 string
+}
+
+// parseSSECustomerKey reads the customer provided key from the algorithm, key and key MD5 headers,
+// it returns nil if none of the headers is set
+func parseSSECustomerKey(header http.Header, algorithmHeader, keyHeader, keyMd5Header string) (*sseCustomerKey, s3err.ErrorCode) {
+	algorithm, encodedKey, keyMd5 := header.Get(algorithmHeader), header.Get(keyHeader), header.Get(keyMd5Header)
+	if algorithm == "" && encodedKey == "" && keyMd5 == "" {
+		return nil, s3err.ErrNone
+	}
+	if algorithm != s3_constants.ServerSideEncryptionAES256 {
+		return nil, s3err.ErrInvalidEncryptionAlgorithm
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != 32 {
+		return nil, s3err.ErrInvalidSSECustomerKey
+	}
+	actualMd5 := md5.Sum(key)
+	if keyMd5 != base64.StdEncoding.EncodeToString(actualMd5[:]) {
+		return nil, s3err.ErrSSECustomerKeyMD5Mismatch
+	}
+	return &sseCustomerKey{key: key, keyMd5: keyMd5}, s3err.ErrNone
+}
+
+func parseRequestSSECustomerKey(r *http.Request) (*sseCustomerKey, s3err.ErrorCode) {
+	return parseSSECustomerKey(r.Header,
+		s3_constants.AmzServerSideEncryptionCustomerAlgorithm,
+		s3_constants.AmzServerSideEncryptionCustomerKey,
+		s3_constants.AmzServerSideEncryptionCustomerKeyMD5)
+}
+
+func parseCopySourceSSECustomerKey(r *http.Request) (*sseCustomerKey, s3err.ErrorCode) {
+	return parseSSECustomerKey(r.Header,
+		s3_constants.AmzCopySourceServerSideEncryptionCustomerAlgorithm,
+		s3_constants.AmzCopySourceServerSideEncryptionCustomerKey,
+		s3_constants.AmzCopySourceServerSideEncryptionCustomerKeyMD5)
+}
+
+// setCipherKeyHeader passes the customer key to the filer, which needs it to read or write the chunks
+func setCipherKeyHeader(header http.Header, customerKey *sseCustomerKey) {
+	header.Del(s3_constants.SeaweedFSCipherKey)
+	if customerKey != nil {
+		header.Set(s3_constants.SeaweedFSCipherKey, base64.StdEncoding.EncodeToString(customerKey.key))
+	}
+}
+
+// serverSideEncryption is the encryption applied to a new object, either SSE-S3 or SSE-C
+type serverSideEncryption struct {
+	algorithm   string
+	customerKey *sseCustomerKey
+}
+
+func (sse serverSideEncryption) isEncrypted() bool {
+	return sse.algorithm != "" || sse.customerKey != nil
+}
+
+func (s3a *S3ApiServer) getEncryptionConfiguration(bucket string) *ServerSideEncryptionConfiguration {
+	bucketMetadata, errCode := s3a.bucketRegistry.GetBucketMetadata(bucket)
+	if errCode != s3err.ErrNone {
+		return nil
+	}
+	return bucketMetadata.Encryption
+}
+
+// serverSideEncryptionForWrite returns the encryption of a new object,
+// taken from the request headers or else from the default encryption of the bucket
+func (s3a *S3ApiServer) serverSideEncryptionForWrite(r *http.Request, bucket string) (sse serverSideEncryption, errCode s3err.ErrorCode) {
+	if sse.customerKey, errCode = parseRequestSSECustomerKey(r); errCode != s3err.ErrNone {
+		return
+	}
+	switch algorithm := r.Header.Get(s3_constants.AmzServerSideEncryption); algorithm {
+	case "":
+	case s3_constants.ServerSideEncryptionAES256:
+		if sse.customerKey != nil {
+			return sse, s3err.ErrInvalidRequest
+		}
+		sse.algorithm = algorithm
+	case s3_constants.ServerSideEncryptionKMS:
+		return sse, s3err.ErrNotImplemented
+	default:
+		return sse, s3err.ErrInvalidEncryptionAlgorithm
+	}
+	if !sse.isEncrypted() {
+		if config := s3a.getEncryptionConfiguration(bucket); config != nil {
+			sse.algorithm = config.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm
+		}
+	}
+	return sse, s3err.ErrNone
+}
+
+// serverSideEncryptionForUploadPart returns the encryption chosen when the multipart upload was created,
+// the parts of an SSE-C upload must be sent with the same customer key
+func (s3a *S3ApiServer) serverSideEncryptionForUploadPart(r *http.Request, bucket, uploadID string) (sse serverSideEncryption, errCode s3err.ErrorCode) {
+	uploadEntry, err := s3a.getEntry(s3a.genUploadsFolder(bucket), uploadID)
+	if err != nil {
+		return sse, s3err.ErrNoSuchUpload
+	}
+	if sse.customerKey, errCode = parseRequestSSECustomerKey(r); errCode != s3err.ErrNone {
+		return
+	}
+	keyMd5, isSSEC := uploadEntry.Extended[s3_constants.ExtSSECustomerKeyMD5Key]
+	if isSSEC != (sse.customerKey != nil) {
+		return sse, s3err.ErrInvalidRequest
+	}
+	if isSSEC && sse.customerKey.keyMd5 != string(keyMd5) {
+		return sse, s3err.ErrSSECustomerKeyMD5Mismatch
+	}
+	sse.algorithm = string(uploadEntry.Extended[s3_constants.ExtServerSideEncryptionKey])
+	return sse, s3err.ErrNone
+}
+
+// toExtended keeps the encryption in the extended attributes of a multipart upload,
+// from where it is copied to the completed object
+func (sse serverSideEncryption) toExtended(extended map[string][]byte) {
+	if sse.algorithm != "" {
+		extended[s3_constants.ExtServerSideEncryptionKey] = []byte(sse.algorithm)
+	}
+	if sse.customerKey != nil {
+		extended[s3_constants.ExtSSECustomerAlgorithmKey] = []byte(s3_constants.ServerSideEncryptionAES256)
+		extended[s3_constants.ExtSSECustomerKeyMD5Key] = []byte(sse.customerKey.keyMd5)
+	}
+}
+
+// setServerSideEncryptionHeader asks the filer to encrypt the uploaded object
+func setServerSideEncryptionHeader(header http.Header, sse serverSideEncryption) {
+	setCipherKeyHeader(header, sse.customerKey)
+	if !sse.isEncrypted() {
+		return
+	}
+	header.Set(s3_constants.SeaweedFSCipher, "true")
+	if sse.algorithm != "" {
+		header.Set(s3_constants.ExtServerSideEncryptionKey, sse.algorithm)
+	}
+	if sse.customerKey != nil {
+		header.Set(s3_constants.ExtSSECustomerAlgorithmKey, s3_constants.ServerSideEncryptionAES256)
+	}
+}
+
+// setServerSideEncryptionResponseHeaders reports the applied encryption in the response to a write
+func setServerSideEncryptionResponseHeaders(w http.ResponseWriter, sse serverSideEncryption) {
+	if sse.algorithm != "" {
+		w.Header().Set(s3_constants.AmzServerSideEncryption, sse.algorithm)
+	}
+	if sse.customerKey != nil {
+		w.Header().Set(s3_constants.AmzServerSideEncryptionCustomerAlgorithm, s3_constants.ServerSideEncryptionAES256)
+		w.Header().Set(s3_constants.AmzServerSideEncryptionCustomerKeyMD5, sse.customerKey.keyMd5)
+	}
+}
+
+// setServerSideEncryptionHeaders replaces the stored encryption attributes with the S3 response headers
+func setServerSideEncryptionHeaders(resp *http.Response) {
+	for extKey, amzKey := range map[string]string{
+		s3_constants.ExtServerSideEncryptionKey: s3_constants.AmzServerSideEncryption,
+		s3_constants.ExtSSECustomerAlgorithmKey: s3_constants.AmzServerSideEncryptionCustomerAlgorithm,
+		s3_constants.ExtSSECustomerKeyMD5Key:    s3_constants.AmzServerSideEncryptionCustomerKeyMD5,
+	} {
+		if value := resp.Header.Get(extKey); value != "" {
+			resp.Header.Set(amzKey, value)
+			resp.Header.Del(extKey)
+		}
+	}
+}
+
+// readFilerObject reads the object content, decrypted with the customer key if provided
+func (s3a *S3ApiServer) readFilerObject(method, destUrl string, rangeHeader string, customerKey *sseCustomerKey) (*http.Response, error) {
+	req, err := http.NewRequest(method, destUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+	setCipherKeyHeader(req.Header, customerKey)
+	s3a.maybeAddFilerJwtAuthorization(req, false)
+	return s3a.client.Do(req)
+}
+
+// readCopySource reads the source object of a copy, with the copy source customer key if provided
+func (s3a *S3ApiServer) readCopySource(r *http.Request, srcUrl string, rangeHeader string) (*http.Response, s3err.ErrorCode) {
+	customerKey, errCode := parseCopySourceSSECustomerKey(r)
+	if errCode != s3err.ErrNone {
+		return nil, errCode
+	}
+	resp, err := s3a.readFilerObject(http.MethodGet, srcUrl, rangeHeader, customerKey)
+	if err != nil {
+		glog.Errorf("read copy source %s: %v", srcUrl, err)
+		return nil, s3err.ErrInvalidCopySource
+	}
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		errCode = s3err.ErrInvalidRequest
+	case resp.StatusCode == http.StatusForbidden:
+		errCode = s3err.ErrAccessDenied
+	case resp.StatusCode >= 300:
+		errCode = s3err.ErrInvalidCopySource
+	}
+	if errCode != s3err.ErrNone {
+		util_http.CloseResponse(resp)
+		return nil, errCode
+	}
+	return resp, s3err.ErrNone
+}
+
+// GetBucketEncryptionHandler Returns the default encryption configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketEncryption.html
+func (s3a *S3ApiServer) GetBucketEncryptionHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetBucketEncryptionHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	bucketEntry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	configBytes, ok := bucketEntry.Extended[s3_constants.ExtEncryptionConfigKey]
+	if !ok || len(configBytes) == 0 {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchBucketEncryptionConfiguration)
+		return
+	}
+	config := &ServerSideEncryptionConfiguration{}
+	if err = xml.Unmarshal(configBytes, config); err != nil {
+		glog.Errorf("GetBucketEncryptionHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	config.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, r, config)
+}
+
+// PutBucketEncryptionHandler Put bucket default encryption
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketEncryption.html
+func (s3a *S3ApiServer) PutBucketEncryptionHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("PutBucketEncryptionHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	defer util_http.CloseRequest(r)
+	configBytes, err := io.ReadAll(io.LimitReader(r.Body, maxEncryptionConfigSize+1))
+	if err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if len(configBytes) > maxEncryptionConfigSize {
+		s3err.WriteErrorResponse(w, r, s3err.ErrEntityTooLarge)
+		return
+	}
+	config := &ServerSideEncryptionConfiguration{}
+	if err = xml.Unmarshal(configBytes, config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrMalformedXML)
+		return
+	}
+	if err = config.validate(); err != nil {
+		glog.V(1).Infof("PutBucketEncryptionHandler %s: %v", bucket, err)
+		s3err.WriteErrorResponse(w, r, s3err.ErrInvalidEncryptionAlgorithm)
+		return
+	}
+	config.Xmlns = ""
+	if configBytes, err = xml.Marshal(config); err != nil {
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		extended[s3_constants.ExtEncryptionConfigKey] = configBytes
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	writeSuccessResponseEmpty(w, r)
+}
+
+// DeleteBucketEncryptionHandler Delete bucket default encryption
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketEncryption.html
+func (s3a *S3ApiServer) DeleteBucketEncryptionHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, _ := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("DeleteBucketEncryptionHandler %s", bucket)
+
+	if err := s3a.checkBucket(r, bucket); err != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, err)
+		return
+	}
+
+	if errCode := s3a.updateBucketExtended(bucket, func(extended map[string][]byte) {
+		delete(extended, s3_constants.ExtEncryptionConfigKey)
+	}); errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	s3err.WriteEmptyResponse(w, r, http.StatusNoContent)
+}
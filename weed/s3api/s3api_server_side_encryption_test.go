@@ -0,0 +1,121 @@
+package s3api
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/stretchr/testify/assert"
+)
+
+func setSSECustomerKeyHeaders(r *http.Request, key []byte) {
+	keyMd5 := md5.Sum(key)
+	r.Header.Set(s3_constants.AmzServerSideEncryptionCustomerAlgorithm, "AES256")
+	r.Header.Set(s3_constants.AmzServerSideEncryptionCustomerKey, base64.StdEncoding.EncodeToString(key))
+	r.Header.Set(s3_constants.AmzServerSideEncryptionCustomerKeyMD5, base64.StdEncoding.EncodeToString(keyMd5[:]))
+}
+
+func TestParseEncryptionConfiguration(t *testing.T) {
+	config, err := parseEncryptionConfiguration([]byte(`<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule>
+</ServerSideEncryptionConfiguration>`))
+	assert.Nil(t, err)
+	assert.Equal(t, "AES256", config.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+
+	invalidConfigurations := []string{
+		`<ServerSideEncryptionConfiguration></ServerSideEncryptionConfiguration>`,
+		`<ServerSideEncryptionConfiguration><Rule></Rule></ServerSideEncryptionConfiguration>`,
+		`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+	}
+	for _, invalidConfiguration := range invalidConfigurations {
+		_, err = parseEncryptionConfiguration([]byte(invalidConfiguration))
+		assert.NotNil(t, err, invalidConfiguration)
+	}
+}
+
+func TestParseSSECustomerKey(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	r := httptest.NewRequest(http.MethodGet, "/bucket/key", nil)
+	customerKey, errCode := parseRequestSSECustomerKey(r)
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Nil(t, customerKey)
+
+	setSSECustomerKeyHeaders(r, key)
+	customerKey, errCode = parseRequestSSECustomerKey(r)
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, key, customerKey.key)
+
+	r.Header.Set(s3_constants.AmzServerSideEncryptionCustomerKeyMD5, "invalid")
+	_, errCode = parseRequestSSECustomerKey(r)
+	assert.Equal(t, s3err.ErrSSECustomerKeyMD5Mismatch, errCode)
+
+	setSSECustomerKeyHeaders(r, key[:16])
+	_, errCode = parseRequestSSECustomerKey(r)
+	assert.Equal(t, s3err.ErrInvalidSSECustomerKey, errCode)
+
+	setSSECustomerKeyHeaders(r, key)
+	r.Header.Set(s3_constants.AmzServerSideEncryptionCustomerAlgorithm, "AES128")
+	_, errCode = parseRequestSSECustomerKey(r)
+	assert.Equal(t, s3err.ErrInvalidEncryptionAlgorithm, errCode)
+}
+
+func TestServerSideEncryptionForWrite(t *testing.T) {
+	config, err := parseEncryptionConfiguration([]byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`))
+	assert.Nil(t, err)
+	s3a := &S3ApiServer{
+		bucketRegistry: &BucketRegistry{
+			metadataCache: map[string]*BucketMetaData{
+				"encrypted": {Name: "encrypted", Encryption: config},
+				"plain":     {Name: "plain"},
+			},
+			notFound: make(map[string]struct{}),
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodPut, "/plain/key", nil)
+	r.Header.Set(s3_constants.ExtSSECustomerKeyMD5Key, "spoofed")
+	sse, errCode := s3a.serverSideEncryptionForWrite(r, "plain")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.False(t, sse.isEncrypted())
+	filerHeader := make(http.Header)
+	setServerSideEncryptionHeader(filerHeader, sse)
+	assert.Equal(t, "", filerHeader.Get(s3_constants.SeaweedFSCipher))
+	assert.Equal(t, "", filerHeader.Get(s3_constants.ExtSSECustomerKeyMD5Key))
+
+	sse, errCode = s3a.serverSideEncryptionForWrite(r, "encrypted")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "AES256", sse.algorithm, "default encryption of the bucket")
+	filerHeader = make(http.Header)
+	setServerSideEncryptionHeader(filerHeader, sse)
+	assert.Equal(t, "true", filerHeader.Get(s3_constants.SeaweedFSCipher))
+	assert.Equal(t, "AES256", filerHeader.Get(s3_constants.ExtServerSideEncryptionKey))
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	r = httptest.NewRequest(http.MethodPut, "/encrypted/key", nil)
+	setSSECustomerKeyHeaders(r, key)
+	sse, errCode = s3a.serverSideEncryptionForWrite(r, "encrypted")
+	assert.Equal(t, s3err.ErrNone, errCode)
+	assert.Equal(t, "", sse.algorithm, "the customer key replaces the default encryption")
+	filerHeader = make(http.Header)
+	setServerSideEncryptionHeader(filerHeader, sse)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(key), filerHeader.Get(s3_constants.SeaweedFSCipherKey))
+	assert.Equal(t, "AES256", filerHeader.Get(s3_constants.ExtSSECustomerAlgorithmKey))
+
+	w := httptest.NewRecorder()
+	setServerSideEncryptionResponseHeaders(w, sse)
+	assert.Equal(t, r.Header.Get(s3_constants.AmzServerSideEncryptionCustomerKeyMD5), w.Header().Get(s3_constants.AmzServerSideEncryptionCustomerKeyMD5))
+
+	r.Header.Set(s3_constants.AmzServerSideEncryption, "AES256")
+	_, errCode = s3a.serverSideEncryptionForWrite(r, "encrypted")
+	assert.Equal(t, s3err.ErrInvalidRequest, errCode, "SSE-S3 and SSE-C together")
+
+	r = httptest.NewRequest(http.MethodPut, "/plain/key", nil)
+	r.Header.Set(s3_constants.AmzServerSideEncryption, "aws:kms")
+	_, errCode = s3a.serverSideEncryptionForWrite(r, "plain")
+	assert.Equal(t, s3err.ErrNotImplemented, errCode)
+}
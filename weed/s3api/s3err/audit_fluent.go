@@ -17,32 +17,32 @@ type AccessLogExtend struct {
 }
 
 type AccessLog struct {
-	Bucket           string `msg:"bucket" json:"bucket"`                   // awsexamplebucket1
-	Time             int64  `msg:"time" json:"time"`                       // [06/Feb/2019:00:00:38 +0000]
-	RemoteIP         string `msg:"remote_ip" json:"remote_ip,omitempty"`   // 192.0.2.3
-	Requester        string `msg:"requester" json:"requester,omitempty"`   // IAM user id
-	RequestID        string `msg:"request_id" json:"request_id,omitempty"` // 3E57427F33A59F07
-	Operation        string `msg:"operation" json:"operation,omitempty"`   // REST.HTTP_method.resource_type REST.PUT.OBJECT
-	Key              string `msg:"key" json:"key,omitempty"`               // /photos/2019/08/puppy.jpg
-	ErrorCode        string `msg:"error_code" json:"error_code,omitempty"`
-	HostId           string `msg:"host_id" json:"host_id,omitempty"`
-	HostHeader       string `msg:"host_header" json:"host_header,omitempty"` // s3.us-west-2.amazonaws.com
-	UserAgent        string `msg:"user_agent" json:"user_agent,omitempty"`
-	HTTPStatus       int    `msg:"status" json:"status,omitempty"`
-	SignatureVersion string `msg:"signature_version" json:"signature_version,omitempty"`
+	Bucket             string `msg:"bucket" json:"bucket"`                   // awsexamplebucket1
+	Time               int64  `msg:"time" json:"time"`                       // [06/Feb/2019:00:00:38 +0000]
+	RemoteIP           string `msg:"remote_ip" json:"remote_ip,omitempty"`   // 192.0.2.3
+	Requester          string `msg:"requester" json:"requester,omitempty"`   // IAM user id
+	RequestID          string `msg:"request_id" json:"request_id,omitempty"` // 3E57427F33A59F07
+	Operation          string `msg:"operation" json:"operation,omitempty"`   // REST.HTTP_method.resource_type REST.PUT.OBJECT
+	Key                string `msg:"key" json:"key,omitempty"`               // /photos/2019/08/puppy.jpg
+	ErrorCode          string `msg:"error_code" json:"error_code,omitempty"`
+	HostId             string `msg:"host_id" json:"host_id,omitempty"`
+	HostHeader         string `msg:"host_header" json:"host_header,omitempty"` // s3.us-west-2.amazonaws.com
+	UserAgent          string `msg:"user_agent" json:"user_agent,omitempty"`
+	HTTPStatus         int    `msg:"status" json:"status,omitempty"`
+	SignatureVersion   string `msg:"signature_version" json:"signature_version,omitempty"`
+	AuthenticationType string `msg:"auth_type" json:"auth_type,omitempty"` // AuthHeader, QueryString for the presigned urls
 }
 
 type AccessLogHTTP struct {
-	RequestURI         string `json:"request_uri,omitempty"` // "GET /awsexamplebucket1/photos/2019/08/puppy.jpg?x-foo=bar HTTP/1.1"
-	BytesSent          string `json:"bytes_sent,omitempty"`
-	ObjectSize         string `json:"object_size,omitempty"`
-	TotalTime          int    `json:"total_time,omitempty"`
-	TurnAroundTime     int    `json:"turn_around_time,omitempty"`
-	Referer            string `json:"Referer,omitempty"`
-	VersionId          string `json:"version_id,omitempty"`
-	CipherSuite        string `json:"cipher_suite,omitempty"`
-	AuthenticationType string `json:"auth_type,omitempty"`
-	TLSVersion         string `json:"TLS_version,omitempty"`
+	RequestURI     string `json:"request_uri,omitempty"` // "GET /awsexamplebucket1/photos/2019/08/puppy.jpg?x-foo=bar HTTP/1.1"
+	BytesSent      string `json:"bytes_sent,omitempty"`
+	ObjectSize     string `json:"object_size,omitempty"`
+	TotalTime      int    `json:"total_time,omitempty"`
+	TurnAroundTime int    `json:"turn_around_time,omitempty"`
+	Referer        string `json:"Referer,omitempty"`
+	VersionId      string `json:"version_id,omitempty"`
+	CipherSuite    string `json:"cipher_suite,omitempty"`
+	TLSVersion     string `json:"TLS_version,omitempty"`
 }
 
 const tag = "s3.access"
@@ -97,6 +97,8 @@ func getResourceType(object string, query_key string, metod string) (string, boo
 			return getREST(metod, "ACCESSCONTROLPOLICY"), true
 		case "policy":
 			return getREST(metod, "BUCKETPOLICY"), true
+		case "presign":
+			return getREST(metod, "PRESIGN"), true
 		default:
 			return getREST(metod, "BUCKET"), false
 		}
@@ -133,6 +135,18 @@ func GetAccessHttpLog(r *http.Request, statusCode int, s3errCode ErrorCode) Acce
 	}
 }
 
+// getAuthenticationType tells how the request is signed, in the terms of the S3 server access logs
+func getAuthenticationType(r *http.Request) string {
+	query := r.URL.Query()
+	if query.Has("X-Amz-Signature") || query.Has("Signature") {
+		return "QueryString"
+	}
+	if r.Header.Get("Authorization") != "" {
+		return "AuthHeader"
+	}
+	return ""
+}
+
 func GetAccessLog(r *http.Request, HTTPStatusCode int, s3errCode ErrorCode) *AccessLog {
 	bucket, key := s3_constants.GetBucketAndObject(r)
 	var errorCode string
@@ -148,19 +162,20 @@ func GetAccessLog(r *http.Request, HTTPStatusCode int, s3errCode ErrorCode) *Acc
 		hostHeader = r.Host
 	}
 	return &AccessLog{
-		HostHeader:       hostHeader,
-		RequestID:        r.Header.Get("X-Request-ID"),
-		RemoteIP:         remoteIP,
-		Requester:        r.Header.Get(s3_constants.AmzIdentityId),
-		SignatureVersion: r.Header.Get(s3_constants.AmzAuthType),
-		UserAgent:        r.Header.Get("user-agent"),
-		HostId:           hostname,
-		Bucket:           bucket,
-		HTTPStatus:       HTTPStatusCode,
-		Time:             time.Now().Unix(),
-		Key:              key,
-		Operation:        getOperation(key, r),
-		ErrorCode:        errorCode,
+		HostHeader:         hostHeader,
+		RequestID:          r.Header.Get("X-Request-ID"),
+		RemoteIP:           remoteIP,
+		Requester:          r.Header.Get(s3_constants.AmzIdentityId),
+		SignatureVersion:   r.Header.Get(s3_constants.AmzAuthType),
+		AuthenticationType: getAuthenticationType(r),
+		UserAgent:          r.Header.Get("user-agent"),
+		HostId:             hostname,
+		Bucket:             bucket,
+		HTTPStatus:         HTTPStatusCode,
+		Time:               time.Now().Unix(),
+		Key:                key,
+		Operation:          getOperation(key, r),
+		ErrorCode:          errorCode,
 	}
 }
 
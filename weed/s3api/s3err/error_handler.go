@@ -2,6 +2,7 @@ package s3err
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"github.com/aws/aws-sdk-go/private/protocol/xml/xmlutil"
@@ -18,6 +19,7 @@ type mimeType string
 const (
 	mimeNone mimeType = ""
 	MimeXML  mimeType = "application/xml"
+	MimeJSON mimeType = "application/json"
 )
 
 func WriteAwsXMLResponse(w http.ResponseWriter, r *http.Request, statusCode int, result interface{}) {
@@ -49,10 +51,21 @@ func WriteErrorResponse(w http.ResponseWriter, r *http.Request, errorCode ErrorC
 
 	apiError := GetAPIError(errorCode)
 	errorResponse := getRESTErrorResponse(apiError, r.URL.Path, bucket, object)
+	recordErrorCode(r, apiError.Code)
 	WriteXMLResponse(w, r, apiError.HTTPStatusCode, errorResponse)
 	PostLog(r, apiError.HTTPStatusCode, errorCode)
 }
 
+// WriteErrorMessageResponse writes an error whose code and message are not one of the ErrorCode,
+// such as the errors reported by S3 Select
+func WriteErrorMessageResponse(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
+	bucket, object := mux.Vars(r)["bucket"], strings.TrimPrefix(mux.Vars(r)["object"], "/")
+	errorResponse := getRESTErrorResponse(APIError{Code: code, Description: message}, r.URL.Path, bucket, object)
+	recordErrorCode(r, code)
+	WriteXMLResponse(w, r, statusCode, errorResponse)
+	PostLog(r, statusCode, ErrNone)
+}
+
 func getRESTErrorResponse(err APIError, resource string, bucket, object string) RESTErrorResponse {
 	return RESTErrorResponse{
 		Code:       err.Code,
@@ -73,10 +86,36 @@ func EncodeXMLResponse(response interface{}) []byte {
 	return bytesBuffer.Bytes()
 }
 
+type bucketCorsKey struct{}
+
+// WithBucketCors marks the request as governed by the CORS configuration of its bucket,
+// so the default allow-all CORS headers are not added to the response
+func WithBucketCors(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), bucketCorsKey{}, true))
+}
+
+func HasBucketCors(r *http.Request) bool {
+	applied, _ := r.Context().Value(bucketCorsKey{}).(bool)
+	return applied
+}
+
+type errorCodeKey struct{}
+
+// WithErrorCodeRecorder lets the error responses to the request record their error code, e.g. for the access logs
+func WithErrorCodeRecorder(r *http.Request, errorCode *string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), errorCodeKey{}, errorCode))
+}
+
+func recordErrorCode(r *http.Request, code string) {
+	if errorCode, found := r.Context().Value(errorCodeKey{}).(*string); found {
+		*errorCode = code
+	}
+}
+
 func setCommonHeaders(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("x-amz-request-id", fmt.Sprintf("%d", time.Now().UnixNano()))
 	w.Header().Set("Accept-Ranges", "bytes")
-	if r.Header.Get("Origin") != "" {
+	if r.Header.Get("Origin") != "" && !HasBucketCors(r) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Expose-Headers", "*")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
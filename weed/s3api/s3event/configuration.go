@@ -0,0 +1,229 @@
+package s3event
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/notification-how-to-event-types-and-destinations.html
+
+const (
+	ObjectCreatedAll                     = "s3:ObjectCreated:*"
+	ObjectCreatedPut                     = "s3:ObjectCreated:Put"
+	ObjectCreatedPost                    = "s3:ObjectCreated:Post"
+	ObjectCreatedCopy                    = "s3:ObjectCreated:Copy"
+	ObjectCreatedCompleteMultipartUpload = "s3:ObjectCreated:CompleteMultipartUpload"
+	ObjectRemovedAll                     = "s3:ObjectRemoved:*"
+	ObjectRemovedDelete                  = "s3:ObjectRemoved:Delete"
+	ObjectRemovedDeleteMarkerCreated     = "s3:ObjectRemoved:DeleteMarkerCreated"
+
+	// the maximum size of a bucket notification configuration
+	MaxConfigurationSize = 64 * 1024
+
+	maxFilterValueLength = 1024
+)
+
+var supportedEvents = map[string]bool{
+	ObjectCreatedAll:                     true,
+	ObjectCreatedPut:                     true,
+	ObjectCreatedPost:                    true,
+	ObjectCreatedCopy:                    true,
+	ObjectCreatedCompleteMultipartUpload: true,
+	ObjectRemovedAll:                     true,
+	ObjectRemovedDelete:                  true,
+	ObjectRemovedDeleteMarkerCreated:     true,
+}
+
+// NotificationConfiguration is the notification configuration of a bucket.
+// The queues and topics are both delivered to the notification targets of the filer,
+// the last field of their ARN names the target, e.g. arn:aws:sqs:us-east-1:000000000000:orders
+type NotificationConfiguration struct {
+	XMLName xml.Name `xml:"NotificationConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+
+	QueueConfigurations         []QueueConfiguration         `xml:"QueueConfiguration,omitempty"`
+	TopicConfigurations         []TopicConfiguration         `xml:"TopicConfiguration,omitempty"`
+	CloudFunctionConfigurations []CloudFunctionConfiguration `xml:"CloudFunctionConfiguration,omitempty"`
+	EventBridgeConfiguration    *struct{}                    `xml:"EventBridgeConfiguration,omitempty"`
+}
+
+type QueueConfiguration struct {
+	Id     string   `xml:"Id,omitempty"`
+	Queue  string   `xml:"Queue"`
+	Events []string `xml:"Event"`
+	Filter *Filter  `xml:"Filter,omitempty"`
+}
+
+type TopicConfiguration struct {
+	Id     string   `xml:"Id,omitempty"`
+	Topic  string   `xml:"Topic"`
+	Events []string `xml:"Event"`
+	Filter *Filter  `xml:"Filter,omitempty"`
+}
+
+// CloudFunctionConfiguration is only parsed to reject it, the lambda functions are not supported
+type CloudFunctionConfiguration struct {
+	Id            string `xml:"Id,omitempty"`
+	CloudFunction string `xml:"CloudFunction"`
+}
+
+type Filter struct {
+	S3Key S3KeyFilter `xml:"S3Key"`
+}
+
+type S3KeyFilter struct {
+	FilterRules []FilterRule `xml:"FilterRule"`
+}
+
+type FilterRule struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+// Rule is a queue or topic configuration, resolved to the target it notifies
+type Rule struct {
+	Id     string
+	Target string
+	Events []string
+	Prefix string
+	Suffix string
+}
+
+// ParseConfiguration parses and validates the notification configuration of a bucket
+func ParseConfiguration(data []byte) (*NotificationConfiguration, error) {
+	config := &NotificationConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if _, err := config.Rules(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// IsEmpty tells whether the configuration notifies nothing, which removes the notifications of the bucket
+func (c *NotificationConfiguration) IsEmpty() bool {
+	return len(c.QueueConfigurations) == 0 && len(c.TopicConfigurations) == 0
+}
+
+// SetDefaultIds names the configurations without id, as AWS does
+func (c *NotificationConfiguration) SetDefaultIds() {
+	for i := range c.QueueConfigurations {
+		if c.QueueConfigurations[i].Id == "" {
+			c.QueueConfigurations[i].Id = uuid.New().String()
+		}
+	}
+	for i := range c.TopicConfigurations {
+		if c.TopicConfigurations[i].Id == "" {
+			c.TopicConfigurations[i].Id = uuid.New().String()
+		}
+	}
+}
+
+// Rules validates and flattens the queue and topic configurations
+func (c *NotificationConfiguration) Rules() (rules []*Rule, err error) {
+	if len(c.CloudFunctionConfigurations) > 0 {
+		return nil, fmt.Errorf("the cloud function configurations are not supported")
+	}
+	if c.EventBridgeConfiguration != nil {
+		return nil, fmt.Errorf("the event bridge configuration is not supported")
+	}
+	ids := make(map[string]bool)
+	addRule := func(id, arn string, events []string, filter *Filter) error {
+		if id != "" {
+			if ids[id] {
+				return fmt.Errorf("duplicated configuration id %s", id)
+			}
+			ids[id] = true
+		}
+		rule, err := newRule(id, arn, events, filter)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+		return nil
+	}
+	for _, queue := range c.QueueConfigurations {
+		if err = addRule(queue.Id, queue.Queue, queue.Events, queue.Filter); err != nil {
+			return nil, err
+		}
+	}
+	for _, topic := range c.TopicConfigurations {
+		if err = addRule(topic.Id, topic.Topic, topic.Events, topic.Filter); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+func newRule(id, arn string, events []string, filter *Filter) (*Rule, error) {
+	target, err := parseTargetArn(arn)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no event of %s", arn)
+	}
+	for _, event := range events {
+		if !supportedEvents[event] {
+			return nil, fmt.Errorf("unsupported event %s", event)
+		}
+	}
+	rule := &Rule{
+		Id:     id,
+		Target: target,
+		Events: events,
+	}
+	if filter == nil {
+		return rule, nil
+	}
+	hasPrefix, hasSuffix := false, false
+	for _, filterRule := range filter.S3Key.FilterRules {
+		if len(filterRule.Value) > maxFilterValueLength {
+			return nil, fmt.Errorf("the filter rule value is longer than %d", maxFilterValueLength)
+		}
+		switch strings.ToLower(filterRule.Name) {
+		case "prefix":
+			if hasPrefix {
+				return nil, fmt.Errorf("more than one prefix filter rule")
+			}
+			hasPrefix, rule.Prefix = true, filterRule.Value
+		case "suffix":
+			if hasSuffix {
+				return nil, fmt.Errorf("more than one suffix filter rule")
+			}
+			hasSuffix, rule.Suffix = true, filterRule.Value
+		default:
+			return nil, fmt.Errorf("invalid filter rule name %q", filterRule.Name)
+		}
+	}
+	return rule, nil
+}
+
+// parseTargetArn returns the target named by the last field of the ARN
+func parseTargetArn(arn string) (string, error) {
+	fields := strings.Split(arn, ":")
+	if len(fields) != 6 || fields[0] != "arn" || (fields[2] != "sqs" && fields[2] != "sns") || fields[5] == "" {
+		return "", fmt.Errorf("invalid queue or topic arn %q", arn)
+	}
+	return fields[5], nil
+}
+
+// Matches tells whether the event of the object key is notified by the rule
+func (r *Rule) Matches(eventName, key string) bool {
+	if !strings.HasPrefix(key, r.Prefix) || !strings.HasSuffix(key, r.Suffix) {
+		return false
+	}
+	for _, event := range r.Events {
+		if event == eventName {
+			return true
+		}
+		if wildcard, found := strings.CutSuffix(event, "*"); found && strings.HasPrefix(eventName, wildcard) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package s3event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfiguration = `<NotificationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <QueueConfiguration>
+    <Id>images</Id>
+    <Queue>arn:aws:sqs:us-east-1:000000000000:orders</Queue>
+    <Event>s3:ObjectCreated:*</Event>
+    <Filter>
+      <S3Key>
+        <FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule>
+        <FilterRule><Name>Suffix</Name><Value>.jpg</Value></FilterRule>
+      </S3Key>
+    </Filter>
+  </QueueConfiguration>
+  <TopicConfiguration>
+    <Topic>arn:aws:sns:us-east-1:000000000000:audit</Topic>
+    <Event>s3:ObjectRemoved:Delete</Event>
+    <Event>s3:ObjectRemoved:DeleteMarkerCreated</Event>
+  </TopicConfiguration>
+</NotificationConfiguration>`
+
+func TestParseConfiguration(t *testing.T) {
+	config, err := ParseConfiguration([]byte(testConfiguration))
+	assert.Nil(t, err)
+	assert.False(t, config.IsEmpty())
+	config.SetDefaultIds()
+	assert.Equal(t, "images", config.QueueConfigurations[0].Id)
+	assert.NotEmpty(t, config.TopicConfigurations[0].Id)
+
+	rules, err := config.Rules()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, &Rule{Id: "images", Target: "orders", Events: []string{ObjectCreatedAll}, Prefix: "images/", Suffix: ".jpg"}, rules[0])
+	assert.Equal(t, "audit", rules[1].Target)
+
+	empty, err := ParseConfiguration([]byte(`<NotificationConfiguration/>`))
+	assert.Nil(t, err)
+	assert.True(t, empty.IsEmpty())
+
+	invalids := []string{
+		`<NotificationConfiguration><QueueConfiguration><Queue>orders</Queue><Event>s3:ObjectCreated:*</Event></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:aws:lambda:us-east-1:000000000000:orders</Queue><Event>s3:ObjectCreated:*</Event></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:aws:sqs:us-east-1:000000000000:orders</Queue></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:aws:sqs:us-east-1:000000000000:orders</Queue><Event>s3:ObjectRestore:*</Event></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:aws:sqs:us-east-1:000000000000:orders</Queue><Event>s3:ObjectCreated:*</Event>
+			<Filter><S3Key><FilterRule><Name>prefix</Name><Value>a</Value></FilterRule><FilterRule><Name>prefix</Name><Value>b</Value></FilterRule></S3Key></Filter></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><QueueConfiguration><Queue>arn:aws:sqs:us-east-1:000000000000:orders</Queue><Event>s3:ObjectCreated:*</Event>
+			<Filter><S3Key><FilterRule><Name>contains</Name><Value>a</Value></FilterRule></S3Key></Filter></QueueConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration>
+			<QueueConfiguration><Id>a</Id><Queue>arn:aws:sqs:us-east-1:000000000000:orders</Queue><Event>s3:ObjectCreated:*</Event></QueueConfiguration>
+			<TopicConfiguration><Id>a</Id><Topic>arn:aws:sns:us-east-1:000000000000:orders</Topic><Event>s3:ObjectCreated:*</Event></TopicConfiguration>
+		</NotificationConfiguration>`,
+		`<NotificationConfiguration><CloudFunctionConfiguration><CloudFunction>arn:aws:lambda:us-east-1:000000000000:function:f</CloudFunction></CloudFunctionConfiguration></NotificationConfiguration>`,
+		`<NotificationConfiguration><EventBridgeConfiguration/></NotificationConfiguration>`,
+	}
+	for _, invalid := range invalids {
+		_, err = ParseConfiguration([]byte(invalid))
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	rule := &Rule{Events: []string{ObjectCreatedAll, ObjectRemovedDelete}, Prefix: "images/", Suffix: ".jpg"}
+	assert.True(t, rule.Matches(ObjectCreatedPut, "images/a.jpg"))
+	assert.True(t, rule.Matches(ObjectCreatedCompleteMultipartUpload, "images/b/c.jpg"))
+	assert.True(t, rule.Matches(ObjectRemovedDelete, "images/a.jpg"))
+	assert.False(t, rule.Matches(ObjectRemovedDeleteMarkerCreated, "images/a.jpg"))
+	assert.False(t, rule.Matches(ObjectCreatedPut, "docs/a.jpg"))
+	assert.False(t, rule.Matches(ObjectCreatedPut, "images/a.png"))
+
+	all := &Rule{Events: []string{ObjectRemovedAll}}
+	assert.True(t, all.Matches(ObjectRemovedDeleteMarkerCreated, "a"))
+	assert.False(t, all.Matches(ObjectCreatedPut, "a"))
+}
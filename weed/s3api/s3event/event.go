@@ -0,0 +1,103 @@
+package s3event
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/notification-content-structure.html
+
+const (
+	eventVersion    = "2.1"
+	eventSource     = "aws:s3"
+	s3SchemaVersion = "1.0"
+	bucketArnPrefix = "arn:aws:s3:::"
+)
+
+// ObjectEvent is a change of an object, as seen by the filer
+type ObjectEvent struct {
+	// EventName is the notified event, e.g. s3:ObjectCreated:Put
+	EventName string
+	Bucket    string
+	Key       string
+	Size      uint64
+	ETag      string
+	VersionId string
+	// Owner is the account owning the object
+	Owner string
+	TsNs  int64
+}
+
+type Message struct {
+	Records []Record `json:"Records"`
+}
+
+type Record struct {
+	EventVersion string       `json:"eventVersion"`
+	EventSource  string       `json:"eventSource"`
+	EventTime    string       `json:"eventTime"`
+	EventName    string       `json:"eventName"`
+	UserIdentity Identity     `json:"userIdentity"`
+	S3           RecordS3Info `json:"s3"`
+}
+
+type Identity struct {
+	PrincipalId string `json:"principalId"`
+}
+
+type RecordS3Info struct {
+	SchemaVersion   string     `json:"s3SchemaVersion"`
+	ConfigurationId string     `json:"configurationId"`
+	Bucket          BucketInfo `json:"bucket"`
+	Object          ObjectInfo `json:"object"`
+}
+
+type BucketInfo struct {
+	Name          string   `json:"name"`
+	OwnerIdentity Identity `json:"ownerIdentity"`
+	Arn           string   `json:"arn"`
+}
+
+type ObjectInfo struct {
+	Key       string `json:"key"`
+	Size      uint64 `json:"size,omitempty"`
+	ETag      string `json:"eTag,omitempty"`
+	VersionId string `json:"versionId,omitempty"`
+	Sequencer string `json:"sequencer"`
+}
+
+// newMessage formats the event notified by the rule of the configuration id
+func newMessage(event *ObjectEvent, configurationId string) *Message {
+	return &Message{
+		Records: []Record{{
+			EventVersion: eventVersion,
+			EventSource:  eventSource,
+			EventTime:    time.Unix(0, event.TsNs).UTC().Format("2006-01-02T15:04:05.000Z"),
+			EventName:    strings.TrimPrefix(event.EventName, "s3:"),
+			UserIdentity: Identity{PrincipalId: event.Owner},
+			S3: RecordS3Info{
+				SchemaVersion:   s3SchemaVersion,
+				ConfigurationId: configurationId,
+				Bucket: BucketInfo{
+					Name:          event.Bucket,
+					OwnerIdentity: Identity{PrincipalId: event.Owner},
+					Arn:           bucketArnPrefix + event.Bucket,
+				},
+				Object: ObjectInfo{
+					Key:       encodeKey(event.Key),
+					Size:      event.Size,
+					ETag:      event.ETag,
+					VersionId: event.VersionId,
+					Sequencer: fmt.Sprintf("%016X", event.TsNs),
+				},
+			},
+		}},
+	}
+}
+
+// encodeKey url encodes the object key as AWS does, keeping the slashes
+func encodeKey(key string) string {
+	return strings.ReplaceAll(url.QueryEscape(key), "%2F", "/")
+}
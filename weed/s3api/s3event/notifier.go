@@ -0,0 +1,167 @@
+package s3event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/notification"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// the events waiting to be sent to a target, the later events are dropped when full
+const targetQueueSize = 4096
+
+// Notifier sends the object events to the targets named by the notification configurations of the buckets
+type Notifier struct {
+	targets map[string]*target
+	// loadConfiguration reads the notification configuration of the bucket, nil if there is none
+	loadConfiguration func(bucket string) ([]byte, error)
+
+	rulesLock     sync.RWMutex
+	rules         map[string][]*Rule
+	invalidations uint64
+}
+
+// target sends the messages in order, in the background
+type target struct {
+	name     string
+	queue    notification.MessageQueue
+	messages chan *targetMessage
+}
+
+type targetMessage struct {
+	key  string
+	data []byte
+}
+
+// LoadNotifier creates the enabled targets of the configuration section, e.g.
+//
+//	[s3_notification.orders]
+//	enabled = true
+//	type = "webhook"
+//	endpoint = "http://localhost:8080/events"
+//
+// and returns nil if there is none.
+func LoadNotifier(config *util.ViperProxy, section string, loadConfiguration func(bucket string) ([]byte, error)) (*Notifier, error) {
+	if config == nil {
+		return nil, nil
+	}
+	n := &Notifier{
+		targets:           make(map[string]*target),
+		loadConfiguration: loadConfiguration,
+		rules:             make(map[string][]*Rule),
+	}
+	for name := range config.GetStringMap(section) {
+		prefix := section + "." + name + "."
+		if !config.GetBool(prefix + "enabled") {
+			continue
+		}
+		queue, err := notification.NewMessageQueue(config.GetString(prefix+"type"), config, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("s3 notification target %s: %v", name, err)
+		}
+		n.addTarget(name, queue)
+		glog.V(0).Infof("Configure s3 notification target %s of type %s", name, queue.GetName())
+	}
+	if len(n.targets) == 0 {
+		return nil, nil
+	}
+	return n, nil
+}
+
+// addTarget starts sending to the queue, the targets are named in lower case as in the configuration files
+func (n *Notifier) addTarget(name string, queue notification.MessageQueue) {
+	t := &target{
+		name:     strings.ToLower(name),
+		queue:    queue,
+		messages: make(chan *targetMessage, targetQueueSize),
+	}
+	n.targets[t.name] = t
+	go t.loop()
+}
+
+func (t *target) loop() {
+	for message := range t.messages {
+		if err := t.queue.SendRawMessage(message.key, message.data); err != nil {
+			glog.Errorf("s3 notification target %s: %v", t.name, err)
+		}
+	}
+}
+
+func (t *target) send(key string, data []byte) {
+	select {
+	case t.messages <- &targetMessage{key: key, data: data}:
+	default:
+		glog.Warningf("s3 notification target %s is full, drop the event of %s", t.name, key)
+	}
+}
+
+// Notify sends the event to the targets of the matching rules of the bucket
+func (n *Notifier) Notify(event *ObjectEvent) {
+	rules, err := n.bucketRules(event.Bucket)
+	if err != nil {
+		glog.Warningf("s3 notification configuration of bucket %s: %v", event.Bucket, err)
+		return
+	}
+	key := event.Bucket + "/" + event.Key
+	for _, rule := range rules {
+		if !rule.Matches(event.EventName, event.Key) {
+			continue
+		}
+		t, found := n.targets[strings.ToLower(rule.Target)]
+		if !found {
+			glog.V(1).Infof("s3 notification %s of bucket %s: unknown target %s", rule.Id, event.Bucket, rule.Target)
+			continue
+		}
+		data, err := json.Marshal(newMessage(event, rule.Id))
+		if err != nil {
+			glog.Errorf("s3 notification of %s: %v", key, err)
+			continue
+		}
+		glog.V(3).Infof("s3 notification %s of %s to %s", event.EventName, key, t.name)
+		t.send(key, data)
+	}
+}
+
+// Invalidate forgets the cached notification configuration of the bucket, after the bucket is changed
+func (n *Notifier) Invalidate(bucket string) {
+	n.rulesLock.Lock()
+	defer n.rulesLock.Unlock()
+	delete(n.rules, bucket)
+	n.invalidations++
+}
+
+func (n *Notifier) bucketRules(bucket string) ([]*Rule, error) {
+	n.rulesLock.RLock()
+	rules, found := n.rules[bucket]
+	invalidations := n.invalidations
+	n.rulesLock.RUnlock()
+	if found {
+		return rules, nil
+	}
+
+	data, err := n.loadConfiguration(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		config, err := ParseConfiguration(data)
+		if err != nil {
+			return nil, err
+		}
+		if rules, err = config.Rules(); err != nil {
+			return nil, err
+		}
+	}
+
+	n.rulesLock.Lock()
+	defer n.rulesLock.Unlock()
+	// keep the configuration unless it may have changed while loading
+	if n.invalidations == invalidations {
+		n.rules[bucket] = rules
+	}
+	return rules, nil
+}
@@ -0,0 +1,101 @@
+package s3event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type testQueue struct {
+	keys     chan string
+	messages chan []byte
+}
+
+func (q *testQueue) GetName() string {
+	return "test"
+}
+
+func (q *testQueue) Initialize(configuration util.Configuration, prefix string) error {
+	return nil
+}
+
+func (q *testQueue) SendMessage(key string, message proto.Message) error {
+	return nil
+}
+
+func (q *testQueue) SendRawMessage(key string, message []byte) error {
+	q.keys <- key
+	q.messages <- message
+	return nil
+}
+
+func (q *testQueue) receive(t *testing.T) (key string, message *Message) {
+	select {
+	case key = <-q.keys:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message")
+	}
+	message = &Message{}
+	assert.Nil(t, json.Unmarshal(<-q.messages, message))
+	return key, message
+}
+
+func (q *testQueue) assertEmpty(t *testing.T) {
+	select {
+	case key := <-q.keys:
+		t.Errorf("unexpected message of %s", key)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	configurations := map[string][]byte{"photos": []byte(testConfiguration)}
+	loads := 0
+	n := &Notifier{
+		targets: make(map[string]*target),
+		loadConfiguration: func(bucket string) ([]byte, error) {
+			loads++
+			return configurations[bucket], nil
+		},
+		rules: make(map[string][]*Rule),
+	}
+	orders := &testQueue{keys: make(chan string, 10), messages: make(chan []byte, 10)}
+	n.addTarget("Orders", orders)
+
+	tsNs := time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC).UnixNano()
+	n.Notify(&ObjectEvent{EventName: ObjectCreatedPut, Bucket: "photos", Key: "images/summer trip/a.jpg", Size: 1024, ETag: "d41d8cd98f00b204e9800998ecf8427e", Owner: "admin", TsNs: tsNs})
+	key, message := orders.receive(t)
+	assert.Equal(t, "photos/images/summer trip/a.jpg", key)
+	assert.Equal(t, 1, len(message.Records))
+	record := message.Records[0]
+	assert.Equal(t, "2.1", record.EventVersion)
+	assert.Equal(t, "aws:s3", record.EventSource)
+	assert.Equal(t, "2024-05-01T10:00:00.123Z", record.EventTime)
+	assert.Equal(t, "ObjectCreated:Put", record.EventName)
+	assert.Equal(t, "admin", record.UserIdentity.PrincipalId)
+	assert.Equal(t, "images", record.S3.ConfigurationId)
+	assert.Equal(t, "photos", record.S3.Bucket.Name)
+	assert.Equal(t, "arn:aws:s3:::photos", record.S3.Bucket.Arn)
+	assert.Equal(t, "images/summer+trip/a.jpg", record.S3.Object.Key)
+	assert.Equal(t, uint64(1024), record.S3.Object.Size)
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", record.S3.Object.ETag)
+	assert.Equal(t, 16, len(record.S3.Object.Sequencer))
+
+	// filtered out, the audit target is not configured, and no configuration
+	n.Notify(&ObjectEvent{EventName: ObjectCreatedPut, Bucket: "photos", Key: "images/a.png", TsNs: tsNs})
+	n.Notify(&ObjectEvent{EventName: ObjectRemovedDelete, Bucket: "photos", Key: "images/a.jpg", TsNs: tsNs})
+	n.Notify(&ObjectEvent{EventName: ObjectCreatedPut, Bucket: "other", Key: "images/a.jpg", TsNs: tsNs})
+	orders.assertEmpty(t)
+	assert.Equal(t, 2, loads, "the configurations are cached")
+
+	// the changed configuration is loaded again
+	delete(configurations, "photos")
+	n.Invalidate("photos")
+	n.Notify(&ObjectEvent{EventName: ObjectCreatedPut, Bucket: "photos", Key: "images/a.jpg", TsNs: tsNs})
+	orders.assertEmpty(t)
+	assert.Equal(t, 3, loads)
+}
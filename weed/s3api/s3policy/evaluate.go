@@ -0,0 +1,222 @@
+package s3policy
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+type Decision int
+
+const (
+	// NotApplicable means no statement matched the request, so the decision is left to other access checks
+	NotApplicable Decision = iota
+	Allow
+	Deny
+)
+
+// Request describes the access to be checked against a policy
+type Request struct {
+	// the identifiers of the requester, e.g. the identity name, the account id and their arns.
+	// Anonymous requests have no principals.
+	Principals []string
+	// the S3 action, e.g. s3:GetObject
+	Action string
+	// the S3 resource arn, e.g. arn:aws:s3:::bucket/key
+	Resource string
+	// the condition keys and their values, e.g. aws:SourceIp
+	Conditions map[string][]string
+}
+
+// Evaluate returns Deny if any matching statement denies the request,
+// Allow if some matching statement allows it, and NotApplicable otherwise.
+func (p *PolicyDocument) Evaluate(req *Request) Decision {
+	decision := NotApplicable
+	for i := range p.Statement {
+		statement := &p.Statement[i]
+		if !statement.matches(req) {
+			continue
+		}
+		if statement.Effect == EffectDeny {
+			return Deny
+		}
+		decision = Allow
+	}
+	return decision
+}
+
+func (s *Statement) matches(req *Request) bool {
+	if len(s.Principal) > 0 && !matchPrincipal(s.Principal, req.Principals) {
+		return false
+	}
+	if len(s.NotPrincipal) > 0 && matchPrincipal(s.NotPrincipal, req.Principals) {
+		return false
+	}
+	if len(s.Action) > 0 && !matchAny(s.Action, req.Action, true) {
+		return false
+	}
+	if len(s.NotAction) > 0 && matchAny(s.NotAction, req.Action, true) {
+		return false
+	}
+	if len(s.Resource) > 0 && !matchAny(s.Resource, req.Resource, false) {
+		return false
+	}
+	if len(s.NotResource) > 0 && matchAny(s.NotResource, req.Resource, false) {
+		return false
+	}
+	return s.Condition.matches(req.Conditions)
+}
+
+func matchPrincipal(principal Principal, principals []string) bool {
+	for _, expected := range principal["AWS"] {
+		if expected == "*" {
+			return true
+		}
+		for _, p := range principals {
+			if expected == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, value string, ignoreCase bool) bool {
+	if ignoreCase {
+		value = strings.ToLower(value)
+	}
+	for _, pattern := range patterns {
+		if ignoreCase {
+			pattern = strings.ToLower(pattern)
+		}
+		if matchWildcard(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard matches the value against a pattern where '*' matches any sequence of characters,
+// including '/', and '?' matches any single character
+func matchWildcard(pattern, value string) bool {
+	p, v := 0, 0
+	starP, starV := -1, 0
+	for v < len(value) {
+		if p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]) {
+			p++
+			v++
+		} else if p < len(pattern) && pattern[p] == '*' {
+			starP, starV = p, v
+			p++
+		} else if starP >= 0 {
+			starV++
+			p, v = starP+1, starV
+		} else {
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// matches returns true if every condition is satisfied. Condition keys are case-insensitive.
+func (c Condition) matches(values map[string][]string) bool {
+	for operator, conditions := range c {
+		ifExists := strings.HasSuffix(operator, "IfExists")
+		evaluate, found := conditionOperators[strings.TrimSuffix(operator, "IfExists")]
+		if !found {
+			return false
+		}
+		for key, expected := range conditions {
+			actual, exists := values[strings.ToLower(key)]
+			if !exists && ifExists {
+				continue
+			}
+			if !evaluate(expected, actual, exists) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type conditionOperator func(expected []string, actual []string, exists bool) bool
+
+var conditionOperators = map[string]conditionOperator{
+	"StringEquals":              anyValue(func(e, a string) bool { return e == a }),
+	"StringNotEquals":           noValue(func(e, a string) bool { return e == a }),
+	"StringEqualsIgnoreCase":    anyValue(strings.EqualFold),
+	"StringNotEqualsIgnoreCase": noValue(strings.EqualFold),
+	"StringLike":                anyValue(matchWildcard),
+	"StringNotLike":             noValue(matchWildcard),
+	"IpAddress":                 anyValue(matchIpAddress),
+	"NotIpAddress":              noValue(matchIpAddress),
+	"Bool":                      anyValue(strings.EqualFold),
+	"NumericEquals":             anyValue(compareNumeric(func(e, a float64) bool { return a == e })),
+	"NumericNotEquals":          noValue(compareNumeric(func(e, a float64) bool { return a == e })),
+	"NumericLessThan":           anyValue(compareNumeric(func(e, a float64) bool { return a < e })),
+	"NumericLessThanEquals":     anyValue(compareNumeric(func(e, a float64) bool { return a <= e })),
+	"NumericGreaterThan":        anyValue(compareNumeric(func(e, a float64) bool { return a > e })),
+	"NumericGreaterThanEquals":  anyValue(compareNumeric(func(e, a float64) bool { return a >= e })),
+	"Null": func(expected []string, actual []string, exists bool) bool {
+		for _, e := range expected {
+			if strings.EqualFold(e, "true") == !exists {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// anyValue is satisfied if any actual value matches any expected value
+func anyValue(match func(expected, actual string) bool) conditionOperator {
+	return func(expected []string, actual []string, exists bool) bool {
+		for _, a := range actual {
+			for _, e := range expected {
+				if match(e, a) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// noValue is satisfied if no actual value matches any expected value
+func noValue(match func(expected, actual string) bool) conditionOperator {
+	positive := anyValue(match)
+	return func(expected []string, actual []string, exists bool) bool {
+		return !positive(expected, actual, exists)
+	}
+}
+
+func matchIpAddress(expected, actual string) bool {
+	ip := net.ParseIP(actual)
+	if ip == nil {
+		return false
+	}
+	if !strings.Contains(expected, "/") {
+		return ip.Equal(net.ParseIP(expected))
+	}
+	_, ipNet, err := net.ParseCIDR(expected)
+	if err != nil {
+		return false
+	}
+	return ipNet.Contains(ip)
+}
+
+func compareNumeric(compare func(expected, actual float64) bool) func(expected, actual string) bool {
+	return func(expected, actual string) bool {
+		e, err := strconv.ParseFloat(expected, 64)
+		if err != nil {
+			return false
+		}
+		a, err := strconv.ParseFloat(actual, 64)
+		if err != nil {
+			return false
+		}
+		return compare(e, a)
+	}
+}
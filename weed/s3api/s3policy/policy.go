@@ -0,0 +1,235 @@
+package s3policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements.html
+
+const (
+	DefaultVersion = "2012-10-17"
+
+	EffectAllow = "Allow"
+	EffectDeny  = "Deny"
+
+	ResourceArnPrefix = "arn:aws:s3:::"
+
+	// the maximum size of a bucket policy
+	MaxPolicySize = 20 * 1024
+)
+
+// StringOrSlice accepts a single string or a list of strings in the policy json
+type StringOrSlice []string
+
+func (s *StringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []string{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expecting a string or a list of strings: %v", err)
+	}
+	*s = list
+	return nil
+}
+
+func (s StringOrSlice) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+// Principal is either "*" or a map such as {"AWS": ["arn:aws:iam::123456789012:root"]}
+type Principal map[string]StringOrSlice
+
+func (p *Principal) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single != "*" {
+			return fmt.Errorf("invalid principal %q", single)
+		}
+		*p = Principal{"AWS": {"*"}}
+		return nil
+	}
+	var m map[string]StringOrSlice
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("invalid principal: %v", err)
+	}
+	*p = m
+	return nil
+}
+
+// Condition maps a condition operator to the condition keys and their expected values, e.g.
+// {"IpAddress": {"aws:SourceIp": ["192.168.0.0/16"]}}
+type Condition map[string]map[string]StringOrSlice
+
+type Statement struct {
+	Sid          string        `json:"Sid,omitempty"`
+	Effect       string        `json:"Effect"`
+	Principal    Principal     `json:"Principal,omitempty"`
+	NotPrincipal Principal     `json:"NotPrincipal,omitempty"`
+	Action       StringOrSlice `json:"Action,omitempty"`
+	NotAction    StringOrSlice `json:"NotAction,omitempty"`
+	Resource     StringOrSlice `json:"Resource,omitempty"`
+	NotResource  StringOrSlice `json:"NotResource,omitempty"`
+	Condition    Condition     `json:"Condition,omitempty"`
+}
+
+type PolicyDocument struct {
+	Version   string      `json:"Version"`
+	Id        string      `json:"Id,omitempty"`
+	Statement []Statement `json:"Statement"`
+}
+
+// ParseBucketPolicy parses the policy json and validates it against the bucket
+func ParseBucketPolicy(data []byte, bucket string) (*PolicyDocument, error) {
+	if len(data) > MaxPolicySize {
+		return nil, fmt.Errorf("policy exceeds the maximum allowed size of %d bytes", MaxPolicySize)
+	}
+	policy := &PolicyDocument{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("parse policy: %v", err)
+	}
+	if err := policy.validate(bucket, true); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ParseIdentityPolicy parses the json of a policy attached to identities, whose statements have no principal
+func ParseIdentityPolicy(data []byte) (*PolicyDocument, error) {
+	if len(data) > MaxPolicySize {
+		return nil, fmt.Errorf("policy exceeds the maximum allowed size of %d bytes", MaxPolicySize)
+	}
+	policy := &PolicyDocument{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("parse policy: %v", err)
+	}
+	if err := policy.validate("", false); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// validate checks the policy of the bucket, or any bucket if empty.
+// The statements of resource-based policies name their principals, the identity-based ones must not.
+func (p *PolicyDocument) validate(bucket string, resourceBased bool) error {
+	if p.Version != DefaultVersion && p.Version != "2008-10-17" {
+		return fmt.Errorf("invalid policy version %q", p.Version)
+	}
+	if len(p.Statement) == 0 {
+		return fmt.Errorf("policy has no statement")
+	}
+	for i, statement := range p.Statement {
+		if statement.Effect != EffectAllow && statement.Effect != EffectDeny {
+			return fmt.Errorf("statement %d: invalid effect %q", i, statement.Effect)
+		}
+		hasPrincipal := len(statement.Principal) > 0 || len(statement.NotPrincipal) > 0
+		if resourceBased && !hasPrincipal {
+			return fmt.Errorf("statement %d: missing principal", i)
+		}
+		if !resourceBased && hasPrincipal {
+			return fmt.Errorf("statement %d: principal is not allowed in identity policies", i)
+		}
+		if len(statement.Action) == 0 && len(statement.NotAction) == 0 {
+			return fmt.Errorf("statement %d: missing action", i)
+		}
+		for _, action := range append(statement.Action, statement.NotAction...) {
+			if action != "*" && !strings.HasPrefix(action, "s3:") {
+				return fmt.Errorf("statement %d: invalid action %q", i, action)
+			}
+		}
+		if len(statement.Resource) == 0 && len(statement.NotResource) == 0 {
+			return fmt.Errorf("statement %d: missing resource", i)
+		}
+		for _, resource := range append(statement.Resource, statement.NotResource...) {
+			if resource == "*" && !resourceBased {
+				continue
+			}
+			if !strings.HasPrefix(resource, ResourceArnPrefix) {
+				return fmt.Errorf("statement %d: invalid resource %q", i, resource)
+			}
+			resourceBucket, _, _ := strings.Cut(strings.TrimPrefix(resource, ResourceArnPrefix), "/")
+			if bucket != "" && !matchWildcard(resourceBucket, bucket) {
+				return fmt.Errorf("statement %d: resource %q does not belong to bucket %s", i, resource, bucket)
+			}
+		}
+		for operator, conditions := range statement.Condition {
+			if _, found := conditionOperators[strings.TrimSuffix(operator, "IfExists")]; !found {
+				return fmt.Errorf("statement %d: unsupported condition operator %q", i, operator)
+			}
+			if len(conditions) == 0 {
+				return fmt.Errorf("statement %d: empty condition %q", i, operator)
+			}
+		}
+	}
+	return nil
+}
+
+// restrictingConditionKeys limit a statement to known requesters, so that it is not public
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/access-control-block-public-access.html#access-control-block-public-access-policy-status
+var restrictingConditionKeys = map[string]bool{
+	"aws:sourceip":         true,
+	"aws:sourcevpc":        true,
+	"aws:sourcevpce":       true,
+	"aws:sourcearn":        true,
+	"aws:sourceaccount":    true,
+	"aws:principalaccount": true,
+	"aws:principalarn":     true,
+	"aws:principalorgid":   true,
+	"aws:userid":           true,
+	"aws:username":         true,
+}
+
+// IsPublic tells whether the policy allows any requester, including anonymous ones,
+// without restricting them with a fixed condition
+func (p *PolicyDocument) IsPublic() bool {
+	for _, statement := range p.Statement {
+		if statement.Effect != EffectAllow {
+			continue
+		}
+		wildcard := len(statement.NotPrincipal) > 0
+		for _, principal := range statement.Principal {
+			for _, value := range principal {
+				wildcard = wildcard || value == "*"
+			}
+		}
+		if wildcard && !statement.Condition.restrictsRequester() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Condition) restrictsRequester() bool {
+	for operator, conditions := range c {
+		if strings.Contains(operator, "Not") || strings.HasSuffix(operator, "IfExists") {
+			continue
+		}
+		for key, values := range conditions {
+			if !restrictingConditionKeys[strings.ToLower(key)] || len(values) == 0 {
+				continue
+			}
+			restricted := true
+			for _, value := range values {
+				if strings.Contains(value, "*") || value == "0.0.0.0/0" || value == "::/0" {
+					restricted = false
+				}
+			}
+			if restricted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *PolicyDocument) String() string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
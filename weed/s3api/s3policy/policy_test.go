@@ -0,0 +1,211 @@
+package s3policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const publicReadPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "PublicRead",
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": ["s3:GetObject"],
+      "Resource": "arn:aws:s3:::bucket1/public/*",
+      "Condition": {
+        "IpAddress": {"aws:SourceIp": "192.168.0.0/16"}
+      }
+    },
+    {
+      "Effect": "Deny",
+      "Principal": {"AWS": "*"},
+      "Action": "s3:*",
+      "Resource": ["arn:aws:s3:::bucket1", "arn:aws:s3:::bucket1/*"],
+      "Condition": {
+        "Bool": {"aws:SecureTransport": "false"},
+        "StringLike": {"aws:UserAgent": "*curl*"}
+      }
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["arn:aws:iam::123456789012:root"]},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::bucket1/uploads/*"
+    }
+  ]
+}`
+
+func TestParseBucketPolicy(t *testing.T) {
+	policy, err := ParseBucketPolicy([]byte(publicReadPolicy), "bucket1")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(policy.Statement))
+	assert.Equal(t, StringOrSlice{"*"}, policy.Statement[0].Principal["AWS"])
+
+	_, err = ParseBucketPolicy([]byte(publicReadPolicy), "bucket2")
+	assert.NotNil(t, err, "resources must belong to the bucket")
+
+	invalidPolicies := []string{
+		`{"Version": "2012-10-17", "Statement": []}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Maybe", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "iam:GetUser", "Resource": "arn:aws:s3:::bucket1/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "bucket1/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*", "Condition": {"Unknown": {"a": "b"}}}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "someone", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`,
+		`not json`,
+	}
+	for _, invalidPolicy := range invalidPolicies {
+		_, err = ParseBucketPolicy([]byte(invalidPolicy), "bucket1")
+		assert.NotNil(t, err, invalidPolicy)
+	}
+}
+
+func TestParseIdentityPolicy(t *testing.T) {
+	policy, err := ParseIdentityPolicy([]byte(`{
+	  "Version": "2012-10-17",
+	  "Statement": [
+	    {"Effect": "Allow", "Action": "s3:*", "Resource": "*"},
+	    {"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::archive/*"}
+	  ]
+	}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(policy.Statement))
+
+	req := &Request{Action: "s3:DeleteObject", Resource: "arn:aws:s3:::archive/2024/a.txt"}
+	assert.Equal(t, Deny, policy.Evaluate(req))
+	req.Resource = "arn:aws:s3:::scratch/a.txt"
+	assert.Equal(t, Allow, policy.Evaluate(req))
+
+	invalidPolicies := []string{
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "bucket1/*"}]}`,
+	}
+	for _, invalidPolicy := range invalidPolicies {
+		_, err = ParseIdentityPolicy([]byte(invalidPolicy))
+		assert.NotNil(t, err, invalidPolicy)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policy, err := ParseBucketPolicy([]byte(publicReadPolicy), "bucket1")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		req      *Request
+		expected Decision
+	}{
+		{
+			name: "anonymous read from allowed network",
+			req: &Request{
+				Action:     "s3:GetObject",
+				Resource:   "arn:aws:s3:::bucket1/public/a/b.txt",
+				Conditions: map[string][]string{"aws:sourceip": {"192.168.1.2"}, "aws:securetransport": {"true"}},
+			},
+			expected: Allow,
+		},
+		{
+			name: "anonymous read from other network",
+			req: &Request{
+				Action:     "s3:GetObject",
+				Resource:   "arn:aws:s3:::bucket1/public/a/b.txt",
+				Conditions: map[string][]string{"aws:sourceip": {"10.0.0.1"}, "aws:securetransport": {"true"}},
+			},
+			expected: NotApplicable,
+		},
+		{
+			name: "action is case insensitive",
+			req: &Request{
+				Action:     "s3:getobject",
+				Resource:   "arn:aws:s3:::bucket1/public/a",
+				Conditions: map[string][]string{"aws:sourceip": {"192.168.1.2"}},
+			},
+			expected: Allow,
+		},
+		{
+			name: "insecure curl is denied",
+			req: &Request{
+				Action:     "s3:GetObject",
+				Resource:   "arn:aws:s3:::bucket1/public/a",
+				Conditions: map[string][]string{"aws:sourceip": {"192.168.1.2"}, "aws:securetransport": {"false"}, "aws:useragent": {"curl/8.0"}},
+			},
+			expected: Deny,
+		},
+		{
+			name: "account principal",
+			req: &Request{
+				Principals: []string{"user1", "arn:aws:iam::123456789012:root"},
+				Action:     "s3:PutObject",
+				Resource:   "arn:aws:s3:::bucket1/uploads/x",
+			},
+			expected: Allow,
+		},
+		{
+			name: "account principal on other resource",
+			req: &Request{
+				Principals: []string{"arn:aws:iam::123456789012:root"},
+				Action:     "s3:PutObject",
+				Resource:   "arn:aws:s3:::bucket1/other/x",
+			},
+			expected: NotApplicable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Evaluate(tt.req))
+		})
+	}
+}
+
+func TestConditionOperators(t *testing.T) {
+	values := map[string][]string{"s3:prefix": {"home/user1/"}, "s3:max-keys": {"10"}}
+
+	assert.True(t, Condition{"StringNotEquals": {"s3:prefix": {"home/"}}}.matches(values))
+	assert.True(t, Condition{"StringNotEquals": {"s3:delimiter": {"/"}}}.matches(values))
+	assert.False(t, Condition{"StringEquals": {"s3:delimiter": {"/"}}}.matches(values))
+	assert.True(t, Condition{"StringEqualsIfExists": {"s3:delimiter": {"/"}}}.matches(values))
+	assert.True(t, Condition{"NumericLessThanEquals": {"s3:max-keys": {"10"}}}.matches(values))
+	assert.False(t, Condition{"NumericGreaterThan": {"s3:max-keys": {"10"}}}.matches(values))
+	assert.True(t, Condition{"Null": {"s3:delimiter": {"true"}}}.matches(values))
+	assert.False(t, Condition{"Null": {"s3:prefix": {"true"}}}.matches(values))
+	assert.True(t, Condition{"NotIpAddress": {"aws:SourceIp": {"10.0.0.0/8"}}}.matches(map[string][]string{"aws:sourceip": {"192.168.1.1"}}))
+	assert.True(t, Condition{"IpAddress": {"aws:SourceIp": {"192.168.1.1"}}}.matches(map[string][]string{"aws:sourceip": {"192.168.1.1"}}))
+}
+
+func TestMatchWildcard(t *testing.T) {
+	assert.True(t, matchWildcard("*", ""))
+	assert.True(t, matchWildcard("arn:aws:s3:::bucket/*", "arn:aws:s3:::bucket/a/b/c"))
+	assert.True(t, matchWildcard("arn:aws:s3:::bucket/a?c", "arn:aws:s3:::bucket/abc"))
+	assert.True(t, matchWildcard("a*b*c", "aXXbYYc"))
+	assert.False(t, matchWildcard("arn:aws:s3:::bucket/*", "arn:aws:s3:::bucket"))
+	assert.False(t, matchWildcard("a*b", "acbd"))
+}
+
+func TestIsPublic(t *testing.T) {
+	policy, err := ParseBucketPolicy([]byte(publicReadPolicy), "bucket1")
+	assert.Nil(t, err)
+	assert.False(t, policy.IsPublic(), "restricted to a source network")
+
+	tests := []struct {
+		policy   string
+		expected bool
+	}{
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": {"AWS": "*"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*", "Condition": {"IpAddress": {"aws:SourceIp": "0.0.0.0/0"}}}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*", "Condition": {"StringEquals": {"aws:UserAgent": "app"}}}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*", "Condition": {"StringNotEquals": {"aws:PrincipalAccount": "123456789012"}}}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*", "Condition": {"StringEquals": {"aws:PrincipalAccount": "123456789012"}}}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket1/*"}]}`, false},
+	}
+	for _, tt := range tests {
+		policy, err := ParseBucketPolicy([]byte(tt.policy), "bucket1")
+		if assert.Nil(t, err, tt.policy) {
+			assert.Equal(t, tt.expected, policy.IsPublic(), tt.policy)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package s3presign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy"
+)
+
+// The presigned urls and post forms let a client without credentials access one object
+// on behalf of an identity, until they expire.
+
+const (
+	// ContentLengthRangeParam restricts the size of the content uploaded with a presigned url, as "min,max".
+	// It does not start with X-Amz, so it is covered by the signature like the other query parameters.
+	ContentLengthRangeParam = "X-Seaweedfs-Content-Length-Range"
+
+	DefaultExpires = time.Hour
+	MaxExpires     = 7 * 24 * time.Hour
+
+	// the gateway accepts the signatures of any region
+	region        = "us-east-1"
+	iso8601Format = "20060102T150405Z"
+	yyyymmdd      = "20060102"
+)
+
+// Request is what a presigned url or post form allows
+type Request struct {
+	// Endpoint is the scheme and host of the s3 gateway, e.g. https://s3.example.com
+	Endpoint string
+	// Method is GET, HEAD, PUT or DELETE for a presigned url, and POST for a post form
+	Method string
+	Bucket string
+	Key    string
+	// KeyPrefix lets a post form upload any key starting with it, instead of Key
+	KeyPrefix string
+	Expires   time.Duration
+	// ContentType is the content type of the uploads. For post forms, a content type ending with "*"
+	// is a prefix, e.g. "image/*".
+	ContentType        string
+	ContentLengthRange *ContentLengthRange
+}
+
+// Presigned is a presigned url, or a post form with its fields
+type Presigned struct {
+	Method string `json:"method"`
+	Url    string `json:"url"`
+	// Headers are to be sent along with the presigned url
+	Headers map[string]string `json:"headers,omitempty"`
+	// Fields are to be posted along with the file
+	Fields     map[string]string `json:"fields,omitempty"`
+	Expiration time.Time         `json:"expiration"`
+}
+
+type ContentLengthRange struct {
+	Min int64
+	Max int64
+}
+
+// ParseContentLengthRange parses "min,max"
+func ParseContentLengthRange(s string) (*ContentLengthRange, error) {
+	minText, maxText, found := strings.Cut(s, ",")
+	if !found {
+		return nil, fmt.Errorf("content length range %q is not min,max", s)
+	}
+	min, err := strconv.ParseInt(strings.TrimSpace(minText), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("content length range %q: %v", s, err)
+	}
+	max, err := strconv.ParseInt(strings.TrimSpace(maxText), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("content length range %q: %v", s, err)
+	}
+	if min < 0 || max < min {
+		return nil, fmt.Errorf("content length range %q is invalid", s)
+	}
+	return &ContentLengthRange{Min: min, Max: max}, nil
+}
+
+func (r *ContentLengthRange) String() string {
+	return fmt.Sprintf("%d,%d", r.Min, r.Max)
+}
+
+// Validate checks the request is complete and consistent
+func (req *Request) Validate() error {
+	if req.Bucket == "" {
+		return fmt.Errorf("bucket is required")
+	}
+	if req.Expires <= 0 || req.Expires > MaxExpires {
+		return fmt.Errorf("expiration %v is not between 1 second and %v", req.Expires, MaxExpires)
+	}
+	if _, err := url.Parse(req.Endpoint); err != nil || !strings.HasPrefix(req.Endpoint, "http") {
+		return fmt.Errorf("invalid endpoint %q", req.Endpoint)
+	}
+	switch req.Method {
+	case http.MethodPost:
+		if (req.Key == "") == (req.KeyPrefix == "") {
+			return fmt.Errorf("either key or key prefix is required")
+		}
+		return nil
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		if req.ContentType != "" || req.ContentLengthRange != nil {
+			return fmt.Errorf("content type and content length range only restrict uploads")
+		}
+	case http.MethodPut:
+		if strings.HasSuffix(req.ContentType, "*") {
+			return fmt.Errorf("content type prefixes are only supported by post forms")
+		}
+	default:
+		return fmt.Errorf("unsupported method %q", req.Method)
+	}
+	if req.Key == "" || req.KeyPrefix != "" {
+		return fmt.Errorf("a presigned url is for one key")
+	}
+	return nil
+}
+
+// Presign signs the request with the credential of an identity,
+// and the session token if the credential is temporary
+func Presign(req *Request, accessKey, secretKey, sessionToken string, now time.Time) (*Presigned, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	// the signatures are dated to the second
+	now = now.UTC().Truncate(time.Second)
+	if req.Method == http.MethodPost {
+		return presignPost(req, accessKey, secretKey, sessionToken, now)
+	}
+	return presignUrl(req, accessKey, secretKey, sessionToken, now)
+}
+
+func presignUrl(req *Request, accessKey, secretKey, sessionToken string, now time.Time) (*Presigned, error) {
+	u, err := url.Parse(strings.TrimSuffix(req.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/" + req.Bucket + "/" + req.Key
+	// the gateway verifies the path escaped the s3 way, not the url way
+	u.RawPath = escapePath(u.Path)
+	if req.ContentLengthRange != nil {
+		u.RawQuery = url.Values{ContentLengthRangeParam: {req.ContentLengthRange.String()}}.Encode()
+	}
+
+	httpReq, err := http.NewRequest(req.Method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	presigned := &Presigned{
+		Method:     req.Method,
+		Expiration: now.Add(req.Expires),
+	}
+	if req.ContentType != "" {
+		httpReq.Header.Set("Content-Type", req.ContentType)
+		presigned.Headers = map[string]string{"Content-Type": req.ContentType}
+	}
+
+	signer := v4.NewSigner(credentials.NewStaticCredentials(accessKey, secretKey, sessionToken))
+	signer.DisableURIPathEscaping = true
+	if _, err = signer.Presign(httpReq, nil, "s3", region, req.Expires, now); err != nil {
+		return nil, err
+	}
+	presigned.Url = httpReq.URL.String()
+	return presigned, nil
+}
+
+func presignPost(req *Request, accessKey, secretKey, sessionToken string, now time.Time) (*Presigned, error) {
+	credential := fmt.Sprintf("%s/%s/%s/s3/aws4_request", accessKey, now.Format(yyyymmdd), region)
+
+	p := policy.NewPostPolicy()
+	if err := p.SetExpires(now.Add(req.Expires)); err != nil {
+		return nil, err
+	}
+	if err := p.SetBucket(req.Bucket); err != nil {
+		return nil, err
+	}
+	if req.Key != "" {
+		if err := p.SetKey(req.Key); err != nil {
+			return nil, err
+		}
+	} else if err := p.SetKeyStartsWith(req.KeyPrefix); err != nil {
+		return nil, err
+	}
+	if contentTypePrefix, found := strings.CutSuffix(req.ContentType, "*"); found {
+		if err := p.SetContentTypeStartsWith(contentTypePrefix); err != nil {
+			return nil, err
+		}
+	} else if req.ContentType != "" {
+		if err := p.SetContentType(req.ContentType); err != nil {
+			return nil, err
+		}
+	}
+	if req.ContentLengthRange != nil {
+		if err := p.SetContentLengthRange(req.ContentLengthRange.Min, req.ContentLengthRange.Max); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.SetCondition("eq", "X-Amz-Algorithm", "AWS4-HMAC-SHA256"); err != nil {
+		return nil, err
+	}
+	if err := p.SetCondition("eq", "X-Amz-Credential", credential); err != nil {
+		return nil, err
+	}
+	if err := p.SetCondition("eq", "X-Amz-Date", now.Format(iso8601Format)); err != nil {
+		return nil, err
+	}
+	if sessionToken != "" {
+		if err := p.SetCondition("eq", "X-Amz-Security-Token", sessionToken); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := p.FormData()
+	if req.KeyPrefix != "" {
+		// the gateway replaces ${filename} with the name of the posted file
+		fields["key"] = req.KeyPrefix + "${filename}"
+	}
+	encodedPolicy := p.Base64()
+	fields["Policy"] = encodedPolicy
+	fields["X-Amz-Signature"] = hex.EncodeToString(sumHMAC(signingKey(secretKey, now), []byte(encodedPolicy)))
+
+	return &Presigned{
+		Method:     http.MethodPost,
+		Url:        strings.TrimSuffix(req.Endpoint, "/") + "/" + req.Bucket,
+		Fields:     fields,
+		Expiration: now.Add(req.Expires),
+	}, nil
+}
+
+func signingKey(secretKey string, t time.Time) []byte {
+	date := sumHMAC([]byte("AWS4"+secretKey), []byte(t.Format(yyyymmdd)))
+	regionBytes := sumHMAC(date, []byte(region))
+	service := sumHMAC(regionBytes, []byte("s3"))
+	return sumHMAC(service, []byte("aws4_request"))
+}
+
+func sumHMAC(key []byte, data []byte) []byte {
+	hash := hmac.New(sha256.New, key)
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// escapePath escapes all but the unreserved characters and the slashes
+func escapePath(path string) string {
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
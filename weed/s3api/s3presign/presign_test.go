@@ -0,0 +1,126 @@
+package s3presign
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContentLengthRange(t *testing.T) {
+	lengthRange, err := ParseContentLengthRange("1, 1048576")
+	assert.Nil(t, err)
+	assert.Equal(t, &ContentLengthRange{Min: 1, Max: 1048576}, lengthRange)
+	assert.Equal(t, "1,1048576", lengthRange.String())
+
+	for _, invalid := range []string{"", "1", "a,2", "1,b", "-1,2", "3,2"} {
+		_, err = ParseContentLengthRange(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Request {
+		return &Request{Endpoint: "http://localhost:8333", Method: http.MethodPut, Bucket: "photos", Key: "a.jpg", Expires: time.Hour}
+	}
+	assert.Nil(t, valid().Validate())
+
+	invalids := map[string]func(req *Request){
+		"no bucket":         func(req *Request) { req.Bucket = "" },
+		"no key":            func(req *Request) { req.Key = "" },
+		"key prefix":        func(req *Request) { req.KeyPrefix = "a/" },
+		"expired":           func(req *Request) { req.Expires = 0 },
+		"too long":          func(req *Request) { req.Expires = MaxExpires + time.Second },
+		"endpoint":          func(req *Request) { req.Endpoint = "localhost:8333" },
+		"method":            func(req *Request) { req.Method = "PATCH" },
+		"content type glob": func(req *Request) { req.ContentType = "image/*" },
+		"download range": func(req *Request) {
+			req.Method = http.MethodGet
+			req.ContentLengthRange = &ContentLengthRange{Max: 1}
+		},
+		"post key and prefix": func(req *Request) {
+			req.Method = http.MethodPost
+			req.KeyPrefix = "a/"
+		},
+	}
+	for name, invalidate := range invalids {
+		req := valid()
+		invalidate(req)
+		assert.NotNil(t, req.Validate(), name)
+	}
+}
+
+func TestPresignUrl(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	presigned, err := Presign(&Request{
+		Endpoint:           "https://s3.example.com/",
+		Method:             http.MethodPut,
+		Bucket:             "photos",
+		Key:                "summer trip/a+b.jpg",
+		Expires:            10 * time.Minute,
+		ContentType:        "image/jpeg",
+		ContentLengthRange: &ContentLengthRange{Min: 1, Max: 1024},
+	}, "access_key", "secret_key", "", now)
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPut, presigned.Method)
+	assert.Equal(t, map[string]string{"Content-Type": "image/jpeg"}, presigned.Headers)
+	assert.Equal(t, now.Add(10*time.Minute), presigned.Expiration)
+
+	u, err := url.Parse(presigned.Url)
+	assert.Nil(t, err)
+	assert.Equal(t, "s3.example.com", u.Host)
+	assert.Equal(t, "/photos/summer%20trip/a%2Bb.jpg", u.EscapedPath())
+	query := u.Query()
+	assert.Equal(t, "1,1024", query.Get(ContentLengthRangeParam))
+	assert.Equal(t, "access_key/20240501/us-east-1/s3/aws4_request", query.Get("X-Amz-Credential"))
+	assert.Equal(t, "600", query.Get("X-Amz-Expires"))
+	assert.Equal(t, "content-type;host", query.Get("X-Amz-SignedHeaders"))
+	assert.Equal(t, 64, len(query.Get("X-Amz-Signature")))
+	assert.False(t, query.Has("X-Amz-Security-Token"))
+
+	// the temporary credentials are signed along with the session token
+	presigned, err = Presign(&Request{
+		Endpoint: "https://s3.example.com",
+		Method:   http.MethodGet,
+		Bucket:   "photos",
+		Key:      "a.jpg",
+		Expires:  time.Minute,
+	}, "ASIA_access_key", "secret_key", "session_token", now)
+	assert.Nil(t, err)
+	u, err = url.Parse(presigned.Url)
+	assert.Nil(t, err)
+	assert.Equal(t, "session_token", u.Query().Get("X-Amz-Security-Token"))
+}
+
+func TestPresignPost(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	presigned, err := Presign(&Request{
+		Endpoint:           "http://localhost:8333",
+		Method:             http.MethodPost,
+		Bucket:             "photos",
+		KeyPrefix:          "uploads/",
+		Expires:            time.Hour,
+		ContentType:        "image/*",
+		ContentLengthRange: &ContentLengthRange{Min: 1, Max: 1024},
+	}, "access_key", "secret_key", "session_token", now)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:8333/photos", presigned.Url)
+	assert.Equal(t, "uploads/${filename}", presigned.Fields["key"])
+	assert.Equal(t, "image/", presigned.Fields["Content-Type"])
+	assert.Equal(t, "AWS4-HMAC-SHA256", presigned.Fields["X-Amz-Algorithm"])
+	assert.Equal(t, "access_key/20240501/us-east-1/s3/aws4_request", presigned.Fields["X-Amz-Credential"])
+	assert.Equal(t, "20240501T100000Z", presigned.Fields["X-Amz-Date"])
+	assert.Equal(t, "session_token", presigned.Fields["X-Amz-Security-Token"])
+	assert.Equal(t, 64, len(presigned.Fields["X-Amz-Signature"]))
+
+	policy, err := base64.StdEncoding.DecodeString(presigned.Fields["Policy"])
+	assert.Nil(t, err)
+	assert.Contains(t, string(policy), `"expiration":"2024-05-01T11:00:00Z"`)
+	assert.Contains(t, string(policy), `["starts-with","$key","uploads/"]`)
+	assert.Contains(t, string(policy), `["starts-with","$Content-Type","image/"]`)
+	assert.Contains(t, string(policy), `["content-length-range", 1, 1024]`)
+	assert.Contains(t, string(policy), `["eq","$X-Amz-Security-Token","session_token"]`)
+}
@@ -0,0 +1,268 @@
+package s3replication
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketReplication.html
+
+const (
+	StatusEnabled  = "Enabled"
+	StatusDisabled = "Disabled"
+
+	// the replication status of the objects, the replicas are marked REPLICA on the destinations
+	StatusPending   = "PENDING"
+	StatusCompleted = "COMPLETED"
+	StatusFailed    = "FAILED"
+	StatusReplica   = "REPLICA"
+
+	// the maximum size of a bucket replication configuration
+	MaxConfigurationSize = 128 * 1024
+
+	maxRules      = 1000
+	maxIdLength   = 255
+	maxPrefixSize = 1024
+)
+
+// ReplicationConfiguration is the replication configuration of a bucket.
+// The objects are copied to the replication targets of the filer, named by the Account of the rule destinations,
+// e.g. <Account>backup</Account> => [s3_replication.backup]. The destinations without Account use the only target.
+type ReplicationConfiguration struct {
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+
+	Role  string            `xml:"Role,omitempty"`
+	Rules []ReplicationRule `xml:"Rule"`
+}
+
+type ReplicationRule struct {
+	ID       string `xml:"ID,omitempty"`
+	Priority *int   `xml:"Priority,omitempty"`
+	Status   string `xml:"Status"`
+	// Prefix is the filter of the first version of the configuration, whose delete markers are always replicated
+	Prefix                  *string                  `xml:"Prefix,omitempty"`
+	Filter                  *Filter                  `xml:"Filter,omitempty"`
+	DeleteMarkerReplication *DeleteMarkerReplication `xml:"DeleteMarkerReplication,omitempty"`
+	Destination             Destination              `xml:"Destination"`
+}
+
+type Filter struct {
+	Prefix *string `xml:"Prefix,omitempty"`
+	Tag    *Tag    `xml:"Tag,omitempty"`
+	And    *And    `xml:"And,omitempty"`
+}
+
+type And struct {
+	Prefix string `xml:"Prefix,omitempty"`
+	Tags   []Tag  `xml:"Tag"`
+}
+
+type Tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type DeleteMarkerReplication struct {
+	Status string `xml:"Status"`
+}
+
+type Destination struct {
+	Bucket       string `xml:"Bucket"`
+	Account      string `xml:"Account,omitempty"`
+	StorageClass string `xml:"StorageClass,omitempty"`
+}
+
+// Rule is an enabled replication rule, resolved to the target and the bucket it copies to
+type Rule struct {
+	Id                     string
+	Priority               int
+	Prefix                 string
+	Tags                   map[string]string
+	Target                 string
+	Bucket                 string
+	StorageClass           string
+	ReplicateDeleteMarkers bool
+}
+
+// ParseConfiguration parses and validates the replication configuration of a bucket
+func ParseConfiguration(data []byte) (*ReplicationConfiguration, error) {
+	config := &ReplicationConfiguration{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if _, err := config.EnabledRules(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SetDefaultIds names the rules without id, as AWS does
+func (c *ReplicationConfiguration) SetDefaultIds() {
+	for i := range c.Rules {
+		if c.Rules[i].ID == "" {
+			c.Rules[i].ID = uuid.New().String()
+		}
+	}
+}
+
+// EnabledRules validates the rules, and resolves the enabled ones
+func (c *ReplicationConfiguration) EnabledRules() (rules []*Rule, err error) {
+	if len(c.Rules) == 0 {
+		return nil, fmt.Errorf("no replication rule")
+	}
+	if len(c.Rules) > maxRules {
+		return nil, fmt.Errorf("more than %d replication rules", maxRules)
+	}
+	ids := make(map[string]bool)
+	priorities := make(map[int]bool)
+	for i := range c.Rules {
+		rule, err := c.Rules[i].resolve()
+		if err != nil {
+			return nil, err
+		}
+		if id := c.Rules[i].ID; id != "" {
+			if ids[id] {
+				return nil, fmt.Errorf("duplicated rule id %s", id)
+			}
+			ids[id] = true
+		}
+		if c.Rules[i].Filter != nil {
+			if priorities[rule.Priority] {
+				return nil, fmt.Errorf("duplicated rule priority %d", rule.Priority)
+			}
+			priorities[rule.Priority] = true
+		}
+		if c.Rules[i].Status == StatusEnabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (r *ReplicationRule) resolve() (*Rule, error) {
+	if len(r.ID) > maxIdLength {
+		return nil, fmt.Errorf("the rule id is longer than %d", maxIdLength)
+	}
+	if r.Status != StatusEnabled && r.Status != StatusDisabled {
+		return nil, fmt.Errorf("invalid rule status %q", r.Status)
+	}
+	bucket, err := parseBucketArn(r.Destination.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	rule := &Rule{
+		Id:           r.ID,
+		Target:       strings.ToLower(r.Destination.Account),
+		Bucket:       bucket,
+		StorageClass: r.Destination.StorageClass,
+	}
+	if r.Priority != nil {
+		rule.Priority = *r.Priority
+	}
+
+	if r.Filter == nil {
+		// the first version of the configuration
+		if r.DeleteMarkerReplication != nil {
+			return nil, fmt.Errorf("the delete marker replication needs a filter")
+		}
+		if r.Prefix != nil {
+			rule.Prefix = *r.Prefix
+		}
+		rule.ReplicateDeleteMarkers = true
+		return rule, checkPrefix(rule.Prefix)
+	}
+
+	if r.Prefix != nil {
+		return nil, fmt.Errorf("both the prefix and the filter are set")
+	}
+	filtered := 0
+	if r.Filter.Prefix != nil {
+		filtered++
+		rule.Prefix = *r.Filter.Prefix
+	}
+	var tags []Tag
+	if r.Filter.Tag != nil {
+		filtered++
+		tags = append(tags, *r.Filter.Tag)
+	}
+	if r.Filter.And != nil {
+		filtered++
+		rule.Prefix = r.Filter.And.Prefix
+		tags = append(tags, r.Filter.And.Tags...)
+	}
+	if filtered > 1 {
+		return nil, fmt.Errorf("more than one of the prefix, tag and and filters")
+	}
+	if err := checkPrefix(rule.Prefix); err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if tag.Key == "" {
+			return nil, fmt.Errorf("the filter tag has no key")
+		}
+		if rule.Tags == nil {
+			rule.Tags = make(map[string]string)
+		}
+		if _, found := rule.Tags[tag.Key]; found {
+			return nil, fmt.Errorf("duplicated filter tag %s", tag.Key)
+		}
+		rule.Tags[tag.Key] = tag.Value
+	}
+
+	if r.DeleteMarkerReplication != nil {
+		switch r.DeleteMarkerReplication.Status {
+		case StatusEnabled:
+			rule.ReplicateDeleteMarkers = true
+		case StatusDisabled:
+		default:
+			return nil, fmt.Errorf("invalid delete marker replication status %q", r.DeleteMarkerReplication.Status)
+		}
+	}
+	if rule.ReplicateDeleteMarkers && len(rule.Tags) > 0 {
+		return nil, fmt.Errorf("the delete marker replication is not supported by the rules with tag filters")
+	}
+	return rule, nil
+}
+
+func checkPrefix(prefix string) error {
+	if len(prefix) > maxPrefixSize {
+		return fmt.Errorf("the prefix is longer than %d", maxPrefixSize)
+	}
+	return nil
+}
+
+// parseBucketArn returns the bucket named by the ARN, e.g. arn:aws:s3:::backup
+func parseBucketArn(arn string) (string, error) {
+	fields := strings.Split(arn, ":")
+	if len(fields) != 6 || fields[0] != "arn" || fields[2] != "s3" || fields[5] == "" || strings.Contains(fields[5], "/") {
+		return "", fmt.Errorf("invalid destination bucket arn %q", arn)
+	}
+	return fields[5], nil
+}
+
+// Matches tells whether the object of the key and the tags is replicated by the rule
+func (r *Rule) Matches(key string, tags map[string]string) bool {
+	if !strings.HasPrefix(key, r.Prefix) {
+		return false
+	}
+	for k, v := range r.Tags {
+		if value, found := tags[k]; !found || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// FindRule returns the matching rule of the highest priority, the first one of the same priority
+func FindRule(rules []*Rule, key string, tags map[string]string) (found *Rule) {
+	for _, rule := range rules {
+		if rule.Matches(key, tags) && (found == nil || rule.Priority > found.Priority) {
+			found = rule
+		}
+	}
+	return found
+}
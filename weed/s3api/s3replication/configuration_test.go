@@ -0,0 +1,92 @@
+package s3replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfiguration = `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Role>arn:aws:iam::000000000000:role/replication</Role>
+  <Rule>
+    <ID>images</ID>
+    <Priority>1</Priority>
+    <Status>Enabled</Status>
+    <Filter><Prefix>images/</Prefix></Filter>
+    <DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>
+    <Destination><Bucket>arn:aws:s3:::backup</Bucket><Account>DR</Account><StorageClass>STANDARD_IA</StorageClass></Destination>
+  </Rule>
+  <Rule>
+    <Priority>2</Priority>
+    <Status>Enabled</Status>
+    <Filter><And><Prefix>images/raw/</Prefix><Tag><Key>keep</Key><Value>yes</Value></Tag></And></Filter>
+    <DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication>
+    <Destination><Bucket>arn:aws:s3:::archive</Bucket></Destination>
+  </Rule>
+  <Rule>
+    <ID>disabled</ID>
+    <Priority>3</Priority>
+    <Status>Disabled</Status>
+    <Filter><Prefix></Prefix></Filter>
+    <Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination>
+  </Rule>
+</ReplicationConfiguration>`
+
+func TestParseConfiguration(t *testing.T) {
+	config, err := ParseConfiguration([]byte(testConfiguration))
+	assert.Nil(t, err)
+	config.SetDefaultIds()
+	assert.Equal(t, "images", config.Rules[0].ID)
+	assert.NotEmpty(t, config.Rules[1].ID)
+
+	rules, err := config.EnabledRules()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, &Rule{Id: "images", Priority: 1, Prefix: "images/", Target: "dr", Bucket: "backup", StorageClass: "STANDARD_IA", ReplicateDeleteMarkers: true}, rules[0])
+	assert.Equal(t, map[string]string{"keep": "yes"}, rules[1].Tags)
+	assert.Equal(t, "images/raw/", rules[1].Prefix)
+	assert.Equal(t, "", rules[1].Target)
+	assert.False(t, rules[1].ReplicateDeleteMarkers)
+
+	// the rules of the first version replicate the delete markers
+	v1, err := ParseConfiguration([]byte(`<ReplicationConfiguration><Rule><Prefix>logs/</Prefix><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule></ReplicationConfiguration>`))
+	assert.Nil(t, err)
+	rules, _ = v1.EnabledRules()
+	assert.Equal(t, &Rule{Prefix: "logs/", Bucket: "backup", ReplicateDeleteMarkers: true}, rules[0])
+
+	invalids := []string{
+		`<ReplicationConfiguration/>`,
+		`<ReplicationConfiguration><Rule><Status>On</Status><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+		`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+		`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>arn:aws:sqs:::backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+		`<ReplicationConfiguration><Rule><Status>Enabled</Status><Prefix>a</Prefix><Filter><Prefix>a</Prefix></Filter><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+		`<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>a</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></Filter><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+		`<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter>
+			<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+		`<ReplicationConfiguration><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+		`<ReplicationConfiguration>
+			<Rule><ID>a</ID><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule>
+			<Rule><ID>a</ID><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule>
+		</ReplicationConfiguration>`,
+		`<ReplicationConfiguration>
+			<Rule><Priority>1</Priority><Status>Enabled</Status><Filter/><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule>
+			<Rule><Priority>1</Priority><Status>Enabled</Status><Filter/><Destination><Bucket>arn:aws:s3:::backup</Bucket></Destination></Rule>
+		</ReplicationConfiguration>`,
+	}
+	for _, invalid := range invalids {
+		_, err = ParseConfiguration([]byte(invalid))
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestFindRule(t *testing.T) {
+	config, err := ParseConfiguration([]byte(testConfiguration))
+	assert.Nil(t, err)
+	rules, _ := config.EnabledRules()
+
+	assert.Nil(t, FindRule(rules, "docs/a.pdf", nil))
+	assert.Equal(t, "backup", FindRule(rules, "images/a.jpg", nil).Bucket)
+	assert.Equal(t, "backup", FindRule(rules, "images/raw/a.jpg", map[string]string{"keep": "no"}).Bucket)
+	// the rule of the higher priority wins
+	assert.Equal(t, "archive", FindRule(rules, "images/raw/a.jpg", map[string]string{"keep": "yes", "other": "tag"}).Bucket)
+}
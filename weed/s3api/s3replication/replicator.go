@@ -0,0 +1,371 @@
+package s3replication
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/replication/sink"
+	"github.com/seaweedfs/seaweedfs/weed/replication/source"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	// the object replications waiting to be sent to a target, the later ones are left pending for the rescans when full,
+	// while the deletes are always queued
+	targetQueueSize = 4096
+	// the attempts of a replication before the object is marked FAILED
+	maxAttempts = 3
+)
+
+// the sink types to replicate to, with the configuration key of their destination
+var destinationKeys = map[string]string{
+	"s3":    "bucket",
+	"filer": "directory",
+}
+
+// Replicator copies the objects to the targets named by the replication configurations of the buckets
+type Replicator struct {
+	targets map[string]*target
+	// loadConfiguration reads the replication configuration of the bucket, nil if there is none
+	loadConfiguration func(bucket string) ([]byte, error)
+	// setStatus records the replication status of the object, unless the object is changed since
+	setStatus func(fullpath util.FullPath, entry *filer_pb.Entry, status string)
+
+	rulesLock     sync.RWMutex
+	rules         map[string][]*Rule
+	invalidations uint64
+}
+
+// target replicates the objects in order, in the background
+type target struct {
+	name     string
+	sinkType string
+	config   util.Configuration
+	prefix   string
+	source   *source.FilerSource
+	// the sinks of the destination buckets, only used by the loop
+	sinks     map[string]sink.ReplicationSink
+	setStatus func(fullpath util.FullPath, entry *filer_pb.Entry, status string)
+
+	tasksLock sync.Mutex
+	tasksCond *sync.Cond
+	tasks     []*Task
+	// the objects queued or being replicated, not queued again by the rescans
+	queued map[util.FullPath]int
+}
+
+// Task is a change of an object to replicate, the object is deleted if there is no new entry
+type Task struct {
+	FullPath   util.FullPath
+	Key        string
+	Rule       *Rule
+	OldEntry   *filer_pb.Entry
+	NewEntry   *filer_pb.Entry
+	Signatures []int32
+}
+
+// LoadReplicator creates the enabled targets of the configuration section, e.g.
+//
+//	[s3_replication.backup]
+//	enabled = true
+//	type = "s3"
+//	endpoint = "http://remote:8333"
+//
+// whose sinks read the object content from the filer, and returns nil if there is none.
+func LoadReplicator(config *util.ViperProxy, section string, filer pb.ServerAddress,
+	loadConfiguration func(bucket string) ([]byte, error),
+	setStatus func(fullpath util.FullPath, entry *filer_pb.Entry, status string)) (*Replicator, error) {
+	if config == nil {
+		return nil, nil
+	}
+	r := &Replicator{
+		targets:           make(map[string]*target),
+		loadConfiguration: loadConfiguration,
+		setStatus:         setStatus,
+		rules:             make(map[string][]*Rule),
+	}
+	filerSource := &source.FilerSource{}
+	filerSource.DoInitialize(filer.ToHttpAddress(), filer.ToGrpcAddress(), "", false)
+	for name := range config.GetStringMap(section) {
+		prefix := section + "." + name + "."
+		if !config.GetBool(prefix + "enabled") {
+			continue
+		}
+		sinkType := config.GetString(prefix + "type")
+		if _, found := destinationKeys[sinkType]; !found {
+			return nil, fmt.Errorf("s3 replication target %s: unsupported type %q, use s3 or filer", name, sinkType)
+		}
+		if sinkType == "filer" {
+			config.SetDefault(prefix+"directory", "/buckets")
+		}
+		r.addTarget(&target{
+			name:     name,
+			sinkType: sinkType,
+			config:   config,
+			prefix:   prefix,
+			source:   filerSource,
+		})
+		glog.V(0).Infof("Configure s3 replication target %s of type %s", name, sinkType)
+	}
+	if len(r.targets) == 0 {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// addTarget starts replicating to the target, the targets are named in lower case as in the configuration files
+func (r *Replicator) addTarget(t *target) {
+	t.name = strings.ToLower(t.name)
+	t.sinks = make(map[string]sink.ReplicationSink)
+	t.tasksCond = sync.NewCond(&t.tasksLock)
+	t.queued = make(map[util.FullPath]int)
+	t.setStatus = r.setStatus
+	r.targets[t.name] = t
+	go t.loop()
+}
+
+// findTarget returns the target of the name, or the only target for the rules without one
+func (r *Replicator) findTarget(name string) *target {
+	if name == "" && len(r.targets) == 1 {
+		for _, t := range r.targets {
+			return t
+		}
+	}
+	return r.targets[name]
+}
+
+// enqueue queues the task, unless it replicates an object while the queue is full, or an object already queued for a rescan
+func (t *target) enqueue(task *Task, isRescan bool) bool {
+	t.tasksLock.Lock()
+	defer t.tasksLock.Unlock()
+	if task.NewEntry != nil {
+		if len(t.tasks) >= targetQueueSize || isRescan && t.queued[task.FullPath] > 0 {
+			return false
+		}
+		t.queued[task.FullPath]++
+	}
+	t.tasks = append(t.tasks, task)
+	t.tasksCond.Signal()
+	return true
+}
+
+func (t *target) dequeue() *Task {
+	t.tasksLock.Lock()
+	defer t.tasksLock.Unlock()
+	for len(t.tasks) == 0 {
+		t.tasksCond.Wait()
+	}
+	task := t.tasks[0]
+	t.tasks[0] = nil
+	t.tasks = t.tasks[1:]
+	return task
+}
+
+func (t *target) done(task *Task) {
+	if task.NewEntry == nil {
+		return
+	}
+	t.tasksLock.Lock()
+	defer t.tasksLock.Unlock()
+	if t.queued[task.FullPath]--; t.queued[task.FullPath] <= 0 {
+		delete(t.queued, task.FullPath)
+	}
+}
+
+func (t *target) loop() {
+	for {
+		task := t.dequeue()
+		t.process(task)
+		t.done(task)
+	}
+}
+
+func (t *target) process(task *Task) {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = t.replicate(task); err == nil {
+			break
+		}
+		glog.Warningf("s3 replication of %s to %s, attempt %d: %v", task.FullPath, t.name, attempt, err)
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+	if task.NewEntry == nil {
+		return
+	}
+	if err != nil {
+		t.setStatus(task.FullPath, task.NewEntry, StatusFailed)
+	} else {
+		glog.V(3).Infof("s3 replication of %s to %s/%s", task.FullPath, t.name, task.Rule.Bucket)
+		t.setStatus(task.FullPath, task.NewEntry, StatusCompleted)
+	}
+}
+
+func (t *target) replicate(task *Task) error {
+	dataSink, err := t.sink(task.Rule.Bucket)
+	if err != nil {
+		return err
+	}
+	key := util.Join(dataSink.GetSinkToDirectory(), task.Key)
+	if task.NewEntry == nil {
+		return dataSink.DeleteEntry(key, false, true, task.Signatures)
+	}
+	replica := toReplica(task.NewEntry, task.Rule.StorageClass)
+	if task.OldEntry != nil {
+		dir, _ := util.FullPath(key).DirAndName()
+		if foundExisting, err := dataSink.UpdateEntry(key, task.OldEntry, dir, replica, true, task.Signatures); foundExisting {
+			return err
+		}
+	} else if t.sinkType == "filer" {
+		// the filer sink keeps an existing entry as new as the created one,
+		// e.g. the replica of the version replaced within the same second
+		if err = dataSink.DeleteEntry(key, false, true, task.Signatures); err != nil {
+			return err
+		}
+	}
+	return dataSink.CreateEntry(key, replica, task.Signatures)
+}
+
+// sink returns the sink writing to the destination bucket
+func (t *target) sink(bucket string) (sink.ReplicationSink, error) {
+	if dataSink, found := t.sinks[bucket]; found {
+		return dataSink, nil
+	}
+	destination := t.prefix + destinationKeys[t.sinkType]
+	overrides := map[string]string{destination: bucket}
+	if t.sinkType == "filer" {
+		// the buckets folder of the destination filer, whose buckets are kept in their own collections by default
+		overrides[destination] = util.Join(t.config.GetString(destination), bucket)
+		if t.config.GetString(t.prefix+"collection") == "" {
+			overrides[t.prefix+"collection"] = bucket
+		}
+	}
+	dataSink, err := sink.NewSink(t.sinkType, &destinationConfiguration{
+		Configuration: t.config,
+		overrides:     overrides,
+	}, t.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("s3 replication target %s: %v", t.name, err)
+	}
+	dataSink.SetSourceFiler(t.source)
+	t.sinks[bucket] = dataSink
+	return dataSink, nil
+}
+
+// destinationConfiguration is the configuration of a target, with the destination of a bucket
+type destinationConfiguration struct {
+	util.Configuration
+	overrides map[string]string
+}
+
+func (c *destinationConfiguration) GetString(key string) string {
+	if value, found := c.overrides[key]; found {
+		return value
+	}
+	return c.Configuration.GetString(key)
+}
+
+// toReplica marks the copy of the object as a replica, in the storage class of the destination
+func toReplica(entry *filer_pb.Entry, storageClass string) *filer_pb.Entry {
+	replica := proto.Clone(entry).(*filer_pb.Entry)
+	if replica.Extended == nil {
+		replica.Extended = make(map[string][]byte)
+	}
+	replica.Extended[s3_constants.ExtReplicationStatusKey] = []byte(StatusReplica)
+	if storageClass != "" {
+		replica.Extended[s3_constants.AmzStorageClass] = []byte(storageClass)
+	}
+	return replica
+}
+
+// FindRule returns the rule replicating the object of the bucket, nil if there is none or its target is not configured
+func (r *Replicator) FindRule(bucket, key string, tags map[string]string) *Rule {
+	rules, err := r.bucketRules(bucket)
+	if err != nil {
+		glog.Warningf("s3 replication configuration of bucket %s: %v", bucket, err)
+		return nil
+	}
+	rule := FindRule(rules, key, tags)
+	if rule == nil {
+		return nil
+	}
+	if r.findTarget(rule.Target) == nil {
+		glog.V(1).Infof("s3 replication %s of bucket %s: unknown target %q", rule.Id, bucket, rule.Target)
+		return nil
+	}
+	return rule
+}
+
+// Replicate queues the change of the object to the target of its rule.
+// The objects not queued when the target is full are left pending, and queued again by the rescans.
+func (r *Replicator) Replicate(task *Task) {
+	t := r.findTarget(task.Rule.Target)
+	if t == nil {
+		return
+	}
+	if !t.enqueue(task, false) {
+		glog.V(1).Infof("s3 replication target %s is full, leave %s pending", t.name, task.FullPath)
+	}
+}
+
+// Retry queues the replication of a pending or failed object found by a rescan, unless it is already queued
+func (r *Replicator) Retry(task *Task) bool {
+	t := r.findTarget(task.Rule.Target)
+	if t == nil {
+		return false
+	}
+	return t.enqueue(task, true)
+}
+
+// IsEnabled tells whether the bucket has replication rules
+func (r *Replicator) IsEnabled(bucket string) bool {
+	rules, err := r.bucketRules(bucket)
+	return err == nil && len(rules) > 0
+}
+
+// Invalidate forgets the cached replication configuration of the bucket, after the bucket is changed
+func (r *Replicator) Invalidate(bucket string) {
+	r.rulesLock.Lock()
+	defer r.rulesLock.Unlock()
+	delete(r.rules, bucket)
+	r.invalidations++
+}
+
+func (r *Replicator) bucketRules(bucket string) ([]*Rule, error) {
+	r.rulesLock.RLock()
+	rules, found := r.rules[bucket]
+	invalidations := r.invalidations
+	r.rulesLock.RUnlock()
+	if found {
+		return rules, nil
+	}
+
+	data, err := r.loadConfiguration(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		config, err := ParseConfiguration(data)
+		if err != nil {
+			return nil, err
+		}
+		if rules, err = config.EnabledRules(); err != nil {
+			return nil, err
+		}
+	}
+
+	r.rulesLock.Lock()
+	defer r.rulesLock.Unlock()
+	// keep the configuration unless it may have changed while loading
+	if r.invalidations == invalidations {
+		r.rules[bucket] = rules
+	}
+	return rules, nil
+}
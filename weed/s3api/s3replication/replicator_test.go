@@ -0,0 +1,160 @@
+package s3replication
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/replication/sink"
+	"github.com/seaweedfs/seaweedfs/weed/replication/source"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSink records the replications, in place of the filer sink
+type testSink struct {
+	dir        string
+	collection string
+	changes    chan string
+	entries    chan *filer_pb.Entry
+}
+
+var testChanges = make(chan string, 10)
+var testEntries = make(chan *filer_pb.Entry, 10)
+
+func init() {
+	sink.Sinks = append(sink.Sinks, &testSink{})
+}
+
+func (s *testSink) GetName() string {
+	return "filer"
+}
+
+func (s *testSink) Initialize(configuration util.Configuration, prefix string) error {
+	s.dir = configuration.GetString(prefix + "directory")
+	s.collection = configuration.GetString(prefix + "collection")
+	s.changes, s.entries = testChanges, testEntries
+	return nil
+}
+
+func (s *testSink) DeleteEntry(key string, isDirectory, deleteIncludeChunks bool, signatures []int32) error {
+	s.changes <- "delete " + key
+	return nil
+}
+
+func (s *testSink) CreateEntry(key string, entry *filer_pb.Entry, signatures []int32) error {
+	s.changes <- "create " + key + " in " + s.collection
+	s.entries <- entry
+	return nil
+}
+
+func (s *testSink) UpdateEntry(key string, oldEntry *filer_pb.Entry, newParentPath string, newEntry *filer_pb.Entry, deleteIncludeChunks bool, signatures []int32) (foundExistingEntry bool, err error) {
+	s.changes <- "update " + key
+	s.entries <- newEntry
+	return true, nil
+}
+
+func (s *testSink) GetSinkToDirectory() string         { return s.dir }
+func (s *testSink) SetSourceFiler(*source.FilerSource) {}
+func (s *testSink) IsIncremental() bool                { return false }
+
+type testConfig map[string]string
+
+func (c testConfig) GetString(key string) string              { return c[key] }
+func (c testConfig) GetBool(key string) bool                  { return c[key] == "true" }
+func (c testConfig) GetInt(key string) int                    { return 0 }
+func (c testConfig) GetStringSlice(key string) []string       { return nil }
+func (c testConfig) SetDefault(key string, value interface{}) {}
+
+func receive(t *testing.T, changes chan string) string {
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(5 * time.Second):
+		t.Fatal("no replication")
+	}
+	return ""
+}
+
+func TestReplicator(t *testing.T) {
+	statuses := make(chan string, 10)
+	r := &Replicator{
+		targets: make(map[string]*target),
+		loadConfiguration: func(bucket string) ([]byte, error) {
+			if bucket == "photos" {
+				return []byte(testConfiguration), nil
+			}
+			return nil, nil
+		},
+		setStatus: func(fullpath util.FullPath, entry *filer_pb.Entry, status string) {
+			statuses <- string(fullpath) + " " + status
+		},
+		rules: make(map[string][]*Rule),
+	}
+	r.addTarget(&target{
+		name:     "DR",
+		sinkType: "filer",
+		config:   testConfig{"s3_replication.dr.directory": "/buckets"},
+		prefix:   "s3_replication.dr.",
+	})
+
+	assert.Nil(t, r.FindRule("photos", "docs/a.pdf", nil))
+	assert.Nil(t, r.FindRule("videos", "images/a.jpg", nil))
+	// the target of the rule without account is ambiguous once there are several targets
+	r.targets["other"] = &target{name: "other"}
+	assert.Nil(t, r.FindRule("photos", "images/raw/a.jpg", map[string]string{"keep": "yes"}))
+	delete(r.targets, "other")
+
+	rule := r.FindRule("photos", "images/a.jpg", nil)
+	assert.Equal(t, "backup", rule.Bucket)
+	entry := &filer_pb.Entry{Name: "a.jpg", Extended: map[string][]byte{s3_constants.ExtReplicationStatusKey: []byte(StatusPending)}}
+	r.Replicate(&Task{FullPath: "/buckets/photos/images/a.jpg", Key: "images/a.jpg", Rule: rule, NewEntry: entry})
+	// the replica of a replaced version is deleted first
+	assert.Equal(t, "delete /buckets/backup/images/a.jpg", receive(t, testChanges))
+	assert.Equal(t, "create /buckets/backup/images/a.jpg in backup", receive(t, testChanges))
+	replica := <-testEntries
+	assert.Equal(t, StatusReplica, string(replica.Extended[s3_constants.ExtReplicationStatusKey]))
+	assert.Equal(t, "STANDARD_IA", string(replica.Extended[s3_constants.AmzStorageClass]))
+	assert.Equal(t, StatusPending, string(entry.Extended[s3_constants.ExtReplicationStatusKey]), "the source entry is not changed")
+	assert.Equal(t, "/buckets/photos/images/a.jpg COMPLETED", receive(t, statuses))
+
+	r.Replicate(&Task{FullPath: "/buckets/photos/images/a.jpg", Key: "images/a.jpg", Rule: rule, OldEntry: entry, NewEntry: entry})
+	assert.Equal(t, "update /buckets/backup/images/a.jpg", receive(t, testChanges))
+	<-testEntries
+	assert.Equal(t, "/buckets/photos/images/a.jpg COMPLETED", receive(t, statuses))
+
+	r.Replicate(&Task{FullPath: "/buckets/photos/images/a.jpg", Key: "images/a.jpg", Rule: rule})
+	assert.Equal(t, "delete /buckets/backup/images/a.jpg", receive(t, testChanges))
+
+	// the changed configuration is loaded again
+	r.Invalidate("photos")
+	r.loadConfiguration = func(bucket string) ([]byte, error) { return nil, nil }
+	assert.Nil(t, r.FindRule("photos", "images/a.jpg", nil))
+}
+
+func TestTargetQueue(t *testing.T) {
+	q := &target{name: "dr", queued: make(map[util.FullPath]int)}
+	q.tasksCond = sync.NewCond(&q.tasksLock)
+	put := func(p util.FullPath) *Task {
+		return &Task{FullPath: p, NewEntry: &filer_pb.Entry{Name: p.Name()}}
+	}
+
+	assert.True(t, q.enqueue(put("/buckets/photos/a.jpg"), false))
+	// the rescans do not queue the objects already queued
+	assert.False(t, q.enqueue(put("/buckets/photos/a.jpg"), true))
+	assert.True(t, q.enqueue(put("/buckets/photos/b.jpg"), true))
+	task := q.dequeue()
+	q.done(task)
+	assert.True(t, q.enqueue(put("/buckets/photos/a.jpg"), true))
+
+	for len(q.tasks) < targetQueueSize {
+		q.enqueue(put(util.FullPath(fmt.Sprintf("/buckets/photos/%d.jpg", len(q.tasks)))), false)
+	}
+	// the objects are left pending when the queue is full, the deletes are kept
+	assert.False(t, q.enqueue(put("/buckets/photos/c.jpg"), false))
+	assert.True(t, q.enqueue(&Task{FullPath: "/buckets/photos/d.jpg"}, false))
+	assert.Len(t, q.tasks, targetQueueSize+1)
+}
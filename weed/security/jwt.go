@@ -1,6 +1,9 @@
 package security
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"strings"
@@ -20,6 +23,21 @@ type SeaweedFileIdClaims struct {
 	jwt.RegisteredClaims
 }
 
+// SessionClaims is carried by the session token of temporary credentials, issued by the iam api server
+// and accepted by the s3 gateways and the webdav servers.
+type SessionClaims struct {
+	AccessKey string `json:"accessKey"`
+	Identity  string `json:"identity"`
+	jwt.RegisteredClaims
+}
+
+// SessionSecretKey derives the secret key of temporary credentials from their access key
+func SessionSecretKey(signingKey SigningKey, accessKey string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte("session:" + accessKey))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)[:30])
+}
+
 // SeaweedFilerClaims is created e.g. by S3 proxy server and consumed by Filer server.
 // Right now, it only contains the standard claims; but this might be extended later
 // for more fine-grained permissions.
@@ -172,6 +172,11 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 	glog.V(4).Infof("UpdateEntry %v", req)
 
 	fullpath := util.Join(req.Directory, req.Entry.Name)
+	if !req.IsFromOtherCluster {
+		if err := filer.CheckSnapshotWritable(util.FullPath(fullpath)); err != nil {
+			return &filer_pb.UpdateEntryResponse{}, err
+		}
+	}
 	entry, err := fs.filer.FindEntry(ctx, util.FullPath(fullpath))
 	if err != nil {
 		return &filer_pb.UpdateEntryResponse{}, fmt.Errorf("not found %s: %v", fullpath, err)
@@ -189,6 +194,16 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		return &filer_pb.UpdateEntryResponse{}, err
 	}
 
+	if !req.IsFromOtherCluster {
+		release, err := fs.filer.ReserveQuota(ctx, entry, newEntry)
+		if err != nil {
+			return &filer_pb.UpdateEntryResponse{}, err
+		}
+		defer release()
+	}
+
+	fs.filer.MarkS3ReplicationPending(entry, newEntry, req.IsFromOtherCluster)
+
 	if err = fs.filer.UpdateEntry(ctx, entry, newEntry); err == nil {
 		fs.filer.DeleteChunksNotRecursive(garbage)
 
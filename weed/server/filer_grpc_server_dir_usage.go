@@ -0,0 +1,41 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func (fs *FilerServer) GetDirectoryUsage(ctx context.Context, req *filer_pb.GetDirectoryUsageRequest) (*filer_pb.GetDirectoryUsageResponse, error) {
+
+	glog.V(4).Infof("GetDirectoryUsage %s recount:%v", req.Directory, req.Recount)
+
+	usage, err := fs.directoryUsage(ctx, util.FullPath(req.Directory), req.Recount)
+	if err != nil {
+		return &filer_pb.GetDirectoryUsageResponse{Error: err.Error()}, nil
+	}
+
+	return &filer_pb.GetDirectoryUsageResponse{Usage: usage}, nil
+}
+
+func (fs *FilerServer) directoryUsage(ctx context.Context, dir util.FullPath, recount bool) (*filer_pb.DirectoryUsage, error) {
+	if dir == "" {
+		dir = "/"
+	}
+	if dir != "/" {
+		entry, err := fs.filer.FindEntry(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		if !entry.IsDirectory() {
+			return nil, fmt.Errorf("%s is not a folder", dir)
+		}
+	}
+	if recount {
+		return fs.filer.RecountDirectoryUsage(ctx, dir)
+	}
+	return fs.filer.DirectoryUsage(ctx, dir)
+}
@@ -0,0 +1,35 @@
+package weed_server
+
+import (
+	"context"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func (fs *FilerServer) CreateSnapshot(ctx context.Context, req *filer_pb.CreateSnapshotRequest) (*filer_pb.CreateSnapshotResponse, error) {
+
+	glog.V(0).Infof("CreateSnapshot %s of %s", req.Name, req.Directory)
+
+	root, fileCount, err := fs.filer.CreateSnapshot(ctx, util.FullPath(req.Directory), req.Name)
+	if err != nil {
+		return &filer_pb.CreateSnapshotResponse{Error: err.Error()}, nil
+	}
+
+	return &filer_pb.CreateSnapshotResponse{
+		Entry:     root.ToProtoEntry(),
+		FileCount: fileCount,
+	}, nil
+}
+
+func (fs *FilerServer) DeleteSnapshot(ctx context.Context, req *filer_pb.DeleteSnapshotRequest) (*filer_pb.DeleteSnapshotResponse, error) {
+
+	glog.V(0).Infof("DeleteSnapshot %s of %s", req.Name, req.Directory)
+
+	if err := fs.filer.DeleteSnapshot(ctx, util.FullPath(req.Directory), req.Name); err != nil {
+		return &filer_pb.DeleteSnapshotResponse{Error: err.Error()}, nil
+	}
+
+	return &filer_pb.DeleteSnapshotResponse{}, nil
+}
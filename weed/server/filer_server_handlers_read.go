@@ -49,7 +49,9 @@ func checkPreconditions(w http.ResponseWriter, r *http.Request, entry *filer.Ent
 	if entry.Attr.Mtime.IsZero() {
 		return false
 	}
-	w.Header().Set("Last-Modified", entry.Attr.Mtime.UTC().Format(http.TimeFormat))
+	// the http dates are in seconds
+	lastModified := entry.Attr.Mtime.Truncate(time.Second)
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
 
 	ifMatchETagHeader := r.Header.Get("If-Match")
 	ifUnmodifiedSinceHeader := r.Header.Get("If-Unmodified-Since")
@@ -60,7 +62,7 @@ func checkPreconditions(w http.ResponseWriter, r *http.Request, entry *filer.Ent
 		}
 	} else if ifUnmodifiedSinceHeader != "" {
 		if t, parseError := time.Parse(http.TimeFormat, ifUnmodifiedSinceHeader); parseError == nil {
-			if t.Before(entry.Attr.Mtime) {
+			if t.Before(lastModified) {
 				w.WriteHeader(http.StatusPreconditionFailed)
 				return true
 			}
@@ -77,7 +79,7 @@ func checkPreconditions(w http.ResponseWriter, r *http.Request, entry *filer.Ent
 		}
 	} else if ifModifiedSinceHeader != "" {
 		if t, parseError := time.Parse(http.TimeFormat, ifModifiedSinceHeader); parseError == nil {
-			if !t.Before(entry.Attr.Mtime) {
+			if !t.Before(lastModified) {
 				SetEtag(w, etag)
 				w.WriteHeader(http.StatusNotModified)
 				return true
@@ -96,6 +98,13 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		path = path[:len(path)-1]
 	}
 
+	query := r.URL.Query()
+
+	if query.Get("usage") == "true" {
+		fs.directoryUsageHandler(w, r, util.FullPath(path), query.Get("recount") == "true")
+		return
+	}
+
 	entry, err := fs.filer.FindEntry(context.Background(), util.FullPath(path))
 	if err != nil {
 		if path == "/" {
@@ -114,8 +123,6 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	query := r.URL.Query()
-
 	if entry.IsDirectory() {
 		if fs.option.DisableDirListing {
 			w.WriteHeader(http.StatusForbidden)
@@ -162,6 +169,13 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	var statusCode int
+	if entry, statusCode, err = decryptChunkCipherKeys(r, entry); err != nil {
+		glog.V(1).Infof("read %s: %v", path, err)
+		w.WriteHeader(statusCode)
+		return
+	}
+
 	var etag string
 	if partNumber, errNum := strconv.Atoi(r.Header.Get(s3_constants.SeaweedFSPartNumber)); errNum == nil {
 		if len(entry.Chunks) < partNumber {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	ui "github.com/seaweedfs/seaweedfs/weed/server/filer_ui"
 	"github.com/seaweedfs/seaweedfs/weed/stats"
 	"github.com/seaweedfs/seaweedfs/weed/util"
@@ -81,6 +82,16 @@ func (fs *FilerServer) listDirectoryHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	directoryUsages := make(map[string]*filer_pb.DirectoryUsage)
+	for _, entry := range entries {
+		if !entry.IsDirectory() {
+			continue
+		}
+		if usage, usageErr := fs.filer.DirectoryUsage(context.Background(), entry.FullPath); usageErr == nil {
+			directoryUsages[entry.Name()] = usage
+		}
+	}
+
 	err = ui.StatusTpl.Execute(w, struct {
 		Version               string
 		Path                  string
@@ -91,6 +102,7 @@ func (fs *FilerServer) listDirectoryHandler(w http.ResponseWriter, r *http.Reque
 		ShouldDisplayLoadMore bool
 		EmptyFolder           bool
 		ShowDirectoryDelete   bool
+		DirectoryUsages       map[string]*filer_pb.DirectoryUsage
 	}{
 		util.Version(),
 		path,
@@ -101,9 +113,32 @@ func (fs *FilerServer) listDirectoryHandler(w http.ResponseWriter, r *http.Reque
 		shouldDisplayLoadMore,
 		emptyFolder,
 		fs.option.ShowUIDirectoryDelete,
+		directoryUsages,
 	})
 	if err != nil {
 		glog.V(0).Infof("Template Execute Error: %v", err)
 	}
 
 }
+
+// directoryUsageHandler returns the recursive usage of a directory, i.e. the files, the folders and the sizes under it.
+// With "recount=true", the directory is walked to correct the usage.
+func (fs *FilerServer) directoryUsageHandler(w http.ResponseWriter, r *http.Request, path util.FullPath, recount bool) {
+
+	if fs.option.DisableDirListing {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	usage, err := fs.directoryUsage(context.Background(), path, recount)
+	if err == filer_pb.ErrNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJsonQuiet(w, r, http.StatusOK, usage)
+}
@@ -114,6 +114,10 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request, conte
 		so.SaveInside = true
 	}
 
+	if r.Header.Get(s3_constants.SeaweedFSCipher) == "true" || r.Header.Get(s3_constants.SeaweedFSCipherKey) != "" {
+		so.Cipher = true
+	}
+
 	if query.Has("mv.from") {
 		fs.move(ctx, w, r, so)
 	} else {
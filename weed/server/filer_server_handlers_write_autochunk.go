@@ -54,6 +54,8 @@ func (fs *FilerServer) autoChunk(ctx context.Context, w http.ResponseWriter, r *
 			writeJsonError(w, r, util.HttpStatusCancelled, err)
 		} else if strings.HasSuffix(err.Error(), "is a file") || strings.HasSuffix(err.Error(), "already exists") {
 			writeJsonError(w, r, http.StatusConflict, err)
+		} else if errors.Is(err, filer.ErrQuotaExceeded) {
+			writeJsonError(w, r, http.StatusInsufficientStorage, err)
 		} else {
 			writeJsonError(w, r, http.StatusInternalServerError, err)
 		}
@@ -190,24 +192,7 @@ func (fs *FilerServer) wormEnforcedForEntry(ctx context.Context, fullPath string
 		return false, err
 	}
 
-	// worm is not enforced
-	if entry.WORMEnforcedAtTsNs == 0 {
-		return false, nil
-	}
-
-	// worm will never expire
-	if rule.WormRetentionTimeSeconds == 0 {
-		return true, nil
-	}
-
-	enforcedAt := time.Unix(0, entry.WORMEnforcedAtTsNs)
-
-	// worm is expired
-	if time.Now().Sub(enforcedAt).Seconds() >= float64(rule.WormRetentionTimeSeconds) {
-		return false, nil
-	}
-
-	return true, nil
+	return filer.WormEnforced(rule, entry.WORMEnforcedAtTsNs, entry.Extended, time.Now()), nil
 }
 
 func (fs *FilerServer) fixFilePath(ctx context.Context, r *http.Request, fileName string) string {
@@ -252,6 +237,11 @@ func (fs *FilerServer) saveMetaData(ctx context.Context, r *http.Request, fileNa
 
 	isAppend := isAppend(r)
 	isOffsetWrite := len(fileChunks) > 0 && fileChunks[0].Offset > 0
+	cipherKey := r.Header.Get(s3_constants.SeaweedFSCipherKey)
+	if cipherKey != "" && (isAppend || isOffsetWrite) {
+		replyerr = fmt.Errorf("append with a cipher key is not supported")
+		return
+	}
 	// when it is an append
 	if isAppend || isOffsetWrite {
 		existingEntry, findErr := fs.filer.FindEntry(ctx, util.FullPath(path))
@@ -334,6 +324,20 @@ func (fs *FilerServer) saveMetaData(ctx context.Context, r *http.Request, fileNa
 			}
 		}
 	}
+	// the trailers carry the metadata only known once the body is read, e.g. the s3 checksum
+	for k, v := range r.Trailer {
+		if len(v) > 0 && len(v[0]) > 0 && strings.HasPrefix(k, needle.PairNamePrefix) {
+			entry.Extended[k] = []byte(v[0])
+		}
+	}
+
+	// the key md5 can only be set together with the encrypted chunk cipher keys
+	delete(entry.Extended, s3_constants.ExtSSECustomerKeyMD5Key)
+	if cipherKey != "" {
+		if replyerr = encryptChunkCipherKeys(entry, cipherKey); replyerr != nil {
+			return
+		}
+	}
 
 	dbErr := fs.filer.CreateEntry(ctx, entry, false, false, nil, skipCheckParentDirEntry(r), so.MaxFileNameLength)
 	// In test_bucket_listv2_delimiter_basic, the valid object key is the parent folder
@@ -367,7 +371,7 @@ func (fs *FilerServer) saveAsChunk(so *operation.StorageOption) filer.SaveDataAs
 			uploadOption := &operation.UploadOption{
 				UploadUrl:         urlLocation,
 				Filename:          name,
-				Cipher:            fs.option.Cipher,
+				Cipher:            fs.option.Cipher || so.Cipher,
 				IsInputCompressed: false,
 				MimeType:          "",
 				PairMap:           nil,
@@ -488,7 +492,7 @@ func SaveAmzMetaData(r *http.Request, existing map[string][]byte, isReplace bool
 
 	//acp-grants
 	acpGrants := r.Header.Get(s3_constants.ExtAmzAclKey)
-	if len(acpOwner) > 0 {
+	if len(acpGrants) > 0 {
 		metadata[s3_constants.ExtAmzAclKey] = []byte(acpGrants)
 	}
 
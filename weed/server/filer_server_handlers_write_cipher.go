@@ -3,6 +3,8 @@ package weed_server
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/operation"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
@@ -105,3 +108,54 @@ func (fs *FilerServer) encrypt(ctx context.Context, w http.ResponseWriter, r *ht
 
 	return
 }
+
+// encryptChunkCipherKeys protects the chunk cipher keys of an entry with the key from the request,
+// and keeps the md5 of the key to verify the key when reading
+func encryptChunkCipherKeys(entry *filer.Entry, encodedKey string) error {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != 32 {
+		return fmt.Errorf("invalid cipher key")
+	}
+	if len(entry.Content) > 0 {
+		return fmt.Errorf("content saved inside the filer store can not be encrypted")
+	}
+	if err = filer.EncryptChunkCipherKeys(entry.GetChunks(), key); err != nil {
+		return err
+	}
+	keyMd5 := md5.Sum(key)
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[s3_constants.ExtSSECustomerKeyMD5Key] = []byte(base64.StdEncoding.EncodeToString(keyMd5[:]))
+	return nil
+}
+
+// decryptChunkCipherKeys returns a copy of the entry with readable chunks if the chunk cipher keys are encrypted,
+// after checking the key from the request
+func decryptChunkCipherKeys(r *http.Request, entry *filer.Entry) (*filer.Entry, int, error) {
+	encodedKey := r.Header.Get(s3_constants.SeaweedFSCipherKey)
+	keyMd5, found := entry.Extended[s3_constants.ExtSSECustomerKeyMD5Key]
+	if !found {
+		if encodedKey != "" {
+			return nil, http.StatusBadRequest, fmt.Errorf("%s is not encrypted with a cipher key", entry.FullPath)
+		}
+		return entry, http.StatusOK, nil
+	}
+	if encodedKey == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s requires a cipher key", entry.FullPath)
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid cipher key")
+	}
+	if actualMd5 := md5.Sum(key); base64.StdEncoding.EncodeToString(actualMd5[:]) != string(keyMd5) {
+		return nil, http.StatusForbidden, fmt.Errorf("cipher key of %s does not match", entry.FullPath)
+	}
+	chunks, err := filer.DecryptChunkCipherKeys(entry.GetChunks(), key)
+	if err != nil {
+		return nil, http.StatusForbidden, err
+	}
+	entry = entry.ShallowClone()
+	entry.Chunks = chunks
+	return entry, http.StatusOK, nil
+}
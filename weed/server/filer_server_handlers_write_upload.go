@@ -86,7 +86,8 @@ func (fs *FilerServer) uploadReaderToChunks(reader io.Reader, startOffset int64,
 			break
 		}
 		if chunkOffset == 0 && !isAppend {
-			if dataSize < fs.option.SaveToFilerLimit {
+			// encrypted content is never saved inside the filer store
+			if dataSize < fs.option.SaveToFilerLimit && !so.Cipher {
 				chunkOffset += dataSize
 				smallContent = make([]byte, dataSize)
 				bytesBuffer.Read(smallContent)
@@ -151,7 +152,7 @@ func (fs *FilerServer) uploadReaderToChunks(reader io.Reader, startOffset int64,
 	return fileChunks, md5Hash, chunkOffset, nil, smallContent
 }
 
-func (fs *FilerServer) doUpload(urlLocation string, limitedReader io.Reader, fileName string, contentType string, pairMap map[string]string, auth security.EncodedJwt) (*operation.UploadResult, error, []byte) {
+func (fs *FilerServer) doUpload(urlLocation string, limitedReader io.Reader, fileName string, contentType string, pairMap map[string]string, auth security.EncodedJwt, cipher bool) (*operation.UploadResult, error, []byte) {
 
 	stats.FilerHandlerCounter.WithLabelValues(stats.ChunkUpload).Inc()
 	start := time.Now()
@@ -162,7 +163,7 @@ func (fs *FilerServer) doUpload(urlLocation string, limitedReader io.Reader, fil
 	uploadOption := &operation.UploadOption{
 		UploadUrl:         urlLocation,
 		Filename:          fileName,
-		Cipher:            fs.option.Cipher,
+		Cipher:            fs.option.Cipher || cipher,
 		IsInputCompressed: false,
 		MimeType:          contentType,
 		PairMap:           pairMap,
@@ -200,7 +201,7 @@ func (fs *FilerServer) dataToChunk(fileName, contentType string, data []byte, ch
 			return uploadErr
 		}
 		// upload the chunk to the volume server
-		uploadResult, uploadErr, _ = fs.doUpload(urlLocation, dataReader, fileName, contentType, nil, auth)
+		uploadResult, uploadErr, _ = fs.doUpload(urlLocation, dataReader, fileName, contentType, nil, auth, so.Cipher)
 		if uploadErr != nil {
 			glog.V(4).Infof("retry later due to upload error: %v", uploadErr)
 			stats.FilerHandlerCounter.WithLabelValues(stats.ChunkDoUploadRetry).Inc()
@@ -1,12 +1,19 @@
 package weed_server
 
 import (
+	"bytes"
+	"strconv"
+	"strings"
+
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/operation"
 	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/seaweedfs/seaweedfs/weed/query/json"
+	"github.com/seaweedfs/seaweedfs/weed/query/s3select"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (vs *VolumeServer) Query(req *volume_server_pb.QueryRequest, stream volume_server_pb.VolumeServer_QueryServer) error {
@@ -36,6 +43,31 @@ func (vs *VolumeServer) Query(req *volume_server_pb.QueryRequest, stream volume_
 
 		if req.InputSerialization.CsvInput != nil {
 
+			selectRequest, err := toSelectRequest(req)
+			if err != nil {
+				return err
+			}
+			selector, err := s3select.NewSelector(selectRequest)
+			if err != nil {
+				glog.V(0).Infof("volume query %s: %v", fid, err)
+				return err
+			}
+			records, err := selector.OpenReader(bytes.NewReader(n.Data))
+			if err != nil {
+				glog.V(0).Infof("volume query failed to read csv %s: %v", fid, err)
+				return err
+			}
+			var buf bytes.Buffer
+			if err = selector.WriteRecords(&buf, records); err != nil {
+				glog.V(0).Infof("volume query %s: %v", fid, err)
+				return err
+			}
+			err = stream.Send(&volume_server_pb.QueriedStripe{
+				Records: buf.Bytes(),
+			})
+			if err != nil {
+				return err
+			}
 		}
 
 		if req.InputSerialization.JsonInput != nil {
@@ -67,3 +99,83 @@ func (vs *VolumeServer) Query(req *volume_server_pb.QueryRequest, stream volume_
 
 	return nil
 }
+
+// toSelectRequest expresses the selections and the filter of the query as a S3 Select statement
+func toSelectRequest(req *volume_server_pb.QueryRequest) (*s3select.SelectObjectContentRequest, error) {
+	quote := func(s string, q string) string {
+		return q + strings.ReplaceAll(s, q, q+q) + q
+	}
+
+	var sql strings.Builder
+	sql.WriteString("SELECT ")
+	if len(req.Selections) == 0 {
+		sql.WriteString("*")
+	}
+	for i, selection := range req.Selections {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString(quote(selection, `"`))
+	}
+	sql.WriteString(" FROM S3Object")
+
+	if filter := req.Filter; filter != nil && filter.Field != "" {
+		value := quote(filter.Value, "'")
+		if _, err := strconv.ParseFloat(filter.Value, 64); err == nil {
+			value = filter.Value
+		}
+		field := quote(filter.Field, `"`)
+		switch filter.Operand {
+		case "":
+			sql.WriteString(" WHERE " + field + " IS NOT NULL")
+		case "%", "!%":
+			// the wildcards of github.com/tidwall/match, as used for json
+			pattern := strings.NewReplacer("%", "\\%", "_", "\\_", "*", "%", "?", "_").Replace(filter.Value)
+			not := ""
+			if filter.Operand == "!%" {
+				not = "NOT "
+			}
+			sql.WriteString(" WHERE " + field + " " + not + "LIKE " + quote(pattern, "'") + " ESCAPE '\\'")
+		case "=", "!=", "<", "<=", ">", ">=":
+			sql.WriteString(" WHERE " + field + " " + filter.Operand + " " + value)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported filter operand %q", filter.Operand)
+		}
+	}
+
+	csvInput := req.InputSerialization.CsvInput
+	selectRequest := &s3select.SelectObjectContentRequest{
+		Expression:     sql.String(),
+		ExpressionType: "SQL",
+		InputSerialization: s3select.InputSerialization{
+			CompressionType: req.InputSerialization.CompressionType,
+			CSV: &s3select.CSVInput{
+				FileHeaderInfo:             csvInput.FileHeaderInfo,
+				Comments:                   csvInput.Comments,
+				QuoteEscapeCharacter:       csvInput.QuoteEscapeCharacter,
+				RecordDelimiter:            csvInput.RecordDelimiter,
+				FieldDelimiter:             csvInput.FieldDelimiter,
+				QuoteCharacter:             csvInput.QuoteCharacter,
+				AllowQuotedRecordDelimiter: csvInput.AllowQuotedRecordDelimiter,
+			},
+		},
+	}
+	output := req.OutputSerialization
+	switch {
+	case output != nil && output.JsonOutput != nil:
+		selectRequest.OutputSerialization.JSON = &s3select.JSONOutput{
+			RecordDelimiter: output.JsonOutput.RecordDelimiter,
+		}
+	case output != nil && output.CsvOutput != nil:
+		selectRequest.OutputSerialization.CSV = &s3select.CSVOutput{
+			QuoteFields:          output.CsvOutput.QuoteFields,
+			QuoteEscapeCharacter: output.CsvOutput.QuoteEscapeCharacter,
+			RecordDelimiter:      output.CsvOutput.RecordDelimiter,
+			FieldDelimiter:       output.CsvOutput.FieldDelimiter,
+			QuoteCharacter:       output.CsvOutput.QuoteCharacter,
+		}
+	default:
+		selectRequest.OutputSerialization.CSV = &s3select.CSVOutput{}
+	}
+	return selectRequest, nil
+}
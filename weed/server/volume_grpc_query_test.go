@@ -0,0 +1,59 @@
+package weed_server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/query/s3select"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQueryCsv(t *testing.T) {
+	data := []byte("name,city,age\nalice,Oslo,31\nbob,Bergen,42\ncarol,Oslo,27\n")
+	query := func(selections []string, filter *volume_server_pb.QueryRequest_Filter) string {
+		req := &volume_server_pb.QueryRequest{
+			Selections: selections,
+			Filter:     filter,
+			InputSerialization: &volume_server_pb.QueryRequest_InputSerialization{
+				CsvInput: &volume_server_pb.QueryRequest_InputSerialization_CSVInput{FileHeaderInfo: "USE"},
+			},
+		}
+		selectRequest, err := toSelectRequest(req)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		selector, err := s3select.NewSelector(selectRequest)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		records, err := selector.OpenReader(bytes.NewReader(data))
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		var buf bytes.Buffer
+		assert.NoError(t, selector.WriteRecords(&buf, records))
+		return buf.String()
+	}
+
+	assert.Equal(t, "alice,31\ncarol,27\n", query([]string{"name", "age"}, &volume_server_pb.QueryRequest_Filter{Field: "city", Operand: "=", Value: "Oslo"}))
+	assert.Equal(t, "bob\n", query([]string{"name"}, &volume_server_pb.QueryRequest_Filter{Field: "age", Operand: ">", Value: "40"}))
+	assert.Equal(t, "bob\n", query([]string{"name"}, &volume_server_pb.QueryRequest_Filter{Field: "name", Operand: "%", Value: "?o*"}))
+	assert.Equal(t, "alice\n", query([]string{"name"}, &volume_server_pb.QueryRequest_Filter{Field: "name", Operand: "!%", Value: "*o*"}))
+	assert.Equal(t, "carol,Oslo,27\n", query(nil, &volume_server_pb.QueryRequest_Filter{Field: "name", Operand: "%", Value: "c*"}))
+	assert.Equal(t, "alice\nbob\ncarol\n", query([]string{"name"}, nil))
+}
+
+func TestQueryCsvInvalidOperand(t *testing.T) {
+	for _, operand := range []string{"= 1 OR 1 =", "LIKE", "<>"} {
+		_, err := toSelectRequest(&volume_server_pb.QueryRequest{
+			Filter: &volume_server_pb.QueryRequest_Filter{Field: "age", Operand: operand, Value: "1"},
+			InputSerialization: &volume_server_pb.QueryRequest_InputSerialization{
+				CsvInput: &volume_server_pb.QueryRequest_InputSerialization_CSVInput{},
+			},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), operand)
+	}
+}
@@ -0,0 +1,167 @@
+package weed_server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"golang.org/x/net/webdav"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// the identities are shared with the s3 gateway, and re-read after this duration
+const webDavIdentitiesRefreshInterval = 10 * time.Second
+
+// ServeHTTP authenticates the request when the authentication is enabled,
+// and serves it confined to the home directory of the identity.
+func (ws *WebDavServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !ws.option.Auth {
+		handler := *ws.Handler
+		handler.LockSystem = ws.lockSystem.rooted(path.Clean("/"+ws.option.FilerRootPath), r.Method != "LOCK")
+		handler.ServeHTTP(w, r)
+		return
+	}
+
+	identity := ws.authenticate(r)
+	if identity == nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="SeaweedFS WebDAV", charset="UTF-8"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	home, readOnly := filer.S3IdentityHomeDirectory(ws.option.FilerRootPath, identity)
+	if readOnly && !isReadOnlyWebDavMethod(r.Method) {
+		glog.V(1).Infof("webdav %s %s by read only identity %s", r.Method, r.URL.Path, identity.Name)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	if err := ws.ensureHomeDirectory(home); err != nil {
+		glog.Errorf("webdav identity %s home %s: %v", identity.Name, home, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	handler := &webdav.Handler{
+		FileSystem: ws.fs,
+		LockSystem: ws.lockSystem.rooted(home, r.Method != "LOCK"),
+	}
+	if home != "/" {
+		handler.FileSystem = NewWrappedFs(ws.fs, home)
+	}
+	handler.ServeHTTP(w, r)
+}
+
+func isReadOnlyWebDavMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND":
+		return true
+	}
+	return false
+}
+
+// authenticate accepts the name or access key of an identity with its secret key as basic auth,
+// or the access key and secret key of temporary credentials as basic auth, with their session token
+// in the X-Amz-Security-Token header
+func (ws *WebDavServer) authenticate(r *http.Request) *iam_pb.Identity {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil
+	}
+	identities, err := ws.loadIdentities()
+	if err != nil {
+		glog.Errorf("webdav identities: %v", err)
+		return nil
+	}
+	if sessionToken := r.Header.Get(s3_constants.AmzSecurityToken); sessionToken != "" {
+		identity, err := ws.findSessionIdentity(identities, username, password, sessionToken)
+		if err != nil {
+			glog.V(1).Infof("webdav session %s from %s: %v", username, r.RemoteAddr, err)
+		}
+		return identity
+	}
+	identity := filer.FindS3IdentityByPassword(identities, username, password)
+	if identity == nil {
+		glog.V(1).Infof("webdav user %s from %s: authentication failed", username, r.RemoteAddr)
+	}
+	return identity
+}
+
+// loadIdentities reads the identities shared with the s3 gateway
+func (ws *WebDavServer) loadIdentities() ([]*iam_pb.Identity, error) {
+	ws.identitiesLock.Lock()
+	defer ws.identitiesLock.Unlock()
+
+	if time.Since(ws.identitiesLoadedAt) < webDavIdentitiesRefreshInterval {
+		return ws.identities, nil
+	}
+	identities, err := filer.ReadS3Identities(ws.fs)
+	if err != nil {
+		return nil, err
+	}
+	ws.identities = identities
+	ws.identitiesLoadedAt = time.Now()
+	return ws.identities, nil
+}
+
+// findSessionIdentity returns the identity assumed by the temporary credentials
+func (ws *WebDavServer) findSessionIdentity(identities []*iam_pb.Identity, accessKey, secretKey, sessionToken string) (*iam_pb.Identity, error) {
+	if len(ws.sessionSigningKey) == 0 {
+		return nil, fmt.Errorf("session tokens are not accepted without jwt.sts.key")
+	}
+	claims := &security.SessionClaims{}
+	if _, err := security.DecodeJwt(ws.sessionSigningKey, security.EncodedJwt(sessionToken), claims); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("session token without expiration")
+	}
+	if claims.AccessKey != accessKey {
+		return nil, fmt.Errorf("session token of another access key")
+	}
+	if subtle.ConstantTimeCompare([]byte(secretKey), []byte(security.SessionSecretKey(ws.sessionSigningKey, accessKey))) != 1 {
+		return nil, fmt.Errorf("wrong secret key")
+	}
+	for _, identity := range identities {
+		if identity.Name == claims.Identity {
+			return identity, nil
+		}
+	}
+	return nil, fmt.Errorf("session token assumes unknown identity %s", claims.Identity)
+}
+
+// ensureHomeDirectory creates the home directory with its parent directories on first use
+func (ws *WebDavServer) ensureHomeDirectory(home string) error {
+	if home == "/" {
+		return nil
+	}
+	if _, found := ws.homeDirectories.Load(home); found {
+		return nil
+	}
+	dir, name := util.FullPath(home).DirAndName()
+	exists, err := filer_pb.Exists(ws.fs, dir, name, true)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = filer_pb.Mkdir(ws.fs, dir, name, func(entry *filer_pb.Entry) {
+			entry.Attributes.FileMode = uint32(0755 | os.ModeDir)
+			entry.Attributes.Uid = ws.option.Uid
+			entry.Attributes.Gid = ws.option.Gid
+		})
+		if err != nil {
+			return err
+		}
+	}
+	ws.homeDirectories.Store(home, true)
+	return nil
+}
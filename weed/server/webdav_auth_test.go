@@ -0,0 +1,54 @@
+package weed_server
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSessionIdentity(t *testing.T) {
+	identities := []*iam_pb.Identity{{Name: "partner", Actions: []string{"Read"}}}
+	ws := &WebDavServer{sessionSigningKey: []byte("sts_key")}
+	secretKey := security.SessionSecretKey(ws.sessionSigningKey, "ASIAPARTNER")
+
+	newToken := func(key, accessKey, identity string, expiresAt time.Time) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, security.SessionClaims{
+			AccessKey:        accessKey,
+			Identity:         identity,
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)},
+		}).SignedString([]byte(key))
+		assert.NoError(t, err)
+		return token
+	}
+	validToken := newToken("sts_key", "ASIAPARTNER", "partner", time.Now().Add(time.Hour))
+
+	identity, err := ws.findSessionIdentity(identities, "ASIAPARTNER", secretKey, validToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", identity.GetName())
+
+	_, err = ws.findSessionIdentity(identities, "ASIAPARTNER", "", validToken)
+	assert.Error(t, err, "the session token alone")
+	_, err = ws.findSessionIdentity(identities, "ASIAOTHER", security.SessionSecretKey(ws.sessionSigningKey, "ASIAOTHER"), validToken)
+	assert.Error(t, err, "the session token of another access key")
+	_, err = ws.findSessionIdentity(identities, "ASIAPARTNER", secretKey, newToken("sts_key", "ASIAPARTNER", "partner", time.Now().Add(-time.Minute)))
+	assert.Error(t, err, "expired")
+	_, err = ws.findSessionIdentity(identities, "ASIAPARTNER", secretKey, newToken("other_key", "ASIAPARTNER", "partner", time.Now().Add(time.Hour)))
+	assert.Error(t, err, "signed with another key")
+	_, err = ws.findSessionIdentity(identities, "ASIAPARTNER", secretKey, newToken("sts_key", "ASIAPARTNER", "admin", time.Now().Add(time.Hour)))
+	assert.Error(t, err, "unknown identity")
+
+	_, err = (&WebDavServer{}).findSessionIdentity(identities, "ASIAPARTNER", secretKey, validToken)
+	assert.Error(t, err, "no signing key")
+}
+
+func TestAuthenticateRejectsBearerSessionToken(t *testing.T) {
+	ws := &WebDavServer{sessionSigningKey: []byte("sts_key"), identitiesLoadedAt: time.Now()}
+	r := httptest.NewRequest("PROPFIND", "/", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	assert.Nil(t, ws.authenticate(r))
+}
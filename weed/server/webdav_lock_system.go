@@ -0,0 +1,414 @@
+package weed_server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/webdav"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	webDavLocksDirectory = "/etc/webdav/locks"
+	// the locks asked for without timeout, or a longer one, expire after this duration unless refreshed
+	webDavMaxLockDuration = time.Hour
+)
+
+// webDavLockSystem keeps the webdav locks in the filers, so all webdav servers of a filer cluster honour them.
+// Each lock holds the distributed lock of its root path, which serializes the lock requests on the same path,
+// and is recorded in webDavLocksDirectory by its token, so any webdav server can confirm, refresh or unlock it,
+// and check the locks above and below the path to lock.
+// The temporary locks taken by the webdav handler for the writes without lock token only live for the request,
+// so they are kept in memory, and only exclude the locks and the temporary locks of this webdav server.
+type webDavLockSystem struct {
+	filerClient        filer_pb.FilerClient
+	temporaryLocks     map[string]*webDavLock
+	temporaryLocksLock sync.Mutex
+}
+
+// webDavLock is the record of a lock
+type webDavLock struct {
+	Token       string        `json:"token"`
+	Root        string        `json:"root"`
+	ZeroDepth   bool          `json:"zeroDepth,omitempty"`
+	OwnerXML    string        `json:"ownerXML,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	ExpiresAtNs int64         `json:"expiresAtNs"`
+	RenewToken  string        `json:"renewToken"`
+}
+
+func newWebDavLockSystem(filerClient filer_pb.FilerClient) *webDavLockSystem {
+	return &webDavLockSystem{
+		filerClient:    filerClient,
+		temporaryLocks: make(map[string]*webDavLock),
+	}
+}
+
+// covers tells whether the lock applies to the resource
+func (l *webDavLock) covers(name string) bool {
+	if name == l.Root {
+		return true
+	}
+	if l.ZeroDepth {
+		return false
+	}
+	return l.Root == "/" || strings.HasPrefix(name, l.Root+"/")
+}
+
+func (l *webDavLock) conflicts(other *webDavLock) bool {
+	return l.covers(other.Root) || other.covers(l.Root)
+}
+
+func (l *webDavLock) isExpired(now time.Time) bool {
+	return l.ExpiresAtNs < now.UnixNano()
+}
+
+func (l *webDavLock) details() webdav.LockDetails {
+	return webdav.LockDetails{
+		Root:      l.Root,
+		Duration:  l.Duration,
+		OwnerXML:  l.OwnerXML,
+		ZeroDepth: l.ZeroDepth,
+	}
+}
+
+func (ls *webDavLockSystem) create(now time.Time, details webdav.LockDetails) (string, error) {
+	lock := &webDavLock{
+		Token:     uuid.New().String(),
+		Root:      details.Root,
+		ZeroDepth: details.ZeroDepth,
+		OwnerXML:  details.OwnerXML,
+		Duration:  details.Duration,
+	}
+	if err := ls.distributedLock(now, lock); err != nil {
+		glog.V(1).Infof("webdav lock %s: %v", lock.Root, err)
+		return "", webdav.ErrLocked
+	}
+	if err := ls.saveLock(lock); err != nil {
+		ls.distributedUnlock(lock)
+		return "", err
+	}
+
+	// the distributed lock only excludes the locks of the same path
+	if err := ls.checkConflicts(now, lock); err != nil {
+		ls.unlock(lock)
+		return "", err
+	}
+	return lock.Token, nil
+}
+
+// createTemporary takes the temporary lock of a request, after checking the lock does not conflict with the others
+func (ls *webDavLockSystem) createTemporary(now time.Time, details webdav.LockDetails) (string, error) {
+	lock := &webDavLock{
+		Token:     uuid.New().String(),
+		Root:      details.Root,
+		ZeroDepth: details.ZeroDepth,
+	}
+	ls.temporaryLocksLock.Lock()
+	ls.temporaryLocks[lock.Token] = lock
+	ls.temporaryLocksLock.Unlock()
+	if err := ls.checkConflicts(now, lock); err != nil {
+		ls.unlockTemporary(lock.Token)
+		return "", err
+	}
+	return lock.Token, nil
+}
+
+func (ls *webDavLockSystem) unlockTemporary(token string) (found bool) {
+	ls.temporaryLocksLock.Lock()
+	defer ls.temporaryLocksLock.Unlock()
+	_, found = ls.temporaryLocks[token]
+	delete(ls.temporaryLocks, token)
+	return found
+}
+
+// checkConflicts returns webdav.ErrLocked if the lock conflicts with another lock, or a temporary lock of this server
+func (ls *webDavLockSystem) checkConflicts(now time.Time, lock *webDavLock) error {
+	locks, err := ls.listLocks(now)
+	if err != nil {
+		return err
+	}
+	ls.temporaryLocksLock.Lock()
+	for _, other := range ls.temporaryLocks {
+		locks = append(locks, other)
+	}
+	ls.temporaryLocksLock.Unlock()
+	for _, other := range locks {
+		if other.Token != lock.Token && lock.conflicts(other) {
+			glog.V(1).Infof("webdav lock %s conflicts with the lock of %s", lock.Root, other.Root)
+			return webdav.ErrLocked
+		}
+	}
+	return nil
+}
+
+func (ls *webDavLockSystem) confirm(now time.Time, names []string, conditions []webdav.Condition) error {
+	var locks []*webDavLock
+	for _, condition := range conditions {
+		if condition.Token == "" {
+			continue
+		}
+		lock, err := ls.loadLock(now, condition.Token)
+		if err == webdav.ErrNoSuchLock {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		locks = append(locks, lock)
+	}
+
+	for _, name := range names {
+		confirmed := false
+		for _, lock := range locks {
+			if lock.covers(name) {
+				confirmed = true
+				break
+			}
+		}
+		if !confirmed {
+			return webdav.ErrConfirmationFailed
+		}
+	}
+	return nil
+}
+
+func (ls *webDavLockSystem) refresh(now time.Time, lock *webDavLock, duration time.Duration) error {
+	lock.Duration = duration
+	if err := ls.distributedLock(now, lock); err != nil {
+		// the filers forget the distributed locks when restarted
+		glog.V(1).Infof("webdav refresh lock %s: %v", lock.Root, err)
+		lock.RenewToken = ""
+		if err = ls.distributedLock(now, lock); err != nil {
+			glog.V(1).Infof("webdav lock %s again: %v", lock.Root, err)
+			return webdav.ErrNoSuchLock
+		}
+	}
+	return ls.saveLock(lock)
+}
+
+func (ls *webDavLockSystem) unlock(lock *webDavLock) error {
+	ls.distributedUnlock(lock)
+	return ls.deleteLock(lock.Token)
+}
+
+func webDavLockKey(root string) string {
+	return "webdav:" + root
+}
+
+// distributedLock acquires or renews the distributed lock of the lock root for the lock duration
+func (ls *webDavLockSystem) distributedLock(now time.Time, lock *webDavLock) error {
+	duration := lock.Duration
+	if duration < 0 || duration > webDavMaxLockDuration {
+		duration = webDavMaxLockDuration
+	}
+	secondsToLock := int64((duration + time.Second - 1) / time.Second)
+
+	return ls.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.DistributedLock(context.Background(), &filer_pb.LockRequest{
+			Name:          webDavLockKey(lock.Root),
+			SecondsToLock: secondsToLock,
+			RenewToken:    lock.RenewToken,
+			Owner:         lock.Token,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		lock.RenewToken = resp.RenewToken
+		lock.ExpiresAtNs = now.Add(time.Duration(secondsToLock) * time.Second).UnixNano()
+		return nil
+	})
+}
+
+func (ls *webDavLockSystem) distributedUnlock(lock *webDavLock) {
+	err := ls.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.DistributedUnlock(context.Background(), &filer_pb.UnlockRequest{
+			Name:       webDavLockKey(lock.Root),
+			RenewToken: lock.RenewToken,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		return nil
+	})
+	if err != nil {
+		// the distributed lock expires anyway
+		glog.V(1).Infof("webdav unlock %s: %v", lock.Root, err)
+	}
+}
+
+func (ls *webDavLockSystem) saveLock(lock *webDavLock) error {
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	return ls.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.SaveInsideFiler(client, webDavLocksDirectory, lock.Token, data)
+	})
+}
+
+// loadLock reads the lock of the token, the expired locks are deleted
+func (ls *webDavLockSystem) loadLock(now time.Time, token string) (*webDavLock, error) {
+	// the token names the lock record
+	if _, err := uuid.Parse(token); err != nil {
+		return nil, webdav.ErrNoSuchLock
+	}
+	var data []byte
+	err := ls.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) (err error) {
+		data, err = filer.ReadInsideFiler(client, webDavLocksDirectory, token)
+		return err
+	})
+	if err == filer_pb.ErrNotFound {
+		return nil, webdav.ErrNoSuchLock
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read lock %s: %v", token, err)
+	}
+	lock := &webDavLock{}
+	if err = json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("parse lock %s: %v", token, err)
+	}
+	if lock.isExpired(now) {
+		ls.deleteLock(token)
+		return nil, webdav.ErrNoSuchLock
+	}
+	return lock, nil
+}
+
+// listLocks reads all the locks, the expired locks are deleted
+func (ls *webDavLockSystem) listLocks(now time.Time) (locks []*webDavLock, err error) {
+	var expiredTokens []string
+	err = filer_pb.ReadDirAllEntries(ls.filerClient, util.FullPath(webDavLocksDirectory), "", func(entry *filer_pb.Entry, isLast bool) error {
+		lock := &webDavLock{}
+		if err := json.Unmarshal(entry.Content, lock); err != nil {
+			glog.Warningf("parse lock %s/%s: %v", webDavLocksDirectory, entry.Name, err)
+			return nil
+		}
+		if lock.isExpired(now) {
+			expiredTokens = append(expiredTokens, entry.Name)
+			return nil
+		}
+		locks = append(locks, lock)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list locks: %v", err)
+	}
+	for _, token := range expiredTokens {
+		ls.deleteLock(token)
+	}
+	return locks, nil
+}
+
+func (ls *webDavLockSystem) deleteLock(token string) error {
+	err := filer_pb.Remove(ls.filerClient, webDavLocksDirectory, token, true, false, false, false, nil)
+	if err != nil {
+		glog.V(1).Infof("delete lock %s/%s: %v", webDavLocksDirectory, token, err)
+	}
+	return err
+}
+
+// rooted returns the webdav.LockSystem of the clients confined to the root directory,
+// whose paths are relative to the root. The locks created for the requests other than LOCK are temporary.
+func (ls *webDavLockSystem) rooted(root string, temporary bool) webdav.LockSystem {
+	return &rootedWebDavLockSystem{
+		lockSystem: ls,
+		root:       root,
+		temporary:  temporary,
+	}
+}
+
+type rootedWebDavLockSystem struct {
+	lockSystem *webDavLockSystem
+	root       string
+	temporary  bool
+}
+
+var _ = webdav.LockSystem(&rootedWebDavLockSystem{})
+
+func (r *rootedWebDavLockSystem) fullPath(name string) string {
+	return path.Join(r.root, path.Clean("/"+name))
+}
+
+func (r *rootedWebDavLockSystem) relativePath(fullPath string) string {
+	if r.root == "/" {
+		return fullPath
+	}
+	if fullPath == r.root {
+		return "/"
+	}
+	return strings.TrimPrefix(fullPath, r.root)
+}
+
+// loadLock reads the lock of the token, if the lock is under the root
+func (r *rootedWebDavLockSystem) loadLock(now time.Time, token string) (*webDavLock, error) {
+	lock, err := r.lockSystem.loadLock(now, token)
+	if err != nil {
+		return nil, err
+	}
+	if r.root != "/" && lock.Root != r.root && !strings.HasPrefix(lock.Root, r.root+"/") {
+		return nil, webdav.ErrNoSuchLock
+	}
+	return lock, nil
+}
+
+func (r *rootedWebDavLockSystem) Confirm(now time.Time, name0, name1 string, conditions ...webdav.Condition) (release func(), err error) {
+	var names []string
+	for _, name := range []string{name0, name1} {
+		if name != "" {
+			names = append(names, r.fullPath(name))
+		}
+	}
+	if err = r.lockSystem.confirm(now, names, conditions); err != nil {
+		return nil, err
+	}
+	return func() {}, nil
+}
+
+func (r *rootedWebDavLockSystem) Create(now time.Time, details webdav.LockDetails) (token string, err error) {
+	details.Root = r.fullPath(details.Root)
+	if r.temporary {
+		return r.lockSystem.createTemporary(now, details)
+	}
+	return r.lockSystem.create(now, details)
+}
+
+func (r *rootedWebDavLockSystem) Refresh(now time.Time, token string, duration time.Duration) (webdav.LockDetails, error) {
+	lock, err := r.loadLock(now, token)
+	if err != nil {
+		return webdav.LockDetails{}, err
+	}
+	if err = r.lockSystem.refresh(now, lock, duration); err != nil {
+		return webdav.LockDetails{}, err
+	}
+	details := lock.details()
+	details.Root = r.relativePath(details.Root)
+	return details, nil
+}
+
+func (r *rootedWebDavLockSystem) Unlock(now time.Time, token string) error {
+	if r.temporary && r.lockSystem.unlockTemporary(token) {
+		return nil
+	}
+	lock, err := r.loadLock(now, token)
+	if err != nil {
+		return err
+	}
+	return r.lockSystem.unlock(lock)
+}
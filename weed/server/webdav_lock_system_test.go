@@ -0,0 +1,39 @@
+package weed_server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebDavLockConflicts(t *testing.T) {
+	dir := &webDavLock{Root: "/docs"}
+	dirOnly := &webDavLock{Root: "/docs", ZeroDepth: true}
+	file := &webDavLock{Root: "/docs/a.txt", ZeroDepth: true}
+	sibling := &webDavLock{Root: "/docs2/a.txt", ZeroDepth: true}
+	root := &webDavLock{Root: "/"}
+
+	assert.True(t, dir.covers("/docs/sub/a.txt"))
+	assert.False(t, dirOnly.covers("/docs/a.txt"))
+	assert.False(t, dir.covers("/docs2"))
+	assert.True(t, root.covers("/docs2/a.txt"))
+
+	assert.True(t, dir.conflicts(file), "the file is under the locked directory")
+	assert.True(t, file.conflicts(dir), "the directory to lock has a locked file")
+	assert.False(t, dirOnly.conflicts(file))
+	assert.True(t, dirOnly.conflicts(dir))
+	assert.False(t, dir.conflicts(sibling))
+	assert.True(t, root.conflicts(sibling))
+}
+
+func TestRootedWebDavLockSystemPaths(t *testing.T) {
+	r := &rootedWebDavLockSystem{root: "/dav/partner"}
+	assert.Equal(t, "/dav/partner", r.fullPath("/"))
+	assert.Equal(t, "/dav/partner/a.txt", r.fullPath("/../a.txt"))
+	assert.Equal(t, "/", r.relativePath("/dav/partner"))
+	assert.Equal(t, "/a.txt", r.relativePath("/dav/partner/a.txt"))
+
+	r = &rootedWebDavLockSystem{root: "/"}
+	assert.Equal(t, "/a.txt", r.fullPath("a.txt"))
+	assert.Equal(t, "/a.txt", r.relativePath("/a.txt"))
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/util/buffered_writer"
@@ -16,6 +17,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/operation"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/seaweedfs/seaweedfs/weed/util/chunk_cache"
 
@@ -39,6 +41,7 @@ type WebDavOption struct {
 	CacheDir       string
 	CacheSizeMB    int64
 	MaxMB          int
+	Auth           bool
 }
 
 type WebDavServer struct {
@@ -47,6 +50,14 @@ type WebDavServer struct {
 	filer          *filer.Filer
 	grpcDialOption grpc.DialOption
 	Handler        *webdav.Handler
+
+	fs                 *WebDavFileSystem
+	lockSystem         *webDavLockSystem
+	sessionSigningKey  security.SigningKey
+	identitiesLock     sync.Mutex
+	identities         []*iam_pb.Identity
+	identitiesLoadedAt time.Time
+	homeDirectories    sync.Map
 }
 
 func max(x, y int64) int64 {
@@ -58,7 +69,10 @@ func max(x, y int64) int64 {
 
 func NewWebDavServer(option *WebDavOption) (ws *WebDavServer, err error) {
 
-	fs, _ := NewWebDavFileSystem(option)
+	webDavFs := newWebDavFileSystem(option)
+	var fs webdav.FileSystem = webDavFs
+	lockSystem := newWebDavLockSystem(webDavFs)
+	rootLockSystem := lockSystem.rooted("/", false)
 
 	// Fix no set filer.path , accessing "/" returns "//"
 	if option.FilerRootPath == "/" {
@@ -67,6 +81,7 @@ func NewWebDavServer(option *WebDavOption) (ws *WebDavServer, err error) {
 	// filer.path non "/" option means we are accessing filer's sub-folders
 	if option.FilerRootPath != "" {
 		fs = NewWrappedFs(fs, path.Clean(option.FilerRootPath))
+		rootLockSystem = lockSystem.rooted(path.Clean(option.FilerRootPath), false)
 	}
 
 	ws = &WebDavServer{
@@ -74,8 +89,11 @@ func NewWebDavServer(option *WebDavOption) (ws *WebDavServer, err error) {
 		grpcDialOption: security.LoadClientTLS(util.GetViper(), "grpc.filer"),
 		Handler: &webdav.Handler{
 			FileSystem: fs,
-			LockSystem: webdav.NewMemLS(),
+			LockSystem: rootLockSystem,
 		},
+		fs:                webDavFs,
+		lockSystem:        lockSystem,
+		sessionSigningKey: security.SigningKey(util.GetViper().GetString("jwt.sts.key")),
 	}
 
 	return ws, nil
@@ -128,6 +146,10 @@ type WebDavFile struct {
 }
 
 func NewWebDavFileSystem(option *WebDavOption) (webdav.FileSystem, error) {
+	return newWebDavFileSystem(option), nil
+}
+
+func newWebDavFileSystem(option *WebDavOption) *WebDavFileSystem {
 
 	cacheUniqueId := util.Md5String([]byte("webdav" + string(option.Filer) + util.Version()))[0:8]
 	cacheDir := path.Join(option.CacheDir, cacheUniqueId)
@@ -140,7 +162,7 @@ func NewWebDavFileSystem(option *WebDavOption) (webdav.FileSystem, error) {
 		signature:  util.RandomInt32(),
 	}
 	t.readerCache = filer.NewReaderCache(32, chunkCache, filer.LookupFn(t))
-	return t, nil
+	return t
 }
 
 var _ = filer_pb.FilerClient(&WebDavFileSystem{})
@@ -385,7 +407,7 @@ func (fs *WebDavFileSystem) stat(ctx context.Context, fullFilePath string) (os.F
 	fi.size = int64(filer.FileSize(entry))
 	fi.name = string(fullpath)
 	fi.mode = os.FileMode(entry.Attributes.FileMode)
-	fi.modifiedTime = time.Unix(entry.Attributes.Mtime, 0)
+	fi.modifiedTime = entry.Attributes.ModTime()
 	fi.etag = filer.ETag(entry)
 	fi.isDirectory = entry.IsDirectory
 
@@ -493,7 +515,7 @@ func (f *WebDavFile) Write(buf []byte) (int, error) {
 			}
 
 			flushErr := f.fs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
-				f.entry.Attributes.Mtime = time.Now().Unix()
+				f.entry.Attributes.SetModTime(time.Now())
 
 				request := &filer_pb.UpdateEntryRequest{
 					Directory:  dir,
@@ -588,7 +610,7 @@ func (f *WebDavFile) Readdir(count int) (ret []os.FileInfo, err error) {
 			size:         int64(filer.FileSize(entry)),
 			name:         entry.Name,
 			mode:         os.FileMode(entry.Attributes.FileMode),
-			modifiedTime: time.Unix(entry.Attributes.Mtime, 0),
+			modifiedTime: entry.Attributes.ModTime(),
 			isDirectory:  entry.IsDirectory,
 		}
 
@@ -0,0 +1,343 @@
+package sftpd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/sftp"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// SftpDriver serves the sftp requests of one user, chrooted to the user's home directory on the filer
+type SftpDriver struct {
+	server   *SftpServer
+	home     string
+	readOnly bool
+}
+
+var (
+	_ = sftp.FileReader(&SftpDriver{})
+	_ = sftp.FileWriter(&SftpDriver{})
+	_ = sftp.PosixRenameFileCmder(&SftpDriver{})
+	_ = sftp.FileLister(&SftpDriver{})
+)
+
+// fullPath maps the path seen by the sftp client to the path on the filer
+func (d *SftpDriver) fullPath(name string) util.FullPath {
+	return util.FullPath(path.Join(d.home, path.Clean("/"+name)))
+}
+
+func (d *SftpDriver) ensureHome() error {
+	if d.home == "/" {
+		return nil
+	}
+	dir, name := util.FullPath(d.home).DirAndName()
+	exists, err := filer_pb.Exists(d.server, dir, name, true)
+	if err != nil || exists {
+		return err
+	}
+	// the filer creates the missing parent directories
+	return filer_pb.Mkdir(d.server, dir, name, func(entry *filer_pb.Entry) {
+		entry.Attributes.FileMode = uint32(0755 | os.ModeDir)
+		entry.Attributes.Uid = d.server.option.Uid
+		entry.Attributes.Gid = d.server.option.Gid
+	})
+}
+
+func (d *SftpDriver) getEntry(fullPath util.FullPath) (*filer_pb.Entry, error) {
+	if fullPath == "/" {
+		return &filer_pb.Entry{
+			Name:        "/",
+			IsDirectory: true,
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode: uint32(0755 | os.ModeDir),
+				Mtime:    time.Now().Unix(),
+			},
+		}, nil
+	}
+	entry, err := filer_pb.GetEntry(d.server, fullPath)
+	if err == filer_pb.ErrNotFound || (err == nil && entry == nil) {
+		return nil, os.ErrNotExist
+	}
+	return entry, err
+}
+
+// Fileread streams the file content from the volume servers
+func (d *SftpDriver) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	glog.V(2).Infof("SftpDriver.Fileread %v", r.Filepath)
+
+	entry, err := d.getEntry(d.fullPath(r.Filepath))
+	if err != nil {
+		return nil, err
+	}
+	if entry.IsDirectory {
+		return nil, fmt.Errorf("%s is a directory", r.Filepath)
+	}
+	reader := filer.NewFileReader(d.server, entry)
+	if chunkReader, ok := reader.(*filer.ChunkStreamReader); ok {
+		return &chunkStreamReaderAt{chunkReader}, nil
+	}
+	return reader.(io.ReaderAt), nil
+}
+
+// chunkStreamReaderAt releases the chunk buffer of the reader when the file is closed
+type chunkStreamReaderAt struct {
+	*filer.ChunkStreamReader
+}
+
+func (r *chunkStreamReaderAt) Close() error {
+	r.ChunkStreamReader.Close()
+	return nil
+}
+
+// Filewrite uploads the file in chunks, the entry is saved when the file is closed
+func (d *SftpDriver) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	glog.V(2).Infof("SftpDriver.Filewrite %v %+v", r.Filepath, r.Pflags())
+
+	if d.readOnly {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+	fullPath := d.fullPath(r.Filepath)
+	if string(fullPath) == d.home {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+	entry, err := d.getEntry(fullPath)
+	if err != nil && err != os.ErrNotExist {
+		return nil, err
+	}
+	flags := r.Pflags()
+	if entry != nil && entry.IsDirectory {
+		return nil, fmt.Errorf("%s is a directory", r.Filepath)
+	}
+	if entry != nil && flags.Creat && flags.Excl {
+		return nil, os.ErrExist
+	}
+
+	f := &SftpFile{
+		driver:   d,
+		fullPath: fullPath,
+		entry:    entry,
+	}
+	if entry == nil || flags.Trunc {
+		now := time.Now().Unix()
+		f.entry = &filer_pb.Entry{
+			Name: fullPath.Name(),
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode: uint32(0644),
+				Uid:      d.server.option.Uid,
+				Gid:      d.server.option.Gid,
+				Crtime:   now,
+				Mtime:    now,
+			},
+		}
+		if attrs := r.Attributes(); r.AttrFlags().Permissions && attrs != nil {
+			f.entry.Attributes.FileMode = uint32(attrs.FileMode().Perm())
+		}
+		return f, nil
+	}
+
+	// keep the existing content, the client writes at the offsets to change or append
+	if len(entry.Content) > 0 {
+		chunk, err := f.saveDataAsChunk(util.NewBytesReader(entry.Content), string(fullPath), 0, time.Now().UnixNano())
+		if err != nil {
+			return nil, err
+		}
+		entry.Content = nil
+		entry.Chunks = append(entry.GetChunks(), chunk)
+	}
+	return f, nil
+}
+
+func (d *SftpDriver) Filecmd(r *sftp.Request) error {
+	glog.V(2).Infof("SftpDriver.Filecmd %s %v %v", r.Method, r.Filepath, r.Target)
+
+	if d.readOnly {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	fullPath := d.fullPath(r.Filepath)
+	if string(fullPath) == d.home && r.Method != "Setstat" {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+
+	switch r.Method {
+	case "Setstat":
+		return d.setstat(r, fullPath)
+	case "Rename":
+		targetPath := d.fullPath(r.Target)
+		if _, err := d.getEntry(targetPath); err == nil {
+			return os.ErrExist
+		}
+		return d.rename(fullPath, targetPath)
+	case "Rmdir":
+		return d.remove(fullPath, true)
+	case "Remove":
+		return d.remove(fullPath, false)
+	case "Mkdir":
+		if _, err := d.getEntry(fullPath); err == nil {
+			return os.ErrExist
+		}
+		dir, name := fullPath.DirAndName()
+		return filer_pb.Mkdir(d.server, dir, name, func(entry *filer_pb.Entry) {
+			entry.Attributes.FileMode = uint32(0755 | os.ModeDir)
+			entry.Attributes.Uid = d.server.option.Uid
+			entry.Attributes.Gid = d.server.option.Gid
+		})
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+// PosixRename replaces the target, the posix-rename@openssh.com extension
+func (d *SftpDriver) PosixRename(r *sftp.Request) error {
+	glog.V(2).Infof("SftpDriver.PosixRename %v %v", r.Filepath, r.Target)
+
+	if d.readOnly {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	fullPath := d.fullPath(r.Filepath)
+	if string(fullPath) == d.home {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	return d.rename(fullPath, d.fullPath(r.Target))
+}
+
+func (d *SftpDriver) rename(oldPath, newPath util.FullPath) error {
+	if string(newPath) == d.home {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	oldDir, oldName := oldPath.DirAndName()
+	newDir, newName := newPath.DirAndName()
+	return d.server.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDir,
+			OldName:      oldName,
+			NewDirectory: newDir,
+			NewName:      newName,
+		}
+		if _, err := client.AtomicRenameEntry(context.Background(), request); err != nil {
+			return fmt.Errorf("renaming %s => %s: %v", oldPath, newPath, err)
+		}
+		return nil
+	})
+}
+
+func (d *SftpDriver) remove(fullPath util.FullPath, isDirectory bool) error {
+	entry, err := d.getEntry(fullPath)
+	if err != nil {
+		return err
+	}
+	if entry.IsDirectory != isDirectory {
+		if isDirectory {
+			return fmt.Errorf("%s is not a directory", fullPath)
+		}
+		return fmt.Errorf("%s is a directory", fullPath)
+	}
+	dir, name := fullPath.DirAndName()
+	return filer_pb.Remove(d.server, dir, name, true, false, false, false, []int32{d.server.signature})
+}
+
+func (d *SftpDriver) setstat(r *sftp.Request, fullPath util.FullPath) error {
+	entry, err := d.getEntry(fullPath)
+	if err != nil {
+		return err
+	}
+	attrFlags, attrs := r.AttrFlags(), r.Attributes()
+	if attrs == nil {
+		return nil
+	}
+	if attrFlags.Size {
+		size := int64(attrs.Size)
+		switch {
+		case size == 0:
+			entry.Content = nil
+			entry.Chunks = nil
+			entry.Attributes.FileSize = 0
+		case size >= int64(filer.FileSize(entry)):
+			entry.Attributes.FileSize = uint64(size)
+		default:
+			return sftp.ErrSSHFxOpUnsupported
+		}
+	}
+	if attrFlags.Permissions {
+		entry.Attributes.FileMode = uint32(os.FileMode(entry.Attributes.FileMode)&^os.ModePerm | attrs.FileMode().Perm())
+	}
+	if attrFlags.UidGid {
+		entry.Attributes.Uid = attrs.UID
+		entry.Attributes.Gid = attrs.GID
+	}
+	if attrFlags.Acmodtime {
+		entry.Attributes.SetModTime(time.Unix(int64(attrs.Mtime), 0))
+	}
+
+	dir, _ := fullPath.DirAndName()
+	return d.server.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{d.server.signature},
+		})
+	})
+}
+
+func (d *SftpDriver) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	glog.V(2).Infof("SftpDriver.Filelist %s %v", r.Method, r.Filepath)
+
+	fullPath := d.fullPath(r.Filepath)
+	switch r.Method {
+	case "List":
+		var fileInfos listerAt
+		err := filer_pb.ReadDirAllEntries(d.server, fullPath, "", func(entry *filer_pb.Entry, isLast bool) error {
+			fileInfos = append(fileInfos, newFileInfo(entry))
+			return nil
+		})
+		return fileInfos, err
+	case "Stat":
+		entry, err := d.getEntry(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		return listerAt{newFileInfo(entry)}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
+
+type listerAt []os.FileInfo
+
+func (l listerAt) ListAt(fileInfos []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(fileInfos, l[offset:])
+	if n < len(fileInfos) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+type fileInfo struct {
+	entry *filer_pb.Entry
+}
+
+func newFileInfo(entry *filer_pb.Entry) *fileInfo {
+	return &fileInfo{entry: entry}
+}
+
+func (fi *fileInfo) Name() string       { return fi.entry.Name }
+func (fi *fileInfo) Size() int64        { return int64(filer.FileSize(fi.entry)) }
+func (fi *fileInfo) ModTime() time.Time { return fi.entry.Attributes.ModTime() }
+func (fi *fileInfo) IsDir() bool        { return fi.entry.IsDirectory }
+func (fi *fileInfo) Sys() interface{}   { return nil }
+
+func (fi *fileInfo) Mode() os.FileMode {
+	mode := os.FileMode(fi.entry.Attributes.FileMode)
+	if fi.entry.IsDirectory {
+		mode |= os.ModeDir
+	}
+	return mode
+}
@@ -0,0 +1,198 @@
+package sftpd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// SftpFile uploads the written data in chunks of MaxMB, and saves the entry to the filer when closed.
+// The sftp clients send several writes at a time, which may be served out of order,
+// so the writes past the buffered data wait until the data in between is written.
+type SftpFile struct {
+	driver   *SftpDriver
+	fullPath util.FullPath
+	entry    *filer_pb.Entry
+
+	sync.Mutex
+	written      bool
+	buffer       bytes.Buffer
+	bufferOffset int64
+	pending      map[int64][]byte
+	pendingSize  int
+}
+
+// maxPendingSize covers the writes sent by the clients while a chunk is uploaded,
+// OpenSSH keeps up to 64 writes of 32KB in flight
+const maxPendingSize = 8 * 1024 * 1024
+
+var _ = io.WriterAt(&SftpFile{})
+
+func (f *SftpFile) WriteAt(p []byte, offset int64) (n int, err error) {
+	f.Lock()
+	defer f.Unlock()
+
+	glog.V(3).Infof("SftpFile.WriteAt %v: [%d,%d)", f.fullPath, offset, offset+int64(len(p)))
+	f.entry.Attributes.FileSize = uint64(max(offset+int64(len(p)), int64(f.entry.Attributes.FileSize)))
+
+	bufferStop := f.bufferOffset + int64(f.buffer.Len())
+	switch {
+	case !f.written:
+		f.written = true
+		f.bufferOffset = offset
+		f.buffer.Write(p)
+	case offset == bufferStop:
+		f.buffer.Write(p)
+	case offset > bufferStop && f.pendingSize+len(p) <= maxPendingSize:
+		if f.pending == nil {
+			f.pending = make(map[int64][]byte)
+		}
+		f.pending[offset] = append([]byte(nil), p...)
+		f.pendingSize += len(p)
+		return len(p), nil
+	default:
+		// rewriting the data, or too far ahead
+		if err = f.flush(); err != nil {
+			return 0, err
+		}
+		f.bufferOffset = offset
+		f.buffer.Write(p)
+	}
+	f.appendPending()
+
+	if f.buffer.Len() >= f.chunkSize() {
+		err = f.flushBuffer()
+	}
+	return len(p), err
+}
+
+func (f *SftpFile) chunkSize() int {
+	return f.driver.server.option.MaxMB * 1024 * 1024
+}
+
+// appendPending moves the pending writes following the buffered data to the buffer
+func (f *SftpFile) appendPending() {
+	for {
+		bufferStop := f.bufferOffset + int64(f.buffer.Len())
+		data, found := f.pending[bufferStop]
+		if !found {
+			return
+		}
+		f.buffer.Write(data)
+		delete(f.pending, bufferStop)
+		f.pendingSize -= len(data)
+	}
+}
+
+// flushBuffer uploads the buffered data as one chunk
+func (f *SftpFile) flushBuffer() error {
+	if f.buffer.Len() == 0 {
+		return nil
+	}
+	chunk, err := f.saveDataAsChunk(util.NewBytesReader(f.buffer.Bytes()), string(f.fullPath), f.bufferOffset, time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	f.entry.Chunks = append(f.entry.GetChunks(), chunk)
+	f.bufferOffset += int64(f.buffer.Len())
+	f.buffer.Reset()
+	return nil
+}
+
+// flush uploads the buffered data, and the pending writes
+func (f *SftpFile) flush() error {
+	for {
+		if err := f.flushBuffer(); err != nil {
+			return err
+		}
+		if len(f.pending) == 0 {
+			return nil
+		}
+		var nextOffset int64 = math.MaxInt64
+		for offset := range f.pending {
+			nextOffset = min(nextOffset, offset)
+		}
+		f.bufferOffset = nextOffset
+		f.appendPending()
+	}
+}
+
+// Close saves the uploaded file
+func (f *SftpFile) Close() error {
+	glog.V(2).Infof("SftpFile.Close %v", f.fullPath)
+
+	f.Lock()
+	defer f.Unlock()
+
+	if err := f.flush(); err != nil {
+		return err
+	}
+	manifestedChunks, manifestErr := filer.MaybeManifestize(f.saveDataAsChunk, f.entry.GetChunks())
+	if manifestErr != nil {
+		// not good, but should be ok
+		glog.V(0).Infof("file %s close MaybeManifestize: %v", f.fullPath, manifestErr)
+	} else {
+		f.entry.Chunks = manifestedChunks
+	}
+	f.entry.Attributes.SetModTime(time.Now())
+
+	dir, _ := f.fullPath.DirAndName()
+	return f.driver.server.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.CreateEntryRequest{
+			Directory:  dir,
+			Entry:      f.entry,
+			Signatures: []int32{f.driver.server.signature},
+		}
+		if err := filer_pb.CreateEntry(client, request); err != nil {
+			return fmt.Errorf("save %s: %v", f.fullPath, err)
+		}
+		return nil
+	})
+}
+
+func (f *SftpFile) saveDataAsChunk(reader io.Reader, name string, offset int64, tsNs int64) (chunk *filer_pb.FileChunk, err error) {
+	option := f.driver.server.option
+	uploader, uploaderErr := operation.NewUploader()
+	if uploaderErr != nil {
+		glog.V(0).Infof("upload data %v: %v", f.fullPath, uploaderErr)
+		return nil, fmt.Errorf("upload data: %v", uploaderErr)
+	}
+
+	fileId, uploadResult, flushErr, _ := uploader.UploadWithRetry(
+		f.driver.server,
+		&filer_pb.AssignVolumeRequest{
+			Count:       1,
+			Replication: option.Replication,
+			Collection:  option.Collection,
+			DiskType:    option.DiskType,
+			Path:        name,
+		},
+		&operation.UploadOption{
+			Filename: f.entry.Name,
+			Cipher:   option.Cipher,
+		},
+		func(host, fileId string) string {
+			return fmt.Sprintf("http://%s/%s", host, fileId)
+		},
+		reader,
+	)
+
+	if flushErr != nil {
+		glog.V(0).Infof("upload data %v: %v", f.fullPath, flushErr)
+		return nil, fmt.Errorf("upload data: %v", flushErr)
+	}
+	if uploadResult.Error != "" {
+		glog.V(0).Infof("upload failure %v: %v", f.fullPath, uploadResult.Error)
+		return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
+	}
+	return uploadResult.ToPbFileChunk(fileId, offset, tsNs), nil
+}
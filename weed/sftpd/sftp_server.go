@@ -0,0 +1,227 @@
+package sftpd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/pkg/sftp"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc"
+)
+
+const (
+	// the generated host key is kept in the filer, so the clients can keep trusting it across restarts
+	hostKeyDirectory = "/etc/sftp"
+	hostKeyFile      = "ssh_host_ed25519_key"
+
+	permissionHome     = "home"
+	permissionReadOnly = "readOnly"
+)
+
+var errAuthenticationFailed = errors.New("authentication failed")
+
+type SftpServerOption struct {
+	Filer          pb.ServerAddress
+	GrpcDialOption grpc.DialOption
+	UserStoreFile  string
+	HostKeyFile    string
+	Collection     string
+	Replication    string
+	DiskType       string
+	Uid            uint32
+	Gid            uint32
+	Cipher         bool
+	MaxMB          int
+}
+
+type SftpServer struct {
+	option    *SftpServerOption
+	sshConfig *ssh.ServerConfig
+	signature int32
+}
+
+var _ = filer_pb.FilerClient(&SftpServer{})
+
+func NewSftpServer(option *SftpServerOption) (*SftpServer, error) {
+	s := &SftpServer{
+		option:    option,
+		signature: util.RandomInt32(),
+	}
+	s.sshConfig = &ssh.ServerConfig{
+		PasswordCallback:  s.passwordCallback,
+		PublicKeyCallback: s.publicKeyCallback,
+	}
+
+	hostKey, err := s.loadHostKey()
+	if err != nil {
+		return nil, err
+	}
+	s.sshConfig.AddHostKey(hostKey)
+
+	return s, nil
+}
+
+func (s *SftpServer) WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithGrpcClient(streamingMode, s.signature, func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		return fn(client)
+	}, s.option.Filer.ToGrpcAddress(), false, s.option.GrpcDialOption)
+}
+
+func (s *SftpServer) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+func (s *SftpServer) GetDataCenter() string {
+	return ""
+}
+
+// loadHostKey reads the host key file, or the host key kept in the filer, generating it on first use
+func (s *SftpServer) loadHostKey() (ssh.Signer, error) {
+	if s.option.HostKeyFile != "" {
+		data, err := os.ReadFile(s.option.HostKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read host key %s: %v", s.option.HostKeyFile, err)
+		}
+		return ssh.ParsePrivateKey(data)
+	}
+
+	var data []byte
+	err := s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) (err error) {
+		data, err = filer.ReadInsideFiler(client, hostKeyDirectory, hostKeyFile)
+		if err != filer_pb.ErrNotFound {
+			return err
+		}
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		block, err := ssh.MarshalPrivateKey(privateKey, "seaweedfs sftp")
+		if err != nil {
+			return err
+		}
+		data = pem.EncodeToMemory(block)
+		glog.V(0).Infof("generated sftp host key %s/%s", hostKeyDirectory, hostKeyFile)
+		return filer.SaveInsideFiler(client, hostKeyDirectory, hostKeyFile, data)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("host key %s/%s: %v", hostKeyDirectory, hostKeyFile, err)
+	}
+	return ssh.ParsePrivateKey(data)
+}
+
+func (s *SftpServer) passwordCallback(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	return s.authenticate(conn, func(user *SftpUser) bool {
+		return user.checkPassword(string(password))
+	})
+}
+
+func (s *SftpServer) publicKeyCallback(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	return s.authenticate(conn, func(user *SftpUser) bool {
+		return user.checkPublicKey(key)
+	})
+}
+
+func (s *SftpServer) authenticate(conn ssh.ConnMetadata, check func(user *SftpUser) bool) (*ssh.Permissions, error) {
+	users, err := s.loadUsers()
+	if err != nil {
+		glog.Errorf("sftp user %s: %v", conn.User(), err)
+		return nil, errAuthenticationFailed
+	}
+	user := users.find(conn.User())
+	if user == nil || !check(user) {
+		glog.V(1).Infof("sftp user %s from %v: %v", conn.User(), conn.RemoteAddr(), errAuthenticationFailed)
+		return nil, errAuthenticationFailed
+	}
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			permissionHome:     user.homeDir(),
+			permissionReadOnly: strconv.FormatBool(user.ReadOnly),
+		},
+	}, nil
+}
+
+// Serve accepts the ssh connections, serving the sftp subsystem
+func (s *SftpServer) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConnection(conn)
+	}
+}
+
+func (s *SftpServer) handleConnection(conn net.Conn) {
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, s.sshConfig)
+	if err != nil {
+		glog.V(1).Infof("ssh handshake with %v: %v", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	driver := &SftpDriver{
+		server:   s,
+		home:     sshConn.Permissions.Extensions[permissionHome],
+		readOnly: sshConn.Permissions.Extensions[permissionReadOnly] == "true",
+	}
+	if err = driver.ensureHome(); err != nil {
+		glog.Errorf("sftp user %s home %s: %v", sshConn.User(), driver.home, err)
+		return
+	}
+	glog.V(1).Infof("sftp user %s from %v logged in to %s", sshConn.User(), sshConn.RemoteAddr(), driver.home)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			glog.V(1).Infof("accept channel from %v: %v", sshConn.RemoteAddr(), err)
+			continue
+		}
+		go s.handleSession(channel, channelRequests, driver)
+	}
+}
+
+// handleSession only serves the sftp subsystem, no shell or command execution
+func (s *SftpServer) handleSession(channel ssh.Channel, requests <-chan *ssh.Request, driver *SftpDriver) {
+	defer channel.Close()
+	for request := range requests {
+		// the payload of the subsystem request is the ssh string of the subsystem name
+		isSftp := request.Type == "subsystem" && len(request.Payload) > 4 && string(request.Payload[4:]) == "sftp"
+		if request.WantReply {
+			request.Reply(isSftp, nil)
+		}
+		if !isSftp {
+			continue
+		}
+
+		server := sftp.NewRequestServer(channel, sftp.Handlers{
+			FileGet:  driver,
+			FilePut:  driver,
+			FileCmd:  driver,
+			FileList: driver,
+		})
+		if err := server.Serve(); err != nil && err != io.EOF {
+			glog.V(1).Infof("sftp session %s: %v", driver.home, err)
+		}
+		server.Close()
+		return
+	}
+}
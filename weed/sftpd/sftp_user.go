@@ -0,0 +1,106 @@
+package sftpd
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+// SftpUser is one user of the user store file, e.g.
+//
+//	{
+//	  "users": [
+//	    {
+//	      "username": "partner",
+//	      "password": "$2a$10$...",
+//	      "publicKeys": ["ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAA... partner@example.com"],
+//	      "homeDir": "/sftp/partner",
+//	      "readOnly": false
+//	    }
+//	  ]
+//	}
+//
+// The password is either a bcrypt hash or the plain password.
+// The home directory defaults to /home/<username>.
+type SftpUser struct {
+	Username   string   `json:"username"`
+	Password   string   `json:"password,omitempty"`
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	HomeDir    string   `json:"homeDir,omitempty"`
+	ReadOnly   bool     `json:"readOnly,omitempty"`
+}
+
+type SftpUsers struct {
+	Users []*SftpUser `json:"users"`
+}
+
+// loadUsers reads the user store file, so changes apply to the next login
+func (s *SftpServer) loadUsers() (*SftpUsers, error) {
+	entry, err := filer_pb.GetEntry(s, util.FullPath(s.option.UserStoreFile))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", s.option.UserStoreFile, err)
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("read %s: %v", s.option.UserStoreFile, filer_pb.ErrNotFound)
+	}
+	content, err := io.ReadAll(filer.NewFileReader(s, entry))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", s.option.UserStoreFile, err)
+	}
+	users := &SftpUsers{}
+	if err = json.Unmarshal(content, users); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", s.option.UserStoreFile, err)
+	}
+	return users, nil
+}
+
+func (users *SftpUsers) find(username string) *SftpUser {
+	for _, user := range users.Users {
+		if user.Username == username {
+			return user
+		}
+	}
+	return nil
+}
+
+func (user *SftpUser) checkPassword(password string) bool {
+	if user.Password == "" || password == "" {
+		return false
+	}
+	if strings.HasPrefix(user.Password, "$2") {
+		return bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil
+	}
+	return subtle.ConstantTimeCompare([]byte(user.Password), []byte(password)) == 1
+}
+
+func (user *SftpUser) checkPublicKey(key ssh.PublicKey) bool {
+	marshaled := key.Marshal()
+	for _, authorizedKey := range user.PublicKeys {
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(publicKey.Marshal(), marshaled) {
+			return true
+		}
+	}
+	return false
+}
+
+// homeDir returns the directory the user is chrooted to
+func (user *SftpUser) homeDir() string {
+	if user.HomeDir != "" {
+		return path.Clean("/" + user.HomeDir)
+	}
+	return path.Join("/home", path.Clean("/"+user.Username))
+}
@@ -0,0 +1,66 @@
+package sftpd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("partner_pw"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	partner := &SftpUser{Username: "partner", Password: string(hash)}
+	assert.True(t, partner.checkPassword("partner_pw"))
+	assert.False(t, partner.checkPassword(string(hash)), "the hash is not the password")
+	assert.False(t, partner.checkPassword(""))
+
+	viewer := &SftpUser{Username: "viewer", Password: "viewer_pw"}
+	assert.True(t, viewer.checkPassword("viewer_pw"))
+	assert.False(t, viewer.checkPassword("viewer"))
+
+	keyOnly := &SftpUser{Username: "keyOnly"}
+	assert.False(t, keyOnly.checkPassword(""))
+}
+
+func TestCheckPublicKey(t *testing.T) {
+	newKey := func() ssh.PublicKey {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		key, err := ssh.NewPublicKey(publicKey)
+		assert.NoError(t, err)
+		return key
+	}
+	authorized, other := newKey(), newKey()
+
+	user := &SftpUser{
+		Username:   "partner",
+		PublicKeys: []string{"not a key", string(ssh.MarshalAuthorizedKey(authorized))},
+	}
+	assert.True(t, user.checkPublicKey(authorized))
+	assert.False(t, user.checkPublicKey(other))
+}
+
+func TestHomeDir(t *testing.T) {
+	assert.Equal(t, "/home/partner", (&SftpUser{Username: "partner"}).homeDir())
+	assert.Equal(t, "/home/viewer", (&SftpUser{Username: "../viewer"}).homeDir())
+	assert.Equal(t, "/sftp/partner", (&SftpUser{Username: "partner", HomeDir: "sftp/partner/"}).homeDir())
+	assert.Equal(t, "/", (&SftpUser{Username: "admin", HomeDir: "/"}).homeDir())
+}
+
+func TestDriverFullPath(t *testing.T) {
+	d := &SftpDriver{home: "/sftp/partner"}
+	assert.Equal(t, util.FullPath("/sftp/partner"), d.fullPath("/"))
+	assert.Equal(t, util.FullPath("/sftp/partner"), d.fullPath("."))
+	assert.Equal(t, util.FullPath("/sftp/partner/a/b.txt"), d.fullPath("a/b.txt"))
+	assert.Equal(t, util.FullPath("/sftp/partner/escape.txt"), d.fullPath("/../escape.txt"))
+	assert.Equal(t, util.FullPath("/sftp/partner"), d.fullPath("../../.."))
+
+	root := &SftpDriver{home: "/"}
+	assert.Equal(t, util.FullPath("/a.txt"), root.fullPath("../a.txt"))
+}
@@ -37,6 +37,12 @@ func (c *commandFsConfigure) Help() string {
 	# example: configure adding only 1 physical volume for each bucket collection
 	fs.configure -locationPrefix=/buckets/ -volumeGrowthCount=1
 
+	# example: keep the deleted files of each bucket in its .trash folder for 7 days
+	fs.configure -locationPrefix=/buckets/ -trash -trashRetentionTime=604800
+
+	# example: limit a folder to 1GiB and 100000 files and folders, see fs.quota for the usage
+	fs.configure -locationPrefix=/home/alice/ -quotaMB=1024 -quotaInodes=100000
+
 	# apply the changes
 	fs.configure -locationPrefix=/my/folder -collection=abc -apply
 
@@ -63,6 +69,10 @@ func (c *commandFsConfigure) Do(args []string, commandEnv *CommandEnv, writer io
 	worm := fsConfigureCommand.Bool("worm", false, "write-once-read-many, written files are readonly")
 	wormGracePeriod := fsConfigureCommand.Uint64("wormGracePeriod", 0, "grace period before worm is enforced, in seconds")
 	wormRetentionTime := fsConfigureCommand.Uint64("wormRetentionTime", 0, "retention time for a worm enforced file, in seconds")
+	trash := fsConfigureCommand.Bool("trash", false, "move the deleted files and folders to the .trash folder, see fs.trash.ls")
+	trashRetentionTime := fsConfigureCommand.Uint64("trashRetentionTime", 0, "retention time for the deleted files in the trash, in seconds, 0 to keep them until fs.trash.purge")
+	quotaMB := fsConfigureCommand.Uint64("quotaMB", 0, "the quota of the bytes under the location prefix, in MiB")
+	quotaInodes := fsConfigureCommand.Uint64("quotaInodes", 0, "the quota of the files and folders under the location prefix")
 	maxFileNameLength := fsConfigureCommand.Uint("maxFileNameLength", 0, "file name length limits in bytes for compatibility with Unix-based systems")
 	dataCenter := fsConfigureCommand.String("dataCenter", "", "assign writes to this dataCenter")
 	rack := fsConfigureCommand.String("rack", "", "assign writes to this rack")
@@ -97,6 +107,10 @@ func (c *commandFsConfigure) Do(args []string, commandEnv *CommandEnv, writer io
 			Worm:                     *worm,
 			WormGracePeriodSeconds:   *wormGracePeriod,
 			WormRetentionTimeSeconds: *wormRetentionTime,
+			Trash:                    *trash,
+			TrashRetentionSeconds:    *trashRetentionTime,
+			QuotaBytes:               *quotaMB * 1024 * 1024,
+			QuotaInodes:              *quotaInodes,
 		}
 
 		// check collection
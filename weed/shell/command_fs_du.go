@@ -1,6 +1,9 @@
 package shell
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 
@@ -26,6 +29,11 @@ func (c *commandFsDu) Help() string {
 	fs.du /dir
 	fs.du /dir/file_name
 	fs.du /dir/file_prefix
+	fs.du -recount /dir     # walk the folder to correct its usage kept by the filer
+	fs.du -traverse /dir    # walk the folder without using the usage kept by the filer
+
+	The usage of a folder, i.e. the files, the folders and the sizes under it, is kept up to date by the filer.
+	The physical size is the size of the chunks before replication.
 `
 }
 
@@ -35,12 +43,22 @@ func (c *commandFsDu) HasTag(CommandTag) bool {
 
 func (c *commandFsDu) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
 
-	path, err := commandEnv.parseUrl(findInputDirectory(args))
+	fsDuCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	recount := fsDuCommand.Bool("recount", false, "walk the folder to correct its usage kept by the filer")
+	traverse := fsDuCommand.Bool("traverse", false, "walk the folder without using the usage kept by the filer")
+	if err = fsDuCommand.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := commandEnv.parseUrl(findInputDirectory(fsDuCommand.Args()))
 	if err != nil {
 		return err
 	}
 
 	if commandEnv.isDirectory(path) {
+		if !*traverse {
+			return duDirectoryUsage(writer, commandEnv, path, *recount)
+		}
 		path = path + "/"
 	}
 
@@ -56,6 +74,25 @@ func (c *commandFsDu) Do(args []string, commandEnv *CommandEnv, writer io.Writer
 
 }
 
+func duDirectoryUsage(writer io.Writer, commandEnv *CommandEnv, dir string, recount bool) error {
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.GetDirectoryUsage(context.Background(), &filer_pb.GetDirectoryUsageRequest{
+			Directory: dir,
+			Recount:   recount,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		usage := resp.Usage
+		fmt.Fprintf(writer, "block:%4d\tlogical size:%10d\tphysical size:%10d\tfiles:%d\tfolders:%d\t%s\n",
+			usage.ChunkCount, usage.LogicalSize, usage.PhysicalSize, usage.FileCount, usage.DirectoryCount, dir)
+		return nil
+	})
+}
+
 func duTraverseDirectory(writer io.Writer, filerClient filer_pb.FilerClient, dir, name string) (blockCount, byteCount uint64, err error) {
 
 	err = filer_pb.ReadDirAllEntries(filerClient, util.FullPath(dir), name, func(entry *filer_pb.Entry, isLast bool) error {
@@ -0,0 +1,92 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsQuota{})
+}
+
+type commandFsQuota struct {
+}
+
+func (c *commandFsQuota) Name() string {
+	return "fs.quota"
+}
+
+func (c *commandFsQuota) Help() string {
+	return `show the quotas of the locations and their usage
+
+	# all the quotas
+	fs.quota
+	# the quotas containing or under a folder
+	fs.quota /home/alice
+
+	/home/alice/  bytes:52428800/1073741824(4.88%)  inodes:1200/100000(1.20%)
+
+	The quotas are configured with "fs.configure -quotaMB -quotaInodes". The writes growing the usage over a quota
+	fail with a quota exceeded error. The usage is counted by the filer once the quota is configured.
+`
+}
+
+func (c *commandFsQuota) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsQuota) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	var path string
+	if len(args) > 0 {
+		if path, err = commandEnv.parseUrl(args[0]); err != nil {
+			return err
+		}
+	}
+
+	fc, err := filer.ReadFilerConf(commandEnv.option.FilerAddress, commandEnv.option.GrpcDialOption, commandEnv.MasterClient)
+	if err != nil {
+		return err
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		for _, location := range fc.ToProto().Locations {
+			if location.QuotaBytes == 0 && location.QuotaInodes == 0 {
+				continue
+			}
+			if path != "" && !strings.HasPrefix(path+"/", location.LocationPrefix) && !strings.HasPrefix(location.LocationPrefix, path) {
+				continue
+			}
+			resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: filer.QuotaUsageKey(location.LocationPrefix)})
+			if err != nil {
+				return err
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("read quota usage of %s: %v", location.LocationPrefix, resp.Error)
+			}
+			if len(resp.Value) == 0 {
+				fmt.Fprintf(writer, "%s  counting the usage\n", location.LocationPrefix)
+				continue
+			}
+			usage, err := filer.DecodeQuotaUsage(resp.Value)
+			if err != nil {
+				return fmt.Errorf("read quota usage of %s: %v", location.LocationPrefix, err)
+			}
+			fmt.Fprintf(writer, "%s  bytes:%s  inodes:%s\n", location.LocationPrefix,
+				quotaUsageString(usage.Bytes, location.QuotaBytes), quotaUsageString(usage.Inodes, location.QuotaInodes))
+		}
+		return nil
+	})
+}
+
+func quotaUsageString(used int64, quota uint64) string {
+	if quota == 0 {
+		return fmt.Sprintf("%d", used)
+	}
+	return fmt.Sprintf("%d/%d(%.2f%%)", used, quota, float64(used)*100/float64(quota))
+}
@@ -0,0 +1,79 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsSnapshotCreate{})
+}
+
+type commandFsSnapshotCreate struct {
+}
+
+func (c *commandFsSnapshotCreate) Name() string {
+	return "fs.snapshot.create"
+}
+
+func (c *commandFsSnapshotCreate) Help() string {
+	return `create a read-only snapshot of a folder or a bucket
+
+	# the snapshot is named by the creation time by default, e.g. 20261017-054640
+	fs.snapshot.create /buckets/data
+	fs.snapshot.create -name=daily /buckets/data
+
+	The snapshot is a copy of the entries of the folder, sharing the file content with them, and is read under
+	the .snapshots folder, over http, s3, mount and webdav:
+
+	/buckets/data/.snapshots/daily/a.txt
+
+	The file content referenced by the snapshots is kept after the files are changed or deleted, until the snapshots
+	are deleted with fs.snapshot.delete.
+
+	The changes made during the creation are replayed from the metadata log. The changes made through the other filers
+	may be missed until they are persisted, so pause the writes through the other filers for a consistent snapshot.
+`
+}
+
+func (c *commandFsSnapshotCreate) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsSnapshotCreate) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	createCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := createCommand.String("name", time.Now().UTC().Format("20060102-150405"), "the snapshot name")
+	if err = createCommand.Parse(args); err != nil {
+		return nil
+	}
+	if createCommand.NArg() != 1 {
+		return fmt.Errorf("need the folder to snapshot")
+	}
+
+	dir, err := commandEnv.parseUrl(createCommand.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.CreateSnapshot(context.Background(), &filer_pb.CreateSnapshotRequest{
+			Directory: dir,
+			Name:      *name,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("create snapshot %s of %s: %v", *name, dir, resp.Error)
+		}
+		fmt.Fprintf(writer, "created snapshot %s/%s/%s with %d files\n", dir, filer.SnapshotsFolder, *name, resp.FileCount)
+		return nil
+	})
+}
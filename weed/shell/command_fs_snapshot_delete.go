@@ -0,0 +1,67 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsSnapshotDelete{})
+}
+
+type commandFsSnapshotDelete struct {
+}
+
+func (c *commandFsSnapshotDelete) Name() string {
+	return "fs.snapshot.delete"
+}
+
+func (c *commandFsSnapshotDelete) Help() string {
+	return `delete a snapshot, listed by fs.snapshot.ls
+
+	fs.snapshot.delete /buckets/data/.snapshots/daily
+
+	The file content referenced only by the snapshot is deleted with it.
+`
+}
+
+func (c *commandFsSnapshotDelete) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsSnapshotDelete) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) != 1 {
+		return fmt.Errorf("need the snapshot path to delete")
+	}
+
+	path, err := commandEnv.parseUrl(args[0])
+	if err != nil {
+		return err
+	}
+	snapshotsDir, name := util.FullPath(path).DirAndName()
+	if util.FullPath(snapshotsDir).Name() != filer.SnapshotsFolder {
+		return fmt.Errorf("%s is not in a %s folder, see fs.snapshot.ls", path, filer.SnapshotsFolder)
+	}
+	dir, _ := util.FullPath(snapshotsDir).DirAndName()
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.DeleteSnapshot(context.Background(), &filer_pb.DeleteSnapshotRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("delete snapshot %s: %v", path, resp.Error)
+		}
+		fmt.Fprintf(writer, "deleted snapshot %s\n", path)
+		return nil
+	})
+}
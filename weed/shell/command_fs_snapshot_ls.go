@@ -0,0 +1,65 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsSnapshotLs{})
+}
+
+type commandFsSnapshotLs struct {
+}
+
+func (c *commandFsSnapshotLs) Name() string {
+	return "fs.snapshot.ls"
+}
+
+func (c *commandFsSnapshotLs) Help() string {
+	return `list the snapshots of a folder or a bucket
+
+	fs.snapshot.ls /buckets/data
+
+	/buckets/data/.snapshots/daily  created:2026-10-17T05:46:40Z
+`
+}
+
+func (c *commandFsSnapshotLs) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsSnapshotLs) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	path, err := commandEnv.parseUrl(findInputDirectory(args))
+	if err != nil {
+		return err
+	}
+
+	snapshotsDir := snapshotsFolderOf(path)
+	err = filer_pb.ReadDirAllEntries(commandEnv, snapshotsDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		createdAt, found := filer.SnapshotCreatedAt(entry.Extended)
+		if !found {
+			return nil
+		}
+		fmt.Fprintf(writer, "%s  created:%s\n", snapshotsDir.Child(entry.Name), createdAt.Format(time.RFC3339))
+		return nil
+	})
+	if err == filer_pb.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// snapshotsFolderOf returns the snapshots folder of a folder or a bucket
+func snapshotsFolderOf(path string) util.FullPath {
+	if util.FullPath(path).Name() == filer.SnapshotsFolder {
+		return util.FullPath(path)
+	}
+	return util.FullPath(path).Child(filer.SnapshotsFolder)
+}
@@ -0,0 +1,82 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsTrashLs{})
+}
+
+type commandFsTrashLs struct {
+}
+
+func (c *commandFsTrashLs) Name() string {
+	return "fs.trash.ls"
+}
+
+func (c *commandFsTrashLs) Help() string {
+	return `list the deleted entries kept in the trash of a folder or a bucket
+
+	fs.trash.ls /data
+	fs.trash.ls /buckets/photos
+
+	The trash is enabled with "fs.configure -trash". The deleted entries are kept in the .trash folder
+	of their bucket, or of the folder of the configured location prefix, until they expire:
+
+	/data/.trash/1760680000123456789  deleted:2026-10-17T05:46:40Z  expires:2026-10-24T05:46:40Z  /data/logs/a.txt
+
+	See fs.trash.restore and fs.trash.purge to restore or purge them.
+`
+}
+
+func (c *commandFsTrashLs) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsTrashLs) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	path, err := commandEnv.parseUrl(findInputDirectory(args))
+	if err != nil {
+		return err
+	}
+
+	fc, err := filer.ReadFilerConf(commandEnv.option.FilerAddress, commandEnv.option.GrpcDialOption, commandEnv.MasterClient)
+	if err != nil {
+		return err
+	}
+
+	trashDir := trashFolderOf(path)
+	return filer_pb.ReadDirAllEntries(commandEnv, trashDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+		originalPath, deletedAt, found := filer.TrashDeletion(entry.Extended)
+		if !found {
+			return nil
+		}
+		expires := "never"
+		if retention := fc.MatchStorageRule(originalPath).TrashRetentionSeconds; retention > 0 {
+			expires = deletedAt.Add(time.Duration(retention) * time.Second).Format(time.RFC3339)
+		}
+		fmt.Fprintf(writer, "%s  deleted:%s  expires:%s  %s\n", trashDir.Child(entry.Name), deletedAt.Format(time.RFC3339), expires, originalPath)
+		return nil
+	})
+}
+
+// trashFolderOf returns the trash folder of a folder or a bucket
+func trashFolderOf(path string) util.FullPath {
+	if util.FullPath(path).Name() == filer.TrashFolder {
+		return util.FullPath(path)
+	}
+	return util.FullPath(path).Child(filer.TrashFolder)
+}
+
+// isTrashDeletion tells whether the path is the folder of one deletion in a trash
+func isTrashDeletion(path string) bool {
+	dir, _ := util.FullPath(path).DirAndName()
+	return util.FullPath(dir).Name() == filer.TrashFolder
+}
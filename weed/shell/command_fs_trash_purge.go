@@ -0,0 +1,97 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsTrashPurge{})
+}
+
+type commandFsTrashPurge struct {
+}
+
+func (c *commandFsTrashPurge) Name() string {
+	return "fs.trash.purge"
+}
+
+func (c *commandFsTrashPurge) Help() string {
+	return `delete the entries in the trash for good, with their file content
+
+	# purge the whole trash of a folder or a bucket
+	fs.trash.purge -apply /data
+	# purge the entries deleted more than one day ago
+	fs.trash.purge -olderThan=24h -apply /buckets/photos
+	# purge one deleted entry, listed by fs.trash.ls
+	fs.trash.purge -apply /data/.trash/1760680000123456789
+
+	The entries are also purged by the filer once the retention time configured with "fs.configure -trashRetentionTime" passes.
+`
+}
+
+func (c *commandFsTrashPurge) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsTrashPurge) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	purgeCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	olderThan := purgeCommand.Duration("olderThan", 0, "only purge the entries deleted before this duration ago")
+	apply := purgeCommand.Bool("apply", false, "purge the entries, instead of only listing them")
+	if err = purgeCommand.Parse(args); err != nil {
+		return nil
+	}
+	if purgeCommand.NArg() != 1 {
+		return fmt.Errorf("need the folder or the trash path to purge")
+	}
+
+	path, err := commandEnv.parseUrl(purgeCommand.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	infoAboutSimulationMode(writer, *apply, "-apply")
+
+	var deletions []*filer_pb.Entry
+	var trashDir util.FullPath
+	if isTrashDeletion(path) {
+		dir, _ := util.FullPath(path).DirAndName()
+		trashDir = util.FullPath(dir)
+		entry, err := filer_pb.GetEntry(commandEnv, util.FullPath(path))
+		if err != nil {
+			return fmt.Errorf("lookup %s: %v", path, err)
+		}
+		deletions = append(deletions, entry)
+	} else {
+		trashDir = trashFolderOf(path)
+		if err = filer_pb.ReadDirAllEntries(commandEnv, trashDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+			deletions = append(deletions, entry)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	purgeBefore := time.Now().Add(-*olderThan)
+	for _, entry := range deletions {
+		originalPath, deletedAt, found := filer.TrashDeletion(entry.Extended)
+		if !found || deletedAt.After(purgeBefore) {
+			continue
+		}
+		fmt.Fprintf(writer, "purge %s  deleted:%s  %s\n", trashDir.Child(entry.Name), deletedAt.Format(time.RFC3339), originalPath)
+		if !*apply {
+			continue
+		}
+		if err = filer_pb.Remove(commandEnv, string(trashDir), entry.Name, true, true, true, false, nil); err != nil {
+			return fmt.Errorf("purge %s: %v", trashDir.Child(entry.Name), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsTrashRestore{})
+}
+
+type commandFsTrashRestore struct {
+}
+
+func (c *commandFsTrashRestore) Name() string {
+	return "fs.trash.restore"
+}
+
+func (c *commandFsTrashRestore) Help() string {
+	return `restore a deleted entry from the trash, listed by fs.trash.ls
+
+	# restore to the original path
+	fs.trash.restore /data/.trash/1760680000123456789
+	# restore to another path, e.g. when the original path is taken again
+	fs.trash.restore -to=/data/logs/a.restored.txt /data/.trash/1760680000123456789
+
+	An existing entry is not overwritten. The s3 objects are restored in their bucket.
+`
+}
+
+func (c *commandFsTrashRestore) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsTrashRestore) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	restoreCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	to := restoreCommand.String("to", "", "the path to restore to, instead of the original path")
+	if err = restoreCommand.Parse(args); err != nil {
+		return nil
+	}
+	if restoreCommand.NArg() != 1 {
+		return fmt.Errorf("need the trash path to restore")
+	}
+
+	deletionPath, err := commandEnv.parseUrl(restoreCommand.Arg(0))
+	if err != nil {
+		return err
+	}
+	if !isTrashDeletion(deletionPath) {
+		return fmt.Errorf("%s is not in a %s folder, see fs.trash.ls", deletionPath, filer.TrashFolder)
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+
+		deletionDir, deletionName := util.FullPath(deletionPath).DirAndName()
+		resp, err := filer_pb.LookupEntry(client, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: deletionDir,
+			Name:      deletionName,
+		})
+		if err != nil {
+			return fmt.Errorf("lookup %s: %v", deletionPath, err)
+		}
+		originalPath, _, found := filer.TrashDeletion(resp.Entry.Extended)
+		if !found {
+			return fmt.Errorf("%s is not a deleted entry, see fs.trash.ls", deletionPath)
+		}
+
+		targetPath := originalPath
+		if *to != "" {
+			if targetPath, err = commandEnv.parseUrl(*to); err != nil {
+				return err
+			}
+		}
+		targetDir, targetName := util.FullPath(targetPath).DirAndName()
+		if _, err = filer_pb.LookupEntry(client, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: targetDir,
+			Name:      targetName,
+		}); err == nil {
+			return fmt.Errorf("%s exists, restore to another path with -to", targetPath)
+		} else if err != filer_pb.ErrNotFound {
+			return fmt.Errorf("lookup %s: %v", targetPath, err)
+		}
+
+		if _, err = client.AtomicRenameEntry(context.Background(), &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: deletionPath,
+			OldName:      util.FullPath(originalPath).Name(),
+			NewDirectory: targetDir,
+			NewName:      targetName,
+		}); err != nil {
+			return fmt.Errorf("restore %s => %s: %v", deletionPath, targetPath, err)
+		}
+
+		// the folder of the deletion is empty now
+		if err = filer_pb.DoRemove(client, deletionDir, deletionName, true, false, false, false, nil); err != nil {
+			return fmt.Errorf("remove %s: %v", deletionPath, err)
+		}
+
+		fmt.Fprintf(writer, "restore: %s => %s\n", deletionPath, targetPath)
+		return nil
+	})
+}
@@ -28,7 +28,7 @@ func (c *commandS3CircuitBreaker) Name() string {
 }
 
 func (c *commandS3CircuitBreaker) Help() string {
-	return `configure and apply s3 circuit breaker options for each bucket
+	return `configure and apply s3 circuit breaker options for each bucket and identity
 
 	# examples
 	# add circuit breaker config for global
@@ -40,6 +40,10 @@ func (c *commandS3CircuitBreaker) Help() string {
 	# add circuit breaker config for buckets x,y,z
 	s3.circuitBreaker -buckets x,y,z -type count -actions Read,Write -values 200,100 -apply
 
+	# limit the identities alice and bob to 100 requests and 50MB per second of each of Read and Write
+	s3.circuitBreaker -identities alice,bob -type CountPerSecond -actions Read,Write -values 100 -apply
+	s3.circuitBreaker -identities alice,bob -type MBPerSecond -actions Read,Write -values 50 -apply
+
 	# disable circuit breaker config of x
 	s3.circuitBreaker -buckets x -disable -apply
 
@@ -48,6 +52,11 @@ func (c *commandS3CircuitBreaker) Help() string {
 
 	# clear all circuit breaker config
 	s3.circuitBreaker -delete -apply
+
+	The Count and MB limits cap the simultaneous requests, rejecting the others with TooManyRequests (429).
+	The CountPerSecond and MBPerSecond limits cap the request rate and the uploaded and downloaded bytes rate,
+	rejecting the requests over the rate with SlowDown (503) and a Retry-After header.
+	The limits of the buckets, of the identities and the global limits all apply, while the global config is enabled.
 	`
 }
 
@@ -61,13 +70,14 @@ func (c *commandS3CircuitBreaker) Do(args []string, commandEnv *CommandEnv, writ
 
 	s3CircuitBreakerCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
 	buckets := s3CircuitBreakerCommand.String("buckets", "", "the bucket name(s) to configure, eg: -buckets x,y,z")
+	identities := s3CircuitBreakerCommand.String("identities", "", "the identity name(s) to configure, eg: -identities alice,bob")
 	global := s3CircuitBreakerCommand.Bool("global", false, "configure global circuit breaker")
 
 	actions := s3CircuitBreakerCommand.String("actions", "", "comma separated actions names: Read,Write,List,Tagging,Admin")
-	limitType := s3CircuitBreakerCommand.String("type", "", "'Count' or 'MB'; Count represents the number of simultaneous requests, and MB represents the content size of all simultaneous requests")
+	limitType := s3CircuitBreakerCommand.String("type", "", "'Count', 'MB', 'CountPerSecond' or 'MBPerSecond'; Count represents the number of simultaneous requests, MB represents the content size of all simultaneous requests, and the per second types represent the rate of requests and of transferred bytes")
 	values := s3CircuitBreakerCommand.String("values", "", "comma separated values")
 
-	disabled := s3CircuitBreakerCommand.Bool("disable", false, "disable global or buckets circuit breaker")
+	disabled := s3CircuitBreakerCommand.Bool("disable", false, "disable global, buckets or identities circuit breaker")
 	deleted := s3CircuitBreakerCommand.Bool("delete", false, "delete circuit breaker config")
 
 	apply := s3CircuitBreakerCommand.Bool("apply", false, "update and apply current configuration")
@@ -84,7 +94,8 @@ func (c *commandS3CircuitBreaker) Do(args []string, commandEnv *CommandEnv, writ
 	}
 
 	cbCfg := &s3_pb.S3CircuitBreakerConfig{
-		Buckets: make(map[string]*s3_pb.S3CircuitBreakerOptions),
+		Buckets:    make(map[string]*s3_pb.S3CircuitBreakerOptions),
+		Identities: make(map[string]*s3_pb.S3CircuitBreakerOptions),
 	}
 	if buf.Len() > 0 {
 		if err = filer.ParseS3ConfigurationFromBytes(buf.Bytes(), cbCfg); err != nil {
@@ -97,8 +108,9 @@ func (c *commandS3CircuitBreaker) Do(args []string, commandEnv *CommandEnv, writ
 		if err != nil {
 			return err
 		}
+		cmdIdentities := splitNames(*identities)
 
-		if len(cmdBuckets) <= 0 && !*global {
+		if len(cmdBuckets) <= 0 && len(cmdIdentities) <= 0 && !*global {
 			if len(cmdActions) > 0 {
 				deleteGlobalActions(cbCfg, cmdActions, limitType)
 				if cbCfg.Buckets != nil {
@@ -108,14 +120,25 @@ func (c *commandS3CircuitBreaker) Do(args []string, commandEnv *CommandEnv, writ
 					}
 					deleteBucketsActions(allBuckets, cbCfg, cmdActions, limitType)
 				}
+				if cbCfg.Identities != nil {
+					var allIdentities []string
+					for identity := range cbCfg.Identities {
+						allIdentities = append(allIdentities, identity)
+					}
+					deleteIdentitiesActions(allIdentities, cbCfg, cmdActions, limitType)
+				}
 			} else {
 				cbCfg.Global = nil
 				cbCfg.Buckets = nil
+				cbCfg.Identities = nil
 			}
 		} else {
 			if len(cmdBuckets) > 0 {
 				deleteBucketsActions(cmdBuckets, cbCfg, cmdActions, limitType)
 			}
+			if len(cmdIdentities) > 0 {
+				deleteIdentitiesActions(cmdIdentities, cbCfg, cmdActions, limitType)
+			}
 			if *global {
 				deleteGlobalActions(cbCfg, cmdActions, nil)
 			}
@@ -126,30 +149,25 @@ func (c *commandS3CircuitBreaker) Do(args []string, commandEnv *CommandEnv, writ
 			return err
 		}
 
-		if len(cmdActions) > 0 && len(*buckets) <= 0 && !*global {
-			return fmt.Errorf("one of -global and -buckets must be specified")
+		if len(cmdActions) > 0 && len(*buckets) <= 0 && len(*identities) <= 0 && !*global {
+			return fmt.Errorf("one of -global, -buckets and -identities must be specified")
 		}
 
 		if len(*buckets) > 0 {
-			for _, bucket := range cmdBuckets {
-				var cbOptions *s3_pb.S3CircuitBreakerOptions
-				var exists bool
-				if cbOptions, exists = cbCfg.Buckets[bucket]; !exists {
-					cbOptions = &s3_pb.S3CircuitBreakerOptions{}
-					cbCfg.Buckets[bucket] = cbOptions
-				}
-				cbOptions.Enabled = !*disabled
-
-				if len(cmdActions) > 0 {
-					err = insertOrUpdateValues(cbOptions, cmdActions, cmdValues, limitType)
-					if err != nil {
-						return err
-					}
-				}
+			if cbCfg.Buckets == nil {
+				cbCfg.Buckets = make(map[string]*s3_pb.S3CircuitBreakerOptions)
+			}
+			if err = updateOptions(cbCfg.Buckets, cmdBuckets, cmdActions, cmdValues, limitType, *disabled); err != nil {
+				return err
+			}
+		}
 
-				if len(cbOptions.Actions) <= 0 && !cbOptions.Enabled {
-					delete(cbCfg.Buckets, bucket)
-				}
+		if len(*identities) > 0 {
+			if cbCfg.Identities == nil {
+				cbCfg.Identities = make(map[string]*s3_pb.S3CircuitBreakerOptions)
+			}
+			if err = updateOptions(cbCfg.Identities, splitNames(*identities), cmdActions, cmdValues, limitType, *disabled); err != nil {
+				return err
 			}
 		}
 
@@ -203,9 +221,40 @@ func loadConfig(commandEnv *CommandEnv, dir string, file string, buf *bytes.Buff
 	return nil
 }
 
+// updateOptions enables or disables the circuit breaker of the buckets or identities, and sets the limits of the actions
+func updateOptions(optionsByName map[string]*s3_pb.S3CircuitBreakerOptions, names []string, cmdActions []string, cmdValues []int64, limitType *string, disabled bool) error {
+	for _, name := range names {
+		var cbOptions *s3_pb.S3CircuitBreakerOptions
+		var exists bool
+		if cbOptions, exists = optionsByName[name]; !exists {
+			cbOptions = &s3_pb.S3CircuitBreakerOptions{}
+			optionsByName[name] = cbOptions
+		}
+		cbOptions.Enabled = !disabled
+
+		if len(cmdActions) > 0 {
+			if err := insertOrUpdateValues(cbOptions, cmdActions, cmdValues, limitType); err != nil {
+				return err
+			}
+		}
+
+		if len(cbOptions.Actions) <= 0 && !cbOptions.Enabled {
+			delete(optionsByName, name)
+		}
+	}
+	return nil
+}
+
+func splitNames(names string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	return strings.Split(names, ",")
+}
+
 func insertOrUpdateValues(cbOptions *s3_pb.S3CircuitBreakerOptions, cmdActions []string, cmdValues []int64, limitType *string) error {
 	if len(*limitType) == 0 {
-		return fmt.Errorf("type not valid, only 'count' and 'bytes' are allowed")
+		return fmt.Errorf("type not valid, only 'Count', 'MB', 'CountPerSecond' and 'MBPerSecond' are allowed")
 	}
 
 	if cbOptions.Actions == nil {
@@ -224,30 +273,43 @@ func deleteBucketsActions(cmdBuckets []string, cbCfg *s3_pb.S3CircuitBreakerConf
 	if cbCfg.Buckets == nil {
 		return
 	}
+	deleteOptionsActions(cbCfg.Buckets, cmdBuckets, cmdActions, limitType)
+	if len(cbCfg.Buckets) == 0 {
+		cbCfg.Buckets = nil
+	}
+}
+
+func deleteIdentitiesActions(cmdIdentities []string, cbCfg *s3_pb.S3CircuitBreakerConfig, cmdActions []string, limitType *string) {
+	if cbCfg.Identities == nil {
+		return
+	}
+	deleteOptionsActions(cbCfg.Identities, cmdIdentities, cmdActions, limitType)
+	if len(cbCfg.Identities) == 0 {
+		cbCfg.Identities = nil
+	}
+}
 
+// deleteOptionsActions deletes the limits of the actions of the buckets or identities, or all their config without actions
+func deleteOptionsActions(optionsByName map[string]*s3_pb.S3CircuitBreakerOptions, names []string, cmdActions []string, limitType *string) {
 	if len(cmdActions) == 0 {
-		for _, bucket := range cmdBuckets {
-			delete(cbCfg.Buckets, bucket)
+		for _, name := range names {
+			delete(optionsByName, name)
 		}
-	} else {
-		for _, bucket := range cmdBuckets {
-			if cbOption, ok := cbCfg.Buckets[bucket]; ok {
-				if len(cmdActions) > 0 && cbOption.Actions != nil {
-					for _, action := range cmdActions {
-						delete(cbOption.Actions, s3_constants.Concat(action, *limitType))
-					}
+		return
+	}
+	for _, name := range names {
+		if cbOption, ok := optionsByName[name]; ok {
+			if cbOption.Actions != nil {
+				for _, action := range cmdActions {
+					delete(cbOption.Actions, s3_constants.Concat(action, *limitType))
 				}
+			}
 
-				if len(cbOption.Actions) == 0 && !cbOption.Enabled {
-					delete(cbCfg.Buckets, bucket)
-				}
+			if len(cbOption.Actions) == 0 && !cbOption.Enabled {
+				delete(optionsByName, name)
 			}
 		}
 	}
-
-	if len(cbCfg.Buckets) == 0 {
-		cbCfg.Buckets = nil
-	}
 }
 
 func deleteGlobalActions(cbCfg *s3_pb.S3CircuitBreakerConfig, cmdActions []string, limitType *string) {
@@ -301,7 +363,7 @@ func (c *commandS3CircuitBreaker) initActionsAndValues(buckets, actions, limitTy
 
 		if len(*limitType) > 0 {
 			switch *limitType {
-			case s3_constants.LimitTypeCount:
+			case s3_constants.LimitTypeCount, s3_constants.LimitTypeCountPerSecond:
 				elements := strings.Split(*values, ",")
 				if len(cmdActions) != len(elements) {
 					if len(elements) != 1 || len(elements) == 0 {
@@ -323,7 +385,7 @@ func (c *commandS3CircuitBreaker) initActionsAndValues(buckets, actions, limitTy
 						cmdValues = append(cmdValues, int64(v))
 					}
 				}
-			case s3_constants.LimitTypeBytes:
+			case s3_constants.LimitTypeBytes, s3_constants.LimitTypeBytesPerSecond:
 				elements := strings.Split(*values, ",")
 				if len(cmdActions) != len(elements) {
 					if len(elements) != 1 || len(elements) == 0 {
@@ -346,7 +408,7 @@ func (c *commandS3CircuitBreaker) initActionsAndValues(buckets, actions, limitTy
 					}
 				}
 			default:
-				return nil, nil, nil, fmt.Errorf("type not valid, only 'count' and 'bytes' are allowed")
+				return nil, nil, nil, fmt.Errorf("type not valid, only 'Count', 'MB', 'CountPerSecond' and 'MBPerSecond' are allowed")
 			}
 		} else {
 			*limitType = ""
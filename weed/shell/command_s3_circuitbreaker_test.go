@@ -243,6 +243,97 @@ var (
 			}`,
 		},
 
+		//limit the request rate of identities alice and bob
+		{
+			args: strings.Split("-identities alice,bob -type CountPerSecond -actions Read,Write -values 100", " "),
+			result: `{
+			  "global": {
+				"enabled": true,
+				"actions": {
+				  "Read:Count": "500",
+				  "Write:Count": "200"
+				}
+			  },
+			  "buckets": {
+				"x": {
+				  "enabled": true
+				},
+				"y": {
+				  "enabled": true,
+				  "actions": {
+					"Read:Count": "200",
+					"Write:Count": "100"
+				  }
+				},
+				"z": {
+				  "enabled": true,
+				  "actions": {
+					"Read:Count": "200",
+					"Write:Count": "100"
+				  }
+				}
+			  },
+			  "identities": {
+				"alice": {
+				  "enabled": true,
+				  "actions": {
+					"Read:CountPerSecond": "100",
+					"Write:CountPerSecond": "100"
+				  }
+				},
+				"bob": {
+				  "enabled": true,
+				  "actions": {
+					"Read:CountPerSecond": "100",
+					"Write:CountPerSecond": "100"
+				  }
+				}
+			  }
+			}`,
+		},
+
+		//delete the config of identity bob
+		{
+			args: strings.Split("-identities bob -delete", " "),
+			result: `{
+			  "global": {
+				"enabled": true,
+				"actions": {
+				  "Read:Count": "500",
+				  "Write:Count": "200"
+				}
+			  },
+			  "buckets": {
+				"x": {
+				  "enabled": true
+				},
+				"y": {
+				  "enabled": true,
+				  "actions": {
+					"Read:Count": "200",
+					"Write:Count": "100"
+				  }
+				},
+				"z": {
+				  "enabled": true,
+				  "actions": {
+					"Read:Count": "200",
+					"Write:Count": "100"
+				  }
+				}
+			  },
+			  "identities": {
+				"alice": {
+				  "enabled": true,
+				  "actions": {
+					"Read:CountPerSecond": "100",
+					"Write:CountPerSecond": "100"
+				  }
+				}
+			  }
+			}`,
+		},
+
 		//clear all circuit breaker config
 		{
 			args: strings.Split("-delete", " "),
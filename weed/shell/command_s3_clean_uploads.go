@@ -31,6 +31,8 @@ func (c *commandS3CleanUploads) Help() string {
 	Example:
 		s3.clean.uploads -timeAgo 1.5h
 
+	Buckets can also clean up their stale uploads automatically, with an AbortIncompleteMultipartUpload lifecycle rule.
+
 `
 }
 
@@ -0,0 +1,119 @@
+package shell
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3presign"
+)
+
+func init() {
+	Commands = append(Commands, &commandS3Presign{})
+}
+
+type commandS3Presign struct {
+}
+
+func (c *commandS3Presign) Name() string {
+	return "s3.presign"
+}
+
+func (c *commandS3Presign) Help() string {
+	return `create a presigned url, or a post form, on behalf of an s3 identity
+
+	The url is signed with the first access key of the identity, and lets anyone having it
+	access the object until it expires. The uploads can be restricted to a content type and a size range.
+
+	Example:
+		# download an object during one hour
+		s3.presign -user=app -bucket=photos -key=a.jpg
+		# upload an object of at most 1MiB, with the header "Content-Type: image/jpeg"
+		s3.presign -user=app -bucket=photos -key=a.jpg -method=PUT -expires=10m -contentType=image/jpeg -contentLengthRange=1,1048576
+		# upload any image under a prefix with an html form
+		s3.presign -user=app -bucket=photos -keyPrefix=uploads/ -method=POST -contentType=image/*
+`
+}
+
+func (c *commandS3Presign) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandS3Presign) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	presignCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	user := presignCommand.String("user", "", "the identity name")
+	endpoint := presignCommand.String("endpoint", "http://localhost:8333", "the s3 endpoint the url is used with")
+	method := presignCommand.String("method", http.MethodGet, "GET, HEAD, PUT or DELETE, or POST for a post form")
+	bucket := presignCommand.String("bucket", "", "bucket name")
+	key := presignCommand.String("key", "", "object key")
+	keyPrefix := presignCommand.String("keyPrefix", "", "the prefix of the keys uploaded with a post form")
+	expires := presignCommand.Duration("expires", s3presign.DefaultExpires, "expiration, up to 168h")
+	contentType := presignCommand.String("contentType", "", "the content type of the upload, a post form accepts a prefix like image/*")
+	contentLengthRange := presignCommand.String("contentLengthRange", "", "the size range of the upload in bytes, as min,max")
+	if err = presignCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	req := &s3presign.Request{
+		Endpoint:    *endpoint,
+		Method:      strings.ToUpper(*method),
+		Bucket:      *bucket,
+		Key:         strings.TrimPrefix(*key, "/"),
+		KeyPrefix:   strings.TrimPrefix(*keyPrefix, "/"),
+		Expires:     *expires,
+		ContentType: *contentType,
+	}
+	if *contentLengthRange != "" {
+		if req.ContentLengthRange, err = s3presign.ParseContentLengthRange(*contentLengthRange); err != nil {
+			return err
+		}
+	}
+	if err = req.Validate(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.ReadEntry(commandEnv.MasterClient, client, filer.IamConfigDirectory, filer.IamIdentityFile, &buf)
+	}); err != nil && err != filer_pb.ErrNotFound {
+		return err
+	}
+	s3cfg := &iam_pb.S3ApiConfiguration{}
+	if buf.Len() > 0 {
+		if err = filer.ParseS3ConfigurationFromBytes(buf.Bytes(), s3cfg); err != nil {
+			return err
+		}
+	}
+	var identity *iam_pb.Identity
+	for _, ident := range s3cfg.Identities {
+		if ident.Name == *user {
+			identity = ident
+			break
+		}
+	}
+	if identity == nil {
+		return fmt.Errorf("identity %q is not found", *user)
+	}
+	if len(identity.Credentials) == 0 {
+		return fmt.Errorf("identity %s has no credentials", identity.Name)
+	}
+
+	credential := identity.Credentials[0]
+	presigned, err := s3presign.Presign(req, credential.AccessKey, credential.SecretKey, "", time.Now())
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(presigned)
+}